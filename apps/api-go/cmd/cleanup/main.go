@@ -2,12 +2,16 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/sacred-vows/api-go/internal/infrastructure/config"
 	"github.com/sacred-vows/api-go/internal/infrastructure/database/firestore"
+	"github.com/sacred-vows/api-go/internal/infrastructure/storage"
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
 	"github.com/sacred-vows/api-go/internal/usecase/asset"
 	"github.com/sacred-vows/api-go/pkg/logger"
 	"go.uber.org/zap"
@@ -15,6 +19,10 @@ import (
 
 func main() {
 	dryRun := flag.Bool("dry-run", false, "Preview what would be deleted without actually deleting")
+	minAge := flag.Duration("min-age", 24*time.Hour, "Only consider records and files older than this")
+	limit := flag.Int("limit", 500, "Maximum number of orphans to delete in this run")
+	deleteUnused := flag.Bool("delete-unused", false, "Also delete assets no invitation uses (they are only reported otherwise)")
+	jsonReport := flag.Bool("json", false, "Print the report as JSON")
 	flag.Parse()
 
 	// Initialize logger
@@ -25,7 +33,7 @@ func main() {
 	defer logger.GetLogger().Sync()
 
 	// Load configuration
-	_, err := config.Load()
+	cfg, err := config.Load()
 	if err != nil {
 		logger.GetLogger().Fatal("Failed to load configuration", zap.Error(err))
 	}
@@ -38,33 +46,68 @@ func main() {
 	}
 	defer firestoreClient.Close()
 
+	// Initialize storage
+	fileStorage, err := storage.NewFromConfig(ctx, cfg.Storage)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to initialize storage", zap.Error(err))
+	}
+
 	// Initialize repositories
 	assetRepo := firestore.NewAssetRepository(firestoreClient)
 
 	// Initialize use case
-	cleanupUC := asset.NewCleanupOrphanedAssetsUseCase(assetRepo)
+	cleanupUC := asset.NewCleanupOrphanedAssetsUseCase(assetRepo, fileStorage, clock.NewRealClock())
 
 	// Run cleanup
-	logger.GetLogger().Info("Starting orphaned asset cleanup", zap.Bool("dryRun", *dryRun))
+	logger.GetLogger().Info("Starting orphaned asset cleanup",
+		zap.Bool("dryRun", *dryRun),
+		zap.Duration("minAge", *minAge),
+		zap.Int("limit", *limit),
+		zap.Bool("deleteUnused", *deleteUnused),
+	)
 
 	output, err := cleanupUC.Execute(ctx, asset.CleanupOrphanedAssetsInput{
-		DryRun: *dryRun,
+		DryRun:       *dryRun,
+		MinAge:       *minAge,
+		Limit:        *limit,
+		DeleteUnused: *deleteUnused,
 	})
 
 	if err != nil {
 		logger.GetLogger().Fatal("Cleanup failed", zap.Error(err))
 	}
 
-	// Print results
+	if *jsonReport {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			logger.GetLogger().Fatal("Failed to write report", zap.Error(err))
+		}
+	} else {
+		printResults(output)
+	}
+
+	logger.GetLogger().Info("Cleanup completed")
+	if len(output.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+func printResults(output *asset.CleanupOrphanedAssetsOutput) {
 	fmt.Printf("\n=== Cleanup Results ===\n")
+	fmt.Printf("Older than: %s\n", output.Cutoff.Format(time.RFC3339))
 	fmt.Printf("Orphaned in DB: %d\n", len(output.OrphanedAssets.OrphanedInDB))
 	fmt.Printf("Orphaned in Storage: %d\n", len(output.OrphanedAssets.OrphanedInStorage))
+	fmt.Printf("Unused by invitations: %d\n", len(output.OrphanedAssets.Unused))
 
-	if *dryRun {
+	if output.DryRun {
 		fmt.Printf("\n[DRY RUN] No files were deleted\n")
 	} else {
 		fmt.Printf("Deleted from DB: %d\n", len(output.DeletedFromDB))
 		fmt.Printf("Deleted from Storage: %d\n", len(output.DeletedFromStorage))
+		if output.LimitReached {
+			fmt.Printf("\nLimit reached; run again to delete the rest\n")
+		}
 	}
 
 	if len(output.Errors) > 0 {
@@ -73,6 +116,4 @@ func main() {
 			fmt.Printf("  - %s\n", err)
 		}
 	}
-
-	logger.GetLogger().Info("Cleanup completed")
 }
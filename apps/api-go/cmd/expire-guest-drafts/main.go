@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sacred-vows/api-go/internal/infrastructure/config"
+	"github.com/sacred-vows/api-go/internal/infrastructure/database/firestore"
+	"github.com/sacred-vows/api-go/internal/infrastructure/storage"
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
+	"github.com/sacred-vows/api-go/internal/usecase/asset"
+	"github.com/sacred-vows/api-go/internal/usecase/invitation"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "Preview which drafts would expire without deleting them")
+	limit := flag.Int("limit", 500, "Maximum number of drafts to expire in this run")
+	flag.Parse()
+
+	// Initialize logger
+	if err := logger.Init(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.GetLogger().Sync()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to load configuration", zap.Error(err))
+	}
+
+	// Initialize Firestore database
+	ctx := context.Background()
+	firestoreClient, err := firestore.NewFromEnv(ctx)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to connect to Firestore", zap.Error(err))
+	}
+	defer firestoreClient.Close()
+
+	// Initialize storage so purged drafts take their unused asset files with them
+	fileStorage, err := storage.NewFromConfig(ctx, cfg.Storage)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to initialize storage", zap.Error(err))
+	}
+
+	// Initialize repositories
+	invitationRepo := firestore.NewInvitationRepository(firestoreClient)
+	invitationRevisionRepo := firestore.NewInvitationRevisionRepository(firestoreClient)
+	assetRepo := firestore.NewAssetRepository(firestoreClient)
+	publishedSiteRepo := firestore.NewPublishedSiteRepository(firestoreClient)
+
+	// Initialize use cases
+	deleteAssetsByURLsUC := asset.NewDeleteAssetsByURLsUseCase(assetRepo)
+	purgeInvitationUC := invitation.NewPurgeInvitationUseCase(invitationRepo, publishedSiteRepo, assetRepo, invitationRevisionRepo, deleteAssetsByURLsUC, fileStorage)
+	expireUC := invitation.NewExpireGuestInvitationsUseCase(invitationRepo, purgeInvitationUC, cfg.Invitations.GuestDraftTTL, clock.NewRealClock())
+
+	// Run expiry
+	logger.GetLogger().Info("Expiring unclaimed guest drafts",
+		zap.Duration("ttl", cfg.Invitations.GuestDraftTTL),
+		zap.Bool("dryRun", *dryRun),
+	)
+
+	output, err := expireUC.Execute(ctx, invitation.ExpireGuestInvitationsInput{
+		DryRun: *dryRun,
+		Limit:  *limit,
+	})
+	if err != nil {
+		logger.GetLogger().Fatal("Expiry failed", zap.Error(err))
+	}
+
+	// Print results
+	fmt.Printf("\n=== Guest Draft Expiry Results ===\n")
+	if *dryRun {
+		fmt.Printf("Would expire: %d\n", len(output.Expired))
+		fmt.Printf("\n[DRY RUN] No drafts were deleted\n")
+	} else {
+		fmt.Printf("Expired: %d\n", len(output.Expired))
+		fmt.Printf("Deleted assets: %d\n", len(output.DeletedAssets))
+	}
+
+	if len(output.Errors) > 0 {
+		fmt.Printf("\nErrors:\n")
+		for _, err := range output.Errors {
+			fmt.Printf("  - %s\n", err)
+		}
+	}
+
+	logger.GetLogger().Info("Guest draft expiry completed")
+}
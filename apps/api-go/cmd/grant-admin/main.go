@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/infrastructure/database/firestore"
+	"github.com/sacred-vows/api-go/internal/usecase/auth"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	email := flag.String("email", "", "Email of the user to change")
+	revoke := flag.Bool("revoke", false, "Revoke admin instead of granting it")
+	flag.Parse()
+
+	if *email == "" {
+		fmt.Fprintln(os.Stderr, "Usage: grant-admin -email user@example.com [-revoke]")
+		os.Exit(2)
+	}
+
+	// Initialize logger
+	if err := logger.Init(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.GetLogger().Sync()
+
+	// Initialize Firestore database
+	ctx := context.Background()
+	firestoreClient, err := firestore.NewFromEnv(ctx)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to connect to Firestore", zap.Error(err))
+	}
+	defer firestoreClient.Close()
+
+	// Initialize repositories and use cases
+	userRepo := firestore.NewUserRepository(firestoreClient)
+	setUserRoleUC := auth.NewSetUserRoleUseCase(userRepo)
+
+	role := domain.UserRoleAdmin
+	if *revoke {
+		role = domain.UserRoleUser
+	}
+
+	output, err := setUserRoleUC.Execute(ctx, *email, role)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to set user role", zap.String("email", *email), zap.Error(err))
+	}
+
+	// Print result
+	if output.Changed {
+		fmt.Printf("%s (%s) is now %s\n", output.User.Email, output.User.ID, output.User.Role)
+	} else {
+		fmt.Printf("%s (%s) already is %s\n", output.User.Email, output.User.ID, output.User.Role)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sacred-vows/api-go/internal/infrastructure/config"
+	"github.com/sacred-vows/api-go/internal/infrastructure/database/firestore"
+	"github.com/sacred-vows/api-go/internal/infrastructure/storage"
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
+	"github.com/sacred-vows/api-go/internal/usecase/asset"
+	"github.com/sacred-vows/api-go/internal/usecase/invitation"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "Preview which trashed items would be purged without deleting them")
+	limit := flag.Int("limit", 500, "Maximum number of invitations and of assets to purge in this run")
+	flag.Parse()
+
+	// Initialize logger
+	if err := logger.Init(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.GetLogger().Sync()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to load configuration", zap.Error(err))
+	}
+
+	// Initialize Firestore database
+	ctx := context.Background()
+	firestoreClient, err := firestore.NewFromEnv(ctx)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to connect to Firestore", zap.Error(err))
+	}
+	defer firestoreClient.Close()
+
+	// Initialize storage
+	fileStorage, err := storage.NewFromConfig(ctx, cfg.Storage)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to initialize storage", zap.Error(err))
+	}
+
+	// Initialize repositories
+	invitationRepo := firestore.NewInvitationRepository(firestoreClient)
+	invitationRevisionRepo := firestore.NewInvitationRevisionRepository(firestoreClient)
+	assetRepo := firestore.NewAssetRepository(firestoreClient)
+	publishedSiteRepo := firestore.NewPublishedSiteRepository(firestoreClient)
+
+	// Initialize use cases
+	clk := clock.NewRealClock()
+	deleteAssetsByURLsUC := asset.NewDeleteAssetsByURLsUseCase(assetRepo)
+	purgeInvitationUC := invitation.NewPurgeInvitationUseCase(invitationRepo, publishedSiteRepo, assetRepo, invitationRevisionRepo, deleteAssetsByURLsUC, fileStorage)
+	purgeInvitationsUC := invitation.NewPurgeTrashedInvitationsUseCase(invitationRepo, purgeInvitationUC, cfg.Invitations.TrashRetention, clk)
+	purgeAssetsUC := asset.NewPurgeTrashedAssetsUseCase(assetRepo, fileStorage, cfg.Invitations.TrashRetention, clk)
+
+	// Run purge; invitations first so their unused assets go in the same run
+	logger.GetLogger().Info("Purging trash",
+		zap.Duration("retention", cfg.Invitations.TrashRetention),
+		zap.Bool("dryRun", *dryRun),
+	)
+
+	invitationsOutput, err := purgeInvitationsUC.Execute(ctx, invitation.PurgeTrashedInvitationsInput{
+		DryRun: *dryRun,
+		Limit:  *limit,
+	})
+	if err != nil {
+		logger.GetLogger().Fatal("Invitation purge failed", zap.Error(err))
+	}
+
+	assetsOutput, err := purgeAssetsUC.Execute(ctx, asset.PurgeTrashedAssetsInput{
+		DryRun: *dryRun,
+		Limit:  *limit,
+	})
+	if err != nil {
+		logger.GetLogger().Fatal("Asset purge failed", zap.Error(err))
+	}
+
+	// Print results
+	fmt.Printf("\n=== Trash Purge Results ===\n")
+	if *dryRun {
+		fmt.Printf("Would purge invitations: %d\n", len(invitationsOutput.Purged))
+		fmt.Printf("Would purge assets: %d\n", len(assetsOutput.Purged))
+		fmt.Printf("\n[DRY RUN] Nothing was deleted\n")
+	} else {
+		fmt.Printf("Purged invitations: %d\n", len(invitationsOutput.Purged))
+		fmt.Printf("Deleted assets of purged invitations: %d\n", len(invitationsOutput.DeletedAssets))
+		fmt.Printf("Purged trashed assets: %d\n", len(assetsOutput.Purged))
+	}
+
+	errs := append(invitationsOutput.Errors, assetsOutput.Errors...)
+	if len(errs) > 0 {
+		fmt.Printf("\nErrors:\n")
+		for _, err := range errs {
+			fmt.Printf("  - %s\n", err)
+		}
+	}
+
+	logger.GetLogger().Info("Trash purge completed")
+}
@@ -0,0 +1,456 @@
+// @title           Wedding Invitation Builder API
+// @version         1.0
+// @description     API for managing wedding invitations, layouts, assets, RSVP responses, and analytics. This API follows Clean Architecture principles.
+// @termsOfService  http://swagger.io/terms/
+
+// @contact.name   API Support
+// @contact.url    http://www.example.com/support
+// @contact.email  support@example.com
+
+// @license.name  ISC
+// @license.url   http://www.apache.org/licenses/LICENSE-2.0.html
+
+// @host      localhost:3000
+// @BasePath  /api
+
+// @securityDefinitions.bearer  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 JWT Bearer token authentication. Format: "Bearer {token}"
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	_ "github.com/sacred-vows/api-go/docs" // Swagger documentation
+	"github.com/sacred-vows/api-go/internal/infrastructure/auth"
+	"github.com/sacred-vows/api-go/internal/infrastructure/config"
+	"github.com/sacred-vows/api-go/internal/infrastructure/database/firestore"
+	"github.com/sacred-vows/api-go/internal/infrastructure/email"
+	"github.com/sacred-vows/api-go/internal/infrastructure/observability"
+	publishinfra "github.com/sacred-vows/api-go/internal/infrastructure/publish"
+	"github.com/sacred-vows/api-go/internal/infrastructure/storage"
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
+	httpRouter "github.com/sacred-vows/api-go/internal/interfaces/http"
+	"github.com/sacred-vows/api-go/internal/interfaces/http/handlers"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/analytics"
+	"github.com/sacred-vows/api-go/internal/usecase/asset"
+	authUC "github.com/sacred-vows/api-go/internal/usecase/auth"
+	"github.com/sacred-vows/api-go/internal/usecase/invitation"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	publishUC "github.com/sacred-vows/api-go/internal/usecase/publish"
+	"github.com/sacred-vows/api-go/internal/usecase/rsvp"
+	"github.com/sacred-vows/api-go/internal/usecase/theme"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	fmt.Fprintf(os.Stderr, "[MAIN] Application starting...\n")
+	// Initialize logger
+	if err := logger.Init(); err != nil {
+		panic(fmt.Sprintf("Failed to initialize logger: %v", err))
+	}
+	defer logger.GetLogger().Sync()
+	fmt.Fprintf(os.Stderr, "[MAIN] Logger initialized\n")
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to load configuration", zap.Error(err))
+	}
+
+	// Initialize Firestore database
+	ctx := context.Background()
+	logger.GetLogger().Info("Initializing Firestore client...",
+		zap.String("emulator_host", os.Getenv("FIRESTORE_EMULATOR_HOST")),
+		zap.String("project_id", cfg.Database.ProjectID),
+		zap.String("database_id", cfg.Database.DatabaseID))
+	firestoreClient, err := firestore.NewFromEnv(ctx)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to connect to Firestore", zap.Error(err))
+	}
+	defer firestoreClient.Close()
+	logger.GetLogger().Info("Firestore client initialized successfully")
+
+	logger.GetLogger().Info("Using Firestore database", zap.String("project_id", cfg.Database.ProjectID), zap.String("database_id", cfg.Database.DatabaseID))
+
+	// Run Firestore migrations with timeout
+	logger.GetLogger().Info("Starting Firestore migrations...")
+	migrationCtx, migrationCancel := context.WithTimeout(ctx, 30*time.Second)
+	defer migrationCancel()
+	if err := firestoreClient.RunMigrations(migrationCtx); err != nil {
+		logger.GetLogger().Error("Failed to run Firestore migrations", zap.Error(err))
+		// Log to stderr as well for visibility in Docker logs
+		fmt.Fprintf(os.Stderr, "[MIGRATIONS] ERROR: Failed to run Firestore migrations: %v\n", err)
+		// Continue anyway - migrations may have partially succeeded
+		// But data migrations (like loading layouts) may fail
+	} else {
+		logger.GetLogger().Info("Firestore migrations completed successfully")
+		fmt.Fprintf(os.Stderr, "[MIGRATIONS] Successfully completed all migrations\n")
+	}
+
+	// Initialize Firestore repositories
+	var userRepo repository.UserRepository
+	var invitationRepo repository.InvitationRepository
+	var invitationRevisionRepo repository.InvitationRevisionRepository
+	var invitationTemplateRepo repository.InvitationTemplateRepository
+	var themeRepo repository.ThemeRepository
+	var layoutRepo repository.LayoutRepository
+	var assetRepo repository.AssetRepository
+	var rsvpRepo repository.RSVPRepository
+	var analyticsRepo repository.AnalyticsRepository
+	var refreshTokenRepo repository.RefreshTokenRepository
+	var passwordResetRepo repository.PasswordResetRepository
+	var passwordChangeOTPRepo repository.PasswordChangeOTPRepository
+	var publishedSiteRepo repository.PublishedSiteRepository
+	var emailUsageRepo repository.EmailUsageRepository
+	var auditRepo repository.AuditRepository
+	var layoutStatsRepo repository.LayoutStatsRepository
+
+	userRepo = firestore.NewUserRepository(firestoreClient)
+	invitationRepo = firestore.NewInvitationRepository(firestoreClient)
+	invitationRevisionRepo = firestore.NewInvitationRevisionRepository(firestoreClient)
+	invitationTemplateRepo = firestore.NewInvitationTemplateRepository(firestoreClient)
+	themeRepo = firestore.NewThemeRepository(firestoreClient)
+	layoutRepo = firestore.NewLayoutRepository(firestoreClient)
+	assetRepo = firestore.NewAssetRepository(firestoreClient)
+	rsvpRepo = firestore.NewRSVPRepository(firestoreClient)
+	analyticsRepo = firestore.NewAnalyticsRepository(firestoreClient)
+	refreshTokenRepo = firestore.NewRefreshTokenRepository(firestoreClient)
+	passwordResetRepo = firestore.NewPasswordResetRepository(firestoreClient)
+	passwordChangeOTPRepo = firestore.NewPasswordChangeOTPRepository(firestoreClient)
+	publishedSiteRepo = firestore.NewPublishedSiteRepository(firestoreClient)
+	emailUsageRepo = firestore.NewEmailUsageRepository(firestoreClient)
+	auditRepo = firestore.NewAuditRepository(firestoreClient)
+	layoutStatsRepo = firestore.NewLayoutStatsRepository(firestoreClient)
+
+	// Initialize services
+	jwtService := auth.NewJWTService(
+		cfg.Auth.JWTSecret,
+		cfg.Auth.JWTAccessExpiration,
+		cfg.Auth.JWTRefreshExpiration,
+		cfg.Auth.JWTIssuer,
+		cfg.Auth.JWTAudience,
+		cfg.Auth.ClockSkewTolerance,
+	)
+	// Guest sessions last as long as unclaimed drafts are kept
+	guestSessions := auth.NewGuestSessionService(cfg.Auth.JWTSecret, cfg.Invitations.GuestDraftTTL, cfg.Auth.JWTIssuer)
+	googleOAuthService := auth.NewGoogleOAuthService(&cfg.Google)
+
+	// Initialize email service
+	emailService, err := email.NewEmailService(cfg.Email, emailUsageRepo)
+	if err != nil {
+		logger.GetLogger().Warn("Email service not configured; password reset will fail", zap.Error(err))
+		// Continue anyway - email service is optional for other features
+	}
+
+	// Initialize storage (GCS for production, S3/MinIO for local)
+	gcsStorage, err := storage.NewFromConfig(ctx, cfg.Storage)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to initialize storage", zap.Error(err))
+	}
+	var fileStorage storage.Storage = gcsStorage
+	if cfg.Storage.GCSBucket != "" {
+		logger.GetLogger().Info("Using GCS storage", zap.String("bucket", cfg.Storage.GCSBucket))
+	} else {
+		logger.GetLogger().Info("Using S3-compatible storage",
+			zap.String("bucket", cfg.Storage.S3Bucket),
+			zap.String("endpoint", cfg.Storage.S3Endpoint))
+	}
+
+	// Initialize image processor if max dimensions or quality are configured
+	var imageProcessor *storage.ImageProcessor
+	if cfg.Storage.MaxImageWidth > 0 || cfg.Storage.MaxImageHeight > 0 || cfg.Storage.ImageQuality > 0 {
+		imageProcessor = storage.NewImageProcessor(cfg.Storage.MaxImageWidth, cfg.Storage.MaxImageHeight, cfg.Storage.ImageQuality)
+		logger.GetLogger().Info("Image processor enabled",
+			zap.Int("maxWidth", cfg.Storage.MaxImageWidth),
+			zap.Int("maxHeight", cfg.Storage.MaxImageHeight),
+			zap.Int("quality", cfg.Storage.ImageQuality))
+	}
+
+	// Initialize use cases
+	registerUC := authUC.NewRegisterUseCase(userRepo)
+	loginUC := authUC.NewLoginUseCase(userRepo)
+	getCurrentUserUC := authUC.NewGetCurrentUserUseCase(userRepo)
+	deleteUserUC := authUC.NewDeleteUserUseCase(userRepo)
+	googleOAuthUC := authUC.NewGoogleOAuthUseCaseWithService(userRepo, googleOAuthService.GetOAuthConfig(), googleOAuthService)
+
+	// Initialize clock
+	clk := clock.NewRealClock()
+
+	hmacKeys := make([]auth.RefreshTokenHMACKey, 0, len(cfg.Auth.RefreshTokenHMACKeys))
+	for _, k := range cfg.Auth.RefreshTokenHMACKeys {
+		hmacKeys = append(hmacKeys, auth.RefreshTokenHMACKey{ID: k.ID, Key: k.Key})
+	}
+	refreshTokenUC := authUC.NewRefreshTokenUseCase(refreshTokenRepo, userRepo, jwtService, clk, hmacKeys, cfg.Auth.RefreshTokenHMACActiveKeyID)
+
+	// Password reset use cases (only if email service is configured)
+	var requestPasswordResetUC *authUC.RequestPasswordResetUseCase
+	var resetPasswordUC *authUC.ResetPasswordUseCase
+	var requestPasswordChangeOTPUC *authUC.RequestPasswordChangeOTPUseCase
+	var verifyPasswordChangeOTPUC *authUC.VerifyPasswordChangeOTPUseCase
+	if emailService != nil {
+		requestPasswordResetUC = authUC.NewRequestPasswordResetUseCase(userRepo, passwordResetRepo, emailService, clk, cfg.Google.FrontendURL)
+		resetPasswordUC = authUC.NewResetPasswordUseCase(passwordResetRepo, userRepo)
+		requestPasswordChangeOTPUC = authUC.NewRequestPasswordChangeOTPUseCase(userRepo, passwordChangeOTPRepo, emailService, clk)
+		verifyPasswordChangeOTPUC = authUC.NewVerifyPasswordChangeOTPUseCase(userRepo, passwordChangeOTPRepo)
+	}
+
+	revisionRecorder := invitation.NewRevisionRecorder(invitationRevisionRepo, cfg.Invitations.RevisionRetention)
+	validateInvitationDataUC := layout.NewValidateInvitationDataUseCase(layoutRepo)
+	migrateInvitationDataUC := layout.NewMigrateInvitationDataUseCase(layoutRepo)
+	popularityRecorder := layout.NewPopularityRecorder(layoutStatsRepo)
+	createInvitationUC := invitation.NewCreateInvitationUseCase(invitationRepo, assetRepo, revisionRecorder, validateInvitationDataUC, popularityRecorder)
+	getInvitationByIDUC := invitation.NewGetInvitationByIDUseCase(invitationRepo)
+	getAllInvitationsUC := invitation.NewGetAllInvitationsUseCase(invitationRepo)
+	getInvitationPreviewUC := invitation.NewGetInvitationPreviewUseCase(invitationRepo)
+	updateInvitationUC := invitation.NewUpdateInvitationUseCase(invitationRepo, assetRepo, revisionRecorder, validateInvitationDataUC)
+	patchInvitationUC := invitation.NewPatchInvitationUseCase(invitationRepo, assetRepo, revisionRecorder, validateInvitationDataUC)
+	listRevisionsUC := invitation.NewListInvitationRevisionsUseCase(invitationRepo, invitationRevisionRepo)
+	getRevisionUC := invitation.NewGetInvitationRevisionUseCase(invitationRepo, invitationRevisionRepo)
+	restoreRevisionUC := invitation.NewRestoreInvitationRevisionUseCase(invitationRepo, invitationRevisionRepo, assetRepo, revisionRecorder)
+	migrateInvitationsUC := invitation.NewMigrateInvitationsUseCase(invitationRepo)
+
+	layoutCatalog := layout.NewLayoutCatalog(layoutRepo, layoutStatsRepo, cfg.Layouts.CatalogCacheTTL, clk)
+	getAllLayoutsUC := layout.NewGetAllLayoutsUseCase(layoutCatalog)
+	getLayoutByIDUC := layout.NewGetLayoutByIDUseCase(layoutRepo)
+	getLayoutManifestUC := layout.NewGetLayoutManifestUseCase(layoutRepo)
+	getManifestsUC := layout.NewGetManifestsUseCase(layoutCatalog)
+
+	auditRecorder := layout.NewAuditRecorder()
+	listAdminLayoutsUC := layout.NewListLayoutsForAdminUseCase(layoutRepo)
+	createLayoutUC := layout.NewCreateLayoutUseCase(layoutRepo, auditRecorder, layoutCatalog)
+	updateLayoutUC := layout.NewUpdateLayoutUseCase(layoutRepo, auditRecorder, layoutCatalog)
+	setLayoutActiveUC := layout.NewSetLayoutActiveUseCase(layoutRepo, auditRecorder, layoutCatalog)
+	retireLayoutUC := layout.NewRetireLayoutUseCase(layoutRepo, auditRecorder, layoutCatalog)
+	listLayoutAuditUC := layout.NewListLayoutAuditUseCase(auditRepo)
+
+	uploadAssetUC := asset.NewUploadAssetUseCase(assetRepo, cfg.Storage.MaxFileSize, cfg.Storage.AllowedTypes)
+	getAllAssetsUC := asset.NewGetAllAssetsUseCase(assetRepo)
+	deleteAssetUC := asset.NewDeleteAssetUseCase(assetRepo)
+	trashAssetUC := asset.NewTrashAssetUseCase(assetRepo, cfg.Invitations.TrashRetention, clk)
+	listTrashedAssetsUC := asset.NewListTrashedAssetsUseCase(assetRepo, cfg.Invitations.TrashRetention)
+	restoreAssetUC := asset.NewRestoreAssetUseCase(assetRepo)
+	deleteAssetsByURLsUC := asset.NewDeleteAssetsByURLsUseCase(assetRepo)
+	getAssetsByURLsUC := asset.NewGetAssetsByURLsUseCase(assetRepo)
+	deleteInvitationUC := invitation.NewDeleteInvitationUseCase(invitationRepo, cfg.Invitations.TrashRetention, clk)
+	listTrashedInvitationsUC := invitation.NewListTrashedInvitationsUseCase(invitationRepo, cfg.Invitations.TrashRetention)
+	restoreInvitationUC := invitation.NewRestoreInvitationUseCase(invitationRepo)
+	duplicateInvitationUC := invitation.NewDuplicateInvitationUseCase(invitationRepo, createInvitationUC, migrateInvitationDataUC)
+	switchLayoutUC := invitation.NewSwitchLayoutUseCase(invitationRepo, assetRepo, revisionRecorder, migrateInvitationDataUC, validateInvitationDataUC, popularityRecorder)
+	upgradeLayoutVersionUC := invitation.NewUpgradeLayoutVersionUseCase(invitationRepo, layoutRepo, revisionRecorder, validateInvitationDataUC)
+	saveAsTemplateUC := invitation.NewSaveAsTemplateUseCase(invitationRepo, invitationTemplateRepo, assetRepo)
+	listTemplatesUC := invitation.NewListTemplatesUseCase(invitationTemplateRepo)
+	getTemplateUC := invitation.NewGetTemplateUseCase(invitationTemplateRepo)
+	deleteTemplateUC := invitation.NewDeleteTemplateUseCase(invitationTemplateRepo, assetRepo)
+	createFromTemplateUC := invitation.NewCreateFromTemplateUseCase(invitationTemplateRepo, createInvitationUC)
+
+	createThemeUC := theme.NewCreateThemeUseCase(themeRepo, layoutCatalog)
+	listThemesUC := theme.NewListThemesUseCase(themeRepo)
+	getThemeUC := theme.NewGetThemeUseCase(themeRepo)
+	updateThemeUC := theme.NewUpdateThemeUseCase(themeRepo, layoutCatalog)
+	deleteThemeUC := theme.NewDeleteThemeUseCase(themeRepo)
+	listThemeFontsUC := theme.NewListFontsUseCase(layoutCatalog)
+	resolveThemeUC := theme.NewResolveThemeUseCase(themeRepo)
+
+	submitRSVPUC := rsvp.NewSubmitRSVPUseCase(rsvpRepo)
+	getRSVPByInvitationUC := rsvp.NewGetRSVPByInvitationUseCase(rsvpRepo)
+
+	trackViewUC := analytics.NewTrackViewUseCase(analyticsRepo)
+	getAnalyticsByInvitationUC := analytics.NewGetAnalyticsByInvitationUseCase(analyticsRepo)
+
+	// Publish use cases (wiring generator/storage happens later; defaults to noop)
+	validateSubdomainUC := publishUC.NewValidateSubdomainUseCase(publishedSiteRepo)
+	var snapshotGen publishUC.SnapshotGenerator
+	var artifactStore publishUC.ArtifactStorage
+
+	snapshotGenConcrete, err := publishinfra.NewNodeSnapshotGenerator(
+		invitationRepo,
+		getLayoutManifestUC,
+		resolveThemeUC,
+		cfg.Publishing.SnapshotRendererScript,
+		cfg.Publishing.SnapshotRendererNode,
+	)
+	if err != nil {
+		logger.GetLogger().Warn("Publish snapshot generator not configured; publishing will fail", zap.Error(err))
+		snapshotGen = &publishinfra.NoopSnapshotGenerator{}
+	} else {
+		snapshotGen = snapshotGenConcrete
+	}
+
+	switch cfg.Publishing.ArtifactStore {
+	case "r2":
+		r2Store, err := publishinfra.NewR2ArtifactStorage(ctx, publishinfra.R2Config{
+			AccountID:       cfg.Publishing.R2AccountID,
+			AccessKeyID:     cfg.Publishing.R2AccessKeyID,
+			SecretAccessKey: cfg.Publishing.R2SecretAccessKey,
+			Bucket:          cfg.Publishing.R2Bucket,
+			PublicBase:      cfg.Publishing.R2PublicBase,
+			Endpoint:        cfg.Publishing.R2Endpoint,
+		})
+		if err != nil {
+			logger.GetLogger().Warn("R2 artifact storage not configured; publishing will fail", zap.Error(err))
+			artifactStore = &publishinfra.NoopArtifactStorage{}
+		} else {
+			artifactStore = r2Store
+		}
+	case "gcs":
+		gcsStore, err := publishinfra.NewGCSArtifactStorage(ctx, publishinfra.GCSConfig{
+			Bucket:     cfg.Publishing.GCSBucket,
+			PublicBase: cfg.Publishing.GCSPublicBase,
+		})
+		if err != nil {
+			logger.GetLogger().Warn("GCS artifact storage not configured; publishing will fail", zap.Error(err))
+			artifactStore = &publishinfra.NoopArtifactStorage{}
+		} else {
+			artifactStore = gcsStore
+			defer gcsStore.Close()
+		}
+	default:
+		artifactStoreConcrete, err := publishinfra.NewFilesystemArtifactStorage()
+		if err != nil {
+			logger.GetLogger().Warn("Filesystem artifact storage not configured; publishing will fail", zap.Error(err))
+			artifactStore = &publishinfra.NoopArtifactStorage{}
+		} else {
+			artifactStore = artifactStoreConcrete
+		}
+	}
+	// Public base used when redirecting guests to object storage (r2/gcs); empty means serve via the API.
+	artifactPublicBase := cfg.Publishing.R2PublicBase
+	if cfg.Publishing.ArtifactStore == "gcs" {
+		artifactPublicBase = cfg.Publishing.GCSPublicBase
+	}
+	var cachePurger publishUC.CachePurger = &publishinfra.NoopCachePurger{}
+	if cfg.Publishing.CachePurger == "cloudflare" {
+		cfPurger, err := publishinfra.NewCloudflareCachePurger(publishinfra.CloudflareConfig{
+			ZoneID:           cfg.Publishing.CloudflareZoneID,
+			APIToken:         cfg.Publishing.CloudflareAPIToken,
+			BaseDomain:       cfg.Publishing.BaseDomain,
+			SubdomainSuffix:  cfg.Publishing.SubdomainSuffix,
+			ResolveAPIOrigin: cfg.Publishing.ResolveAPIOrigin,
+		})
+		if err != nil {
+			logger.GetLogger().Warn("Cloudflare cache purger not configured; CDN caches will expire on their TTL", zap.Error(err))
+		} else {
+			cachePurger = cfPurger
+		}
+	}
+	publishInvitationUC := publishUC.NewPublishInvitationUseCase(invitationRepo, publishedSiteRepo, snapshotGen, artifactStore, cachePurger, popularityRecorder, clk, cfg.Publishing.VersionRetentionCount)
+	unpublishInvitationUC := publishUC.NewUnpublishInvitationUseCase(invitationRepo, publishedSiteRepo, cachePurger, clk)
+	listVersionsUC := publishUC.NewListPublishedVersionsUseCase(publishedSiteRepo, artifactStore)
+	rollbackUC := publishUC.NewRollbackPublishedSiteUseCase(publishedSiteRepo, artifactStore, cachePurger)
+	exportUC := publishUC.NewExportPublishedSiteUseCase(publishedSiteRepo, artifactStore, cfg.Publishing.ExportMaxBytes)
+
+	// Initialize handlers
+	authHandler := handlers.NewAuthHandler(registerUC, loginUC, getCurrentUserUC, deleteUserUC, googleOAuthUC, refreshTokenUC, requestPasswordResetUC, resetPasswordUC, requestPasswordChangeOTPUC, verifyPasswordChangeOTPUC, refreshTokenRepo, jwtService, googleOAuthService, hmacKeys, cfg.Auth.RefreshTokenHMACActiveKeyID)
+	invitationHandler := handlers.NewInvitationHandler(createInvitationUC, getInvitationByIDUC, getAllInvitationsUC, getInvitationPreviewUC, updateInvitationUC, patchInvitationUC, deleteInvitationUC, listTrashedInvitationsUC, restoreInvitationUC, duplicateInvitationUC, switchLayoutUC, upgradeLayoutVersionUC, migrateInvitationsUC)
+	revisionHandler := handlers.NewInvitationRevisionHandler(listRevisionsUC, getRevisionUC, restoreRevisionUC)
+	templateHandler := handlers.NewInvitationTemplateHandler(saveAsTemplateUC, listTemplatesUC, getTemplateUC, deleteTemplateUC, createFromTemplateUC)
+	themeHandler := handlers.NewThemeHandler(createThemeUC, listThemesUC, getThemeUC, updateThemeUC, deleteThemeUC, listThemeFontsUC)
+	layoutHandler := handlers.NewLayoutHandler(getAllLayoutsUC, getLayoutByIDUC, getLayoutManifestUC, getManifestsUC, cfg.Layouts.CacheMaxAge)
+	adminLayoutHandler := handlers.NewAdminLayoutHandler(listAdminLayoutsUC, createLayoutUC, updateLayoutUC, setLayoutActiveUC, retireLayoutUC, listLayoutAuditUC)
+	assetHandler := handlers.NewAssetHandler(uploadAssetUC, getAllAssetsUC, deleteAssetUC, trashAssetUC, listTrashedAssetsUC, restoreAssetUC, deleteAssetsByURLsUC, getAssetsByURLsUC, fileStorage, gcsStorage, cfg.Storage.SignedURLExpiration, imageProcessor, cfg.Storage.MaxFileSize)
+	rsvpHandler := handlers.NewRSVPHandler(submitRSVPUC, getRSVPByInvitationUC)
+	analyticsHandler := handlers.NewAnalyticsHandler(trackViewUC, getAnalyticsByInvitationUC)
+	publishHandler := handlers.NewPublishHandler(validateSubdomainUC, publishInvitationUC, unpublishInvitationUC, listVersionsUC, rollbackUC, exportUC, cfg.Publishing.BaseDomain, cfg.Publishing.SubdomainSuffix, cfg.Server.Port)
+	var artifactHandler *handlers.PublishedArtifactHandler
+	if cfg.Publishing.ServeArtifacts {
+		artifactHandler = handlers.NewPublishedArtifactHandler(artifactStore)
+		logger.GetLogger().Info("Serving published artifacts from the API", zap.String("artifactStore", cfg.Publishing.ArtifactStore))
+	}
+	resolveHandler := handlers.NewPublishedSiteResolveHandler(publishedSiteRepo, cfg.Publishing.BaseDomain, artifactPublicBase, cfg.Publishing.ArtifactStore, artifactHandler)
+	resolveAPIHandler := handlers.NewPublishedResolveAPIHandler(publishedSiteRepo, cfg.Publishing.BaseDomain)
+
+	// Initialize observability if enabled
+	if cfg.Observability.Enabled {
+		tracerCfg := observability.TracerConfig{
+			Enabled:               cfg.Observability.Enabled,
+			Endpoint:              cfg.Observability.ExporterEndpoint,
+			Protocol:              cfg.Observability.ExporterProtocol,
+			ServiceName:           cfg.Observability.ServiceName,
+			ServiceVersion:        cfg.Observability.ServiceVersion,
+			DeploymentEnvironment: cfg.Observability.DeploymentEnvironment,
+			SamplingRate:          cfg.Observability.SamplingRate,
+		}
+		meterCfg := observability.MeterConfig{
+			Enabled:               cfg.Observability.Enabled,
+			Endpoint:              cfg.Observability.MetricsEndpoint,
+			Protocol:              cfg.Observability.MetricsProtocol,
+			ServiceName:           cfg.Observability.ServiceName,
+			ServiceVersion:        cfg.Observability.ServiceVersion,
+			DeploymentEnvironment: cfg.Observability.DeploymentEnvironment,
+		}
+		if err := observability.Init(ctx, tracerCfg, meterCfg); err != nil {
+			logger.GetLogger().Error("Failed to initialize observability", zap.Error(err))
+		} else {
+			// Initialize metrics
+			meter := observability.Meter("sacred-vows-api")
+			if err := observability.InitMetrics(meter); err != nil {
+				logger.GetLogger().Warn("Failed to initialize metrics", zap.Error(err))
+			} else {
+				// Start active users tracker with context for graceful shutdown
+				observability.StartActiveUsersTracker(ctx)
+				logger.GetLogger().Info("Active users tracker started")
+			}
+		}
+	}
+
+	// Setup router
+	router := httpRouter.NewRouter(authHandler, invitationHandler, revisionHandler, templateHandler, themeHandler, layoutHandler, adminLayoutHandler, assetHandler, rsvpHandler, analyticsHandler, publishHandler, resolveHandler, resolveAPIHandler, artifactHandler, jwtService, guestSessions, userRepo, cfg.Google.FrontendURL, cfg.Observability, artifactPublicBase, cfg.Publishing.ArtifactStore)
+	engine := router.Setup()
+
+	// Create HTTP server
+	srv := &http.Server{
+		Addr:         ":" + cfg.Server.Port,
+		Handler:      engine,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+	}
+
+	// Start server in goroutine
+	go func() {
+		logger.GetLogger().Info("Starting server", zap.String("port", cfg.Server.Port))
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.GetLogger().Fatal("Failed to start server", zap.Error(err))
+		}
+	}()
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.GetLogger().Info("Shutting down server...")
+
+	// Graceful shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.GetLogger().Fatal("Server forced to shutdown", zap.Error(err))
+	}
+
+	// Shutdown observability (flush telemetry)
+	if cfg.Observability.Enabled {
+		// Stop active users tracker gracefully
+		observability.StopActiveUsersTracker()
+		logger.GetLogger().Info("Active users tracker stopped")
+
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := observability.Shutdown(shutdownCtx); err != nil {
+			logger.GetLogger().Warn("Failed to shutdown observability", zap.Error(err))
+		}
+	}
+
+	logger.GetLogger().Info("Server exited")
+}
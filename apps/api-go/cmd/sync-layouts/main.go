@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/sacred-vows/api-go/internal/infrastructure/database/firestore"
+	"github.com/sacred-vows/api-go/internal/infrastructure/layoutcatalog"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/layouts"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "Preview which layouts would be created or updated without writing them")
+	dir := flag.String("dir", "", "Read the catalog from this directory instead of the one built into the binary")
+	flag.Parse()
+
+	// Initialize logger
+	if err := logger.Init(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.GetLogger().Sync()
+
+	// Load the catalog before connecting so a broken catalog fails fast
+	var catalogFS fs.FS = layouts.FS
+	if *dir != "" {
+		catalogFS = os.DirFS(*dir)
+	}
+	catalog, err := layoutcatalog.Load(catalogFS)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid layout catalog:\n%s\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize Firestore database
+	ctx := context.Background()
+	firestoreClient, err := firestore.NewFromEnv(ctx)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to connect to Firestore", zap.Error(err))
+	}
+	defer firestoreClient.Close()
+
+	// Initialize repositories and use cases
+	layoutRepo := firestore.NewLayoutRepository(firestoreClient)
+	syncLayoutsUC := layout.NewSyncLayoutsUseCase(layoutRepo, layout.NewAuditRecorder(), nil)
+
+	output, err := syncLayoutsUC.Execute(ctx, layout.SyncLayoutsInput{
+		Layouts: catalog,
+		DryRun:  *dryRun,
+	})
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok && len(appErr.Details) > 0 {
+			fmt.Fprintf(os.Stderr, "%s:\n", appErr.Message)
+			for _, detail := range appErr.Details {
+				fmt.Fprintf(os.Stderr, "  - %s: %s\n", detail.Field, detail.Message)
+			}
+			os.Exit(1)
+		}
+		logger.GetLogger().Fatal("Layout sync failed", zap.Error(err))
+	}
+
+	// Print results
+	fmt.Printf("\n=== Layout Sync Results ===\n")
+	for _, result := range output.Results {
+		line := fmt.Sprintf("%-24s %-18s catalog %s", result.ID, result.Result, result.CatalogVersion)
+		if result.StoredVersion != "" {
+			line += fmt.Sprintf(", stored %s", result.StoredVersion)
+		}
+		if len(result.Changes) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(result.Changes, ", "))
+		}
+		fmt.Println(line)
+	}
+	if *dryRun {
+		fmt.Printf("\n[DRY RUN] Nothing was written\n")
+	}
+
+	logger.GetLogger().Info("Layout sync completed")
+}
@@ -13,7 +13,38 @@ type Asset struct {
 	Size         int64
 	MimeType     string
 	UserID       string
-	CreatedAt    time.Time
+	// Width and Height are the stored image's pixel size; zero for files that are not processed
+	Width  int
+	Height int
+	// Variants are downscaled copies stored alongside the original, narrowest first
+	Variants  []AssetVariant
+	CreatedAt time.Time
+	// DeletedAt is set while the asset is in the trash; the record and file are purged after the retention window
+	DeletedAt *time.Time
+}
+
+// AssetVariant is a downscaled copy of an image asset, for responsive images (srcset)
+type AssetVariant struct {
+	Name     string // thumbnail, medium or large
+	Filename string
+	URL      string
+	Width    int
+	Height   int
+	Size     int64
+}
+
+// Filenames returns the stored files of the asset: the original, then its variants
+func (a *Asset) Filenames() []string {
+	filenames := []string{a.Filename}
+	for _, variant := range a.Variants {
+		filenames = append(filenames, variant.Filename)
+	}
+	return filenames
+}
+
+// IsTrashed reports whether the asset has been moved to the trash
+func (a *Asset) IsTrashed() bool {
+	return a.DeletedAt != nil
 }
 
 // Validate validates asset entity
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// AuditAction names an administrative change
+type AuditAction string
+
+const (
+	AuditActionLayoutCreate     AuditAction = "layout.create"
+	AuditActionLayoutUpdate     AuditAction = "layout.update"
+	AuditActionLayoutActivate   AuditAction = "layout.activate"
+	AuditActionLayoutDeactivate AuditAction = "layout.deactivate"
+	AuditActionLayoutRetire     AuditAction = "layout.retire"
+)
+
+// AuditResourceLayout is the ResourceType of layout audit entries
+const AuditResourceLayout = "layout"
+
+// AuditEntry records who changed what. Entries are append-only.
+type AuditEntry struct {
+	ID           string
+	ActorID      string
+	Action       AuditAction
+	ResourceType string
+	ResourceID   string
+	// Changes lists the fields the action changed
+	Changes   []string
+	CreatedAt time.Time
+}
+
+// NewAuditEntry creates an audit entry for an action by actorID on a resource
+func NewAuditEntry(actorID string, action AuditAction, resourceType, resourceID string, changes []string) (*AuditEntry, error) {
+	entry := &AuditEntry{
+		ActorID:      actorID,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Changes:      changes,
+	}
+
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Validate validates audit entry
+func (e *AuditEntry) Validate() error {
+	if e.ActorID == "" {
+		return ErrInvalidUserID
+	}
+	if e.Action == "" || e.ResourceType == "" || e.ResourceID == "" {
+		return ErrInvalidAuditEntry
+	}
+	return nil
+}
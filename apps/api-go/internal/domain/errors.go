@@ -14,6 +14,13 @@ var (
 	ErrInvalidInvitationID  = errors.New("invalid invitation ID")
 	ErrInvalidSubdomain     = errors.New("invalid subdomain")
 	ErrSubdomainTaken       = errors.New("subdomain already taken")
+	ErrArtifactNotFound     = errors.New("artifact not found")
+	ErrExportTooLarge       = errors.New("export exceeds size limit")
+	ErrInvalidLocale        = errors.New("invalid locale")
+	ErrVersionConflict      = errors.New("invitation version conflict")
+	ErrInvalidStatus        = errors.New("invalid invitation status")
+	ErrInvalidTransition    = errors.New("invalid invitation status transition")
+	ErrInvalidTemplateName  = errors.New("invalid template name")
 	ErrInvalidName          = errors.New("invalid name")
 	ErrInvalidDate          = errors.New("invalid date")
 	ErrInvalidAnalyticsType = errors.New("invalid analytics type")
@@ -26,4 +33,8 @@ var (
 	ErrRefreshTokenNotFound = errors.New("refresh token not found")
 	ErrRefreshTokenExpired  = errors.New("refresh token expired")
 	ErrRefreshTokenRevoked  = errors.New("refresh token revoked")
+	ErrInvalidRole          = errors.New("invalid role")
+	ErrLayoutRetired        = errors.New("layout is retired")
+	ErrInvalidAuditEntry    = errors.New("invalid audit entry")
+	ErrInvalidThemeName     = errors.New("invalid theme name")
 )
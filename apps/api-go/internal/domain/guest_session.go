@@ -0,0 +1,12 @@
+package domain
+
+import "strings"
+
+// GuestSessionIDPrefix marks user IDs that belong to an anonymous browser session rather than
+// a registered user. Invitations created while logged out are owned by such an ID until claimed.
+const GuestSessionIDPrefix = "guest_"
+
+// IsGuestSessionID reports whether userID identifies an anonymous browser session
+func IsGuestSessionID(userID string) bool {
+	return strings.HasPrefix(userID, GuestSessionIDPrefix) && len(userID) > len(GuestSessionIDPrefix)
+}
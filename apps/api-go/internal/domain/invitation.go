@@ -2,17 +2,75 @@ package domain
 
 import (
 	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 )
 
 // Invitation represents an invitation entity
 type Invitation struct {
-	ID        string
-	LayoutID  string
-	Data      json.RawMessage // Wedding configuration data
-	UserID    string
+	ID       string
+	LayoutID string
+	// LayoutVersion pins the layout version the invitation renders and validates with, so
+	// layout changes only reach it through an explicit upgrade. Empty means the current version.
+	LayoutVersion string
+	Data          json.RawMessage // Wedding configuration data
+	UserID        string
+	Title         string
+	// Status changes only through TransitionTo; publish and unpublish drive the published states
+	Status InvitationStatus
+	// Translations maps a locale (e.g. "en", "pt-br") to the strings passed to the renderer.
+	// Each locale is published as its own variant; DefaultLocale is served when none matches.
+	Translations  map[string]json.RawMessage
+	DefaultLocale string
+	// Version increases by one on every successful update; updates must name the version they
+	// were based on so that concurrent editors cannot silently overwrite each other.
+	Version   int
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// DeletedAt is set while the invitation is in the trash; it is purged after the retention window
+	DeletedAt *time.Time
+}
+
+// IsTrashed reports whether the invitation has been moved to the trash
+func (i *Invitation) IsTrashed() bool {
+	return i.DeletedAt != nil
+}
+
+// VersionConflictError is returned when an update is based on a stale invitation version.
+type VersionConflictError struct {
+	CurrentVersion int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s (current version %d)", ErrVersionConflict, e.CurrentVersion)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// NormalizeLocale lowercases a BCP 47 style tag ("pt_BR" -> "pt-br") and validates it.
+func NormalizeLocale(locale string) (string, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if !localePattern.MatchString(normalized) {
+		return "", ErrInvalidLocale
+	}
+	return normalized, nil
+}
+
+// Locales returns the configured locales in sorted order.
+func (i *Invitation) Locales() []string {
+	locales := make([]string, 0, len(i.Translations))
+	for locale := range i.Translations {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
 }
 
 // Validate validates invitation entity
@@ -23,6 +81,23 @@ func (i *Invitation) Validate() error {
 	if i.UserID == "" {
 		return ErrInvalidUserID
 	}
+	if i.Status != "" {
+		if _, err := ParseInvitationStatus(string(i.Status)); err != nil {
+			return err
+		}
+	}
+	for locale := range i.Translations {
+		if normalized, err := NormalizeLocale(locale); err != nil || normalized != locale {
+			return ErrInvalidLocale
+		}
+	}
+	if len(i.Translations) > 0 {
+		if _, ok := i.Translations[i.DefaultLocale]; !ok {
+			return ErrInvalidLocale
+		}
+	} else if i.DefaultLocale != "" {
+		return ErrInvalidLocale
+	}
 	return nil
 }
 
@@ -32,6 +107,8 @@ func NewInvitation(layoutID, userID string, data json.RawMessage) (*Invitation,
 		LayoutID: layoutID,
 		UserID:   userID,
 		Data:     data,
+		Status:   InvitationStatusDraft,
+		Version:  1,
 	}
 
 	if err := invitation.Validate(); err != nil {
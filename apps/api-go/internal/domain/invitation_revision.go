@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// InvitationRevision is an immutable snapshot of an invitation's content, appended on every change
+// so that an edit can be undone by restoring an earlier revision.
+type InvitationRevision struct {
+	InvitationID  string
+	Revision      int // 1-based, increasing per invitation
+	LayoutID      string
+	LayoutVersion string
+	Data          json.RawMessage
+	Translations  map[string]json.RawMessage
+	DefaultLocale string
+	AuthorID      string
+	SizeBytes     int
+	RestoredFrom  int // revision this snapshot was restored from, 0 for regular edits
+	CreatedAt     time.Time
+}
+
+// NewInvitationRevision snapshots the current content of an invitation.
+// The revision number is assigned by the repository when the revision is appended.
+func NewInvitationRevision(invitation *Invitation, authorID string) *InvitationRevision {
+	size := len(invitation.Data)
+	for _, t := range invitation.Translations {
+		size += len(t)
+	}
+	return &InvitationRevision{
+		InvitationID:  invitation.ID,
+		LayoutID:      invitation.LayoutID,
+		LayoutVersion: invitation.LayoutVersion,
+		Data:          invitation.Data,
+		Translations:  invitation.Translations,
+		DefaultLocale: invitation.DefaultLocale,
+		AuthorID:      authorID,
+		SizeBytes:     size,
+	}
+}
+
+func (r *InvitationRevision) Validate() error {
+	if r.InvitationID == "" {
+		return ErrInvalidInvitationID
+	}
+	if r.LayoutID == "" {
+		return ErrInvalidLayoutID
+	}
+	return nil
+}
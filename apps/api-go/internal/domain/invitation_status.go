@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// InvitationStatus is the lifecycle state of an invitation
+type InvitationStatus string
+
+const (
+	InvitationStatusDraft       InvitationStatus = "draft"
+	InvitationStatusPublished   InvitationStatus = "published"
+	InvitationStatusUnpublished InvitationStatus = "unpublished"
+	InvitationStatusArchived    InvitationStatus = "archived"
+)
+
+// invitationTransitions lists the states each state may move to. Publishing a published
+// invitation is allowed (it publishes a new version); archiving leaves a published site online,
+// and unpublishing such an invitation takes the site down without changing its status.
+var invitationTransitions = map[InvitationStatus][]InvitationStatus{
+	InvitationStatusDraft:       {InvitationStatusPublished, InvitationStatusArchived},
+	InvitationStatusPublished:   {InvitationStatusPublished, InvitationStatusUnpublished, InvitationStatusArchived},
+	InvitationStatusUnpublished: {InvitationStatusPublished, InvitationStatusArchived},
+	InvitationStatusArchived:    {InvitationStatusDraft},
+}
+
+// ParseInvitationStatus validates a status string
+func ParseInvitationStatus(s string) (InvitationStatus, error) {
+	status := InvitationStatus(s)
+	if _, ok := invitationTransitions[status]; !ok {
+		return "", ErrInvalidStatus
+	}
+	return status, nil
+}
+
+// CanTransitionTo reports whether an invitation in state s may move to next.
+// The zero status (records written before statuses existed) counts as draft.
+func (s InvitationStatus) CanTransitionTo(next InvitationStatus) bool {
+	if s == "" {
+		s = InvitationStatusDraft
+	}
+	for _, allowed := range invitationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the invitation to next, or returns ErrInvalidTransition
+func (i *Invitation) TransitionTo(next InvitationStatus) error {
+	if !i.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, i.Status, next)
+	}
+	i.Status = next
+	return nil
+}
+
+// SplitLegacyMetadata removes the "_meta" object that older clients stored title and status in
+// from an invitation's data, returning the remaining data and the values it held.
+// Data without "_meta" is returned unchanged.
+func SplitLegacyMetadata(data json.RawMessage) (rest json.RawMessage, title, status string, err error) {
+	if len(data) == 0 {
+		return data, "", "", nil
+	}
+	var dataMap map[string]json.RawMessage
+	if err := json.Unmarshal(data, &dataMap); err != nil {
+		return nil, "", "", err
+	}
+	raw, ok := dataMap["_meta"]
+	if !ok {
+		return data, "", "", nil
+	}
+
+	var meta struct {
+		Title  string `json:"title"`
+		Status string `json:"status"`
+	}
+	// A malformed _meta is dropped rather than rejected; it never held user content
+	_ = json.Unmarshal(raw, &meta)
+	delete(dataMap, "_meta")
+
+	rest, err = json.Marshal(dataMap)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return rest, meta.Title, meta.Status, nil
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// MaxTemplateNameLength bounds the length of a template name
+const MaxTemplateNameLength = 120
+
+// InvitationTemplate is a user's reusable starting point for new invitations, saved from one of
+// their invitations. It holds a copy of the content; later edits to the source do not change it.
+type InvitationTemplate struct {
+	ID          string
+	UserID      string
+	Name        string
+	Description string
+	LayoutID    string
+	Data        json.RawMessage
+	// Translations and DefaultLocale are copied from the source like Data
+	Translations  map[string]json.RawMessage
+	DefaultLocale string
+	// SourceInvitationID is informational; the source may since have been changed or deleted
+	SourceInvitationID string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// NewInvitationTemplate copies an invitation's content into a template owned by userID
+func NewInvitationTemplate(invitation *Invitation, userID, name, description string) (*InvitationTemplate, error) {
+	template := &InvitationTemplate{
+		UserID:             userID,
+		Name:               strings.TrimSpace(name),
+		Description:        strings.TrimSpace(description),
+		LayoutID:           invitation.LayoutID,
+		Data:               append(json.RawMessage(nil), invitation.Data...),
+		Translations:       CopyTranslations(invitation.Translations),
+		DefaultLocale:      invitation.DefaultLocale,
+		SourceInvitationID: invitation.ID,
+	}
+
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// Validate validates invitation template entity
+func (t *InvitationTemplate) Validate() error {
+	if t.Name == "" || len(t.Name) > MaxTemplateNameLength {
+		return ErrInvalidTemplateName
+	}
+	if t.LayoutID == "" {
+		return ErrInvalidLayoutID
+	}
+	if t.UserID == "" {
+		return ErrInvalidUserID
+	}
+	return nil
+}
+
+// CopyTranslations returns a deep copy of an invitation's translations, or nil when there are none
+func CopyTranslations(translations map[string]json.RawMessage) map[string]json.RawMessage {
+	if len(translations) == 0 {
+		return nil
+	}
+	out := make(map[string]json.RawMessage, len(translations))
+	for locale, t := range translations {
+		out[locale] = append(json.RawMessage(nil), t...)
+	}
+	return out
+}
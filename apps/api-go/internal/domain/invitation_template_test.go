@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInvitationTemplate_CopiesInvitationContent(t *testing.T) {
+	// Arrange
+	invitation := &Invitation{
+		ID:            "inv-1",
+		LayoutID:      "classic-scroll",
+		UserID:        "user-123",
+		Data:          json.RawMessage(`{"events":{}}`),
+		Translations:  map[string]json.RawMessage{"en": json.RawMessage(`{"title":"Hi"}`)},
+		DefaultLocale: "en",
+	}
+
+	// Act
+	template, err := NewInvitationTemplate(invitation, "user-123", "  Planner base  ", "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Planner base", template.Name)
+	assert.Equal(t, "classic-scroll", template.LayoutID)
+	assert.Equal(t, "inv-1", template.SourceInvitationID)
+	assert.JSONEq(t, `{"events":{}}`, string(template.Data))
+	assert.Equal(t, "en", template.DefaultLocale)
+
+	// The template must not share memory with the invitation
+	invitation.Data[2] = 'X'
+	invitation.Translations["en"][2] = 'X'
+	assert.JSONEq(t, `{"events":{}}`, string(template.Data))
+	assert.JSONEq(t, `{"title":"Hi"}`, string(template.Translations["en"]))
+}
+
+func TestNewInvitationTemplate_InvalidName_ReturnsError(t *testing.T) {
+	invitation := &Invitation{LayoutID: "classic-scroll", UserID: "user-123"}
+
+	for _, name := range []string{"", "   ", strings.Repeat("a", MaxTemplateNameLength+1)} {
+		_, err := NewInvitationTemplate(invitation, "user-123", name, "")
+		assert.ErrorIs(t, err, ErrInvalidTemplateName)
+	}
+}
+
+func TestStripPersonalData_RemovesPersonalSections(t *testing.T) {
+	// Arrange
+	data := json.RawMessage(`{"couple":{"bride":{"name":"Jane"}},"wedding":{"dates":["2026-01-22"]},"rsvp":{"whatsappNumber":"91"},"events":{"day1":{}},"music":{"volume":0.5}}`)
+
+	// Act
+	stripped, err := StripPersonalData(data)
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"events":{"day1":{}},"music":{"volume":0.5}}`, string(stripped))
+}
+
+func TestStripPersonalData_BlanksEventDetails(t *testing.T) {
+	// Arrange
+	data := json.RawMessage(`{"events":{"day1":{"date":"22 Jan","events":[{"label":"Mehendi","time":"4 PM","image":"/uploads/m.jpg"}]}}}`)
+
+	// Act
+	stripped, err := StripPersonalData(data)
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"events":{"day1":{"date":"","events":[{"label":"Mehendi","time":"","image":""}]}}}`, string(stripped))
+}
+
+func TestStripPersonalTranslations_StripsEachLocale(t *testing.T) {
+	// Arrange
+	translations := map[string]json.RawMessage{
+		"en": json.RawMessage(`{"couple":{"bride":{"name":"Jane"}},"events":{"title":"Events"}}`),
+		"hi": json.RawMessage(`{"wedding":{"venue":{"address":"Delhi"}}}`),
+	}
+
+	// Act
+	stripped, err := StripPersonalTranslations(translations)
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"events":{"title":"Events"}}`, string(stripped["en"]))
+	assert.JSONEq(t, `{}`, string(stripped["hi"]))
+	assert.Contains(t, string(translations["en"]), "Jane", "The source translations are left as they were")
+}
+
+func TestStripPersonalData_InvalidJSON_ReturnsError(t *testing.T) {
+	_, err := StripPersonalData(json.RawMessage(`[1,2]`))
+
+	assert.Error(t, err)
+}
@@ -71,3 +71,86 @@ func TestNewInvitation_ValidData_ReturnsInvitation(t *testing.T) {
 	assert.Equal(t, userID, invitation.UserID, "User ID should match")
 	assert.Equal(t, data, invitation.Data, "Data should match")
 }
+
+func TestInvitation_Validate_Translations(t *testing.T) {
+	tests := []struct {
+		name          string
+		translations  map[string]json.RawMessage
+		defaultLocale string
+		wantErr       bool
+	}{
+		{"no_translations", nil, "", false},
+		{"default_present", map[string]json.RawMessage{"en": json.RawMessage(`{}`), "hi": json.RawMessage(`{}`)}, "hi", false},
+		{"default_missing", map[string]json.RawMessage{"en": json.RawMessage(`{}`)}, "fr", true},
+		{"default_without_translations", nil, "en", true},
+		{"unnormalized_locale", map[string]json.RawMessage{"pt_BR": json.RawMessage(`{}`)}, "pt_BR", true},
+		{"invalid_locale", map[string]json.RawMessage{"../x": json.RawMessage(`{}`)}, "../x", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitation := &Invitation{LayoutID: "classic-scroll", UserID: "user-123", Translations: tt.translations, DefaultLocale: tt.defaultLocale}
+			err := invitation.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidLocale)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	got, err := NormalizeLocale(" pt_BR ")
+	require.NoError(t, err)
+	assert.Equal(t, "pt-br", got)
+
+	_, err = NormalizeLocale("english!")
+	assert.ErrorIs(t, err, ErrInvalidLocale)
+}
+
+func TestInvitationStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to InvitationStatus
+		want     bool
+	}{
+		{InvitationStatusDraft, InvitationStatusPublished, true},
+		{InvitationStatusDraft, InvitationStatusUnpublished, false},
+		{InvitationStatusDraft, InvitationStatusArchived, true},
+		{InvitationStatusPublished, InvitationStatusPublished, true},
+		{InvitationStatusPublished, InvitationStatusUnpublished, true},
+		{InvitationStatusPublished, InvitationStatusArchived, true},
+		{InvitationStatusPublished, InvitationStatusDraft, false},
+		{InvitationStatusUnpublished, InvitationStatusPublished, true},
+		{InvitationStatusUnpublished, InvitationStatusDraft, false},
+		{InvitationStatusArchived, InvitationStatusDraft, true},
+		{InvitationStatusArchived, InvitationStatusPublished, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to), "%s -> %s", tt.from, tt.to)
+	}
+}
+
+func TestInvitation_TransitionTo_InvalidTransition_KeepsStatus(t *testing.T) {
+	invitation := &Invitation{Status: InvitationStatusArchived}
+
+	err := invitation.TransitionTo(InvitationStatusPublished)
+
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+	assert.Equal(t, InvitationStatusArchived, invitation.Status)
+}
+
+func TestSplitLegacyMetadata(t *testing.T) {
+	rest, title, status, err := SplitLegacyMetadata(json.RawMessage(`{"couple":{"bride":"A"},"_meta":{"title":"Our Wedding","status":"published"}}`))
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"couple":{"bride":"A"}}`, string(rest))
+	assert.Equal(t, "Our Wedding", title)
+	assert.Equal(t, "published", status)
+
+	unchanged := json.RawMessage(`{"couple":{}}`)
+	rest, title, status, err = SplitLegacyMetadata(unchanged)
+	require.NoError(t, err)
+	assert.Equal(t, unchanged, rest)
+	assert.Empty(t, title)
+	assert.Empty(t, status)
+}
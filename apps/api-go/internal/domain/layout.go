@@ -16,14 +16,47 @@ type Layout struct {
 	Config       *json.RawMessage
 	Manifest     *json.RawMessage
 	IsActive     bool
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// RetiredAt is set once a layout is withdrawn for good. Retired layouts stay readable so
+	// invitations built on them keep rendering, but they cannot be chosen or reactivated.
+	RetiredAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 // Validate validates layout entity
 func (l *Layout) Validate() error {
+	if l.ID == "" {
+		return ErrInvalidLayoutID
+	}
 	if l.Name == "" {
 		return ErrInvalidLayoutName
 	}
 	return nil
 }
+
+// IsRetired reports whether the layout has been retired
+func (l *Layout) IsRetired() bool {
+	return l.RetiredAt != nil
+}
+
+// IsSelectable reports whether new invitations may use the layout
+func (l *Layout) IsSelectable() bool {
+	return l.IsActive && !l.IsRetired()
+}
+
+// SetActive activates or deactivates the layout. Retired layouts cannot be activated.
+func (l *Layout) SetActive(active bool) error {
+	if active && l.IsRetired() {
+		return ErrLayoutRetired
+	}
+	l.IsActive = active
+	return nil
+}
+
+// Retire withdraws the layout from the catalog for good
+func (l *Layout) Retire(at time.Time) {
+	l.IsActive = false
+	if l.RetiredAt == nil {
+		l.RetiredAt = &at
+	}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// layoutPublishWeight is how many selections a publish counts for when ranking layouts: many
+// invitations are started and never finished, so a published one says more about the layout
+const layoutPublishWeight = 3
+
+// LayoutStats counts how often a layout is chosen, for ranking the catalog by popularity
+type LayoutStats struct {
+	LayoutID string
+	// Selections counts invitations created on the layout or switched to it
+	Selections int
+	// Publishes counts invitations published on the layout for the first time
+	Publishes int
+	UpdatedAt time.Time
+}
+
+// Popularity is the score layouts are ranked by. Nil stats score zero.
+func (s *LayoutStats) Popularity() int {
+	if s == nil {
+		return 0
+	}
+	return s.Selections + layoutPublishWeight*s.Publishes
+}
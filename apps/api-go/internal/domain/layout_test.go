@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayout_SetActive_RetiredLayout_ReturnsError(t *testing.T) {
+	// Arrange
+	layout := &Layout{ID: "classic-scroll", Name: "Classic Scroll", IsActive: true}
+	retiredAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	layout.Retire(retiredAt)
+	err := layout.SetActive(true)
+
+	// Assert
+	require.ErrorIs(t, err, ErrLayoutRetired)
+	assert.False(t, layout.IsActive)
+	assert.False(t, layout.IsSelectable())
+	require.NotNil(t, layout.RetiredAt)
+	assert.Equal(t, retiredAt, *layout.RetiredAt)
+
+	// Retiring again keeps the original date
+	layout.Retire(retiredAt.Add(time.Hour))
+	assert.Equal(t, retiredAt, *layout.RetiredAt)
+}
+
+func TestLayout_IsSelectable(t *testing.T) {
+	layout := &Layout{ID: "classic-scroll", Name: "Classic Scroll"}
+	assert.False(t, layout.IsSelectable())
+
+	require.NoError(t, layout.SetActive(true))
+	assert.True(t, layout.IsSelectable())
+
+	require.NoError(t, layout.SetActive(false))
+	assert.False(t, layout.IsSelectable())
+}
+
+func TestLayoutStats_Popularity_WeighsPublishes(t *testing.T) {
+	var none *LayoutStats
+	stats := &LayoutStats{LayoutID: "classic-scroll", Selections: 4, Publishes: 2}
+
+	assert.Equal(t, 0, none.Popularity())
+	assert.Equal(t, 10, stats.Popularity())
+}
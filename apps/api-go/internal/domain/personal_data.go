@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// PersonalDataKeys are the top-level invitation data sections that describe a specific couple:
+// names, family, wedding party, dates, venue, RSVP contacts, letters, photos and videos. Event
+// structure, music, theme and layout configuration are kept so the invitation can be reused for
+// someone else.
+var PersonalDataKeys = []string{
+	"branding",
+	"couple",
+	"wedding",
+	"weddingParty",
+	"rsvp",
+	"customContent",
+	"gallery",
+	"hero",
+	"editorialIntro",
+	"video",
+}
+
+// PersonalEventKeys are the fields blanked at any depth of the "events" section, so the event
+// names and order survive while the couple's dates, times and photos do not.
+var PersonalEventKeys = []string{"date", "time", "image"}
+
+// StripPersonalData returns a copy of invitation data without the PersonalDataKeys sections and
+// with the PersonalEventKeys of its events blanked. Empty data is returned unchanged.
+func StripPersonalData(data json.RawMessage) (json.RawMessage, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	var dataMap map[string]json.RawMessage
+	if err := json.Unmarshal(data, &dataMap); err != nil {
+		return nil, err
+	}
+	for _, key := range PersonalDataKeys {
+		delete(dataMap, key)
+	}
+	if events, ok := dataMap["events"]; ok {
+		blanked, err := blankEventDetails(events)
+		if err != nil {
+			return nil, err
+		}
+		dataMap["events"] = blanked
+	}
+	return json.Marshal(dataMap)
+}
+
+// blankEventDetails replaces the PersonalEventKeys of every object inside an events section with
+// an empty string.
+func blankEventDetails(events json.RawMessage) (json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(events))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(blankKeys(value))
+}
+
+func blankKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = blankKeys(child)
+		}
+		for _, key := range PersonalEventKeys {
+			if _, ok := v[key]; ok {
+				v[key] = ""
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = blankKeys(child)
+		}
+	}
+	return value
+}
+
+// StripPersonalTranslations returns a copy of an invitation's translations with StripPersonalData
+// applied to each locale's overrides.
+func StripPersonalTranslations(translations map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	out := CopyTranslations(translations)
+	for locale, t := range out {
+		stripped, err := StripPersonalData(t)
+		if err != nil {
+			return nil, err
+		}
+		out[locale] = stripped
+	}
+	return out, nil
+}
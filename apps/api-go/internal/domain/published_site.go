@@ -10,9 +10,12 @@ type PublishedSite struct {
 	Subdomain      string
 	Published      bool
 	CurrentVersion int
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	PublishedAt    *time.Time
+	// Locales published with the current version; empty for single-language sites.
+	Locales       []string
+	DefaultLocale string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	PublishedAt   *time.Time
 }
 
 func (p *PublishedSite) Validate() error {
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxThemeNameLength bounds the length of a theme name
+const MaxThemeNameLength = 60
+
+var (
+	// ThemeColorRoles are the colors a theme may set, as in the themes of layout manifests
+	ThemeColorRoles = []string{"primary", "secondary", "background", "text", "accent"}
+	// RequiredThemeColorRoles must be set; accent falls back to the primary color in the renderer
+	RequiredThemeColorRoles = []string{"primary", "secondary", "background", "text"}
+	// ThemeFontRoles are the fonts a theme sets; all are required
+	ThemeFontRoles = []string{"heading", "body", "script"}
+)
+
+// themeColorPattern matches the hex colors layout manifests accept (#rgb, #rgba, #rrggbb, #rrggbbaa)
+var themeColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// Theme is a user's own palette and fonts. Invitations refer to it from
+// layoutConfig.theme.customThemeId, so one theme can be applied to any invitation and layout.
+type Theme struct {
+	ID     string
+	UserID string
+	Name   string
+	// Colors and Fonts are keyed by role (ThemeColorRoles, ThemeFontRoles)
+	Colors    map[string]string
+	Fonts     map[string]string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewTheme creates a theme owned by userID. Colors and fonts are checked by the caller, which
+// knows the fonts layouts support.
+func NewTheme(userID, name string, colors, fonts map[string]string) (*Theme, error) {
+	theme := &Theme{
+		UserID: userID,
+		Name:   strings.TrimSpace(name),
+		Colors: maps.Clone(colors),
+		Fonts:  maps.Clone(fonts),
+	}
+
+	if err := theme.Validate(); err != nil {
+		return nil, err
+	}
+
+	return theme, nil
+}
+
+// Validate validates theme entity
+func (t *Theme) Validate() error {
+	if t.Name == "" || len(t.Name) > MaxThemeNameLength {
+		return ErrInvalidThemeName
+	}
+	if t.UserID == "" {
+		return ErrInvalidUserID
+	}
+	return nil
+}
+
+// IsValidThemeColor reports whether color is a hex color
+func IsValidThemeColor(color string) bool {
+	return themeColorPattern.MatchString(color)
+}
+
+// IsThemeColorRole reports whether role is one of ThemeColorRoles
+func IsThemeColorRole(role string) bool {
+	return slices.Contains(ThemeColorRoles, role)
+}
+
+// IsThemeFontRole reports whether role is one of ThemeFontRoles
+func IsThemeFontRole(role string) bool {
+	return slices.Contains(ThemeFontRoles, role)
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTheme_ValidatesNameAndOwner(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		theme   string
+		wantErr error
+	}{
+		{name: "valid", userID: "user-1", theme: "  Marigold  "},
+		{name: "blank name", userID: "user-1", theme: "   ", wantErr: ErrInvalidThemeName},
+		{name: "long name", userID: "user-1", theme: strings.Repeat("a", MaxThemeNameLength+1), wantErr: ErrInvalidThemeName},
+		{name: "no owner", theme: "Marigold", wantErr: ErrInvalidUserID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := NewTheme(tt.userID, tt.theme, map[string]string{"primary": "#fff"}, nil)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Marigold", theme.Name)
+		})
+	}
+}
+
+func TestIsValidThemeColor(t *testing.T) {
+	for _, color := range []string{"#fff", "#FFFA", "#d4af37", "#d4af37cc"} {
+		assert.True(t, IsValidThemeColor(color), color)
+	}
+	for _, color := range []string{"", "d4af37", "#d4af3", "#ggg", "red", "rgb(0,0,0)"} {
+		assert.False(t, IsValidThemeColor(color), color)
+	}
+}
@@ -4,22 +4,45 @@ import (
 	"time"
 )
 
+// UserRole controls what a user may do beyond managing their own invitations
+type UserRole string
+
+const (
+	UserRoleUser  UserRole = "user"
+	UserRoleAdmin UserRole = "admin"
+)
+
+// IsValid reports whether r is a known role
+func (r UserRole) IsValid() bool {
+	return r == UserRoleUser || r == UserRoleAdmin
+}
+
 // User represents a user entity
 type User struct {
-	ID        string
-	Email     string
-	Name      *string
-	Password  string
+	ID       string
+	Email    string
+	Name     *string
+	Password string
+	// Role is UserRoleUser unless granted otherwise; an empty role is treated as UserRoleUser
+	Role      UserRole
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// IsAdmin reports whether the user may manage the layout catalog
+func (u *User) IsAdmin() bool {
+	return u.Role == UserRoleAdmin
+}
+
 // Validate validates user entity
 // Note: Password can be empty for OAuth users (who authenticate via OAuth providers)
 func (u *User) Validate() error {
 	if u.Email == "" {
 		return ErrInvalidEmail
 	}
+	if u.Role != "" && !u.Role.IsValid() {
+		return ErrInvalidRole
+	}
 	// Password validation removed - OAuth users don't have passwords
 	// Regular users will have hashed passwords (non-empty) from bcrypt
 	// Password strength validation happens at use case level via validator.NewPassword
@@ -32,6 +55,7 @@ func NewUser(email, password string, name *string) (*User, error) {
 		Email:    email,
 		Password: password,
 		Name:     name,
+		Role:     UserRoleUser,
 	}
 
 	if err := user.Validate(); err != nil {
@@ -65,3 +65,27 @@ func TestNewUser_InvalidEmail_ReturnsError(t *testing.T) {
 	require.Error(t, err, "Invalid email should return error")
 	assert.Nil(t, user, "User should be nil on error")
 }
+
+func TestUser_Validate_Role(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    UserRole
+		wantErr error
+	}{
+		{name: "empty defaults to user", role: ""},
+		{name: "user", role: UserRoleUser},
+		{name: "admin", role: UserRoleAdmin},
+		{name: "unknown", role: "owner", wantErr: ErrInvalidRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{Email: "test@example.com", Role: tt.role}
+
+			err := user.Validate()
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.role == UserRoleAdmin, user.IsAdmin())
+		})
+	}
+}
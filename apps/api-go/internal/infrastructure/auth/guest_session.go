@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// guestSessionAudience keeps guest tokens distinguishable from access tokens
+const guestSessionAudience = "guest-session"
+
+// GuestSessionService issues and validates signed tokens that identify an anonymous browser session.
+// Tokens are signed with a key derived from the JWT secret, so a guest token can never pass as an access token.
+type GuestSessionService struct {
+	key    []byte
+	ttl    time.Duration
+	issuer string
+}
+
+func NewGuestSessionService(secret string, ttl time.Duration, issuer string) *GuestSessionService {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(guestSessionAudience))
+	return &GuestSessionService{
+		key:    mac.Sum(nil),
+		ttl:    ttl,
+		issuer: issuer,
+	}
+}
+
+type GuestSessionClaims struct {
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// NewSessionID generates a new guest session ID
+func (s *GuestSessionService) NewSessionID() string {
+	return domain.GuestSessionIDPrefix + ksuid.New().String()
+}
+
+// IssueToken signs a token for sessionID that is valid for the session TTL
+func (s *GuestSessionService) IssueToken(sessionID string) (string, error) {
+	now := time.Now()
+	claims := &GuestSessionClaims{
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    s.issuer,
+			Audience:  []string{guestSessionAudience},
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.key)
+}
+
+// ValidateToken returns the claims of a valid guest session token
+func (s *GuestSessionService) ValidateToken(tokenString string) (*GuestSessionClaims, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{"HS256"}),
+		jwt.WithAudience(guestSessionAudience),
+		jwt.WithExpirationRequired(),
+	)
+
+	claims := &GuestSessionClaims{}
+	token, err := parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return s.key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid guest session token")
+	}
+	if s.issuer != "" && claims.Issuer != s.issuer {
+		return nil, errors.New("invalid issuer")
+	}
+	if !domain.IsGuestSessionID(claims.SessionID) {
+		return nil, errors.New("invalid guest session ID")
+	}
+	return claims, nil
+}
+
+// ShouldRenew reports whether a token is past half its lifetime and should be reissued,
+// so sessions stay alive while the browser keeps using them
+func (s *GuestSessionService) ShouldRenew(claims *GuestSessionClaims) bool {
+	if claims.ExpiresAt == nil {
+		return true
+	}
+	return time.Until(claims.ExpiresAt.Time) < s.ttl/2
+}
+
+// TTL returns how long an issued guest session token is valid
+func (s *GuestSessionService) TTL() time.Duration {
+	return s.ttl
+}
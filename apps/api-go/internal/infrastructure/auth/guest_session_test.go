@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGuestSecret = "test-secret-key-32-characters-long"
+
+func TestGuestSessionService_IssueToken_ValidatesToSameSession(t *testing.T) {
+	// Arrange
+	service := NewGuestSessionService(testGuestSecret, 24*time.Hour, "test-issuer")
+	sessionID := service.NewSessionID()
+
+	// Act
+	token, err := service.IssueToken(sessionID)
+	require.NoError(t, err)
+	claims, err := service.ValidateToken(token)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, sessionID, claims.SessionID)
+	assert.True(t, strings.HasPrefix(sessionID, "guest_"), "Session IDs should carry the guest prefix")
+	assert.False(t, service.ShouldRenew(claims), "A fresh token should not need renewal")
+}
+
+func TestGuestSessionService_NewSessionID_IsUnique(t *testing.T) {
+	service := NewGuestSessionService(testGuestSecret, time.Hour, "")
+
+	assert.NotEqual(t, service.NewSessionID(), service.NewSessionID())
+}
+
+func TestGuestSessionService_ValidateToken_Rejects(t *testing.T) {
+	service := NewGuestSessionService(testGuestSecret, time.Hour, "test-issuer")
+	valid, err := service.IssueToken(service.NewSessionID())
+	require.NoError(t, err)
+
+	otherSecret, err := NewGuestSessionService("another-secret-key-32-characters", time.Hour, "test-issuer").IssueToken(service.NewSessionID())
+	require.NoError(t, err)
+	otherIssuer, err := NewGuestSessionService(testGuestSecret, time.Hour, "other-issuer").IssueToken(service.NewSessionID())
+	require.NoError(t, err)
+	expired, err := NewGuestSessionService(testGuestSecret, -time.Minute, "test-issuer").IssueToken(service.NewSessionID())
+	require.NoError(t, err)
+	notGuest, err := service.IssueToken("user-123")
+	require.NoError(t, err)
+	accessToken, err := NewJWTService(testGuestSecret, 15*time.Minute, time.Hour, "test-issuer", "test-audience", 0).GenerateAccessToken("user-123", "test@example.com")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"tampered", valid[:len(valid)-2] + "xx"},
+		{"signed with another secret", otherSecret},
+		{"other issuer", otherIssuer},
+		{"expired", expired},
+		{"non-guest session ID", notGuest},
+		{"access token", accessToken},
+		{"garbage", "not-a-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ValidateToken(tt.token)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJWTService_ValidateAccessToken_RejectsGuestToken(t *testing.T) {
+	// Arrange
+	jwtService := NewJWTService(testGuestSecret, 15*time.Minute, time.Hour, "test-issuer", "test-audience", 0)
+	guestSessions := NewGuestSessionService(testGuestSecret, time.Hour, "test-issuer")
+	token, err := guestSessions.IssueToken(guestSessions.NewSessionID())
+	require.NoError(t, err)
+
+	// Act
+	_, err = jwtService.ValidateAccessToken(token)
+
+	// Assert
+	assert.Error(t, err, "Guest tokens must never authenticate as a user")
+}
@@ -21,6 +21,8 @@ type Config struct {
 	Storage       StorageConfig
 	Google        GoogleConfig
 	Publishing    PublishingConfig
+	Invitations   InvitationsConfig
+	Layouts       LayoutsConfig
 	PublicAssets  PublicAssetsConfig
 	Email         EmailConfig
 	Observability ObservabilityConfig
@@ -82,7 +84,7 @@ type GoogleConfig struct {
 type PublishingConfig struct {
 	BaseDomain      string
 	SubdomainSuffix string // Optional suffix for subdomain in URL (e.g., "-dev" for dev environment)
-	ArtifactStore   string // filesystem|r2
+	ArtifactStore   string // filesystem|r2|gcs
 
 	// R2 (S3-compatible)
 	R2AccountID       string
@@ -92,14 +94,48 @@ type PublishingConfig struct {
 	R2PublicBase      string
 	R2Endpoint        string // Optional custom endpoint (for local MinIO, etc.)
 
+	// GCS (uses Application Default Credentials, no secrets)
+	GCSBucket     string
+	GCSPublicBase string // Optional public base URL for the bucket (e.g., https://storage.googleapis.com/<bucket>)
+
 	// Version retention: number of versions to keep (default: 3)
 	VersionRetentionCount int
 
+	// ServeArtifacts makes the API stream published artifacts from the artifact store
+	// (ETag, Range, precompressed variants) instead of redirecting to the bucket.
+	ServeArtifacts bool
+
+	// CDN cache purge after publish/rollback: none|cloudflare
+	CachePurger        string
+	CloudflareZoneID   string
+	CloudflareAPIToken string
+	ResolveAPIOrigin   string // Public API origin the edge worker resolves against (e.g., https://api.sacredvows.io)
+
+	// ExportMaxBytes caps the uncompressed size of a ZIP export (default: 100MB, env: PUBLISH_EXPORT_MAX_BYTES)
+	ExportMaxBytes int64
+
 	// Snapshot renderer
 	SnapshotRendererScript string
 	SnapshotRendererNode   string
 }
 
+type InvitationsConfig struct {
+	// RevisionRetention is the number of revisions kept per invitation (default: 50, 0 keeps all)
+	RevisionRetention int
+	// GuestDraftTTL is how long a guest session and its unclaimed drafts live after the last save (default: 720h)
+	GuestDraftTTL time.Duration
+	// TrashRetention is how long trashed invitations and assets can be restored before they are purged (default: 720h)
+	TrashRetention time.Duration
+}
+
+type LayoutsConfig struct {
+	// CatalogCacheTTL is how long the layout catalog is served from memory; layout changes made
+	// through the admin API show immediately, catalog syncs after at most this long (default: 5m, 0 disables)
+	CatalogCacheTTL time.Duration
+	// CacheMaxAge is the Cache-Control max-age of the layout endpoints (default: 60s)
+	CacheMaxAge time.Duration
+}
+
 type PublicAssetsConfig struct {
 	R2Bucket   string // R2 bucket name for public default assets
 	CDNBaseURL string // CDN base URL for public assets
@@ -166,12 +202,28 @@ type ConfigFile struct {
 		R2Bucket                     string `yaml:"r2_bucket"`
 		R2PublicBase                 string `yaml:"r2_public_base"`
 		R2Endpoint                   string `yaml:"r2_endpoint"`
+		GCSBucket                    string `yaml:"gcs_bucket"`
+		GCSPublicBase                string `yaml:"gcs_public_base"`
 		VersionRetentionCount        int    `yaml:"version_retention_count"`
+		ServeArtifacts               bool   `yaml:"serve_artifacts"`
+		CachePurger                  string `yaml:"cache_purger"`
+		CloudflareZoneID             string `yaml:"cloudflare_zone_id"`
+		ResolveAPIOrigin             string `yaml:"resolve_api_origin"`
+		ExportMaxBytes               int64  `yaml:"export_max_bytes"`
 		SnapshotRendererScript       string `yaml:"snapshot_renderer_script"`
 		SnapshotRendererNode         string `yaml:"snapshot_renderer_node"`
 		PublishedArtifactsDir        string `yaml:"published_artifacts_dir"`
 		PublishedArtifactsPublicBase string `yaml:"published_artifacts_public_base"`
 	} `yaml:"publishing"`
+	Invitations struct {
+		RevisionRetention int    `yaml:"revision_retention"`
+		GuestDraftTTL     string `yaml:"guest_draft_ttl"`
+		TrashRetention    string `yaml:"trash_retention"`
+	} `yaml:"invitations"`
+	Layouts struct {
+		CatalogCacheTTL string `yaml:"catalog_cache_ttl"`
+		CacheMaxAge     string `yaml:"cache_max_age"`
+	} `yaml:"layouts"`
 	PublicAssets struct {
 		R2Bucket   string `yaml:"r2_bucket"`
 		CDNBaseURL string `yaml:"cdn_base_url"`
@@ -259,10 +311,27 @@ func Load() (*Config, error) {
 			R2Bucket:               getEnv("R2_BUCKET", getYAMLString(yamlConfig, "publishing.r2_bucket", "")),
 			R2PublicBase:           getEnv("R2_PUBLIC_BASE", getYAMLString(yamlConfig, "publishing.r2_public_base", "")),
 			R2Endpoint:             getEnv("R2_ENDPOINT", getYAMLString(yamlConfig, "publishing.r2_endpoint", "")),
+			GCSBucket:              getEnv("PUBLISH_GCS_BUCKET", getYAMLString(yamlConfig, "publishing.gcs_bucket", "")),
+			GCSPublicBase:          getEnv("PUBLISH_GCS_PUBLIC_BASE", getYAMLString(yamlConfig, "publishing.gcs_public_base", "")),
 			VersionRetentionCount:  getEnvAsInt("PUBLISH_VERSION_RETENTION_COUNT", getYAMLInt(yamlConfig, "publishing.version_retention_count", 3)),
+			ServeArtifacts:         getEnvAsBool("PUBLISH_SERVE_ARTIFACTS", getYAMLBool(yamlConfig, "publishing.serve_artifacts", false)),
+			CachePurger:            getEnv("PUBLISH_CACHE_PURGER", getYAMLString(yamlConfig, "publishing.cache_purger", "none")),
+			CloudflareZoneID:       getEnv("CLOUDFLARE_ZONE_ID", getYAMLString(yamlConfig, "publishing.cloudflare_zone_id", "")),
+			CloudflareAPIToken:     getEnv("CLOUDFLARE_API_TOKEN", ""), // Always from env (sensitive)
+			ResolveAPIOrigin:       getEnv("PUBLISH_RESOLVE_API_ORIGIN", getYAMLString(yamlConfig, "publishing.resolve_api_origin", "")),
+			ExportMaxBytes:         getEnvAsInt64("PUBLISH_EXPORT_MAX_BYTES", getYAMLInt64(yamlConfig, "publishing.export_max_bytes", 100*1024*1024)),
 			SnapshotRendererScript: getEnv("SNAPSHOT_RENDERER_SCRIPT", getYAMLString(yamlConfig, "publishing.snapshot_renderer_script", "")),
 			SnapshotRendererNode:   getEnv("SNAPSHOT_RENDERER_NODE", getYAMLString(yamlConfig, "publishing.snapshot_renderer_node", "node")),
 		},
+		Invitations: InvitationsConfig{
+			RevisionRetention: getEnvAsInt("INVITATION_REVISION_RETENTION", getYAMLInt(yamlConfig, "invitations.revision_retention", 50)),
+			GuestDraftTTL:     parseDuration(getEnv("INVITATION_GUEST_DRAFT_TTL", getYAMLString(yamlConfig, "invitations.guest_draft_ttl", "720h")), 720*time.Hour),
+			TrashRetention:    parseDuration(getEnv("INVITATION_TRASH_RETENTION", getYAMLString(yamlConfig, "invitations.trash_retention", "720h")), 720*time.Hour),
+		},
+		Layouts: LayoutsConfig{
+			CatalogCacheTTL: parseDuration(getEnv("LAYOUT_CATALOG_CACHE_TTL", getYAMLString(yamlConfig, "layouts.catalog_cache_ttl", "5m")), 5*time.Minute),
+			CacheMaxAge:     parseDuration(getEnv("LAYOUT_CACHE_MAX_AGE", getYAMLString(yamlConfig, "layouts.cache_max_age", "60s")), 60*time.Second),
+		},
 		PublicAssets: PublicAssetsConfig{
 			R2Bucket:   getEnv("PUBLIC_ASSETS_R2_BUCKET", getYAMLString(yamlConfig, "public_assets.r2_bucket", "")),
 			CDNBaseURL: getEnv("PUBLIC_ASSETS_CDN_URL", getYAMLString(yamlConfig, "public_assets.cdn_base_url", "")),
@@ -379,6 +448,26 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsInt64(key string, defaultValue int64) int64 {
+	valueStr := os.Getenv(key)
+	if value, err := strconv.ParseInt(valueStr, 10, 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsBool accepts "true"/"1" and "false"/"0"; anything else yields the default value.
+func getEnvAsBool(key string, defaultValue bool) bool {
+	switch strings.ToLower(os.Getenv(key)) {
+	case "true", "1":
+		return true
+	case "false", "0":
+		return false
+	default:
+		return defaultValue
+	}
+}
+
 // getEnvAsInt16 gets an environment variable as int16 with bounds checking.
 // int16 range: -32768 to 32767
 // If the value is out of range or cannot be parsed, returns the default value.
@@ -527,6 +616,28 @@ func getYAMLString(cfg *ConfigFile, path string, defaultValue string) string {
 				return cfg.Google.FrontendURL
 			}
 		}
+	case "invitations":
+		switch parts[1] {
+		case "guest_draft_ttl":
+			if cfg.Invitations.GuestDraftTTL != "" {
+				return cfg.Invitations.GuestDraftTTL
+			}
+		case "trash_retention":
+			if cfg.Invitations.TrashRetention != "" {
+				return cfg.Invitations.TrashRetention
+			}
+		}
+	case "layouts":
+		switch parts[1] {
+		case "catalog_cache_ttl":
+			if cfg.Layouts.CatalogCacheTTL != "" {
+				return cfg.Layouts.CatalogCacheTTL
+			}
+		case "cache_max_age":
+			if cfg.Layouts.CacheMaxAge != "" {
+				return cfg.Layouts.CacheMaxAge
+			}
+		}
 	case "publishing":
 		switch parts[1] {
 		case "base_domain":
@@ -549,6 +660,26 @@ func getYAMLString(cfg *ConfigFile, path string, defaultValue string) string {
 			if cfg.Publishing.R2PublicBase != "" {
 				return cfg.Publishing.R2PublicBase
 			}
+		case "gcs_bucket":
+			if cfg.Publishing.GCSBucket != "" {
+				return cfg.Publishing.GCSBucket
+			}
+		case "gcs_public_base":
+			if cfg.Publishing.GCSPublicBase != "" {
+				return cfg.Publishing.GCSPublicBase
+			}
+		case "cache_purger":
+			if cfg.Publishing.CachePurger != "" {
+				return cfg.Publishing.CachePurger
+			}
+		case "cloudflare_zone_id":
+			if cfg.Publishing.CloudflareZoneID != "" {
+				return cfg.Publishing.CloudflareZoneID
+			}
+		case "resolve_api_origin":
+			if cfg.Publishing.ResolveAPIOrigin != "" {
+				return cfg.Publishing.ResolveAPIOrigin
+			}
 		case "snapshot_renderer_script":
 			if cfg.Publishing.SnapshotRendererScript != "" {
 				return cfg.Publishing.SnapshotRendererScript
@@ -600,6 +731,10 @@ func getYAMLInt(cfg *ConfigFile, path string, defaultValue int) int {
 		if parts[1] == "version_retention_count" && cfg.Publishing.VersionRetentionCount > 0 {
 			return cfg.Publishing.VersionRetentionCount
 		}
+	case "invitations":
+		if parts[1] == "revision_retention" && cfg.Invitations.RevisionRetention > 0 {
+			return cfg.Invitations.RevisionRetention
+		}
 	case "email":
 		if parts[1] == "mailjet" {
 			if parts[2] == "daily_limit" && cfg.Email.Mailjet.DailyLimit > 0 {
@@ -622,6 +757,19 @@ func getYAMLInt(cfg *ConfigFile, path string, defaultValue int) int {
 	return defaultValue
 }
 
+func getYAMLBool(cfg *ConfigFile, path string, defaultValue bool) bool {
+	if cfg == nil {
+		return defaultValue
+	}
+
+	parts := strings.Split(path, ".")
+	if parts[0] == "publishing" && parts[1] == "serve_artifacts" && cfg.Publishing.ServeArtifacts {
+		return true
+	}
+
+	return defaultValue
+}
+
 func getYAMLInt64(cfg *ConfigFile, path string, defaultValue int64) int64 {
 	if cfg == nil {
 		return defaultValue
@@ -631,6 +779,9 @@ func getYAMLInt64(cfg *ConfigFile, path string, defaultValue int64) int64 {
 	if parts[0] == "storage" && parts[1] == "max_file_size" && cfg.Storage.MaxFileSize > 0 {
 		return cfg.Storage.MaxFileSize
 	}
+	if parts[0] == "publishing" && parts[1] == "export_max_bytes" && cfg.Publishing.ExportMaxBytes > 0 {
+		return cfg.Publishing.ExportMaxBytes
+	}
 
 	return defaultValue
 }
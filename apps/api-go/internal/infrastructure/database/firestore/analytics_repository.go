@@ -35,9 +35,16 @@ func (r *analyticsRepository) Create(ctx context.Context, analytics *domain.Anal
 	return err
 }
 
-func (r *analyticsRepository) FindByInvitationID(ctx context.Context, invitationID string) ([]*domain.Analytics, error) {
-	iter := r.client.Collection("analytics").Where("invitation_id", "==", invitationID).Documents(ctx)
-	docs, err := iter.GetAll()
+var analyticsListSpec = listSpec{
+	sortFields:       map[string]string{"timestamp": "timestamp"},
+	filterFields:     map[string]string{"type": "type"},
+	defaultSort:      "timestamp",
+	defaultDirection: repository.SortDesc,
+}
+
+func (r *analyticsRepository) FindByInvitationID(ctx context.Context, invitationID string, opts repository.ListOptions) (*repository.Page[*domain.Analytics], error) {
+	query := r.client.Collection("analytics").Where("invitation_id", "==", invitationID)
+	docs, next, err := paginate(ctx, query, analyticsListSpec, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +53,7 @@ func (r *analyticsRepository) FindByInvitationID(ctx context.Context, invitation
 	for i, doc := range docs {
 		analytics[i] = r.docToAnalytics(doc)
 	}
-	return analytics, nil
+	return &repository.Page[*domain.Analytics]{Items: analytics, NextCursor: next}, nil
 }
 
 func (r *analyticsRepository) CountByType(ctx context.Context, invitationID string, analyticsType domain.AnalyticsType) (int64, error) {
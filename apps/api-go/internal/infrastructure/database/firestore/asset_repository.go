@@ -31,11 +31,48 @@ func (r *assetRepository) Create(ctx context.Context, asset *domain.Asset) error
 		"size":          asset.Size,
 		"mime_type":     asset.MimeType,
 		"user_id":       asset.UserID,
+		"width":         asset.Width,
+		"height":        asset.Height,
+		"variants":      variantsToData(asset.Variants),
+		"trashed":       false,
 		"created_at":    asset.CreatedAt,
 	})
 	return err
 }
 
+func variantsToData(variants []domain.AssetVariant) []map[string]interface{} {
+	data := make([]map[string]interface{}, len(variants))
+	for i, variant := range variants {
+		data[i] = map[string]interface{}{
+			"name":     variant.Name,
+			"filename": variant.Filename,
+			"url":      variant.URL,
+			"width":    variant.Width,
+			"height":   variant.Height,
+			"size":     variant.Size,
+		}
+	}
+	return data
+}
+
+func dataToVariants(data map[string]interface{}) []domain.AssetVariant {
+	items, _ := data["variants"].([]interface{})
+	variants := make([]domain.AssetVariant, 0, len(items))
+	for _, item := range items {
+		if variant, ok := item.(map[string]interface{}); ok {
+			variants = append(variants, domain.AssetVariant{
+				Name:     getString(variant, "name"),
+				Filename: getString(variant, "filename"),
+				URL:      getString(variant, "url"),
+				Width:    getInt(variant, "width"),
+				Height:   getInt(variant, "height"),
+				Size:     getInt64(variant, "size"),
+			})
+		}
+	}
+	return variants
+}
+
 func (r *assetRepository) FindByID(ctx context.Context, id string) (*domain.Asset, error) {
 	doc, err := r.client.Collection("assets").Doc(id).Get(ctx)
 	if err != nil {
@@ -44,12 +81,48 @@ func (r *assetRepository) FindByID(ctx context.Context, id string) (*domain.Asse
 		}
 		return nil, err
 	}
-	return r.docToAsset(doc)
+	asset, err := r.docToAsset(doc)
+	if err != nil || asset.IsTrashed() {
+		return nil, err
+	}
+	return asset, nil
 }
 
-func (r *assetRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Asset, error) {
-	iter := r.client.Collection("assets").Where("user_id", "==", userID).Documents(ctx)
-	docs, err := iter.GetAll()
+var assetListSpec = listSpec{
+	sortFields:       map[string]string{"createdAt": "created_at", "size": "size"},
+	filterFields:     map[string]string{"mimeType": "mime_type"},
+	defaultSort:      "createdAt",
+	defaultDirection: repository.SortDesc,
+}
+
+func (r *assetRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+	query := r.client.Collection("assets").Where("user_id", "==", userID).Where("trashed", "==", false)
+	return r.paginateAssets(ctx, query, assetListSpec, opts)
+}
+
+var trashedAssetListSpec = listSpec{
+	sortFields:       map[string]string{"deletedAt": "deleted_at"},
+	defaultSort:      "deletedAt",
+	defaultDirection: repository.SortDesc,
+}
+
+func (r *assetRepository) FindTrashedByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+	query := r.client.Collection("assets").Where("user_id", "==", userID).Where("trashed", "==", true)
+	return r.paginateAssets(ctx, query, trashedAssetListSpec, opts)
+}
+
+var allAssetListSpec = listSpec{
+	sortFields:       map[string]string{"createdAt": "created_at"},
+	defaultSort:      "createdAt",
+	defaultDirection: repository.SortAsc,
+}
+
+func (r *assetRepository) FindAllIncludingTrashed(ctx context.Context, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+	return r.paginateAssets(ctx, r.client.Collection("assets").Query, allAssetListSpec, opts)
+}
+
+func (r *assetRepository) paginateAssets(ctx context.Context, query firestore.Query, spec listSpec, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+	docs, next, err := paginate(ctx, query, spec, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +131,7 @@ func (r *assetRepository) FindByUserID(ctx context.Context, userID string) ([]*d
 	for i, doc := range docs {
 		assets[i] = r.docToAssetFromData(doc.Data(), doc.Ref.ID)
 	}
-	return assets, nil
+	return &repository.Page[*domain.Asset]{Items: assets, NextCursor: next}, nil
 }
 
 func (r *assetRepository) FindByURL(ctx context.Context, url string) (*domain.Asset, error) {
@@ -70,7 +143,11 @@ func (r *assetRepository) FindByURL(ctx context.Context, url string) (*domain.As
 	if len(docs) == 0 {
 		return nil, nil
 	}
-	return r.docToAsset(docs[0])
+	asset, err := r.docToAsset(docs[0])
+	if err != nil || asset.IsTrashed() {
+		return nil, err
+	}
+	return asset, nil
 }
 
 func (r *assetRepository) Delete(ctx context.Context, id string) error {
@@ -93,6 +170,59 @@ func (r *assetRepository) DeleteByURL(ctx context.Context, url string) error {
 	return err
 }
 
+// MoveToTrash keeps the record and the stored file so the asset can be restored until purged.
+func (r *assetRepository) MoveToTrash(ctx context.Context, id string, deletedAt time.Time) error {
+	_, err := r.client.Collection("assets").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "trashed", Value: true},
+		{Path: "deleted_at", Value: deletedAt},
+	})
+	return err
+}
+
+func (r *assetRepository) RestoreFromTrash(ctx context.Context, id string) error {
+	_, err := r.client.Collection("assets").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "trashed", Value: false},
+		{Path: "deleted_at", Value: firestore.Delete},
+	})
+	return err
+}
+
+func (r *assetRepository) FindTrashedByID(ctx context.Context, id string) (*domain.Asset, error) {
+	doc, err := r.client.Collection("assets").Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	asset, err := r.docToAsset(doc)
+	if err != nil || !asset.IsTrashed() {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// FindTrashedBefore requires a composite index on (trashed, deleted_at).
+func (r *assetRepository) FindTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Asset, error) {
+	query := r.client.Collection("assets").
+		Where("trashed", "==", true).
+		Where("deleted_at", "<", cutoff).
+		OrderBy("deleted_at", firestore.Asc)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	assets := make([]*domain.Asset, len(docs))
+	for i, doc := range docs {
+		assets[i] = r.docToAssetFromData(doc.Data(), doc.Ref.ID)
+	}
+	return assets, nil
+}
+
 func (r *assetRepository) docToAsset(doc *firestore.DocumentSnapshot) (*domain.Asset, error) {
 	return r.docToAssetFromData(doc.Data(), doc.Ref.ID), nil
 }
@@ -122,7 +252,7 @@ func (r *assetRepository) FindByURLs(ctx context.Context, urls []string) ([]*dom
 
 		for _, doc := range docs {
 			asset, err := r.docToAsset(doc)
-			if err == nil && asset != nil {
+			if err == nil && asset != nil && !asset.IsTrashed() {
 				allAssets = append(allAssets, asset)
 			}
 		}
@@ -199,12 +329,19 @@ func (r *assetRepository) docToAssetFromData(data map[string]interface{}, id str
 		Size:      getInt64(data, "size"),
 		MimeType:  getString(data, "mime_type"),
 		UserID:    getString(data, "user_id"),
+		Width:     getInt(data, "width"),
+		Height:    getInt(data, "height"),
+		Variants:  dataToVariants(data),
 		CreatedAt: getTime(data, "created_at"),
 	}
 
 	if originalName, ok := data["original_name"].(string); ok {
 		asset.OriginalName = originalName
 	}
+	if getBool(data, "trashed") {
+		deletedAt := getTime(data, "deleted_at")
+		asset.DeletedAt = &deletedAt
+	}
 
 	return asset
 }
@@ -0,0 +1,88 @@
+package firestore
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+)
+
+type auditRepository struct {
+	client *Client
+}
+
+// NewAuditRepository creates a new Firestore audit log repository
+func NewAuditRepository(client *Client) repository.AuditRepository {
+	return &auditRepository{client: client}
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	// Create (not Set) so an existing entry can never be overwritten
+	_, err := r.client.Collection("audit_log").Doc(entry.ID).Create(ctx, auditEntryData(entry))
+	return err
+}
+
+// createAuditEntry adds entry to batch, for repositories that log a change in the same write
+func createAuditEntry(client *Client, batch *firestore.WriteBatch, entry *domain.AuditEntry) {
+	if entry == nil {
+		return
+	}
+	batch.Create(client.Collection("audit_log").Doc(entry.ID), auditEntryData(entry))
+}
+
+// auditEntryData stamps entry with the current time and returns its stored form
+func auditEntryData(entry *domain.AuditEntry) map[string]interface{} {
+	entry.CreatedAt = time.Now()
+
+	changes := entry.Changes
+	if changes == nil {
+		changes = []string{}
+	}
+	return map[string]interface{}{
+		"id":            entry.ID,
+		"actor_id":      entry.ActorID,
+		"action":        string(entry.Action),
+		"resource_type": entry.ResourceType,
+		"resource_id":   entry.ResourceID,
+		"changes":       changes,
+		"created_at":    entry.CreatedAt,
+	}
+}
+
+var auditListSpec = listSpec{
+	sortFields:       map[string]string{"createdAt": "created_at"},
+	filterFields:     map[string]string{"action": "action", "actorId": "actor_id"},
+	defaultSort:      "createdAt",
+	defaultDirection: repository.SortDesc,
+}
+
+func (r *auditRepository) FindByResource(ctx context.Context, resourceType, resourceID string, opts repository.ListOptions) (*repository.Page[*domain.AuditEntry], error) {
+	query := r.client.Collection("audit_log").
+		Where("resource_type", "==", resourceType).
+		Where("resource_id", "==", resourceID)
+	docs, next, err := paginate(ctx, query, auditListSpec, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, len(docs))
+	for i, doc := range docs {
+		entries[i] = r.docToEntry(doc)
+	}
+	return &repository.Page[*domain.AuditEntry]{Items: entries, NextCursor: next}, nil
+}
+
+func (r *auditRepository) docToEntry(doc *firestore.DocumentSnapshot) *domain.AuditEntry {
+	data := doc.Data()
+	return &domain.AuditEntry{
+		ID:           doc.Ref.ID,
+		ActorID:      getString(data, "actor_id"),
+		Action:       domain.AuditAction(getString(data, "action")),
+		ResourceType: getString(data, "resource_type"),
+		ResourceID:   getString(data, "resource_id"),
+		Changes:      getStringSlice(data, "changes"),
+		CreatedAt:    getTime(data, "created_at"),
+	}
+}
@@ -18,6 +18,34 @@ func getStringPtr(data map[string]interface{}, key string) *string {
 	return nil
 }
 
+func getStringSlice(data map[string]interface{}, key string) []string {
+	vals, ok := data[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(vals))
+	for _, v := range vals {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func getStringMap(data map[string]interface{}, key string) map[string]string {
+	vals, ok := data[key].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	out := make(map[string]string, len(vals))
+	for k, v := range vals {
+		if s, ok := v.(string); ok {
+			out[k] = s
+		}
+	}
+	return out
+}
+
 func getInt64(data map[string]interface{}, key string) int64 {
 	if val, ok := data[key].(int64); ok {
 		return val
@@ -25,17 +25,33 @@ func (r *invitationRepository) Create(ctx context.Context, invitation *domain.In
 	now := time.Now()
 	invitation.CreatedAt = now
 	invitation.UpdatedAt = now
+	if invitation.Version <= 0 {
+		invitation.Version = 1
+	}
+
+	translations, err := marshalTranslations(invitation.Translations)
+	if err != nil {
+		return err
+	}
 
 	data := map[string]interface{}{
-		"id":         invitation.ID,
-		"layout_id":  invitation.LayoutID,
-		"data":       string(invitation.Data),
-		"user_id":    invitation.UserID,
-		"created_at": invitation.CreatedAt,
-		"updated_at": invitation.UpdatedAt,
+		"id":             invitation.ID,
+		"layout_id":      invitation.LayoutID,
+		"layout_version": invitation.LayoutVersion,
+		"data":           string(invitation.Data),
+		"translations":   translations,
+		"default_locale": invitation.DefaultLocale,
+		"user_id":        invitation.UserID,
+		"title":          invitation.Title,
+		"status":         string(invitation.Status),
+		"guest":          domain.IsGuestSessionID(invitation.UserID),
+		"trashed":        false,
+		"version":        invitation.Version,
+		"created_at":     invitation.CreatedAt,
+		"updated_at":     invitation.UpdatedAt,
 	}
 
-	_, err := r.client.Collection("invitations").Doc(invitation.ID).Set(ctx, data)
+	_, err = r.client.Collection("invitations").Doc(invitation.ID).Set(ctx, data)
 	return err
 }
 
@@ -47,12 +63,38 @@ func (r *invitationRepository) FindByID(ctx context.Context, id string) (*domain
 		}
 		return nil, err
 	}
-	return r.docToInvitation(doc)
+	inv, err := r.docToInvitation(doc)
+	if err != nil || inv.IsTrashed() {
+		return nil, err
+	}
+	return inv, nil
 }
 
-func (r *invitationRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Invitation, error) {
-	iter := r.client.Collection("invitations").Where("user_id", "==", userID).Documents(ctx)
-	docs, err := iter.GetAll()
+var invitationListSpec = listSpec{
+	sortFields:       map[string]string{"createdAt": "created_at", "updatedAt": "updated_at"},
+	filterFields:     map[string]string{"layoutId": "layout_id", "status": "status"},
+	defaultSort:      "updatedAt",
+	defaultDirection: repository.SortDesc,
+}
+
+func (r *invitationRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
+	query := r.client.Collection("invitations").Where("user_id", "==", userID).Where("trashed", "==", false)
+	return r.paginateInvitations(ctx, query, invitationListSpec, opts)
+}
+
+var trashedInvitationListSpec = listSpec{
+	sortFields:       map[string]string{"deletedAt": "deleted_at"},
+	defaultSort:      "deletedAt",
+	defaultDirection: repository.SortDesc,
+}
+
+func (r *invitationRepository) FindTrashedByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
+	query := r.client.Collection("invitations").Where("user_id", "==", userID).Where("trashed", "==", true)
+	return r.paginateInvitations(ctx, query, trashedInvitationListSpec, opts)
+}
+
+func (r *invitationRepository) paginateInvitations(ctx context.Context, query firestore.Query, spec listSpec, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
+	docs, next, err := paginate(ctx, query, spec, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -65,16 +107,60 @@ func (r *invitationRepository) FindByUserID(ctx context.Context, userID string)
 		}
 		invitations[i] = inv
 	}
-	return invitations, nil
+	return &repository.Page[*domain.Invitation]{Items: invitations, NextCursor: next}, nil
 }
 
+// Update writes the invitation if the stored version still equals invitation.Version (the version
+// the caller read), then advances invitation.Version. A stale version yields *domain.VersionConflictError.
 func (r *invitationRepository) Update(ctx context.Context, invitation *domain.Invitation) error {
-	invitation.UpdatedAt = time.Now()
-	_, err := r.client.Collection("invitations").Doc(invitation.ID).Update(ctx, []firestore.Update{
-		{Path: "layout_id", Value: invitation.LayoutID},
-		{Path: "data", Value: string(invitation.Data)},
-		{Path: "user_id", Value: invitation.UserID},
-		{Path: "updated_at", Value: invitation.UpdatedAt},
+	translations, err := marshalTranslations(invitation.Translations)
+	if err != nil {
+		return err
+	}
+
+	ref := r.client.Collection("invitations").Doc(invitation.ID)
+	updatedAt := time.Now()
+	var newVersion int
+	err = r.client.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		// Documents written before versioning have no version field and count as version 0
+		current := getInt(doc.Data(), "version")
+		if current != invitation.Version {
+			return &domain.VersionConflictError{CurrentVersion: current}
+		}
+
+		newVersion = current + 1
+		return tx.Update(ref, []firestore.Update{
+			{Path: "layout_id", Value: invitation.LayoutID},
+			{Path: "layout_version", Value: invitation.LayoutVersion},
+			{Path: "data", Value: string(invitation.Data)},
+			{Path: "translations", Value: translations},
+			{Path: "default_locale", Value: invitation.DefaultLocale},
+			{Path: "user_id", Value: invitation.UserID},
+			{Path: "title", Value: invitation.Title},
+			{Path: "guest", Value: domain.IsGuestSessionID(invitation.UserID)},
+			{Path: "version", Value: newVersion},
+			{Path: "updated_at", Value: updatedAt},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	invitation.Version = newVersion
+	invitation.UpdatedAt = updatedAt
+	return nil
+}
+
+// UpdateStatus is written by publish/unpublish outside the edit flow, so it leaves version alone
+// and editors holding the current version can keep saving.
+func (r *invitationRepository) UpdateStatus(ctx context.Context, id string, status domain.InvitationStatus) error {
+	_, err := r.client.Collection("invitations").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "status", Value: string(status)},
+		{Path: "status_updated_at", Value: time.Now()},
 	})
 	return err
 }
@@ -84,6 +170,48 @@ func (r *invitationRepository) Delete(ctx context.Context, id string) error {
 	return err
 }
 
+// MoveToTrash keeps the invitation and its revisions intact so it can be restored until purged.
+// The "trashed" flag mirrors deleted_at because Firestore cannot query for a missing field.
+func (r *invitationRepository) MoveToTrash(ctx context.Context, id string, deletedAt time.Time) error {
+	_, err := r.client.Collection("invitations").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "trashed", Value: true},
+		{Path: "deleted_at", Value: deletedAt},
+	})
+	return err
+}
+
+func (r *invitationRepository) RestoreFromTrash(ctx context.Context, id string) error {
+	_, err := r.client.Collection("invitations").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "trashed", Value: false},
+		{Path: "deleted_at", Value: firestore.Delete},
+	})
+	return err
+}
+
+func (r *invitationRepository) FindTrashedByID(ctx context.Context, id string) (*domain.Invitation, error) {
+	doc, err := r.client.Collection("invitations").Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	inv, err := r.docToInvitation(doc)
+	if err != nil || !inv.IsTrashed() {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// FindTrashedBefore requires a composite index on (trashed, deleted_at).
+func (r *invitationRepository) FindTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error) {
+	query := r.client.Collection("invitations").
+		Where("trashed", "==", true).
+		Where("deleted_at", "<", cutoff).
+		OrderBy("deleted_at", firestore.Asc)
+	return r.findInvitations(ctx, query, limit)
+}
+
 func (r *invitationRepository) MigrateUserInvitations(ctx context.Context, fromUserID, toUserID string) (int, error) {
 	iter := r.client.Collection("invitations").Where("user_id", "==", fromUserID).Documents(ctx)
 	docs, err := iter.GetAll()
@@ -96,6 +224,7 @@ func (r *invitationRepository) MigrateUserInvitations(ctx context.Context, fromU
 	for _, doc := range docs {
 		batch.Update(doc.Ref, []firestore.Update{
 			{Path: "user_id", Value: toUserID},
+			{Path: "guest", Value: domain.IsGuestSessionID(toUserID)},
 		})
 		count++
 	}
@@ -110,6 +239,37 @@ func (r *invitationRepository) MigrateUserInvitations(ctx context.Context, fromU
 	return count, nil
 }
 
+// FindGuestInvitationsUpdatedBefore returns up to limit guest session drafts last saved before cutoff.
+// Trashed drafts are left to the trash purge. Requires a composite index on (guest, trashed, updated_at).
+func (r *invitationRepository) FindGuestInvitationsUpdatedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error) {
+	query := r.client.Collection("invitations").
+		Where("guest", "==", true).
+		Where("trashed", "==", false).
+		Where("updated_at", "<", cutoff).
+		OrderBy("updated_at", firestore.Asc)
+	return r.findInvitations(ctx, query, limit)
+}
+
+func (r *invitationRepository) findInvitations(ctx context.Context, query firestore.Query, limit int) ([]*domain.Invitation, error) {
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]*domain.Invitation, len(docs))
+	for i, doc := range docs {
+		inv, err := r.docToInvitation(doc)
+		if err != nil {
+			return nil, err
+		}
+		invitations[i] = inv
+	}
+	return invitations, nil
+}
+
 func (r *invitationRepository) docToInvitation(doc *firestore.DocumentSnapshot) (*domain.Invitation, error) {
 	data := doc.Data()
 	invitation := &domain.Invitation{
@@ -125,5 +285,36 @@ func (r *invitationRepository) docToInvitation(doc *firestore.DocumentSnapshot)
 		invitation.Data = json.RawMessage(dataStr)
 	}
 
+	// Translations are stored as a JSON string, like data
+	if translationsStr, ok := data["translations"].(string); ok && translationsStr != "" {
+		if err := json.Unmarshal([]byte(translationsStr), &invitation.Translations); err != nil {
+			return nil, err
+		}
+	}
+	invitation.LayoutVersion = getString(data, "layout_version")
+	invitation.DefaultLocale = getString(data, "default_locale")
+	invitation.Title = getString(data, "title")
+	invitation.Status = domain.InvitationStatus(getString(data, "status"))
+	if invitation.Status == "" {
+		invitation.Status = domain.InvitationStatusDraft
+	}
+	invitation.Version = getInt(data, "version")
+	if getBool(data, "trashed") {
+		deletedAt := getTime(data, "deleted_at")
+		invitation.DeletedAt = &deletedAt
+	}
+
 	return invitation, nil
 }
+
+// marshalTranslations encodes translations as a JSON string ("" when there are none).
+func marshalTranslations(translations map[string]json.RawMessage) (string, error) {
+	if len(translations) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(translations)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
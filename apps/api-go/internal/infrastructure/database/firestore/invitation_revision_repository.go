@@ -0,0 +1,170 @@
+package firestore
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Revisions live in a subcollection so they never inflate the invitation document:
+// invitations/{invitationID}/revisions/{revision}
+const invitationRevisionsCollection = "revisions"
+
+type invitationRevisionRepository struct {
+	client *Client
+}
+
+// NewInvitationRevisionRepository creates a new Firestore invitation revision repository
+func NewInvitationRevisionRepository(client *Client) repository.InvitationRevisionRepository {
+	return &invitationRevisionRepository{client: client}
+}
+
+func (r *invitationRevisionRepository) revisions(invitationID string) *firestore.CollectionRef {
+	return r.client.Collection("invitations").Doc(invitationID).Collection(invitationRevisionsCollection)
+}
+
+func (r *invitationRevisionRepository) Append(ctx context.Context, revision *domain.InvitationRevision) error {
+	translations, err := marshalTranslations(revision.Translations)
+	if err != nil {
+		return err
+	}
+	revision.CreatedAt = time.Now()
+
+	col := r.revisions(revision.InvitationID)
+	// Read the latest revision and create the next one atomically so concurrent saves don't collide.
+	return r.client.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docs, err := tx.Documents(col.OrderBy("revision", firestore.Desc).Limit(1)).GetAll()
+		if err != nil {
+			return err
+		}
+		next := 1
+		if len(docs) > 0 {
+			next = getInt(docs[0].Data(), "revision") + 1
+		}
+
+		revision.Revision = next
+		return tx.Create(col.Doc(strconv.Itoa(next)), map[string]interface{}{
+			"invitation_id":  revision.InvitationID,
+			"revision":       revision.Revision,
+			"layout_id":      revision.LayoutID,
+			"layout_version": revision.LayoutVersion,
+			"data":           string(revision.Data),
+			"translations":   translations,
+			"default_locale": revision.DefaultLocale,
+			"author_id":      revision.AuthorID,
+			"size_bytes":     revision.SizeBytes,
+			"restored_from":  revision.RestoredFrom,
+			"created_at":     revision.CreatedAt,
+		})
+	})
+}
+
+func (r *invitationRevisionRepository) ListByInvitationID(ctx context.Context, invitationID string) ([]*domain.InvitationRevision, error) {
+	// Skip the (potentially large) content fields; callers fetch a single revision to see its data.
+	docs, err := r.revisions(invitationID).
+		Select("invitation_id", "revision", "layout_id", "layout_version", "default_locale", "author_id", "size_bytes", "restored_from", "created_at").
+		OrderBy("revision", firestore.Desc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*domain.InvitationRevision, 0, len(docs))
+	for _, doc := range docs {
+		rev, err := r.docToRevision(doc)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, nil
+}
+
+func (r *invitationRevisionRepository) FindByRevision(ctx context.Context, invitationID string, revision int) (*domain.InvitationRevision, error) {
+	doc, err := r.revisions(invitationID).Doc(strconv.Itoa(revision)).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.docToRevision(doc)
+}
+
+func (r *invitationRevisionRepository) Prune(ctx context.Context, invitationID string, keep int) (int, error) {
+	if keep <= 0 {
+		return 0, nil
+	}
+	docs, err := r.revisions(invitationID).
+		Select().
+		OrderBy("revision", firestore.Desc).
+		Offset(keep).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return 0, err
+	}
+	if err := r.deleteDocs(ctx, docs); err != nil {
+		return 0, err
+	}
+	return len(docs), nil
+}
+
+func (r *invitationRevisionRepository) DeleteByInvitationID(ctx context.Context, invitationID string) error {
+	docs, err := r.revisions(invitationID).Select().Documents(ctx).GetAll()
+	if err != nil {
+		return err
+	}
+	return r.deleteDocs(ctx, docs)
+}
+
+// deleteDocs deletes documents in batches (Firestore allows at most 500 writes per batch).
+func (r *invitationRevisionRepository) deleteDocs(ctx context.Context, docs []*firestore.DocumentSnapshot) error {
+	const batchSize = 500
+	for start := 0; start < len(docs); start += batchSize {
+		end := start + batchSize
+		if end > len(docs) {
+			end = len(docs)
+		}
+		batch := r.client.Batch()
+		for _, doc := range docs[start:end] {
+			batch.Delete(doc.Ref)
+		}
+		if _, err := batch.Commit(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *invitationRevisionRepository) docToRevision(doc *firestore.DocumentSnapshot) (*domain.InvitationRevision, error) {
+	data := doc.Data()
+	revision := &domain.InvitationRevision{
+		InvitationID:  getString(data, "invitation_id"),
+		Revision:      getInt(data, "revision"),
+		LayoutID:      getString(data, "layout_id"),
+		LayoutVersion: getString(data, "layout_version"),
+		DefaultLocale: getString(data, "default_locale"),
+		AuthorID:      getString(data, "author_id"),
+		SizeBytes:     getInt(data, "size_bytes"),
+		RestoredFrom:  getInt(data, "restored_from"),
+		CreatedAt:     getTime(data, "created_at"),
+	}
+
+	if dataStr, ok := data["data"].(string); ok {
+		revision.Data = json.RawMessage(dataStr)
+	}
+	if translationsStr, ok := data["translations"].(string); ok && translationsStr != "" {
+		if err := json.Unmarshal([]byte(translationsStr), &revision.Translations); err != nil {
+			return nil, err
+		}
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,116 @@
+package firestore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type invitationTemplateRepository struct {
+	client *Client
+}
+
+// NewInvitationTemplateRepository creates a new Firestore invitation template repository
+func NewInvitationTemplateRepository(client *Client) repository.InvitationTemplateRepository {
+	return &invitationTemplateRepository{client: client}
+}
+
+func (r *invitationTemplateRepository) Create(ctx context.Context, template *domain.InvitationTemplate) error {
+	now := time.Now()
+	template.CreatedAt = now
+	template.UpdatedAt = now
+
+	translations, err := marshalTranslations(template.Translations)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"id":                   template.ID,
+		"user_id":              template.UserID,
+		"name":                 template.Name,
+		"description":          template.Description,
+		"layout_id":            template.LayoutID,
+		"data":                 string(template.Data),
+		"translations":         translations,
+		"default_locale":       template.DefaultLocale,
+		"source_invitation_id": template.SourceInvitationID,
+		"created_at":           template.CreatedAt,
+		"updated_at":           template.UpdatedAt,
+	}
+
+	_, err = r.client.Collection("invitation_templates").Doc(template.ID).Set(ctx, data)
+	return err
+}
+
+func (r *invitationTemplateRepository) FindByID(ctx context.Context, id string) (*domain.InvitationTemplate, error) {
+	doc, err := r.client.Collection("invitation_templates").Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.docToTemplate(doc)
+}
+
+var invitationTemplateListSpec = listSpec{
+	sortFields:       map[string]string{"createdAt": "created_at", "name": "name"},
+	filterFields:     map[string]string{"layoutId": "layout_id"},
+	defaultSort:      "createdAt",
+	defaultDirection: repository.SortDesc,
+}
+
+func (r *invitationTemplateRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.InvitationTemplate], error) {
+	query := r.client.Collection("invitation_templates").Where("user_id", "==", userID)
+	docs, next, err := paginate(ctx, query, invitationTemplateListSpec, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*domain.InvitationTemplate, len(docs))
+	for i, doc := range docs {
+		template, err := r.docToTemplate(doc)
+		if err != nil {
+			return nil, err
+		}
+		templates[i] = template
+	}
+	return &repository.Page[*domain.InvitationTemplate]{Items: templates, NextCursor: next}, nil
+}
+
+func (r *invitationTemplateRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.Collection("invitation_templates").Doc(id).Delete(ctx)
+	return err
+}
+
+func (r *invitationTemplateRepository) docToTemplate(doc *firestore.DocumentSnapshot) (*domain.InvitationTemplate, error) {
+	data := doc.Data()
+	template := &domain.InvitationTemplate{
+		ID:                 doc.Ref.ID,
+		UserID:             getString(data, "user_id"),
+		Name:               getString(data, "name"),
+		Description:        getString(data, "description"),
+		LayoutID:           getString(data, "layout_id"),
+		Data:               json.RawMessage(getString(data, "data")),
+		DefaultLocale:      getString(data, "default_locale"),
+		SourceInvitationID: getString(data, "source_invitation_id"),
+		CreatedAt:          getTime(data, "created_at"),
+		UpdatedAt:          getTime(data, "updated_at"),
+	}
+
+	// Translations are stored as a JSON string, like data
+	if translationsStr := getString(data, "translations"); translationsStr != "" {
+		if err := json.Unmarshal([]byte(translationsStr), &template.Translations); err != nil {
+			return nil, err
+		}
+	}
+
+	return template, nil
+}
@@ -21,7 +21,7 @@ func NewLayoutRepository(client *Client) repository.LayoutRepository {
 	return &layoutRepository{client: client}
 }
 
-func (r *layoutRepository) Create(ctx context.Context, layout *domain.Layout) error {
+func (r *layoutRepository) Create(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
 	now := time.Now()
 	layout.CreatedAt = now
 	layout.UpdatedAt = now
@@ -34,6 +34,7 @@ func (r *layoutRepository) Create(ctx context.Context, layout *domain.Layout) er
 		"tags":          layout.Tags,
 		"version":       layout.Version,
 		"is_active":     layout.IsActive,
+		"retired_at":    layout.RetiredAt,
 		"created_at":    layout.CreatedAt,
 		"updated_at":    layout.UpdatedAt,
 	}
@@ -45,7 +46,11 @@ func (r *layoutRepository) Create(ctx context.Context, layout *domain.Layout) er
 		data["manifest"] = string(*layout.Manifest)
 	}
 
-	_, err := r.client.Collection("layouts").Doc(layout.ID).Set(ctx, data)
+	batch := r.client.Batch()
+	batch.Set(r.client.Collection("layouts").Doc(layout.ID), data)
+	r.setVersion(batch, layout)
+	createAuditEntry(r.client, batch, audit)
+	_, err := batch.Commit(ctx)
 	return err
 }
 
@@ -61,8 +66,15 @@ func (r *layoutRepository) FindByID(ctx context.Context, id string) (*domain.Lay
 }
 
 func (r *layoutRepository) FindAll(ctx context.Context) ([]*domain.Layout, error) {
-	iter := r.client.Collection("layouts").Where("is_active", "==", true).Documents(ctx)
-	docs, err := iter.GetAll()
+	return r.findAll(ctx, r.client.Collection("layouts").Where("is_active", "==", true))
+}
+
+func (r *layoutRepository) FindAllIncludingInactive(ctx context.Context) ([]*domain.Layout, error) {
+	return r.findAll(ctx, r.client.Collection("layouts").OrderBy(firestore.DocumentID, firestore.Asc))
+}
+
+func (r *layoutRepository) findAll(ctx context.Context, query firestore.Query) ([]*domain.Layout, error) {
+	docs, err := query.Documents(ctx).GetAll()
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +90,7 @@ func (r *layoutRepository) FindAll(ctx context.Context) ([]*domain.Layout, error
 	return layouts, nil
 }
 
-func (r *layoutRepository) Update(ctx context.Context, layout *domain.Layout) error {
+func (r *layoutRepository) Update(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
 	layout.UpdatedAt = time.Now()
 
 	updates := []firestore.Update{
@@ -88,6 +100,7 @@ func (r *layoutRepository) Update(ctx context.Context, layout *domain.Layout) er
 		{Path: "tags", Value: layout.Tags},
 		{Path: "version", Value: layout.Version},
 		{Path: "is_active", Value: layout.IsActive},
+		{Path: "retired_at", Value: layout.RetiredAt},
 		{Path: "updated_at", Value: layout.UpdatedAt},
 	}
 
@@ -98,10 +111,62 @@ func (r *layoutRepository) Update(ctx context.Context, layout *domain.Layout) er
 		updates = append(updates, firestore.Update{Path: "manifest", Value: string(*layout.Manifest)})
 	}
 
-	_, err := r.client.Collection("layouts").Doc(layout.ID).Update(ctx, updates)
+	batch := r.client.Batch()
+	batch.Update(r.client.Collection("layouts").Doc(layout.ID), updates)
+	r.setVersion(batch, layout)
+	createAuditEntry(r.client, batch, audit)
+	_, err := batch.Commit(ctx)
 	return err
 }
 
+func (r *layoutRepository) FindVersion(ctx context.Context, id, version string) (*domain.Layout, error) {
+	if version == "" {
+		return nil, nil
+	}
+	doc, err := r.versions(id).Doc(version).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	layout, err := r.docToLayout(doc)
+	if err != nil {
+		return nil, err
+	}
+	layout.ID = id
+	return layout, nil
+}
+
+// versions is the subcollection holding a copy of every saved version of a layout
+func (r *layoutRepository) versions(id string) *firestore.CollectionRef {
+	return r.client.Collection("layouts").Doc(id).Collection("versions")
+}
+
+// setVersion copies the versioned content of layout to its versions subcollection. Saving a
+// version again overwrites the copy, so callers bump the version when config or manifest change.
+func (r *layoutRepository) setVersion(batch *firestore.WriteBatch, layout *domain.Layout) {
+	if layout.Version == "" {
+		return
+	}
+	data := map[string]interface{}{
+		"name":          layout.Name,
+		"description":   layout.Description,
+		"preview_image": layout.PreviewImage,
+		"tags":          layout.Tags,
+		"version":       layout.Version,
+		"created_at":    layout.UpdatedAt,
+		"updated_at":    layout.UpdatedAt,
+	}
+	if layout.Config != nil {
+		data["config"] = string(*layout.Config)
+	}
+	if layout.Manifest != nil {
+		data["manifest"] = string(*layout.Manifest)
+	}
+	batch.Set(r.versions(layout.ID).Doc(layout.Version), data)
+}
+
 func (r *layoutRepository) Delete(ctx context.Context, id string) error {
 	_, err := r.client.Collection("layouts").Doc(id).Delete(ctx)
 	return err
@@ -119,6 +184,9 @@ func (r *layoutRepository) docToLayout(doc *firestore.DocumentSnapshot) (*domain
 		CreatedAt:    getTime(data, "created_at"),
 		UpdatedAt:    getTime(data, "updated_at"),
 	}
+	if retiredAt, ok := data["retired_at"].(time.Time); ok {
+		layout.RetiredAt = &retiredAt
+	}
 
 	if tags, ok := data["tags"].([]interface{}); ok {
 		layout.Tags = make([]string, len(tags))
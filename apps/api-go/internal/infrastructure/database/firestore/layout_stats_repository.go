@@ -0,0 +1,56 @@
+package firestore
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+)
+
+type layoutStatsRepository struct {
+	client *Client
+}
+
+// NewLayoutStatsRepository creates a new Firestore layout stats repository
+func NewLayoutStatsRepository(client *Client) repository.LayoutStatsRepository {
+	return &layoutStatsRepository{client: client}
+}
+
+func (r *layoutStatsRepository) IncrementSelections(ctx context.Context, layoutID string) error {
+	return r.increment(ctx, layoutID, "selections")
+}
+
+func (r *layoutStatsRepository) IncrementPublishes(ctx context.Context, layoutID string) error {
+	return r.increment(ctx, layoutID, "publishes")
+}
+
+// increment uses a server-side increment, so concurrent selections need no transaction
+func (r *layoutStatsRepository) increment(ctx context.Context, layoutID, field string) error {
+	_, err := r.client.Collection("layout_stats").Doc(layoutID).Set(ctx, map[string]interface{}{
+		"layout_id":  layoutID,
+		field:        firestore.Increment(1),
+		"updated_at": time.Now(),
+	}, firestore.MergeAll)
+	return err
+}
+
+func (r *layoutStatsRepository) FindAll(ctx context.Context) ([]*domain.LayoutStats, error) {
+	docs, err := r.client.Collection("layout_stats").Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*domain.LayoutStats, len(docs))
+	for i, doc := range docs {
+		data := doc.Data()
+		stats[i] = &domain.LayoutStats{
+			LayoutID:   doc.Ref.ID,
+			Selections: getInt(data, "selections"),
+			Publishes:  getInt(data, "publishes"),
+			UpdatedAt:  getTime(data, "updated_at"),
+		}
+	}
+	return stats, nil
+}
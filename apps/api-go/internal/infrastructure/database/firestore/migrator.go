@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"cloud.google.com/go/firestore"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/infrastructure/layoutcatalog"
+	"github.com/sacred-vows/api-go/layouts"
 	"github.com/sacred-vows/api-go/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -246,594 +249,41 @@ func validateMigrationSequence(applied map[int]bool, migrations []Migration) err
 // Firestore doesn't require schema migrations (collections are created automatically)
 func getAllMigrations() []Migration {
 	return []Migration{
-		{Version: 1, Name: "load_layouts", Up: migration001LoadLayouts},                                   // Seeds classic-scroll and editorial-elegance layouts
-		{Version: 2, Name: "create_password_reset_tokens", Up: migration002CreatePasswordResetTokens},     // Creates password_reset_tokens collection structure
-		{Version: 3, Name: "add_editorial_elegance_presets", Up: migration003AddEditorialElegancePresets}, // Adds presets to existing editorial-elegance layouts
+		{Version: 1, Name: "load_layouts", Up: migration001LoadLayouts},                                         // Seeds the layouts of the embedded catalog
+		{Version: 2, Name: "create_password_reset_tokens", Up: migration002CreatePasswordResetTokens},           // Creates password_reset_tokens collection structure
+		{Version: 3, Name: "add_editorial_elegance_presets", Up: migration003AddEditorialElegancePresets},       // Adds presets to existing editorial-elegance layouts
+		{Version: 4, Name: "lift_invitation_metadata", Up: migration004LiftInvitationMetadata},                  // Moves title/status out of invitation data into fields
+		{Version: 5, Name: "backfill_trashed_flag", Up: migration005BackfillTrashedFlag},                        // Marks existing invitations and assets as not trashed
+		{Version: 6, Name: "add_layout_data_schemas", Up: migration006AddLayoutDataSchemas},                     // Adds a dataSchema to layout manifests for server-side validation
+		{Version: 7, Name: "pin_layout_versions", Up: migration007PinLayoutVersions},                            // Snapshots current layout versions and pins invitations to them
+		{Version: 8, Name: "flag_legacy_anonymous_invitations", Up: migration008FlagLegacyAnonymousInvitations}, // Turns invitations owned by "anonymous" into guest drafts
+		{Version: 9, Name: "add_layout_migrations", Up: migration009AddLayoutMigrations},                        // Adds the catalog's layout switch mappings to existing manifests
 	}
 }
 
 // Migration 001: Load Layouts
-// Seeds initial layout data into layouts collection
-// Creates both classic-scroll and editorial-elegance layouts with correct paths and naming
-// Layout IDs are defined as constants to ensure consistency across environments
+// Seeds the layouts of the embedded catalog (the layouts directory) that do not exist yet.
+// Layouts used to be embedded here; changes to existing layouts are now applied by syncing the
+// catalog with cmd/sync-layouts.
 func migration001LoadLayouts(ctx context.Context, client *Client) error {
-	// Define canonical layout IDs - these must remain consistent across all environments
-	const (
-		classicScrollID     = "classic-scroll"
-		editorialEleganceID = "editorial-elegance"
-	)
-
-	// Load both layouts directly (optimized for fresh Firestore setup)
-	now := time.Now()
+	catalog, err := layoutcatalog.Load(layouts.FS)
+	if err != nil {
+		return fmt.Errorf("failed to load layout catalog: %w", err)
+	}
 
-	configJSON := fmt.Sprintf(`{
-  "id": "%s",
-  "name": "Classic Scroll",
-  "version": "1.0.0",
-  "metadata": {
-    "description": "Traditional single-column layout with elegant typography and centered content. Perfect for couples who appreciate classic design and formal celebrations.",
-    "previewImage": "/layouts/classic-scroll/preview.jpg",
-    "tags": ["elegant", "classic", "traditional"],
-    "author": "Sacred Vows"
-  },
-  "sections": [
-    {"id": "header", "enabled": true, "config": {}},
-    {"id": "hero", "enabled": true, "config": {}},
-    {"id": "couple", "enabled": true, "config": {}},
-    {"id": "fathers-letter", "enabled": true, "config": {}},
-    {"id": "gallery", "enabled": true, "config": {}},
-    {"id": "events", "enabled": true, "config": {}},
-    {"id": "venue", "enabled": true, "config": {}},
-    {"id": "rsvp", "enabled": true, "config": {}},
-    {"id": "footer", "enabled": true, "config": {}}
-  ],
-  "themes": [
-    {
-      "id": "royal-gold",
-      "name": "Royal Gold",
-      "isDefault": true,
-      "colors": {
-        "primary": "#d4af37",
-        "secondary": "#8b6914",
-        "background": "#fff8f0",
-        "text": "#2c2c2c",
-        "accent": "#c9a227"
-      },
-      "fonts": {
-        "heading": "Playfair Display",
-        "body": "Poppins",
-        "script": "Great Vibes"
-      }
-    },
-    {
-      "id": "rose-blush",
-      "name": "Rose Blush",
-      "isDefault": false,
-      "colors": {
-        "primary": "#c77d8a",
-        "secondary": "#9b5c6a",
-        "background": "#fff5f7",
-        "text": "#4a3539",
-        "accent": "#e8b4b8"
-      },
-      "fonts": {
-        "heading": "Cormorant Garamond",
-        "body": "Lato",
-        "script": "Dancing Script"
-      }
-    },
-    {
-      "id": "ivory-cream",
-      "name": "Ivory Cream",
-      "isDefault": false,
-      "colors": {
-        "primary": "#a67c52",
-        "secondary": "#8b6914",
-        "background": "#fffef5",
-        "text": "#3d3d3d",
-        "accent": "#d4b896"
-      },
-      "fonts": {
-        "heading": "Libre Baskerville",
-        "body": "Inter",
-        "script": "Alex Brush"
-      }
-    },
-    {
-      "id": "forest-sage",
-      "name": "Forest Sage",
-      "isDefault": false,
-      "colors": {
-        "primary": "#6b8e6b",
-        "secondary": "#4a6f4a",
-        "background": "#f5f8f5",
-        "text": "#2c3c2c",
-        "accent": "#8fbc8f"
-      },
-      "fonts": {
-        "heading": "EB Garamond",
-        "body": "Montserrat",
-        "script": "Tangerine"
-      }
-    },
-    {
-      "id": "midnight-navy",
-      "name": "Midnight Navy",
-      "isDefault": false,
-      "colors": {
-        "primary": "#1e3a5f",
-        "secondary": "#2c5282",
-        "background": "#f7fafc",
-        "text": "#1a202c",
-        "accent": "#4299e1"
-      },
-      "fonts": {
-        "heading": "Crimson Text",
-        "body": "Poppins",
-        "script": "Great Vibes"
-      }
-    },
-    {
-      "id": "lavender-dream",
-      "name": "Lavender Dream",
-      "isDefault": false,
-      "colors": {
-        "primary": "#9b8bb4",
-        "secondary": "#7a6a96",
-        "background": "#f8f5fc",
-        "text": "#3c3544",
-        "accent": "#c4b5dc"
-      },
-      "fonts": {
-        "heading": "Playfair Display",
-        "body": "Lato",
-        "script": "Dancing Script"
-      }
-    }
-  ],
-  "theme": {
-    "preset": "royal-gold",
-    "colors": {
-      "primary": "#d4af37",
-      "secondary": "#8b6914",
-      "background": "#fff8f0",
-      "text": "#2c2c2c",
-      "accent": "#c9a227"
-    },
-    "fonts": {
-      "heading": "Playfair Display",
-      "body": "Poppins",
-      "script": "Great Vibes"
-    }
-  }
-}`, classicScrollID)
-
-	manifestJSON := fmt.Sprintf(`{
-  "id": "%s",
-  "name": "Classic Scroll",
-  "version": "1.0.0",
-  "description": "Traditional single-column layout with elegant typography and centered content. Perfect for couples who appreciate classic design and formal celebrations.",
-  "names": "Capt (Dr) Priya & Dr Saurabh",
-  "date": "22 & 23 January 2026",
-  "previewImage": "/layouts/classic-scroll/preview.jpg",
-  "previewImages": [
-    "/layouts/classic-scroll/preview-1.jpg",
-    "/layouts/classic-scroll/preview-2.jpg"
-  ],
-  "tags": ["elegant", "classic", "traditional"],
-  "category": "traditional",
-  "author": "Sacred Vows",
-  "price": 499,
-  "currency": "INR",
-  "status": "ready",
-  "isAvailable": true,
-  "isFeatured": true,
-  "sections": [
-    {"id": "header", "name": "Header", "description": "Navigation and branding header", "icon": "📌", "required": true, "defaultEnabled": true},
-    {"id": "hero", "name": "Hero Banner", "description": "Main hero section with couple photo and countdown", "icon": "🖼️", "required": false, "defaultEnabled": true},
-    {"id": "couple", "name": "Couple Profile", "description": "Bride and groom information with photos", "icon": "💑", "required": false, "defaultEnabled": true},
-    {"id": "fathers-letter", "name": "Father's Letter", "description": "Heartfelt letter from the father", "icon": "✉️", "required": false, "defaultEnabled": true},
-    {"id": "gallery", "name": "Photo Gallery", "description": "Photo gallery with lightbox", "icon": "📷", "required": false, "defaultEnabled": true},
-    {"id": "events", "name": "Events Timeline", "description": "Wedding events schedule", "icon": "📅", "required": false, "defaultEnabled": true},
-    {"id": "venue", "name": "Venue Details", "description": "Venue location with map", "icon": "📍", "required": false, "defaultEnabled": true},
-    {"id": "rsvp", "name": "RSVP Section", "description": "Guest RSVP form and contacts", "icon": "💌", "required": false, "defaultEnabled": true},
-    {"id": "footer", "name": "Footer", "description": "Closing message and credits", "icon": "🎀", "required": true, "defaultEnabled": true}
-  ],
-  "defaultSectionOrder": [
-    "header",
-    "hero",
-    "couple",
-    "fathers-letter",
-    "gallery",
-    "events",
-    "venue",
-    "rsvp",
-    "footer"
-  ],
-  "themes": [
-    {
-      "id": "royal-gold",
-      "name": "Royal Gold",
-      "isDefault": true,
-      "colors": {
-        "primary": "#d4af37",
-        "secondary": "#8b6914",
-        "background": "#fff8f0",
-        "text": "#2c2c2c",
-        "accent": "#c9a227"
-      },
-      "fonts": {
-        "heading": "Playfair Display",
-        "body": "Poppins",
-        "script": "Great Vibes"
-      }
-    },
-    {
-      "id": "rose-blush",
-      "name": "Rose Blush",
-      "isDefault": false,
-      "colors": {
-        "primary": "#c77d8a",
-        "secondary": "#9b5c6a",
-        "background": "#fff5f7",
-        "text": "#4a3539",
-        "accent": "#e8b4b8"
-      },
-      "fonts": {
-        "heading": "Cormorant Garamond",
-        "body": "Lato",
-        "script": "Dancing Script"
-      }
-    },
-    {
-      "id": "ivory-cream",
-      "name": "Ivory Cream",
-      "isDefault": false,
-      "colors": {
-        "primary": "#a67c52",
-        "secondary": "#8b6914",
-        "background": "#fffef5",
-        "text": "#3d3d3d",
-        "accent": "#d4b896"
-      },
-      "fonts": {
-        "heading": "Libre Baskerville",
-        "body": "Inter",
-        "script": "Alex Brush"
-      }
-    },
-    {
-      "id": "forest-sage",
-      "name": "Forest Sage",
-      "isDefault": false,
-      "colors": {
-        "primary": "#6b8e6b",
-        "secondary": "#4a6f4a",
-        "background": "#f5f8f5",
-        "text": "#2c3c2c",
-        "accent": "#8fbc8f"
-      },
-      "fonts": {
-        "heading": "EB Garamond",
-        "body": "Montserrat",
-        "script": "Tangerine"
-      }
-    },
-    {
-      "id": "midnight-navy",
-      "name": "Midnight Navy",
-      "isDefault": false,
-      "colors": {
-        "primary": "#1e3a5f",
-        "secondary": "#2c5282",
-        "background": "#f7fafc",
-        "text": "#1a202c",
-        "accent": "#4299e1"
-      },
-      "fonts": {
-        "heading": "Crimson Text",
-        "body": "Poppins",
-        "script": "Great Vibes"
-      }
-    },
-    {
-      "id": "lavender-dream",
-      "name": "Lavender Dream",
-      "isDefault": false,
-      "colors": {
-        "primary": "#9b8bb4",
-        "secondary": "#7a6a96",
-        "background": "#f8f5fc",
-        "text": "#3c3544",
-        "accent": "#c4b5dc"
-      },
-      "fonts": {
-        "heading": "Playfair Display",
-        "body": "Lato",
-        "script": "Dancing Script"
-      }
-    }
-  ]
-}`, classicScrollID)
-
-	// Create classic-scroll layout
-	classicScrollDoc, err := client.Collection("layouts").Doc(classicScrollID).Get(ctx)
-	if err == nil && classicScrollDoc.Exists() {
-		// Layout already exists, skip (idempotent)
-	} else {
-		classicScrollData := map[string]interface{}{
-			"id":            classicScrollID,
-			"name":          "Classic Scroll",
-			"description":   "Traditional single-column layout with elegant typography and centered content. Perfect for couples who appreciate classic design and formal celebrations.",
-			"preview_image": "/layouts/classic-scroll/preview.jpg",
-			"tags":          []string{"elegant", "classic", "traditional"},
-			"version":       "1.0.0",
-			"config":        configJSON,
-			"manifest":      manifestJSON,
-			"is_active":     true,
-			"created_at":    now,
-			"updated_at":    now,
-		}
-
-		_, err = client.Collection("layouts").Doc(classicScrollID).Set(ctx, classicScrollData)
-		if err != nil {
-			return fmt.Errorf("failed to create classic-scroll layout: %w", err)
-		}
-	}
-
-	// Create editorial-elegance layout
-	editorialEleganceConfigJSON := fmt.Sprintf(`{
-  "id": "%s",
-  "name": "Editorial Elegance",
-  "version": "1.0.0",
-  "metadata": {
-    "description": "Luxury magazine-style layout with minimal design, typography-led aesthetics, and editorial photography. Perfect for couples who appreciate subtle luxury and modern design.",
-    "previewImage": "/layouts/editorial-elegance/preview.jpg",
-    "tags": ["luxury", "minimal", "editorial", "modern", "premium", "magazine"],
-    "author": "Sacred Vows",
-    "category": "Modern",
-    "featured": true,
-    "status": "ready"
-  },
-  "sections": [
-    {"id": "hero", "name": "Editorial Cover", "icon": "📰", "description": "Full-height hero with image or video background", "required": true, "enabled": true, "order": 0, "config": {"alignment": "center", "mediaType": "image"}},
-    {"id": "editorial-intro", "name": "Editorial Intro", "icon": "✍️", "description": "Magazine-style opening paragraph with portrait", "required": false, "enabled": true, "order": 1, "config": {"layout": "two-column"}},
-    {"id": "couple", "name": "The Couple", "icon": "💑", "description": "Bride and groom photos and names", "required": false, "enabled": false, "order": 2, "config": {}},
-    {"id": "events", "name": "Event Schedule", "icon": "📅", "description": "Horizontal card-based event schedule", "required": false, "enabled": true, "order": 3, "config": {}},
-    {"id": "wedding-party", "name": "Wedding Party", "icon": "👥", "description": "Optional party members (bridesmaids, groomsmen, etc.)", "required": false, "enabled": false, "order": 4, "config": {"showBios": false, "filter": "bw"}},
-    {"id": "location", "name": "Location", "icon": "📍", "description": "Venue details with embedded map", "required": false, "enabled": true, "order": 5, "config": {"mapStyle": "desaturated"}},
-    {"id": "gallery", "name": "Gallery", "icon": "🖼️", "description": "Editorial-style masonry or single-column gallery (8-12 images)", "required": false, "enabled": true, "order": 6, "config": {"layout": "masonry", "maxImages": 12}},
-    {"id": "rsvp", "name": "RSVP", "icon": "✉️", "description": "Ultra-minimal centered RSVP form", "required": false, "enabled": true, "order": 7, "config": {}},
-    {"id": "footer", "name": "Footer", "icon": "📄", "description": "Minimal footer with couple names", "required": true, "enabled": true, "order": 8, "config": {}}
-  ],
-  "themes": [
-    {
-      "id": "editorial-classic",
-      "name": "Editorial Classic",
-      "isDefault": true,
-      "colors": {
-        "primary": "#C6A15B",
-        "background": "#FAF9F7",
-        "text": "#1C1C1C",
-        "accent": "#C6A15B",
-        "secondary": "#6B6B6B",
-        "divider": "#E6E6E6"
-      },
-      "fonts": {
-        "heading": "Playfair Display",
-        "body": "Inter",
-        "script": "Playfair Display"
-      },
-      "typography": {
-        "heroNames": "80px",
-        "sectionHeadings": "42px",
-        "subHeadings": "24px",
-        "bodyText": "18px",
-        "metaText": "14px",
-        "letterSpacing": {"metaText": "0.1em"}
-      }
-    },
-    {
-      "id": "warm-editorial",
-      "name": "Warm Editorial",
-      "isDefault": false,
-      "colors": {
-        "primary": "#B8956A",
-        "background": "#FAF7F2",
-        "text": "#2C2416",
-        "accent": "#B8956A",
-        "secondary": "#7C7265",
-        "divider": "#E8E4DE"
-      },
-      "fonts": {
-        "heading": "Playfair Display",
-        "body": "Inter",
-        "script": "Playfair Display"
-      }
-    },
-    {
-      "id": "cool-editorial",
-      "name": "Cool Editorial",
-      "isDefault": false,
-      "colors": {
-        "primary": "#94A3B8",
-        "background": "#F9FAFB",
-        "text": "#1A1D23",
-        "accent": "#94A3B8",
-        "secondary": "#64748B",
-        "divider": "#E2E8F0"
-      },
-      "fonts": {
-        "heading": "Playfair Display",
-        "body": "Inter",
-        "script": "Playfair Display"
-      }
-    }
-  ],
-  "features": {
-    "videoHero": true,
-    "imageFilters": true,
-    "embeddedMaps": true,
-    "masonryGallery": true,
-    "minimalAnimations": true,
-    "premiumFonts": false
-  }
-}`, editorialEleganceID)
-
-	editorialEleganceManifestJSON := fmt.Sprintf(`{
-  "id": "%s",
-  "name": "Editorial Elegance",
-  "version": "1.0.0",
-  "description": "Luxury magazine-style layout with minimal design, typography-led aesthetics, and editorial photography. Perfect for couples who appreciate subtle luxury and modern design.",
-  "previewImage": "/layouts/editorial-elegance/preview.jpg",
-  "previewImages": [
-    "/layouts/editorial-elegance/preview-1.jpg",
-    "/layouts/editorial-elegance/preview-2.jpg"
-  ],
-  "tags": ["luxury", "minimal", "editorial", "modern", "premium", "magazine"],
-  "category": "Modern",
-  "author": "Sacred Vows",
-  "price": 0,
-  "currency": "INR",
-  "status": "ready",
-  "isAvailable": true,
-  "isFeatured": true,
-  "sections": [
-    {"id": "hero", "name": "Editorial Cover", "description": "Full-height hero with image or video background", "icon": "📰", "required": true, "defaultEnabled": true},
-    {"id": "editorial-intro", "name": "Editorial Intro", "description": "Magazine-style opening paragraph with portrait", "icon": "✍️", "required": false, "defaultEnabled": true},
-    {"id": "couple", "name": "The Couple", "description": "Bride and groom photos and names", "icon": "💑", "required": false, "defaultEnabled": false},
-    {"id": "events", "name": "Event Schedule", "description": "Horizontal card-based event schedule", "icon": "📅", "required": false, "defaultEnabled": true},
-    {"id": "wedding-party", "name": "Wedding Party", "description": "Optional party members (bridesmaids, groomsmen, etc.)", "icon": "👥", "required": false, "defaultEnabled": false},
-    {"id": "location", "name": "Location", "description": "Venue details with embedded map", "icon": "📍", "required": false, "defaultEnabled": true},
-    {"id": "gallery", "name": "Gallery", "description": "Editorial-style masonry or single-column gallery (8-12 images)", "icon": "🖼️", "required": false, "defaultEnabled": true},
-    {"id": "rsvp", "name": "RSVP", "description": "Ultra-minimal centered RSVP form", "icon": "✉️", "required": false, "defaultEnabled": true},
-    {"id": "footer", "name": "Footer", "description": "Minimal footer with couple names", "icon": "📄", "required": true, "defaultEnabled": true}
-  ],
-  "defaultSectionOrder": [
-    "hero",
-    "editorial-intro",
-    "couple",
-    "events",
-    "wedding-party",
-    "location",
-    "gallery",
-    "rsvp",
-    "footer"
-  ],
-  "themes": [
-    {
-      "id": "editorial-classic",
-      "name": "Editorial Classic",
-      "isDefault": true,
-      "colors": {
-        "primary": "#C6A15B",
-        "background": "#FAF9F7",
-        "text": "#1C1C1C",
-        "accent": "#C6A15B",
-        "secondary": "#6B6B6B"
-      },
-      "fonts": {
-        "heading": "Playfair Display",
-        "body": "Inter",
-        "script": "Playfair Display"
-      }
-    },
-    {
-      "id": "warm-editorial",
-      "name": "Warm Editorial",
-      "isDefault": false,
-      "colors": {
-        "primary": "#B8956A",
-        "background": "#FAF7F2",
-        "text": "#2C2416",
-        "accent": "#B8956A",
-        "secondary": "#7C7265"
-      },
-      "fonts": {
-        "heading": "Playfair Display",
-        "body": "Inter",
-        "script": "Playfair Display"
-      }
-    },
-    {
-      "id": "cool-editorial",
-      "name": "Cool Editorial",
-      "isDefault": false,
-      "colors": {
-        "primary": "#94A3B8",
-        "background": "#F9FAFB",
-        "text": "#1A1D23",
-        "accent": "#94A3B8",
-        "secondary": "#64748B"
-      },
-      "fonts": {
-        "heading": "Playfair Display",
-        "body": "Inter",
-        "script": "Playfair Display"
-      }
-    }
-  ],
-  "presets": [
-    {
-      "id": "modern-editorial",
-      "name": "Modern Editorial",
-      "emoji": "🖤",
-      "description": "Minimal & Luxe",
-      "useCase": "For couples who want elegance, restraint, and strong visual impact.",
-      "bestFor": "City weddings, intimate guest lists, design-forward couples",
-      "sectionIds": ["hero", "countdown", "quote", "editorial-intro", "couple", "events", "location", "gallery", "rsvp", "footer"]
-    },
-    {
-      "id": "love-story-feature",
-      "name": "Love Story Feature",
-      "emoji": "🤍",
-      "description": "Romantic & Narrative",
-      "useCase": "Feels like a full magazine wedding spread. Perfect for couples who love storytelling.",
-      "bestFor": "Couples who love storytelling, emotional depth, destination weddings",
-      "sectionIds": ["hero", "quote", "editorial-intro", "story", "couple", "wedding-party", "events", "location", "travel", "things-to-do", "gallery", "dress-code", "rsvp", "footer"]
-    },
-    {
-      "id": "guest-experience",
-      "name": "Guest Experience",
-      "emoji": "✨",
-      "description": "Clean & Thoughtful",
-      "useCase": "Designed around guest clarity without killing elegance.",
-      "bestFor": "Larger weddings, mixed-age guests, practical planners",
-      "sectionIds": ["hero", "countdown", "editorial-intro", "events", "location", "travel", "dress-code", "faq", "registry", "gallery", "rsvp", "contact", "footer"]
-    }
-  ]
-}`, editorialEleganceID)
-
-	editorialEleganceDoc, err := client.Collection("layouts").Doc(editorialEleganceID).Get(ctx)
-	if err == nil && editorialEleganceDoc.Exists() {
-		// Layout already exists, update manifest to include presets
-		_, err = client.Collection("layouts").Doc(editorialEleganceID).Update(ctx, []firestore.Update{
-			{Path: "manifest", Value: editorialEleganceManifestJSON},
-			{Path: "updated_at", Value: now},
-		})
+	layoutRepo := NewLayoutRepository(client)
+	for _, layout := range catalog {
+		existing, err := layoutRepo.FindByID(ctx, layout.ID)
 		if err != nil {
-			return fmt.Errorf("failed to update editorial-elegance layout manifest: %w", err)
+			return fmt.Errorf("failed to get %s layout: %w", layout.ID, err)
+		}
+		if existing != nil {
+			// Layout already exists, skip (idempotent)
+			continue
+		}
+		if err := layoutRepo.Create(ctx, layout, nil); err != nil {
+			return fmt.Errorf("failed to create %s layout: %w", layout.ID, err)
 		}
-		return nil
-	}
-
-	editorialEleganceData := map[string]interface{}{
-		"id":            editorialEleganceID,
-		"name":          "Editorial Elegance",
-		"description":   "Luxury magazine-style layout with minimal design, typography-led aesthetics, and editorial photography. Perfect for couples who appreciate subtle luxury and modern design.",
-		"preview_image": "/layouts/editorial-elegance/preview.jpg",
-		"tags":          []string{"luxury", "minimal", "editorial", "modern", "premium", "magazine"},
-		"version":       "1.0.0",
-		"config":        editorialEleganceConfigJSON,
-		"manifest":      editorialEleganceManifestJSON,
-		"is_active":     true,
-		"created_at":    now,
-		"updated_at":    now,
-	}
-
-	_, err = client.Collection("layouts").Doc(editorialEleganceID).Set(ctx, editorialEleganceData)
-	if err != nil {
-		return fmt.Errorf("failed to create editorial-elegance layout: %w", err)
 	}
 
 	return nil
@@ -960,3 +410,466 @@ func migration003AddEditorialElegancePresets(ctx context.Context, client *Client
 
 	return nil
 }
+
+// Migration 004: Lift Invitation Metadata
+// Title and status used to live in a "_meta" key inside the invitation data JSON.
+// Moves them to top-level fields so status can be queried, and strips "_meta" from data.
+// Invitations with a live published site become "published"; everything else falls back to
+// the "_meta" status when it is valid, otherwise "draft". Documents that already have a status are skipped.
+func migration004LiftInvitationMetadata(ctx context.Context, client *Client) error {
+	// Firestore batches are limited to 500 writes
+	const batchSize = 400
+
+	published := map[string]bool{}
+	siteDocs, err := client.Collection("published_sites").Where("published", "==", true).Documents(ctx).GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to load published sites: %w", err)
+	}
+	for _, doc := range siteDocs {
+		published[getString(doc.Data(), "invitation_id")] = true
+	}
+
+	docs, err := client.Collection("invitations").Documents(ctx).GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to load invitations: %w", err)
+	}
+
+	batch := client.Batch()
+	pending := 0
+	for _, doc := range docs {
+		data := doc.Data()
+		if getString(data, "status") != "" {
+			continue
+		}
+
+		rest, title, metaStatus, err := domain.SplitLegacyMetadata(json.RawMessage(getString(data, "data")))
+		if err != nil {
+			// Unparseable data is left as is; the invitation still gets a status
+			rest = json.RawMessage(getString(data, "data"))
+		}
+
+		status := domain.InvitationStatusDraft
+		if parsed, err := domain.ParseInvitationStatus(metaStatus); err == nil {
+			status = parsed
+		}
+		if published[doc.Ref.ID] {
+			status = domain.InvitationStatusPublished
+		} else if status == domain.InvitationStatusPublished {
+			status = domain.InvitationStatusUnpublished
+		}
+
+		updates := []firestore.Update{
+			{Path: "status", Value: string(status)},
+			{Path: "data", Value: string(rest)},
+		}
+		if getString(data, "title") == "" && title != "" {
+			updates = append(updates, firestore.Update{Path: "title", Value: title})
+		}
+		batch.Update(doc.Ref, updates)
+		pending++
+
+		if pending == batchSize {
+			if _, err := batch.Commit(ctx); err != nil {
+				return fmt.Errorf("failed to update invitations: %w", err)
+			}
+			batch = client.Batch()
+			pending = 0
+		}
+	}
+
+	if pending > 0 {
+		if _, err := batch.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to update invitations: %w", err)
+		}
+	}
+	return nil
+}
+
+// Migration 005: Backfill Trashed Flag
+// Listings now filter on trashed == false, which does not match documents without the field.
+// Sets trashed=false on every invitation and asset written before soft deletion existed.
+func migration005BackfillTrashedFlag(ctx context.Context, client *Client) error {
+	// Firestore batches are limited to 500 writes
+	const batchSize = 400
+
+	for _, collection := range []string{"invitations", "assets"} {
+		docs, err := client.Collection(collection).Documents(ctx).GetAll()
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", collection, err)
+		}
+
+		batch := client.Batch()
+		pending := 0
+		for _, doc := range docs {
+			if _, ok := doc.Data()["trashed"]; ok {
+				continue
+			}
+			batch.Update(doc.Ref, []firestore.Update{{Path: "trashed", Value: false}})
+			pending++
+
+			if pending == batchSize {
+				if _, err := batch.Commit(ctx); err != nil {
+					return fmt.Errorf("failed to update %s: %w", collection, err)
+				}
+				batch = client.Batch()
+				pending = 0
+			}
+		}
+
+		if pending > 0 {
+			if _, err := batch.Commit(ctx); err != nil {
+				return fmt.Errorf("failed to update %s: %w", collection, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Migration 006: Add Layout Data Schemas
+// Invitation data is validated against the JSON Schema under "dataSchema" in its layout manifest.
+// Derives that schema from each manifest's own sections, presets and themes. Manifests that
+// already declare a dataSchema are left alone.
+func migration006AddLayoutDataSchemas(ctx context.Context, client *Client) error {
+	docs, err := client.Collection("layouts").Documents(ctx).GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to load layouts: %w", err)
+	}
+
+	for _, doc := range docs {
+		manifestStr := getString(doc.Data(), "manifest")
+		if manifestStr == "" {
+			continue
+		}
+
+		var manifest map[string]interface{}
+		if err := json.Unmarshal([]byte(manifestStr), &manifest); err != nil {
+			return fmt.Errorf("failed to parse manifest of layout %s: %w", doc.Ref.ID, err)
+		}
+		if _, ok := manifest["dataSchema"]; ok {
+			continue
+		}
+		manifest["dataSchema"] = layoutDataSchema(manifest)
+
+		updatedManifestJSON, err := json.Marshal(manifest)
+		if err != nil {
+			return fmt.Errorf("failed to marshal updated manifest: %w", err)
+		}
+		_, err = doc.Ref.Update(ctx, []firestore.Update{
+			{Path: "manifest", Value: string(updatedManifestJSON)},
+			{Path: "updated_at", Value: time.Now()},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update %s layout manifest: %w", doc.Ref.ID, err)
+		}
+	}
+	return nil
+}
+
+// Migration 007: Pin Layout Versions
+// Invitations render with the layout version they are pinned to, read from the layout's versions
+// subcollection. Copies each layout's current version there, and pins existing invitations to the
+// current version of their layout, which is what they have been rendering with.
+func migration007PinLayoutVersions(ctx context.Context, client *Client) error {
+	// Firestore batches are limited to 500 writes
+	const batchSize = 400
+
+	layoutRepo := &layoutRepository{client: client}
+	layouts, err := layoutRepo.FindAllIncludingInactive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load layouts: %w", err)
+	}
+
+	batch := client.Batch()
+	for _, layout := range layouts {
+		layoutRepo.setVersion(batch, layout)
+	}
+	if len(layouts) > 0 {
+		if _, err := batch.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to snapshot layout versions: %w", err)
+		}
+	}
+
+	currentVersions := make(map[string]string, len(layouts))
+	for _, layout := range layouts {
+		currentVersions[layout.ID] = layout.Version
+	}
+
+	docs, err := client.Collection("invitations").Documents(ctx).GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to load invitations: %w", err)
+	}
+
+	batch = client.Batch()
+	pending := 0
+	for _, doc := range docs {
+		data := doc.Data()
+		if getString(data, "layout_version") != "" {
+			continue
+		}
+		version := currentVersions[getString(data, "layout_id")]
+		if version == "" {
+			continue
+		}
+		batch.Update(doc.Ref, []firestore.Update{{Path: "layout_version", Value: version}})
+		pending++
+
+		if pending == batchSize {
+			if _, err := batch.Commit(ctx); err != nil {
+				return fmt.Errorf("failed to pin invitations: %w", err)
+			}
+			batch = client.Batch()
+			pending = 0
+		}
+	}
+
+	if pending > 0 {
+		if _, err := batch.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to pin invitations: %w", err)
+		}
+	}
+	return nil
+}
+
+// legacyAnonymousUserID owned every invitation created while logged out before guest sessions
+const legacyAnonymousUserID = "anonymous"
+
+// Migration 008: Flag Legacy Anonymous Invitations
+// Before guest sessions, logged-out drafts were all owned by "anonymous", which any caller could
+// edit. Now only the owner can, and "anonymous" is neither a user nor a guest session. Gives each
+// such invitation a guest session ID of its own and flags it as a guest draft, so it is handled
+// like other unclaimed guest drafts: nobody else can edit it and it expires after the guest TTL.
+//
+// No browser holds these session IDs, so the drafts can no longer be claimed and expiry deletes
+// them together with their assets and revisions. That data loss is accepted, but updated_at is
+// stamped with the migration time so the drafts get a full guest TTL as a grace period instead of
+// being deleted by the first cmd/expire-guest-drafts run.
+func migration008FlagLegacyAnonymousInvitations(ctx context.Context, client *Client) error {
+	// Firestore batches are limited to 500 writes
+	const batchSize = 400
+	now := time.Now()
+
+	docs, err := client.Collection("invitations").Where("user_id", "==", legacyAnonymousUserID).Documents(ctx).GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to load anonymous invitations: %w", err)
+	}
+
+	batch := client.Batch()
+	pending := 0
+	for _, doc := range docs {
+		batch.Update(doc.Ref, []firestore.Update{
+			{Path: "user_id", Value: legacyGuestSessionID(doc.Ref.ID)},
+			{Path: "guest", Value: true},
+			{Path: "updated_at", Value: now},
+		})
+		pending++
+
+		if pending == batchSize {
+			if _, err := batch.Commit(ctx); err != nil {
+				return fmt.Errorf("failed to flag anonymous invitations: %w", err)
+			}
+			batch = client.Batch()
+			pending = 0
+		}
+	}
+
+	if pending > 0 {
+		if _, err := batch.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to flag anonymous invitations: %w", err)
+		}
+	}
+	return nil
+}
+
+// legacyGuestSessionID is the guest session ID a legacy anonymous invitation is moved to
+func legacyGuestSessionID(invitationID string) string {
+	return domain.GuestSessionIDPrefix + "legacy-" + invitationID
+}
+
+// Migration 009: Add Layout Migrations
+// Switching layouts carries invitation data over with the "migrations" the target manifest
+// declares for the previous layout. Layouts seeded before the catalog shipped them have none, so
+// switching to them migrated nothing. Copies the mappings from the embedded catalog into the
+// stored manifest of each such layout and its current version; manifests that already declare
+// migrations are left alone.
+func migration009AddLayoutMigrations(ctx context.Context, client *Client) error {
+	catalog, err := layoutcatalog.Load(layouts.FS)
+	if err != nil {
+		return fmt.Errorf("failed to load layout catalog: %w", err)
+	}
+
+	layoutRepo := &layoutRepository{client: client}
+	for _, catalogLayout := range catalog {
+		if catalogLayout.Manifest == nil {
+			continue
+		}
+		layout, err := layoutRepo.FindByID(ctx, catalogLayout.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get %s layout: %w", catalogLayout.ID, err)
+		}
+		if layout == nil || layout.Manifest == nil {
+			// Layout doesn't exist, nothing to update
+			continue
+		}
+
+		manifest, changed, err := withLayoutMigrations(*layout.Manifest, *catalogLayout.Manifest)
+		if err != nil {
+			return fmt.Errorf("failed to add migrations to layout %s: %w", layout.ID, err)
+		}
+		if !changed {
+			continue
+		}
+		layout.Manifest = &manifest
+		if err := layoutRepo.Update(ctx, layout, nil); err != nil {
+			return fmt.Errorf("failed to update %s layout manifest: %w", layout.ID, err)
+		}
+	}
+	return nil
+}
+
+// withLayoutMigrations returns stored with the "migrations" of the catalog manifest added, and
+// whether anything changed. Stored manifests that declare migrations, and catalog manifests that
+// declare none, leave stored as it is.
+func withLayoutMigrations(stored, catalog json.RawMessage) (json.RawMessage, bool, error) {
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(stored, &manifest); err != nil {
+		return nil, false, fmt.Errorf("failed to parse stored manifest: %w", err)
+	}
+	if _, ok := manifest["migrations"]; ok {
+		return stored, false, nil
+	}
+
+	var catalogManifest struct {
+		Migrations json.RawMessage `json:"migrations"`
+	}
+	if err := json.Unmarshal(catalog, &catalogManifest); err != nil {
+		return nil, false, fmt.Errorf("failed to parse catalog manifest: %w", err)
+	}
+	if len(catalogManifest.Migrations) == 0 || string(catalogManifest.Migrations) == "null" {
+		return stored, false, nil
+	}
+	manifest["migrations"] = catalogManifest.Migrations
+
+	updated, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal updated manifest: %w", err)
+	}
+	return updated, true, nil
+}
+
+// layoutDataSchema builds the JSON Schema for invitation data of a layout. Section IDs must be
+// declared by the manifest (as a section or in a preset) and the theme preset must be one of its
+// themes, or "custom"/"default" as set by the builder. Content sections are only type-checked so
+// layouts can keep adding fields.
+func layoutDataSchema(manifest map[string]interface{}) map[string]interface{} {
+	sectionIDs := []string{}
+	seen := map[string]bool{}
+	addSection := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			sectionIDs = append(sectionIDs, id)
+		}
+	}
+	if sections, ok := manifest["sections"].([]interface{}); ok {
+		for _, section := range sections {
+			if section, ok := section.(map[string]interface{}); ok {
+				id, _ := section["id"].(string)
+				addSection(id)
+			}
+		}
+	}
+	if presets, ok := manifest["presets"].([]interface{}); ok {
+		for _, preset := range presets {
+			if preset, ok := preset.(map[string]interface{}); ok {
+				ids, _ := preset["sectionIds"].([]interface{})
+				for _, id := range ids {
+					id, _ := id.(string)
+					addSection(id)
+				}
+			}
+		}
+	}
+
+	themeIDs := []string{"custom", "default"}
+	if themes, ok := manifest["themes"].([]interface{}); ok {
+		for _, theme := range themes {
+			if theme, ok := theme.(map[string]interface{}); ok {
+				if id, _ := theme["id"].(string); id != "" {
+					themeIDs = append(themeIDs, id)
+				}
+			}
+		}
+	}
+
+	object := map[string]interface{}{"type": "object"}
+	str := map[string]interface{}{"type": "string"}
+
+	return map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"layoutConfig": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"sections": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type":     "object",
+							"required": []string{"id"},
+							"properties": map[string]interface{}{
+								"id":      map[string]interface{}{"type": "string", "enum": sectionIDs},
+								"enabled": map[string]interface{}{"type": "boolean"},
+								"order":   map[string]interface{}{"type": "integer", "minimum": 0},
+							},
+						},
+					},
+					"theme": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"preset": map[string]interface{}{"type": "string", "enum": themeIDs},
+							"colors": map[string]interface{}{
+								"type":                 "object",
+								"additionalProperties": map[string]interface{}{"$ref": "#/definitions/color"},
+							},
+							"fonts": map[string]interface{}{
+								"type":                 "object",
+								"additionalProperties": str,
+							},
+						},
+					},
+					"themes": map[string]interface{}{"type": "array", "items": object},
+				},
+			},
+			"branding": object,
+			"couple":   object,
+			"wedding": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"dates":           map[string]interface{}{"type": "array", "items": str},
+					"venue":           object,
+					"countdownTarget": str,
+				},
+			},
+			"events":        object,
+			"gallery":       object,
+			"rsvp":          object,
+			"customContent": object,
+			"hero": map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"mainImage": str},
+			},
+			"music": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"file":   str,
+					"volume": map[string]interface{}{"type": "number", "minimum": 0, "maximum": 1},
+				},
+			},
+		},
+		"definitions": map[string]interface{}{
+			"color": map[string]interface{}{
+				"type":    "string",
+				"pattern": "^#([0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$",
+			},
+		},
+	}
+}
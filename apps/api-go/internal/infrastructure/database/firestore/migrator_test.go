@@ -2,7 +2,11 @@ package firestore
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/jsonschema"
 )
 
 // TestMigration003PresetStructure validates that the presets added by migration 003
@@ -221,3 +225,150 @@ func TestMigration003PresetSectionIds(t *testing.T) {
 		}
 	}
 }
+
+// TestLayoutDataSchema validates that the schema added by migration 006 compiles and accepts
+// builder data while rejecting sections and themes the manifest does not declare.
+func TestLayoutDataSchema(t *testing.T) {
+	manifest := map[string]interface{}{}
+	manifestJSON := `{
+		"sections": [{"id": "hero"}, {"id": "couple"}, {"id": "footer"}],
+		"presets": [{"id": "short", "sectionIds": ["hero", "countdown", "footer"]}],
+		"themes": [{"id": "royal-gold"}, {"id": "rose-blush"}]
+	}`
+	if err := json.Unmarshal([]byte(manifestJSON), &manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	schemaJSON, err := json.Marshal(layoutDataSchema(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := jsonschema.Compile(schemaJSON)
+	if err != nil {
+		t.Fatalf("schema does not compile: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		data      string
+		wantPaths []string
+	}{
+		{
+			name: "builder data",
+			data: `{
+				"couple": {"bride": {"name": "Priya"}},
+				"wedding": {"dates": ["2026-01-22"], "venue": {"name": "Hall"}},
+				"music": {"file": "/music/1.mp3", "volume": 0.5},
+				"layoutConfig": {
+					"sections": [{"id": "hero", "enabled": true, "order": 0}, {"id": "countdown", "enabled": false, "order": 1}],
+					"theme": {"preset": "royal-gold", "colors": {"primary": "#d4af37", "accent": "#fff"}, "fonts": {"heading": "Playfair"}}
+				}
+			}`,
+		},
+		{
+			name: "custom theme",
+			data: `{"layoutConfig": {"theme": {"preset": "custom"}}}`,
+		},
+		{
+			name: "mismatched layout",
+			data: `{
+				"music": {"volume": 2},
+				"layoutConfig": {
+					"sections": [{"id": "fathers-letter", "enabled": "yes", "order": -1}, {"enabled": true}],
+					"theme": {"preset": "editorial-classic", "colors": {"primary": "gold"}}
+				}
+			}`,
+			wantPaths: []string{
+				"/layoutConfig/sections/0/enabled",
+				"/layoutConfig/sections/0/id",
+				"/layoutConfig/sections/0/order",
+				"/layoutConfig/sections/1",
+				"/layoutConfig/theme/colors/primary",
+				"/layoutConfig/theme/preset",
+				"/music/volume",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := schema.Validate([]byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var paths []string
+			for _, v := range violations {
+				paths = append(paths, v.Path)
+			}
+			if fmt.Sprint(paths) != fmt.Sprint(tt.wantPaths) {
+				t.Errorf("violations = %v, want paths %v", violations, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestLegacyGuestSessionID(t *testing.T) {
+	id := legacyGuestSessionID("2bZ8yQm6a8K3k1bTQeYxk2m0TfA")
+
+	if id != "guest_legacy-2bZ8yQm6a8K3k1bTQeYxk2m0TfA" {
+		t.Errorf("legacyGuestSessionID() = %q", id)
+	}
+	if !domain.IsGuestSessionID(id) {
+		t.Errorf("%q should be a guest session ID, so legacy drafts are handled as guest drafts", id)
+	}
+	if id == legacyGuestSessionID("another-invitation") {
+		t.Error("each legacy draft should get its own owner")
+	}
+}
+
+func TestWithLayoutMigrations(t *testing.T) {
+	catalog := json.RawMessage(`{"id":"editorial-elegance","migrations":{"classic-scroll":{"sections":{"venue":"location"}}}}`)
+
+	tests := []struct {
+		name        string
+		stored      string
+		catalog     json.RawMessage
+		wantChanged bool
+		want        string
+	}{
+		{
+			name:        "adds the catalog migrations",
+			stored:      `{"id":"editorial-elegance","themes":[]}`,
+			catalog:     catalog,
+			wantChanged: true,
+			want:        `{"id":"editorial-elegance","themes":[],"migrations":{"classic-scroll":{"sections":{"venue":"location"}}}}`,
+		},
+		{
+			name:    "keeps migrations the stored manifest declares",
+			stored:  `{"id":"editorial-elegance","migrations":{}}`,
+			catalog: catalog,
+			want:    `{"id":"editorial-elegance","migrations":{}}`,
+		},
+		{
+			name:    "catalog without migrations",
+			stored:  `{"id":"editorial-elegance"}`,
+			catalog: json.RawMessage(`{"id":"editorial-elegance"}`),
+			want:    `{"id":"editorial-elegance"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed, err := withLayoutMigrations(json.RawMessage(tt.stored), tt.catalog)
+			if err != nil {
+				t.Fatalf("withLayoutMigrations() error = %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			var gotValue, wantValue interface{}
+			if err := json.Unmarshal(got, &gotValue); err != nil {
+				t.Fatalf("invalid manifest %s: %v", got, err)
+			}
+			_ = json.Unmarshal([]byte(tt.want), &wantValue)
+			if fmt.Sprint(gotValue) != fmt.Sprint(wantValue) {
+				t.Errorf("manifest = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package firestore
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/firestore/apiv1/firestorepb"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+)
+
+// listSpec describes which API fields a list query can sort and filter on, mapped to stored field names.
+// Each sort field combined with each filter and the parent equality filter needs a composite index,
+// declared in firestore_queries of infra/terraform/modules/gcp-resources/main.tf.
+type listSpec struct {
+	sortFields       map[string]string
+	filterFields     map[string]string
+	defaultSort      string
+	defaultDirection repository.SortDirection
+}
+
+// pageCursor is the decoded form of ListOptions.Cursor. It records the sort it was issued for so a
+// cursor cannot be replayed against a different order.
+type pageCursor struct {
+	Sort      string                   `json:"s"`
+	Direction repository.SortDirection `json:"d"`
+	Kind      string                   `json:"k"`
+	Value     string                   `json:"v"`
+	ID        string                   `json:"id"`
+}
+
+// paginate applies opts to query (which already holds the parent filter) and returns at most one page
+// of documents plus the cursor for the next page.
+func paginate(ctx context.Context, query firestore.Query, spec listSpec, opts repository.ListOptions) ([]*firestore.DocumentSnapshot, string, error) {
+	sortField := opts.SortField
+	if sortField == "" {
+		sortField = spec.defaultSort
+	}
+	storedSort, ok := spec.sortFields[sortField]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: cannot sort by %q", repository.ErrInvalidListOptions, sortField)
+	}
+	direction := opts.SortDirection
+	if direction == "" {
+		direction = spec.defaultDirection
+	}
+	if direction != repository.SortAsc && direction != repository.SortDesc {
+		return nil, "", fmt.Errorf("%w: invalid sort direction %q", repository.ErrInvalidListOptions, direction)
+	}
+
+	for key, value := range opts.Filters {
+		storedFilter, ok := spec.filterFields[key]
+		if !ok {
+			return nil, "", fmt.Errorf("%w: cannot filter by %q", repository.ErrInvalidListOptions, key)
+		}
+		query = query.Where(storedFilter, "==", value)
+	}
+
+	dir := firestore.Asc
+	if direction == repository.SortDesc {
+		dir = firestore.Desc
+	}
+	// Ordering by document ID as well makes the order total, so ties on the sort field are not skipped
+	query = query.OrderBy(storedSort, dir).OrderBy(firestore.DocumentID, dir)
+
+	if opts.Cursor != "" {
+		cursor, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if cursor.Sort != sortField || cursor.Direction != direction {
+			return nil, "", fmt.Errorf("%w: cursor was issued for a different sort", repository.ErrInvalidListOptions)
+		}
+		value, err := cursor.value()
+		if err != nil {
+			return nil, "", err
+		}
+		query = query.StartAfter(value, cursor.ID)
+	}
+
+	limit := opts.PageLimit()
+	docs, err := query.Limit(limit + 1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(docs) <= limit {
+		return docs, "", nil
+	}
+
+	docs = docs[:limit]
+	last := docs[limit-1]
+	next, err := encodeCursor(sortField, direction, last.Data()[storedSort], last.Ref.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	return docs, next, nil
+}
+
+// countMatching counts the documents of query (which already holds the parent filter) that match
+// filters, with an aggregation query rather than reading them.
+func countMatching(ctx context.Context, query firestore.Query, spec listSpec, filters map[string]string) (int, error) {
+	for key, value := range filters {
+		storedFilter, ok := spec.filterFields[key]
+		if !ok {
+			return 0, fmt.Errorf("%w: cannot filter by %q", repository.ErrInvalidListOptions, key)
+		}
+		query = query.Where(storedFilter, "==", value)
+	}
+
+	result, err := query.NewAggregationQuery().WithCount("count").Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count, ok := result["count"].(*firestorepb.Value)
+	if !ok {
+		return 0, fmt.Errorf("unexpected count result %T", result["count"])
+	}
+	return int(count.GetIntegerValue()), nil
+}
+
+func encodeCursor(sortField string, direction repository.SortDirection, value interface{}, id string) (string, error) {
+	cursor := pageCursor{Sort: sortField, Direction: direction, ID: id}
+	switch v := value.(type) {
+	case time.Time:
+		cursor.Kind, cursor.Value = "time", v.UTC().Format(time.RFC3339Nano)
+	case int64:
+		cursor.Kind, cursor.Value = "int", strconv.FormatInt(v, 10)
+	case string:
+		cursor.Kind, cursor.Value = "string", v
+	default:
+		return "", fmt.Errorf("unsupported cursor value type %T", value)
+	}
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(encoded string) (*pageCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", repository.ErrInvalidListOptions)
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(b, &cursor); err != nil || cursor.ID == "" {
+		return nil, fmt.Errorf("%w: malformed cursor", repository.ErrInvalidListOptions)
+	}
+	return &cursor, nil
+}
+
+func (c *pageCursor) value() (interface{}, error) {
+	switch c.Kind {
+	case "time":
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed cursor", repository.ErrInvalidListOptions)
+		}
+		return t, nil
+	case "int":
+		n, err := strconv.ParseInt(c.Value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed cursor", repository.ErrInvalidListOptions)
+		}
+		return n, nil
+	case "string":
+		return c.Value, nil
+	default:
+		return nil, fmt.Errorf("%w: malformed cursor", repository.ErrInvalidListOptions)
+	}
+}
@@ -0,0 +1,51 @@
+package firestore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursor_RoundTrip(t *testing.T) {
+	ts := time.Date(2025, 6, 15, 10, 30, 0, 123456789, time.UTC)
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"time", ts},
+		{"int", int64(2048)},
+		{"string", "Jane Doe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := encodeCursor("field", repository.SortDesc, tt.value, "doc-1")
+			require.NoError(t, err)
+
+			cursor, err := decodeCursor(encoded)
+			require.NoError(t, err)
+			assert.Equal(t, "field", cursor.Sort)
+			assert.Equal(t, repository.SortDesc, cursor.Direction)
+			assert.Equal(t, "doc-1", cursor.ID)
+
+			value, err := cursor.value()
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
+
+func TestEncodeCursor_UnsupportedValue(t *testing.T) {
+	_, err := encodeCursor("field", repository.SortAsc, nil, "doc-1")
+	assert.Error(t, err)
+}
+
+func TestDecodeCursor_Malformed(t *testing.T) {
+	for _, encoded := range []string{"not base64!", "bm90IGpzb24", "e30"} {
+		_, err := decodeCursor(encoded)
+		assert.True(t, errors.Is(err, repository.ErrInvalidListOptions), "cursor %q should be rejected", encoded)
+	}
+}
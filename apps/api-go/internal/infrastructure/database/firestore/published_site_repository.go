@@ -54,6 +54,8 @@ func (r *publishedSiteRepository) Create(ctx context.Context, site *domain.Publi
 		"subdomain":       site.Subdomain,
 		"published":       site.Published,
 		"current_version": site.CurrentVersion,
+		"locales":         site.Locales,
+		"default_locale":  site.DefaultLocale,
 		"created_at":      site.CreatedAt,
 		"updated_at":      site.UpdatedAt,
 	}
@@ -75,6 +77,8 @@ func (r *publishedSiteRepository) Update(ctx context.Context, site *domain.Publi
 		{Path: "subdomain", Value: site.Subdomain},
 		{Path: "published", Value: site.Published},
 		{Path: "current_version", Value: site.CurrentVersion},
+		{Path: "locales", Value: site.Locales},
+		{Path: "default_locale", Value: site.DefaultLocale},
 		{Path: "updated_at", Value: site.UpdatedAt},
 	}
 
@@ -95,6 +99,8 @@ func (r *publishedSiteRepository) docToPublishedSite(doc *firestore.DocumentSnap
 		Subdomain:      getString(data, "subdomain"),
 		Published:      getBool(data, "published"),
 		CurrentVersion: getInt(data, "current_version"),
+		Locales:        getStringSlice(data, "locales"),
+		DefaultLocale:  getString(data, "default_locale"),
 		CreatedAt:      getTime(data, "created_at"),
 		UpdatedAt:      getTime(data, "updated_at"),
 	}
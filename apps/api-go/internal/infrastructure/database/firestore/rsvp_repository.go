@@ -38,9 +38,16 @@ func (r *rsvpRepository) Create(ctx context.Context, rsvp *domain.RSVPResponse)
 	return err
 }
 
-func (r *rsvpRepository) FindByInvitationID(ctx context.Context, invitationID string) ([]*domain.RSVPResponse, error) {
-	iter := r.client.Collection("rsvp_responses").Where("invitation_id", "==", invitationID).Documents(ctx)
-	docs, err := iter.GetAll()
+var rsvpListSpec = listSpec{
+	sortFields:       map[string]string{"submittedAt": "submitted_at", "name": "name"},
+	filterFields:     map[string]string{"date": "date"},
+	defaultSort:      "submittedAt",
+	defaultDirection: repository.SortDesc,
+}
+
+func (r *rsvpRepository) FindByInvitationID(ctx context.Context, invitationID string, opts repository.ListOptions) (*repository.Page[*domain.RSVPResponse], error) {
+	query := r.client.Collection("rsvp_responses").Where("invitation_id", "==", invitationID)
+	docs, next, err := paginate(ctx, query, rsvpListSpec, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +56,12 @@ func (r *rsvpRepository) FindByInvitationID(ctx context.Context, invitationID st
 	for i, doc := range docs {
 		rsvps[i] = r.docToRSVP(doc)
 	}
-	return rsvps, nil
+	return &repository.Page[*domain.RSVPResponse]{Items: rsvps, NextCursor: next}, nil
+}
+
+func (r *rsvpRepository) CountByInvitationID(ctx context.Context, invitationID string, filters map[string]string) (int, error) {
+	query := r.client.Collection("rsvp_responses").Where("invitation_id", "==", invitationID)
+	return countMatching(ctx, query, rsvpListSpec, filters)
 }
 
 func (r *rsvpRepository) FindByID(ctx context.Context, id string) (*domain.RSVPResponse, error) {
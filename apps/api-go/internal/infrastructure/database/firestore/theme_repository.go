@@ -0,0 +1,103 @@
+package firestore
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type themeRepository struct {
+	client *Client
+}
+
+// NewThemeRepository creates a new Firestore custom theme repository
+func NewThemeRepository(client *Client) repository.ThemeRepository {
+	return &themeRepository{client: client}
+}
+
+func (r *themeRepository) Create(ctx context.Context, theme *domain.Theme) error {
+	now := time.Now()
+	theme.CreatedAt = now
+	theme.UpdatedAt = now
+
+	_, err := r.client.Collection("themes").Doc(theme.ID).Set(ctx, map[string]interface{}{
+		"id":         theme.ID,
+		"user_id":    theme.UserID,
+		"name":       theme.Name,
+		"colors":     theme.Colors,
+		"fonts":      theme.Fonts,
+		"created_at": theme.CreatedAt,
+		"updated_at": theme.UpdatedAt,
+	})
+	return err
+}
+
+func (r *themeRepository) FindByID(ctx context.Context, id string) (*domain.Theme, error) {
+	doc, err := r.client.Collection("themes").Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.docToTheme(doc), nil
+}
+
+var themeListSpec = listSpec{
+	sortFields:       map[string]string{"createdAt": "created_at", "name": "name"},
+	defaultSort:      "createdAt",
+	defaultDirection: repository.SortDesc,
+}
+
+func (r *themeRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Theme], error) {
+	query := r.client.Collection("themes").Where("user_id", "==", userID)
+	docs, next, err := paginate(ctx, query, themeListSpec, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	themes := make([]*domain.Theme, len(docs))
+	for i, doc := range docs {
+		themes[i] = r.docToTheme(doc)
+	}
+	return &repository.Page[*domain.Theme]{Items: themes, NextCursor: next}, nil
+}
+
+func (r *themeRepository) Update(ctx context.Context, theme *domain.Theme) error {
+	theme.UpdatedAt = time.Now()
+
+	// Set rather than Update, so roles removed from colors or fonts do not linger in the maps
+	_, err := r.client.Collection("themes").Doc(theme.ID).Set(ctx, map[string]interface{}{
+		"id":         theme.ID,
+		"user_id":    theme.UserID,
+		"name":       theme.Name,
+		"colors":     theme.Colors,
+		"fonts":      theme.Fonts,
+		"created_at": theme.CreatedAt,
+		"updated_at": theme.UpdatedAt,
+	})
+	return err
+}
+
+func (r *themeRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.Collection("themes").Doc(id).Delete(ctx)
+	return err
+}
+
+func (r *themeRepository) docToTheme(doc *firestore.DocumentSnapshot) *domain.Theme {
+	data := doc.Data()
+	return &domain.Theme{
+		ID:        doc.Ref.ID,
+		UserID:    getString(data, "user_id"),
+		Name:      getString(data, "name"),
+		Colors:    getStringMap(data, "colors"),
+		Fonts:     getStringMap(data, "fonts"),
+		CreatedAt: getTime(data, "created_at"),
+		UpdatedAt: getTime(data, "updated_at"),
+	}
+}
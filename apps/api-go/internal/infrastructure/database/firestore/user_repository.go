@@ -24,12 +24,16 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	now := time.Now()
 	user.CreatedAt = now
 	user.UpdatedAt = now
+	if user.Role == "" {
+		user.Role = domain.UserRoleUser
+	}
 
 	_, err := r.client.Collection("users").Doc(user.ID).Set(ctx, map[string]interface{}{
 		"id":         user.ID,
 		"email":      user.Email,
 		"name":       user.Name,
 		"password":   user.Password,
+		"role":       string(user.Role),
 		"created_at": user.CreatedAt,
 		"updated_at": user.UpdatedAt,
 	})
@@ -61,11 +65,22 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain
 
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 	user.UpdatedAt = time.Now()
-	_, err := r.client.Collection("users").Doc(user.ID).Update(ctx, []firestore.Update{
+	updates := []firestore.Update{
 		{Path: "email", Value: user.Email},
 		{Path: "name", Value: user.Name},
 		{Path: "password", Value: user.Password},
 		{Path: "updated_at", Value: user.UpdatedAt},
+	}
+	_, err := r.client.Collection("users").Doc(user.ID).Update(ctx, updates)
+	return err
+}
+
+// UpdateRole writes the role on its own, so a profile or password update racing with a role
+// change cannot write the previous role back
+func (r *userRepository) UpdateRole(ctx context.Context, id string, role domain.UserRole) error {
+	_, err := r.client.Collection("users").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "role", Value: string(role)},
+		{Path: "updated_at", Value: time.Now()},
 	})
 	return err
 }
@@ -82,8 +97,12 @@ func (r *userRepository) docToUser(doc *firestore.DocumentSnapshot) (*domain.Use
 		Email:     getString(data, "email"),
 		Name:      getStringPtr(data, "name"),
 		Password:  getString(data, "password"),
+		Role:      domain.UserRole(getString(data, "role")),
 		CreatedAt: getTime(data, "created_at"),
 		UpdatedAt: getTime(data, "updated_at"),
 	}
+	if user.Role == "" {
+		user.Role = domain.UserRoleUser
+	}
 	return user, nil
 }
@@ -0,0 +1,127 @@
+// Package layoutcatalog reads layout definitions from manifest files
+package layoutcatalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+)
+
+const (
+	// ManifestFile is required in every layout directory
+	ManifestFile = "manifest.json"
+	// ConfigFile holds the layout's render defaults; it is optional
+	ConfigFile = "config.json"
+)
+
+// manifestHeader holds the manifest fields that are also stored as layout fields
+type manifestHeader struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	PreviewImage string   `json:"previewImage"`
+	Tags         []string `json:"tags"`
+	Version      string   `json:"version"`
+}
+
+// Load reads every layout directory at the root of fsys. Problems in all layouts are reported
+// together, so a broken catalog fails before anything is written. Layouts come back in
+// directory order and active; whether a stored layout stays active is up to the caller.
+func Load(fsys fs.FS) ([]*domain.Layout, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layout catalog: %w", err)
+	}
+
+	var layouts []*domain.Layout
+	var errs []error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		layout, err := loadLayout(fsys, entry.Name())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.Name(), err))
+			continue
+		}
+		layouts = append(layouts, layout)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if len(layouts) == 0 {
+		return nil, errors.New("layout catalog is empty")
+	}
+	return layouts, nil
+}
+
+func loadLayout(fsys fs.FS, dir string) (*domain.Layout, error) {
+	manifest, err := readJSONObject(fsys, path.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var header manifestHeader
+	if err := json.Unmarshal(manifest, &header); err != nil {
+		return nil, fmt.Errorf("%s: %w", ManifestFile, err)
+	}
+	if header.ID != dir {
+		return nil, fmt.Errorf("%s: id %q does not match the directory name", ManifestFile, header.ID)
+	}
+	if strings.TrimSpace(header.Name) == "" {
+		return nil, fmt.Errorf("%s: name is required", ManifestFile)
+	}
+	if strings.TrimSpace(header.Version) == "" {
+		return nil, fmt.Errorf("%s: version is required", ManifestFile)
+	}
+
+	layout := &domain.Layout{
+		ID:       header.ID,
+		Name:     strings.TrimSpace(header.Name),
+		Tags:     header.Tags,
+		Version:  strings.TrimSpace(header.Version),
+		Manifest: &manifest,
+		IsActive: true,
+	}
+	if header.Description != "" {
+		layout.Description = &header.Description
+	}
+	if header.PreviewImage != "" {
+		layout.PreviewImage = &header.PreviewImage
+	}
+
+	config, err := readJSONObject(fsys, path.Join(dir, ConfigFile))
+	switch {
+	case err == nil:
+		layout.Config = &config
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+
+	return layout, nil
+}
+
+// readJSONObject reads a JSON object file and compacts it, which is how layouts store JSON
+func readJSONObject(fsys fs.FS, name string) (json.RawMessage, error) {
+	raw, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return nil, fmt.Errorf("%s: invalid JSON: %w", path.Base(name), err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil || fields == nil {
+		return nil, fmt.Errorf("%s: must be a JSON object", path.Base(name))
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,222 @@
+package layoutcatalog
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/layouts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"classic-scroll/manifest.json": {Data: []byte(`{
+			"id": "classic-scroll",
+			"name": "Classic Scroll",
+			"version": "1.0.0",
+			"description": "Traditional",
+			"tags": ["classic"]
+		}`)},
+		"classic-scroll/config.json": {Data: []byte(`{"theme": {}}`)},
+		"minimal/manifest.json":      {Data: []byte(`{"id": "minimal", "name": "Minimal", "version": "0.1.0"}`)},
+		"README.md":                  {Data: []byte("not a layout")},
+	}
+
+	loaded, err := Load(fsys)
+
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	classic := loaded[0]
+	assert.Equal(t, "classic-scroll", classic.ID)
+	assert.Equal(t, "Classic Scroll", classic.Name)
+	assert.Equal(t, "1.0.0", classic.Version)
+	require.NotNil(t, classic.Description)
+	assert.Equal(t, "Traditional", *classic.Description)
+	assert.Nil(t, classic.PreviewImage)
+	assert.Equal(t, []string{"classic"}, classic.Tags)
+	assert.True(t, classic.IsActive)
+	assert.Equal(t, `{"theme":{}}`, string(*classic.Config))
+	assert.Contains(t, string(*classic.Manifest), `"name":"Classic Scroll"`)
+	assert.Nil(t, loaded[1].Config)
+}
+
+func TestLoad_ReportsEveryBrokenLayout(t *testing.T) {
+	fsys := fstest.MapFS{
+		"broken-json/manifest.json":    {Data: []byte(`{"id": "broken-json",}`)},
+		"missing-manifest/config.json": {Data: []byte(`{}`)},
+		"wrong-id/manifest.json":       {Data: []byte(`{"id": "other", "name": "Other", "version": "1.0.0"}`)},
+		"no-version/manifest.json":     {Data: []byte(`{"id": "no-version", "name": "No Version"}`)},
+		"bad-config/manifest.json":     {Data: []byte(`{"id": "bad-config", "name": "Bad Config", "version": "1.0.0"}`)},
+		"bad-config/config.json":       {Data: []byte(`[]`)},
+	}
+
+	_, err := Load(fsys)
+
+	require.Error(t, err)
+	for _, want := range []string{
+		"bad-config: config.json: must be a JSON object",
+		"broken-json: manifest.json: invalid JSON",
+		"missing-manifest: open missing-manifest/manifest.json",
+		`no-version: manifest.json: version is required`,
+		`wrong-id: manifest.json: id "other" does not match the directory name`,
+	} {
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+func TestLoad_EmptyCatalog(t *testing.T) {
+	_, err := Load(fstest.MapFS{})
+
+	assert.EqualError(t, err, "layout catalog is empty")
+}
+
+// emptyLayoutRepository stores nothing, so a dry-run sync validates and creates every layout
+type emptyLayoutRepository struct{}
+
+func (emptyLayoutRepository) Create(ctx context.Context, l *domain.Layout, audit *domain.AuditEntry) error {
+	return nil
+}
+func (emptyLayoutRepository) FindByID(ctx context.Context, id string) (*domain.Layout, error) {
+	return nil, nil
+}
+func (emptyLayoutRepository) FindAll(ctx context.Context) ([]*domain.Layout, error) {
+	return nil, nil
+}
+func (emptyLayoutRepository) FindAllIncludingInactive(ctx context.Context) ([]*domain.Layout, error) {
+	return nil, nil
+}
+func (emptyLayoutRepository) FindVersion(ctx context.Context, id, version string) (*domain.Layout, error) {
+	return nil, nil
+}
+func (emptyLayoutRepository) Update(ctx context.Context, l *domain.Layout, audit *domain.AuditEntry) error {
+	return nil
+}
+func (emptyLayoutRepository) Delete(ctx context.Context, id string) error { return nil }
+
+// TestEmbeddedCatalog validates the layouts that ship with the API: they load, pass the checks
+// of a sync, and their migrations only map between themes the layouts declare.
+func TestEmbeddedCatalog(t *testing.T) {
+	loaded, err := Load(layouts.FS)
+	require.NoError(t, err)
+
+	output, err := layout.NewSyncLayoutsUseCase(emptyLayoutRepository{}, nil, nil).Execute(context.Background(), layout.SyncLayoutsInput{
+		Layouts: loaded,
+		DryRun:  true,
+	})
+	require.NoError(t, err)
+	for _, result := range output.Results {
+		assert.Equal(t, layout.SyncResultCreated, result.Result, result.ID)
+	}
+
+	type catalogManifest struct {
+		Themes []struct {
+			ID string `json:"id"`
+		} `json:"themes"`
+		Migrations map[string]layout.LayoutMigration `json:"migrations"`
+	}
+	manifests := map[string]catalogManifest{}
+	themes := map[string][]string{}
+	for _, l := range loaded {
+		var manifest catalogManifest
+		require.NoError(t, json.Unmarshal(*l.Manifest, &manifest), l.ID)
+		manifests[l.ID] = manifest
+		for _, theme := range manifest.Themes {
+			themes[l.ID] = append(themes[l.ID], theme.ID)
+		}
+	}
+	require.Contains(t, manifests, "classic-scroll")
+	require.Contains(t, manifests, "editorial-elegance")
+
+	for target, manifest := range manifests {
+		for source, migration := range manifest.Migrations {
+			require.Contains(t, themes, source, "%s migrates from unknown layout %s", target, source)
+			for from, to := range migration.Themes {
+				assert.Contains(t, themes[source], from, "%s <- %s: unknown source theme", target, source)
+				assert.Contains(t, themes[target], to, "%s <- %s: unknown target theme", target, source)
+			}
+			// Every source theme needs a counterpart so switching keeps the couple's color choice
+			assert.Len(t, migration.Themes, len(themes[source]), "%s <- %s: themes mapped", target, source)
+		}
+	}
+}
+
+// catalogLayoutRepository serves the loaded catalog as if it had been synced
+type catalogLayoutRepository struct {
+	emptyLayoutRepository
+	layouts []*domain.Layout
+}
+
+func (r catalogLayoutRepository) FindByID(ctx context.Context, id string) (*domain.Layout, error) {
+	for _, l := range r.layouts {
+		if l.ID == id {
+			return l, nil
+		}
+	}
+	return nil, nil
+}
+
+// TestEmbeddedCatalog_MigratesBetweenLayouts switches data with the migrations the shipped
+// manifests declare, in both directions.
+func TestEmbeddedCatalog_MigratesBetweenLayouts(t *testing.T) {
+	loaded, err := Load(layouts.FS)
+	require.NoError(t, err)
+	for _, l := range loaded {
+		l.IsActive = true
+	}
+	migrator := layout.NewMigrateInvitationDataUseCase(catalogLayoutRepository{layouts: loaded})
+
+	tests := []struct {
+		from, to     string
+		data         string
+		wantSection  string
+		wantTheme    string
+		droppedField string
+	}{
+		{
+			from:         "classic-scroll",
+			to:           "editorial-elegance",
+			data:         `{"customContent":{"fathersLetter":{"author":"Sanjay"}},"layoutConfig":{"sections":[{"id":"venue","enabled":true,"order":0}],"theme":{"preset":"rose-blush"}}}`,
+			wantSection:  "location",
+			wantTheme:    "warm-editorial",
+			droppedField: "/customContent/fathersLetter",
+		},
+		{
+			from:         "editorial-elegance",
+			to:           "classic-scroll",
+			data:         `{"weddingParty":{"title":"Bridesmaids"},"layoutConfig":{"sections":[{"id":"location","enabled":true,"order":0}],"theme":{"preset":"cool-editorial"}}}`,
+			wantSection:  "venue",
+			wantTheme:    "midnight-navy",
+			droppedField: "/weddingParty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			output, err := migrator.Execute(context.Background(), tt.from, tt.to, json.RawMessage(tt.data))
+
+			require.NoError(t, err)
+			var migrated struct {
+				LayoutConfig struct {
+					Sections []struct {
+						ID string `json:"id"`
+					} `json:"sections"`
+					Theme struct {
+						Preset string `json:"preset"`
+					} `json:"theme"`
+				} `json:"layoutConfig"`
+			}
+			require.NoError(t, json.Unmarshal(output.Data, &migrated))
+			require.NotEmpty(t, migrated.LayoutConfig.Sections)
+			assert.Equal(t, tt.wantSection, migrated.LayoutConfig.Sections[0].ID)
+			assert.Equal(t, tt.wantTheme, migrated.LayoutConfig.Theme.Preset)
+			assert.Contains(t, output.Report.NotCarriedOver, layout.ContentNotCarriedOver{
+				Path:   tt.droppedField,
+				Reason: `not used by layout "` + tt.to + `"`,
+			})
+		})
+	}
+}
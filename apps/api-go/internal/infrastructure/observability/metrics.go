@@ -11,6 +11,8 @@ var (
 	httpRequestCount    otelmetric.Int64Counter
 	httpErrorCount      otelmetric.Int64Counter
 	httpRequestDuration otelmetric.Float64Histogram
+
+	cachePurgeFailureCount otelmetric.Int64Counter
 )
 
 // InitMetrics initializes RED metrics (Rate, Errors, Duration) and business metrics
@@ -47,6 +49,16 @@ func InitMetrics(meter otelmetric.Meter) error {
 		return err
 	}
 
+	// CDN cache purge failures (after retries are exhausted)
+	cachePurgeFailureCount, err = meter.Int64Counter(
+		"publish_cache_purge_failures_total",
+		otelmetric.WithDescription("Total number of failed CDN cache purges for published sites"),
+		otelmetric.WithUnit("1"),
+	)
+	if err != nil {
+		return err
+	}
+
 	// Initialize business metrics
 	if err := InitBusinessMetrics(meter); err != nil {
 		return err
@@ -87,3 +99,11 @@ func RecordHTTPError(method, route string, statusCode int) {
 		httpErrorCount.Add(context.Background(), 1, otelmetric.WithAttributes(attrs...))
 	}
 }
+
+// RecordCachePurgeFailure records a CDN cache purge that failed after all retries.
+// trigger is the operation that moved the published pointer (publish, rollback or unpublish).
+func RecordCachePurgeFailure(trigger string) {
+	if cachePurgeFailureCount != nil {
+		cachePurgeFailureCount.Add(context.Background(), 1, otelmetric.WithAttributes(attribute.String("trigger", trigger)))
+	}
+}
@@ -0,0 +1,49 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRecordCachePurgeFailure_RecordsTriggerAttribute(t *testing.T) {
+	// Arrange
+	ResetMetrics()
+	provider, reader := NewTestMeterProvider()
+	defer provider.Shutdown(context.Background())
+	require.NoError(t, InitMetrics(GetMeter(provider)))
+
+	// Act
+	RecordCachePurgeFailure("rollback")
+	RecordCachePurgeFailure("rollback")
+
+	// Assert
+	rm, err := CollectMetrics(reader)
+	require.NoError(t, err)
+
+	var sum *metricdata.Sum[int64]
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			if m.Name == "publish_cache_purge_failures_total" {
+				data, ok := m.Data.(metricdata.Sum[int64])
+				require.True(t, ok)
+				sum = &data
+			}
+		}
+	}
+	require.NotNil(t, sum, "publish_cache_purge_failures_total metric should be recorded")
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(2), sum.DataPoints[0].Value)
+	trigger, ok := sum.DataPoints[0].Attributes.Value(attribute.Key("trigger"))
+	require.True(t, ok)
+	assert.Equal(t, "rollback", trigger.AsString())
+}
+
+func TestRecordCachePurgeFailure_NotInitialized_DoesNotPanic(t *testing.T) {
+	ResetMetrics()
+	assert.NotPanics(t, func() { RecordCachePurgeFailure("publish") })
+}
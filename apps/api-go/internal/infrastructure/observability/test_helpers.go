@@ -46,6 +46,8 @@ func ResetMetrics() {
 	businessThemeChangesTotal = nil
 	businessSectionTogglesTotal = nil
 	businessLanguageSwitchesTotal = nil
+
+	cachePurgeFailureCount = nil
 }
 
 // CollectMetrics collects metrics from the manual reader
@@ -0,0 +1,197 @@
+package publishinfra
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/publish"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runArtifactStorageContract is the behaviour every publish.ArtifactStorage implementation must provide.
+// newStore must return an empty store.
+func runArtifactStorageContract(t *testing.T, newStore func(t *testing.T) publish.ArtifactStorage) {
+	t.Helper()
+	ctx := context.Background()
+
+	put := func(t *testing.T, s publish.ArtifactStorage, key string) {
+		t.Helper()
+		require.NoError(t, s.Put(ctx, key, "text/html; charset=utf-8", "public, max-age=60", []byte("<html></html>")))
+	}
+
+	t.Run("list_versions_empty_for_unknown_subdomain", func(t *testing.T) {
+		s := newStore(t)
+		versions, err := s.ListVersions(ctx, "nobody")
+		require.NoError(t, err)
+		assert.Empty(t, versions)
+	})
+
+	t.Run("list_versions_sorted_descending", func(t *testing.T) {
+		s := newStore(t)
+		put(t, s, "sites/alice/v1/index.html")
+		put(t, s, "sites/alice/v3/index.html")
+		put(t, s, "sites/alice/v2/index.html")
+		put(t, s, "sites/alice/v2/assets/photo.jpg")
+		put(t, s, "sites/alice/v10/index.html")
+
+		versions, err := s.ListVersions(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, []int{10, 3, 2, 1}, versions)
+	})
+
+	t.Run("list_versions_isolated_per_subdomain", func(t *testing.T) {
+		s := newStore(t)
+		put(t, s, "sites/alice/v1/index.html")
+		put(t, s, "sites/alice-b/v7/index.html")
+		put(t, s, "sites/bob/v2/index.html")
+
+		versions, err := s.ListVersions(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, versions)
+	})
+
+	t.Run("delete_version_removes_only_that_version", func(t *testing.T) {
+		s := newStore(t)
+		put(t, s, "sites/alice/v1/index.html")
+		put(t, s, "sites/alice/v1/assets/photo.jpg")
+		put(t, s, "sites/alice/v2/index.html")
+		put(t, s, "sites/alice/v11/index.html")
+
+		require.NoError(t, s.DeleteVersion(ctx, "alice", 1))
+
+		versions, err := s.ListVersions(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, []int{11, 2}, versions)
+	})
+
+	t.Run("delete_missing_version_is_noop", func(t *testing.T) {
+		s := newStore(t)
+		require.NoError(t, s.DeleteVersion(ctx, "alice", 42))
+	})
+
+	t.Run("put_overwrites_existing_key", func(t *testing.T) {
+		s := newStore(t)
+		put(t, s, "sites/alice/v1/index.html")
+		put(t, s, "sites/alice/v1/index.html")
+
+		versions, err := s.ListVersions(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, versions)
+	})
+
+	t.Run("get_returns_body_and_headers", func(t *testing.T) {
+		s := newStore(t)
+		key := "sites/alice/v1/styles.css"
+		require.NoError(t, s.Put(ctx, key, "text/css; charset=utf-8", "public, max-age=31536000, immutable", []byte("body{}")))
+
+		artifact, err := s.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("body{}"), artifact.Body)
+		assert.Equal(t, "text/css; charset=utf-8", artifact.ContentType)
+		assert.Equal(t, "public, max-age=31536000, immutable", artifact.CacheControl)
+	})
+
+	t.Run("get_missing_returns_not_found", func(t *testing.T) {
+		s := newStore(t)
+		_, err := s.Get(ctx, "sites/alice/v1/index.html")
+		assert.ErrorIs(t, err, domain.ErrArtifactNotFound)
+	})
+
+	t.Run("get_after_delete_version_returns_not_found", func(t *testing.T) {
+		s := newStore(t)
+		put(t, s, "sites/alice/v1/index.html")
+		require.NoError(t, s.DeleteVersion(ctx, "alice", 1))
+		_, err := s.Get(ctx, "sites/alice/v1/index.html")
+		assert.ErrorIs(t, err, domain.ErrArtifactNotFound)
+	})
+
+	t.Run("list_objects_returns_only_that_version", func(t *testing.T) {
+		s := newStore(t)
+		put(t, s, "sites/alice/v1/index.html")
+		put(t, s, "sites/alice/v1/assets/photo.jpg")
+		put(t, s, "sites/alice/v11/index.html")
+		put(t, s, "sites/alice-b/v1/index.html")
+
+		objects, err := s.ListObjects(ctx, "alice", 1)
+		require.NoError(t, err)
+		size := int64(len("<html></html>"))
+		assert.Equal(t, []publish.ArtifactObject{
+			{Key: "sites/alice/v1/assets/photo.jpg", Size: size},
+			{Key: "sites/alice/v1/index.html", Size: size},
+		}, objects)
+
+		objects, err = s.ListObjects(ctx, "alice", 2)
+		require.NoError(t, err)
+		assert.Empty(t, objects)
+
+		_, err = s.ListObjects(ctx, "../etc", 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("put_rejects_invalid_keys", func(t *testing.T) {
+		s := newStore(t)
+		for _, key := range []string{"", "/sites/alice/v1/index.html", "../escape", "sites/alice/v1/../v2/index.html"} {
+			assert.Error(t, s.Put(ctx, key, "text/plain", "", []byte("x")), "key %q should be rejected", key)
+			_, err := s.Get(ctx, key)
+			assert.Error(t, err, "key %q should be rejected", key)
+		}
+	})
+
+	t.Run("rejects_invalid_subdomains", func(t *testing.T) {
+		s := newStore(t)
+		_, err := s.ListVersions(ctx, "../etc")
+		assert.Error(t, err)
+		assert.Error(t, s.DeleteVersion(ctx, "a/b", 1))
+	})
+
+	t.Run("public_url_ends_with_key", func(t *testing.T) {
+		s := newStore(t)
+		key := "sites/alice/v1/index.html"
+		url := s.PublicURL(key)
+		require.NotEmpty(t, url)
+		assert.True(t, strings.HasSuffix(url, "/"+key), "got %q", url)
+	})
+}
+
+func TestFilesystemArtifactStorage_Contract(t *testing.T) {
+	runArtifactStorageContract(t, func(t *testing.T) publish.ArtifactStorage {
+		t.Setenv("PUBLISHED_ARTIFACTS_DIR", t.TempDir())
+		t.Setenv("PUBLISHED_ARTIFACTS_PUBLIC_BASE", "")
+		s, err := NewFilesystemArtifactStorage()
+		require.NoError(t, err)
+		return s
+	})
+}
+
+func TestGCSArtifactStorage_Contract(t *testing.T) {
+	runArtifactStorageContract(t, func(t *testing.T) publish.ArtifactStorage {
+		client, _ := newFakeGCSClient(t)
+		return NewGCSArtifactStorageWithClient(client, GCSConfig{
+			Bucket:     "published-test",
+			PublicBase: "https://storage.googleapis.com/published-test",
+		})
+	})
+}
+
+func TestGCSArtifactStorage_Put_StoresHeaders(t *testing.T) {
+	client, fake := newFakeGCSClient(t)
+	s := NewGCSArtifactStorageWithClient(client, GCSConfig{Bucket: "published-test"})
+
+	err := s.Put(context.Background(), "sites/alice/v1/styles.css", "text/css; charset=utf-8", "public, max-age=31536000, immutable", []byte("body{}"))
+	require.NoError(t, err)
+
+	obj, ok := fake.object("published-test", "sites/alice/v1/styles.css")
+	require.True(t, ok)
+	assert.Equal(t, "text/css; charset=utf-8", obj.ContentType)
+	assert.Equal(t, "public, max-age=31536000, immutable", obj.CacheControl)
+	assert.Equal(t, []byte("body{}"), obj.body)
+}
+
+func TestGCSArtifactStorage_PublicURL_EmptyWithoutBase(t *testing.T) {
+	client, _ := newFakeGCSClient(t)
+	s := NewGCSArtifactStorageWithClient(client, GCSConfig{Bucket: "published-test"})
+	assert.Empty(t, s.PublicURL("sites/alice/v1/index.html"))
+}
@@ -0,0 +1,190 @@
+package publishinfra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+)
+
+const (
+	defaultCloudflareAPIBase = "https://api.cloudflare.com/client/v4"
+	// cloudflarePurgeBatchSize is the most URLs a single purge request may list on every plan
+	cloudflarePurgeBatchSize = 30
+)
+
+// CloudflareCachePurger purges cached URLs for a published site through the Cloudflare API
+// (purge by URL, available on every plan). Transient failures are retried with exponential backoff.
+type CloudflareCachePurger struct {
+	httpClient       *http.Client
+	apiBase          string
+	zoneID           string
+	apiToken         string
+	baseDomain       string
+	subdomainSuffix  string
+	resolveAPIOrigin string
+	maxAttempts      int
+	initialBackoff   time.Duration
+}
+
+type CloudflareConfig struct {
+	ZoneID           string
+	APIToken         string
+	BaseDomain       string // e.g. sacredvows.io
+	SubdomainSuffix  string // e.g. "-dev"; matches PUBLISHED_SUBDOMAIN_SUFFIX
+	ResolveAPIOrigin string // origin the edge worker calls for /api/published/resolve, e.g. https://api.sacredvows.io
+
+	// Optional overrides (tests)
+	APIBase        string
+	HTTPClient     *http.Client
+	MaxAttempts    int           // default 3
+	InitialBackoff time.Duration // default 200ms, doubled per retry
+}
+
+func NewCloudflareCachePurger(cfg CloudflareConfig) (*CloudflareCachePurger, error) {
+	if cfg.ZoneID == "" || cfg.APIToken == "" {
+		return nil, fmt.Errorf("cloudflare purge config missing: require CLOUDFLARE_ZONE_ID, CLOUDFLARE_API_TOKEN")
+	}
+	if cfg.BaseDomain == "" && cfg.ResolveAPIOrigin == "" {
+		return nil, fmt.Errorf("cloudflare purge config missing: require PUBLISHED_BASE_DOMAIN or PUBLISH_RESOLVE_API_ORIGIN")
+	}
+
+	p := &CloudflareCachePurger{
+		httpClient:       cfg.HTTPClient,
+		apiBase:          strings.TrimRight(cfg.APIBase, "/"),
+		zoneID:           cfg.ZoneID,
+		apiToken:         cfg.APIToken,
+		baseDomain:       cfg.BaseDomain,
+		subdomainSuffix:  cfg.SubdomainSuffix,
+		resolveAPIOrigin: strings.TrimRight(cfg.ResolveAPIOrigin, "/"),
+		maxAttempts:      cfg.MaxAttempts,
+		initialBackoff:   cfg.InitialBackoff,
+	}
+	if p.httpClient == nil {
+		p.httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if p.apiBase == "" {
+		p.apiBase = defaultCloudflareAPIBase
+	}
+	if p.maxAttempts < 1 {
+		p.maxAttempts = 3
+	}
+	if p.initialBackoff <= 0 {
+		p.initialBackoff = 200 * time.Millisecond
+	}
+	return p, nil
+}
+
+// PurgeSite purges the resolve API response cached by the edge worker and the site's HTML entry points,
+// those under /<locale>/ included. Versioned assets are immutable and never need purging.
+func (p *CloudflareCachePurger) PurgeSite(ctx context.Context, subdomain string, locales []string) error {
+	if err := validateSubdomain(subdomain); err != nil {
+		return fmt.Errorf("invalid subdomain: %w", err)
+	}
+	for _, locale := range locales {
+		if normalized, err := domain.NormalizeLocale(locale); err != nil || normalized != locale {
+			return fmt.Errorf("invalid locale %q", locale)
+		}
+	}
+	urls := p.purgeURLs(subdomain, locales)
+	for start := 0; start < len(urls); start += cloudflarePurgeBatchSize {
+		batch := urls[start:min(start+cloudflarePurgeBatchSize, len(urls))]
+		if err := p.purgeWithRetry(ctx, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgeWithRetry purges urls, retrying transient failures with exponential backoff.
+func (p *CloudflareCachePurger) purgeWithRetry(ctx context.Context, urls []string) error {
+	body, err := json.Marshal(map[string][]string{"files": urls})
+	if err != nil {
+		return err
+	}
+
+	backoff := p.initialBackoff
+	var lastErr error
+	for attempt := 1; attempt <= p.maxAttempts; attempt++ {
+		retryable, err := p.purge(ctx, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retryable || attempt == p.maxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return lastErr
+}
+
+func (p *CloudflareCachePurger) purgeURLs(subdomain string, locales []string) []string {
+	var urls []string
+	if p.resolveAPIOrigin != "" {
+		urls = append(urls, p.resolveAPIOrigin+"/api/published/resolve?subdomain="+url.QueryEscape(subdomain))
+	}
+	if p.baseDomain != "" {
+		site := "https://" + subdomain + p.subdomainSuffix + "." + p.baseDomain
+		urls = append(urls, site+"/", site+"/index.html")
+		for _, locale := range locales {
+			urls = append(urls, site+"/"+locale+"/", site+"/"+locale+"/index.html")
+		}
+	}
+	return urls
+}
+
+type cloudflareResponse struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// purge sends one purge request and reports whether a failure is worth retrying.
+func (p *CloudflareCachePurger) purge(ctx context.Context, body []byte) (retryable bool, err error) {
+	endpoint := fmt.Sprintf("%s/zones/%s/purge_cache", p.apiBase, url.PathEscape(p.zoneID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("cloudflare purge request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return true, fmt.Errorf("cloudflare purge returned status %d", resp.StatusCode)
+	}
+
+	var parsed cloudflareResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return false, fmt.Errorf("cloudflare purge returned status %d with unreadable body: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode >= 300 || !parsed.Success {
+		msgs := make([]string, 0, len(parsed.Errors))
+		for _, e := range parsed.Errors {
+			msgs = append(msgs, fmt.Sprintf("%d: %s", e.Code, e.Message))
+		}
+		return false, fmt.Errorf("cloudflare purge failed (status %d): %s", resp.StatusCode, strings.Join(msgs, "; "))
+	}
+	return false, nil
+}
@@ -0,0 +1,156 @@
+package publishinfra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCloudflarePurger(t *testing.T, handler http.HandlerFunc) *CloudflareCachePurger {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	p, err := NewCloudflareCachePurger(CloudflareConfig{
+		ZoneID:           "zone123",
+		APIToken:         "token",
+		BaseDomain:       "sacredvows.io",
+		SubdomainSuffix:  "-dev",
+		ResolveAPIOrigin: "https://api.dev.sacredvows.io/",
+		APIBase:          srv.URL,
+		InitialBackoff:   time.Millisecond,
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestCloudflareCachePurger_PurgesResolveAndSiteURLs(t *testing.T) {
+	var got struct {
+		Files []string `json:"files"`
+	}
+	p := newTestCloudflarePurger(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/zones/zone123/purge_cache", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"success":true,"errors":[],"result":{"id":"zone123"}}`))
+	})
+
+	require.NoError(t, p.PurgeSite(context.Background(), "alice", nil))
+
+	assert.Equal(t, []string{
+		"https://api.dev.sacredvows.io/api/published/resolve?subdomain=alice",
+		"https://alice-dev.sacredvows.io/",
+		"https://alice-dev.sacredvows.io/index.html",
+	}, got.Files)
+}
+
+func TestCloudflareCachePurger_PurgesLocalePages(t *testing.T) {
+	var got struct {
+		Files []string `json:"files"`
+	}
+	p := newTestCloudflarePurger(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"success":true}`))
+	})
+
+	require.NoError(t, p.PurgeSite(context.Background(), "alice", []string{"en", "pt-br"}))
+
+	assert.Equal(t, []string{
+		"https://api.dev.sacredvows.io/api/published/resolve?subdomain=alice",
+		"https://alice-dev.sacredvows.io/",
+		"https://alice-dev.sacredvows.io/index.html",
+		"https://alice-dev.sacredvows.io/en/",
+		"https://alice-dev.sacredvows.io/en/index.html",
+		"https://alice-dev.sacredvows.io/pt-br/",
+		"https://alice-dev.sacredvows.io/pt-br/index.html",
+	}, got.Files)
+}
+
+func TestCloudflareCachePurger_SplitsLargePurgesIntoBatches(t *testing.T) {
+	var batches [][]string
+	p := newTestCloudflarePurger(t, func(w http.ResponseWriter, r *http.Request) {
+		var got struct {
+			Files []string `json:"files"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		batches = append(batches, got.Files)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	})
+	locales := make([]string, 20)
+	for i := range locales {
+		locales[i] = fmt.Sprintf("x%c", 'a'+i)
+	}
+
+	require.NoError(t, p.PurgeSite(context.Background(), "alice", locales))
+
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0], cloudflarePurgeBatchSize)
+	assert.Len(t, batches[1], 3+2*len(locales)-cloudflarePurgeBatchSize)
+}
+
+func TestCloudflareCachePurger_RetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	p := newTestCloudflarePurger(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true}`))
+	})
+
+	require.NoError(t, p.PurgeSite(context.Background(), "alice", nil))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCloudflareCachePurger_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	p := newTestCloudflarePurger(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	err := p.PurgeSite(context.Background(), "alice", nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCloudflareCachePurger_DoesNotRetryAPIErrors(t *testing.T) {
+	var calls atomic.Int32
+	p := newTestCloudflarePurger(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}]}`))
+	})
+
+	err := p.PurgeSite(context.Background(), "alice", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Authentication error")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCloudflareCachePurger_RejectsInvalidSubdomain(t *testing.T) {
+	p := newTestCloudflarePurger(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("no request expected")
+	})
+	assert.Error(t, p.PurgeSite(context.Background(), "../etc", nil))
+}
+
+func TestCloudflareCachePurger_RejectsInvalidLocale(t *testing.T) {
+	p := newTestCloudflarePurger(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("no request expected")
+	})
+	assert.Error(t, p.PurgeSite(context.Background(), "alice", []string{"../admin"}))
+}
+
+func TestNewCloudflareCachePurger_RequiresCredentials(t *testing.T) {
+	_, err := NewCloudflareCachePurger(CloudflareConfig{BaseDomain: "sacredvows.io"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,214 @@
+package publishinfra
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+)
+
+// fakeGCSServer implements the small subset of the GCS API used by GCSArtifactStorage:
+// multipart upload, media download, object listing by prefix and object deletion.
+type fakeGCSServer struct {
+	mu      sync.Mutex
+	objects map[string]fakeGCSObject // key: bucket + "/" + name
+}
+
+type fakeGCSObject struct {
+	Bucket       string `json:"bucket"`
+	Name         string `json:"name"`
+	ContentType  string `json:"contentType,omitempty"`
+	CacheControl string `json:"cacheControl,omitempty"`
+	Size         string `json:"size"`
+	Updated      string `json:"updated,omitempty"`
+	Generation   string `json:"generation,omitempty"`
+	body         []byte
+}
+
+// newFakeGCSClient starts a fake GCS server and returns a client pointed at it.
+func newFakeGCSClient(t *testing.T) (*storage.Client, *fakeGCSServer) {
+	t.Helper()
+	fake := &fakeGCSServer{objects: make(map[string]fakeGCSObject)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	client, err := storage.NewClient(t.Context(),
+		option.WithEndpoint(srv.URL+"/storage/v1/"),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client, fake
+}
+
+func (f *fakeGCSServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+	switch {
+	case r.Method == http.MethodPost && strings.HasPrefix(path, "/upload/storage/v1/b/"):
+		bucket := strings.TrimSuffix(strings.TrimPrefix(path, "/upload/storage/v1/b/"), "/o")
+		f.handleUpload(w, r, bucket)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/storage/v1/b/") && strings.HasSuffix(path, "/o"):
+		bucket := strings.TrimSuffix(strings.TrimPrefix(path, "/storage/v1/b/"), "/o")
+		f.handleList(w, r, bucket)
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "/storage/v1/b/"):
+		rest := strings.TrimPrefix(path, "/storage/v1/b/")
+		bucket, escapedName, ok := strings.Cut(rest, "/o/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		name, err := url.PathUnescape(escapedName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.handleDelete(w, bucket, name)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/storage/v1/b/") && r.URL.Query().Get("alt") == "media":
+		// JSON API media download
+		rest := strings.TrimPrefix(path, "/storage/v1/b/")
+		bucket, escapedName, ok := strings.Cut(rest, "/o/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		name, err := url.PathUnescape(escapedName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.handleDownload(w, bucket, name)
+	case r.Method == http.MethodGet:
+		// XML API media download: /<bucket>/<object>
+		bucket, escapedName, ok := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		name, err := url.PathUnescape(escapedName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.handleDownload(w, bucket, name)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeGCSServer) handleUpload(w http.ResponseWriter, r *http.Request, bucket string) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mr := multipart.NewReader(r.Body, params["boundary"])
+
+	metaPart, err := mr.NextPart()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var obj fakeGCSObject
+	if err := json.NewDecoder(metaPart).Decode(&obj); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if obj.Name == "" {
+		obj.Name = r.URL.Query().Get("name")
+	}
+
+	dataPart, err := mr.NextPart()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(dataPart)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	obj.Bucket = bucket
+	obj.body = body
+	obj.Size = strconv.Itoa(len(body))
+	obj.Updated = time.Now().UTC().Format(time.RFC3339Nano)
+	obj.Generation = "1"
+
+	f.mu.Lock()
+	f.objects[bucket+"/"+obj.Name] = obj
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+func (f *fakeGCSServer) handleList(w http.ResponseWriter, r *http.Request, bucket string) {
+	prefix := r.URL.Query().Get("prefix")
+
+	f.mu.Lock()
+	items := make([]fakeGCSObject, 0)
+	for _, obj := range f.objects {
+		if obj.Bucket == bucket && strings.HasPrefix(obj.Name, prefix) {
+			items = append(items, obj)
+		}
+	}
+	f.mu.Unlock()
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"kind":  "storage#objects",
+		"items": items,
+	})
+}
+
+func (f *fakeGCSServer) handleDownload(w http.ResponseWriter, bucket, name string) {
+	obj, ok := f.object(bucket, name)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", obj.ContentType)
+	w.Header().Set("Cache-Control", obj.CacheControl)
+	w.Header().Set("Content-Length", obj.Size)
+	w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	w.Header().Set("X-Goog-Generation", obj.Generation)
+	w.Header().Set("X-Goog-Metageneration", "1")
+	_, _ = w.Write(obj.body)
+}
+
+func (f *fakeGCSServer) handleDelete(w http.ResponseWriter, bucket, name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := bucket + "/" + name
+	if _, ok := f.objects[key]; !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"No such object"}}`))
+		return
+	}
+	delete(f.objects, key)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// object returns a stored object, for assertions on upload metadata.
+func (f *fakeGCSServer) object(bucket, name string) (fakeGCSObject, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	obj, ok := f.objects[bucket+"/"+name]
+	return obj, ok
+}
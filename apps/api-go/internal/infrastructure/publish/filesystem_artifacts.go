@@ -2,12 +2,19 @@ package publishinfra
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/fs"
+	"mime"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/publish"
 )
 
 // FilesystemArtifactStorage stores published artifacts on local disk.
@@ -33,7 +40,6 @@ func NewFilesystemArtifactStorage() (*FilesystemArtifactStorage, error) {
 }
 
 func (s *FilesystemArtifactStorage) Put(ctx context.Context, key string, contentType string, cacheControl string, body []byte) error {
-	// contentType/cacheControl are ignored for filesystem implementation; the HTTP layer will set headers.
 	if err := validateArtifactKey(key); err != nil {
 		return err
 	}
@@ -41,7 +47,69 @@ func (s *FilesystemArtifactStorage) Put(ctx context.Context, key string, content
 	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
 		return err
 	}
-	return os.WriteFile(full, body, 0644)
+	if err := os.WriteFile(full, body, 0644); err != nil {
+		return err
+	}
+
+	// Headers are kept in a sidecar tree so they can be replayed when the API serves the artifact.
+	meta, err := json.Marshal(filesystemArtifactMeta{ContentType: contentType, CacheControl: cacheControl})
+	if err != nil {
+		return err
+	}
+	metaPath := s.metaPath(key)
+	if err := os.MkdirAll(filepath.Dir(metaPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(metaPath, meta, 0644)
+}
+
+// Get reads an artifact and the headers recorded when it was stored.
+// Artifacts written before headers were recorded fall back to a content type guessed from the extension.
+func (s *FilesystemArtifactStorage) Get(ctx context.Context, key string) (*publish.Artifact, error) {
+	if err := validateArtifactKey(key); err != nil {
+		return nil, err
+	}
+	full := filepath.Join(s.rootDir, filepath.FromSlash(key))
+	info, err := os.Stat(full)
+	if os.IsNotExist(err) {
+		return nil, domain.ErrArtifactNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, domain.ErrArtifactNotFound
+	}
+	body, err := os.ReadFile(full)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := &publish.Artifact{
+		Body:        body,
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		ModTime:     info.ModTime(),
+	}
+	if raw, err := os.ReadFile(s.metaPath(key)); err == nil {
+		var meta filesystemArtifactMeta
+		if err := json.Unmarshal(raw, &meta); err == nil {
+			if meta.ContentType != "" {
+				artifact.ContentType = meta.ContentType
+			}
+			artifact.CacheControl = meta.CacheControl
+		}
+	}
+	return artifact, nil
+}
+
+type filesystemArtifactMeta struct {
+	ContentType  string `json:"contentType"`
+	CacheControl string `json:"cacheControl"`
+}
+
+// metaPath returns the sidecar path holding headers for key, e.g. <root>/.meta/sites/foo/v1/index.html.json.
+func (s *FilesystemArtifactStorage) metaPath(key string) string {
+	return filepath.Join(s.rootDir, ".meta", filepath.FromSlash(key)+".json")
 }
 
 func (s *FilesystemArtifactStorage) PublicURL(key string) string {
@@ -98,6 +166,43 @@ func (s *FilesystemArtifactStorage) ListVersions(ctx context.Context, subdomain
 	return versions, nil
 }
 
+// ListObjects walks "sites/{subdomain}/v{version}/" and returns the keys and sizes of all files in it.
+func (s *FilesystemArtifactStorage) ListObjects(ctx context.Context, subdomain string, version int) ([]publish.ArtifactObject, error) {
+	// Validate subdomain to prevent path injection
+	if err := validateSubdomain(subdomain); err != nil {
+		return nil, fmt.Errorf("invalid subdomain: %w", err)
+	}
+	versionPath := filepath.Join(s.rootDir, "sites", subdomain, fmt.Sprintf("v%d", version))
+	if _, err := os.Stat(versionPath); os.IsNotExist(err) {
+		return []publish.ArtifactObject{}, nil
+	}
+
+	objects := []publish.ArtifactObject{}
+	err := filepath.WalkDir(versionPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(s.rootDir, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, publish.ArtifactObject{Key: filepath.ToSlash(rel), Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list version directory: %w", err)
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
 // DeleteVersion deletes all artifacts for a specific version of a subdomain.
 // It removes the entire version directory "sites/{subdomain}/v{version}/".
 func (s *FilesystemArtifactStorage) DeleteVersion(ctx context.Context, subdomain string, version int) error {
@@ -116,6 +221,10 @@ func (s *FilesystemArtifactStorage) DeleteVersion(ctx context.Context, subdomain
 	if err := os.RemoveAll(versionPath); err != nil {
 		return fmt.Errorf("failed to delete version directory: %w", err)
 	}
+	metaVersionPath := filepath.Join(s.rootDir, ".meta", "sites", subdomain, fmt.Sprintf("v%d", version))
+	if err := os.RemoveAll(metaVersionPath); err != nil {
+		return fmt.Errorf("failed to delete version metadata: %w", err)
+	}
 
 	return nil
 }
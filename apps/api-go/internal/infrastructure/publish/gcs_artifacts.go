@@ -0,0 +1,212 @@
+package publishinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"cloud.google.com/go/storage"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/publish"
+	"google.golang.org/api/iterator"
+)
+
+// GCSArtifactStorage stores published artifacts in a Google Cloud Storage bucket.
+type GCSArtifactStorage struct {
+	client     *storage.Client
+	bucket     string
+	publicBase string
+}
+
+type GCSConfig struct {
+	Bucket     string
+	PublicBase string // e.g. https://storage.googleapis.com/<bucket> or a CDN domain in front of the bucket
+}
+
+// NewGCSArtifactStorage creates a GCS-backed artifact store using Application Default Credentials
+// (the Cloud Run service account in production).
+func NewGCSArtifactStorage(ctx context.Context, cfg GCSConfig) (*GCSArtifactStorage, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("GCS config missing: require PUBLISH_GCS_BUCKET")
+	}
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+	return NewGCSArtifactStorageWithClient(client, cfg), nil
+}
+
+// NewGCSArtifactStorageWithClient wraps an existing client (e.g. one pointed at a fake GCS server in tests).
+func NewGCSArtifactStorageWithClient(client *storage.Client, cfg GCSConfig) *GCSArtifactStorage {
+	return &GCSArtifactStorage{
+		client:     client,
+		bucket:     cfg.Bucket,
+		publicBase: cfg.PublicBase,
+	}
+}
+
+func (s *GCSArtifactStorage) Put(ctx context.Context, key string, contentType string, cacheControl string, body []byte) error {
+	if err := validateArtifactKey(key); err != nil {
+		return err
+	}
+	w := s.client.Bucket(s.bucket).Object(key).NewWriter(ctx)
+	w.ContentType = contentType
+	w.CacheControl = cacheControl
+	// Artifacts are small; upload in a single request instead of a resumable session.
+	w.ChunkSize = 0
+	if _, err := w.Write(body); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("failed to write to GCS: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to close GCS writer: %w", err)
+	}
+	return nil
+}
+
+func (s *GCSArtifactStorage) Get(ctx context.Context, key string) (*publish.Artifact, error) {
+	if err := validateArtifactKey(key); err != nil {
+		return nil, err
+	}
+	r, err := s.client.Bucket(s.bucket).Object(key).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, domain.ErrArtifactNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open object: %w", err)
+	}
+	defer r.Close()
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	return &publish.Artifact{
+		Body:         body,
+		ContentType:  r.Attrs.ContentType,
+		CacheControl: r.Attrs.CacheControl,
+		ModTime:      r.Attrs.LastModified,
+	}, nil
+}
+
+func (s *GCSArtifactStorage) PublicURL(key string) string {
+	if s.publicBase == "" {
+		// Bucket is private and served via the API or Worker; callers should not rely on object URLs.
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", s.publicBase, key)
+}
+
+// ListVersions lists all version numbers for a given subdomain.
+// It scans the bucket for objects with prefix "sites/{subdomain}/v" and extracts version numbers.
+func (s *GCSArtifactStorage) ListVersions(ctx context.Context, subdomain string) ([]int, error) {
+	if err := validateSubdomain(subdomain); err != nil {
+		return nil, fmt.Errorf("invalid subdomain: %w", err)
+	}
+	prefix := fmt.Sprintf("sites/%s/v", subdomain)
+	versionMap := make(map[int]bool)
+
+	query := &storage.Query{Prefix: prefix}
+	if err := query.SetAttrSelection([]string{"Name"}); err != nil {
+		return nil, err
+	}
+
+	// Regex to extract version number from key like "sites/subdomain/v123/path"
+	versionRegex := regexp.MustCompile(`^sites/[^/]+/v(\d+)/`)
+
+	it := s.client.Bucket(s.bucket).Objects(ctx, query)
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		matches := versionRegex.FindStringSubmatch(attrs.Name)
+		if len(matches) >= 2 {
+			if version, err := strconv.Atoi(matches[1]); err == nil {
+				versionMap[version] = true
+			}
+		}
+	}
+
+	// Convert map to sorted slice
+	versions := make([]int, 0, len(versionMap))
+	for v := range versionMap {
+		versions = append(versions, v)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions))) // Sort descending
+
+	return versions, nil
+}
+
+// ListObjects lists all object names and sizes under "sites/{subdomain}/v{version}/".
+func (s *GCSArtifactStorage) ListObjects(ctx context.Context, subdomain string, version int) ([]publish.ArtifactObject, error) {
+	if err := validateSubdomain(subdomain); err != nil {
+		return nil, fmt.Errorf("invalid subdomain: %w", err)
+	}
+	query := &storage.Query{Prefix: fmt.Sprintf("sites/%s/v%d/", subdomain, version)}
+	if err := query.SetAttrSelection([]string{"Name", "Size"}); err != nil {
+		return nil, err
+	}
+
+	objects := []publish.ArtifactObject{}
+	it := s.client.Bucket(s.bucket).Objects(ctx, query)
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		objects = append(objects, publish.ArtifactObject{Key: attrs.Name, Size: attrs.Size})
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// DeleteVersion deletes all artifacts for a specific version of a subdomain.
+// GCS has no batch delete in the JSON API client, so objects are removed one by one.
+func (s *GCSArtifactStorage) DeleteVersion(ctx context.Context, subdomain string, version int) error {
+	if err := validateSubdomain(subdomain); err != nil {
+		return fmt.Errorf("invalid subdomain: %w", err)
+	}
+	prefix := fmt.Sprintf("sites/%s/v%d/", subdomain, version)
+
+	query := &storage.Query{Prefix: prefix}
+	if err := query.SetAttrSelection([]string{"Name"}); err != nil {
+		return err
+	}
+
+	bucket := s.client.Bucket(s.bucket)
+	it := bucket.Objects(ctx, query)
+	var names []string
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list objects for deletion: %w", err)
+		}
+		names = append(names, attrs.Name)
+	}
+
+	for _, name := range names {
+		if err := bucket.Object(name).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return fmt.Errorf("failed to delete object %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *GCSArtifactStorage) Close() error {
+	return s.client.Close()
+}
@@ -12,16 +12,24 @@ import (
 	"path/filepath"
 
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
 	"github.com/sacred-vows/api-go/internal/usecase/publish"
+	"github.com/sacred-vows/api-go/internal/usecase/theme"
 )
 
 type NodeSnapshotGenerator struct {
 	invitationRepo repository.InvitationRepository
-	nodeBinary     string
-	scriptPath     string
+	// manifests resolves the layout version an invitation is pinned to. Optional: without it the
+	// renderer uses the manifests it was built with.
+	manifests *layout.GetLayoutManifestUseCase
+	// themes applies the custom theme an invitation refers to. Optional: without it the renderer
+	// uses the colors and fonts saved in the invitation data.
+	themes     *theme.ResolveThemeUseCase
+	nodeBinary string
+	scriptPath string
 }
 
-func NewNodeSnapshotGenerator(invitationRepo repository.InvitationRepository, scriptPath string, nodeBinary string) (*NodeSnapshotGenerator, error) {
+func NewNodeSnapshotGenerator(invitationRepo repository.InvitationRepository, manifests *layout.GetLayoutManifestUseCase, themes *theme.ResolveThemeUseCase, scriptPath string, nodeBinary string) (*NodeSnapshotGenerator, error) {
 	if scriptPath == "" {
 		return nil, errors.New("snapshot renderer script path is required (path to apps/renderer/dist-ssr/render.js)")
 	}
@@ -38,6 +46,8 @@ func NewNodeSnapshotGenerator(invitationRepo repository.InvitationRepository, sc
 		}
 		return &NodeSnapshotGenerator{
 			invitationRepo: invitationRepo,
+			manifests:      manifests,
+			themes:         themes,
 			nodeBinary:     nodeBinary,
 			scriptPath:     scriptPath,
 		}, nil
@@ -59,6 +69,8 @@ func NewNodeSnapshotGenerator(invitationRepo repository.InvitationRepository, sc
 	}
 	return &NodeSnapshotGenerator{
 		invitationRepo: invitationRepo,
+		manifests:      manifests,
+		themes:         themes,
 		nodeBinary:     nodeBinary,
 		scriptPath:     resolvedPath,
 	}, nil
@@ -114,7 +126,7 @@ func findWorkspaceRoot(startPath string) string {
 	return ""
 }
 
-func (g *NodeSnapshotGenerator) GenerateBundle(ctx context.Context, invitationID string) (*publish.SnapshotBundle, error) {
+func (g *NodeSnapshotGenerator) GenerateBundle(ctx context.Context, invitationID string, locale string) (*publish.SnapshotBundle, error) {
 	inv, err := g.invitationRepo.FindByID(ctx, invitationID)
 	if err != nil {
 		return nil, err
@@ -133,6 +145,11 @@ func (g *NodeSnapshotGenerator) GenerateBundle(ctx context.Context, invitationID
 	var layoutConfig any
 	if lc, ok := dataMap["layoutConfig"]; ok {
 		layoutConfig = lc
+		if config, ok := lc.(map[string]any); ok && g.themes != nil {
+			if err := g.themes.Execute(ctx, inv.UserID, config); err != nil {
+				return nil, fmt.Errorf("failed to resolve custom theme: %w", err)
+			}
+		}
 		// Remove layoutConfig from data to avoid duplication
 		delete(dataMap, "layoutConfig")
 	}
@@ -154,10 +171,32 @@ func (g *NodeSnapshotGenerator) GenerateBundle(ctx context.Context, invitationID
 		invitationPayload["layoutConfig"] = layoutConfig
 	}
 
+	// Translations for the requested locale; none for single-language sites.
+	var translations any = map[string]any{}
+	if locale != "" {
+		t, ok := inv.Translations[locale]
+		if !ok {
+			return nil, fmt.Errorf("invitation has no translations for locale %q", locale)
+		}
+		translations = t
+	}
+
 	// Keep invitation payload close to what the builder expects.
 	payload := map[string]any{
 		"invitation":   invitationPayload,
-		"translations": map[string]any{},
+		"translations": translations,
+		"locale":       locale,
+	}
+
+	// Render pinned invitations with the manifest of their layout version, which may be older
+	// than the one bundled with the renderer
+	if inv.LayoutVersion != "" && g.manifests != nil {
+		output, err := g.manifests.Execute(ctx, inv.LayoutID, inv.LayoutVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get manifest of layout %s version %s: %w", inv.LayoutID, inv.LayoutVersion, err)
+		}
+		invitationPayload["layoutVersion"] = inv.LayoutVersion
+		payload["layoutManifest"] = output.Manifest
 	}
 
 	stdin, err := json.Marshal(payload)
@@ -8,8 +8,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -38,17 +40,22 @@ func (m *MockInvitationRepository) Update(ctx context.Context, inv *domain.Invit
 	return args.Error(0)
 }
 
+func (m *MockInvitationRepository) UpdateStatus(ctx context.Context, id string, status domain.InvitationStatus) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
 func (m *MockInvitationRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockInvitationRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Invitation, error) {
-	args := m.Called(ctx, userID)
+func (m *MockInvitationRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
+	args := m.Called(ctx, userID, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*domain.Invitation), args.Error(1)
+	return args.Get(0).(*repository.Page[*domain.Invitation]), args.Error(1)
 }
 
 func (m *MockInvitationRepository) MigrateUserInvitations(ctx context.Context, fromUserID, toUserID string) (int, error) {
@@ -56,6 +63,48 @@ func (m *MockInvitationRepository) MigrateUserInvitations(ctx context.Context, f
 	return args.Get(0).(int), args.Error(1)
 }
 
+func (m *MockInvitationRepository) FindGuestInvitationsUpdatedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error) {
+	args := m.Called(ctx, cutoff, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) MoveToTrash(ctx context.Context, id string, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) RestoreFromTrash(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) FindTrashedByID(ctx context.Context, id string) (*domain.Invitation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) FindTrashedByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
+	args := m.Called(ctx, userID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.Page[*domain.Invitation]), args.Error(1)
+}
+
+func (m *MockInvitationRepository) FindTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error) {
+	args := m.Called(ctx, cutoff, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Invitation), args.Error(1)
+}
+
 func TestNewNodeSnapshotGenerator(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -112,7 +161,7 @@ func TestNewNodeSnapshotGenerator(t *testing.T) {
 				defer tt.cleanup(cleanupPath)
 			}
 
-			generator, err := NewNodeSnapshotGenerator(mockRepo, tt.scriptPath, tt.nodeBinary)
+			generator, err := NewNodeSnapshotGenerator(mockRepo, nil, nil, tt.scriptPath, tt.nodeBinary)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -142,10 +191,10 @@ func TestNodeSnapshotGenerator_GenerateBundle_InvalidInvitation(t *testing.T) {
 	scriptPath := filepath.Join(tmpDir, "render.js")
 	_ = os.WriteFile(scriptPath, []byte("#!/usr/bin/env node\n"), 0644)
 
-	generator, err := NewNodeSnapshotGenerator(mockRepo, scriptPath, "node")
+	generator, err := NewNodeSnapshotGenerator(mockRepo, nil, nil, scriptPath, "node")
 	require.NoError(t, err)
 
-	bundle, err := generator.GenerateBundle(ctx, "non-existent-id")
+	bundle, err := generator.GenerateBundle(ctx, "non-existent-id", "")
 
 	assert.Error(t, err)
 	assert.Nil(t, bundle)
@@ -170,10 +219,10 @@ func TestNodeSnapshotGenerator_GenerateBundle_InvalidJSON(t *testing.T) {
 	scriptPath := filepath.Join(tmpDir, "render.js")
 	_ = os.WriteFile(scriptPath, []byte("#!/usr/bin/env node\n"), 0644)
 
-	generator, err := NewNodeSnapshotGenerator(mockRepo, scriptPath, "node")
+	generator, err := NewNodeSnapshotGenerator(mockRepo, nil, nil, scriptPath, "node")
 	require.NoError(t, err)
 
-	bundle, err := generator.GenerateBundle(ctx, "test-id")
+	bundle, err := generator.GenerateBundle(ctx, "test-id", "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse invitation data")
@@ -209,12 +258,12 @@ func TestNodeSnapshotGenerator_GenerateBundle_ValidInvitation(t *testing.T) {
 	// For now, we'll test the error case when script doesn't exist or fails
 	_ = os.WriteFile(scriptPath, []byte("#!/usr/bin/env node\n"), 0644)
 
-	generator, err := NewNodeSnapshotGenerator(mockRepo, scriptPath, "node")
+	generator, err := NewNodeSnapshotGenerator(mockRepo, nil, nil, scriptPath, "node")
 	require.NoError(t, err)
 
 	// This will fail because the script doesn't actually render
 	// but we're testing the Go integration logic
-	_, err = generator.GenerateBundle(ctx, "test-id")
+	_, err = generator.GenerateBundle(ctx, "test-id", "")
 
 	// The script will fail, but we verify the Go code handles it correctly
 	require.Error(t, err)
@@ -9,7 +9,7 @@ import (
 
 type NoopSnapshotGenerator struct{}
 
-func (g *NoopSnapshotGenerator) GenerateBundle(ctx context.Context, invitationID string) (*publish.SnapshotBundle, error) {
+func (g *NoopSnapshotGenerator) GenerateBundle(ctx context.Context, invitationID string, locale string) (*publish.SnapshotBundle, error) {
 	return nil, errors.New("snapshot generator not configured")
 }
 
@@ -19,6 +19,10 @@ func (s *NoopArtifactStorage) Put(ctx context.Context, key string, contentType s
 	return errors.New("artifact storage not configured")
 }
 
+func (s *NoopArtifactStorage) Get(ctx context.Context, key string) (*publish.Artifact, error) {
+	return nil, errors.New("artifact storage not configured")
+}
+
 func (s *NoopArtifactStorage) PublicURL(key string) string {
 	return ""
 }
@@ -27,6 +31,17 @@ func (s *NoopArtifactStorage) ListVersions(ctx context.Context, subdomain string
 	return nil, errors.New("artifact storage not configured")
 }
 
+func (s *NoopArtifactStorage) ListObjects(ctx context.Context, subdomain string, version int) ([]publish.ArtifactObject, error) {
+	return nil, errors.New("artifact storage not configured")
+}
+
 func (s *NoopArtifactStorage) DeleteVersion(ctx context.Context, subdomain string, version int) error {
 	return errors.New("artifact storage not configured")
 }
+
+// NoopCachePurger is used when no CDN sits in front of published sites.
+type NoopCachePurger struct{}
+
+func (p *NoopCachePurger) PurgeSite(ctx context.Context, subdomain string, locales []string) error {
+	return nil
+}
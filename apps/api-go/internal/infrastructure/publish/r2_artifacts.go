@@ -3,7 +3,9 @@ package publishinfra
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"regexp"
 	"sort"
@@ -14,6 +16,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/publish"
 )
 
 // R2ArtifactStorage stores artifacts in Cloudflare R2 via the S3-compatible API.
@@ -90,6 +94,38 @@ func (s *R2ArtifactStorage) Put(ctx context.Context, key string, contentType str
 	return err
 }
 
+func (s *R2ArtifactStorage) Get(ctx context.Context, key string) (*publish.Artifact, error) {
+	if err := validateArtifactKey(key); err != nil {
+		return nil, err
+	}
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, domain.ErrArtifactNotFound
+		}
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	defer out.Body.Close()
+
+	body, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	artifact := &publish.Artifact{
+		Body:         body,
+		ContentType:  aws.ToString(out.ContentType),
+		CacheControl: aws.ToString(out.CacheControl),
+	}
+	if out.LastModified != nil {
+		artifact.ModTime = *out.LastModified
+	}
+	return artifact, nil
+}
+
 func (s *R2ArtifactStorage) PublicURL(key string) string {
 	if s.publicBase == "" {
 		// In Worker-only mode, callers should not rely on object URLs.
@@ -145,6 +181,32 @@ func (s *R2ArtifactStorage) ListVersions(ctx context.Context, subdomain string)
 	return versions, nil
 }
 
+// ListObjects lists all object keys and sizes under "sites/{subdomain}/v{version}/".
+func (s *R2ArtifactStorage) ListObjects(ctx context.Context, subdomain string, version int) ([]publish.ArtifactObject, error) {
+	if err := validateSubdomain(subdomain); err != nil {
+		return nil, fmt.Errorf("invalid subdomain: %w", err)
+	}
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(fmt.Sprintf("sites/%s/v%d/", subdomain, version)),
+	})
+
+	objects := []publish.ArtifactObject{}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		for _, obj := range page.Contents {
+			if obj.Key != nil {
+				objects = append(objects, publish.ArtifactObject{Key: *obj.Key, Size: aws.ToInt64(obj.Size)})
+			}
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
 // DeleteVersion deletes all artifacts for a specific version of a subdomain.
 // It lists all objects with prefix "sites/{subdomain}/v{version}/" and deletes them in batches.
 func (s *R2ArtifactStorage) DeleteVersion(ctx context.Context, subdomain string, version int) error {
@@ -0,0 +1,336 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"mime"
+
+	// Register the formats image.DecodeConfig reads; JPEG and PNG come with the image processor
+	_ "image/gif"
+
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// maxImagePixels bounds the decoded size of an image: a small compressed file can declare huge
+	// dimensions and take gigabytes of memory to decode (a decompression bomb). 50 megapixels
+	// covers phone cameras.
+	maxImagePixels = 50_000_000
+	// maxImageSide bounds either dimension, so extreme strips are rejected as well
+	maxImageSide = 16384
+	// pdfHeaderWindow is how far into a file PDF readers look for the %PDF- header
+	pdfHeaderWindow = 1024
+	// zipDirectoryWindow is how far from the end ZIP readers look for the end of central directory
+	zipDirectoryWindow = 65535 + 22
+)
+
+var (
+	ErrUnsupportedContent = errors.New("file content is not a supported image")
+	ErrContentMismatch    = errors.New("file content does not match its type")
+	ErrSVGNotAllowed      = errors.New("SVG images are not allowed")
+	ErrEmbeddedContent    = errors.New("file contains embedded content")
+	ErrImageTooLarge      = errors.New("image dimensions exceed limit")
+)
+
+// activeContentMarkers are (lowercase) markers of content browsers or other readers would run
+// or render; an image carrying one is a polyglot
+var activeContentMarkers = [][]byte{
+	[]byte("<script"),
+	[]byte("<html"),
+	[]byte("<body"),
+	[]byte("<iframe"),
+	[]byte("<svg"),
+	[]byte("<?php"),
+	[]byte("javascript:"),
+}
+
+// CheckImageContent validates an upload by its bytes rather than its declared MIME type and
+// returns the detected type, which should be stored instead of the declared one. It rejects
+// content that is not a JPEG, PNG, GIF or WebP, content of a different type than declared, SVG
+// (which can carry scripts), polyglots that are also HTML, PDF or ZIP files, and images whose
+// dimensions exceed the limits. Only the headers are parsed; nothing is decoded.
+func CheckImageContent(data []byte, declaredType string) (string, error) {
+	detected := sniffImageType(data)
+	if detected == "image/svg+xml" {
+		return "", ErrSVGNotAllowed
+	}
+	if detected == "" {
+		return "", ErrUnsupportedContent
+	}
+
+	declared, _, err := mime.ParseMediaType(declaredType)
+	if err != nil {
+		return "", ErrContentMismatch
+	}
+	if declared == "image/jpg" {
+		declared = "image/jpeg"
+	}
+	if declared != detected {
+		return "", ErrContentMismatch
+	}
+
+	if hasEmbeddedContent(data, detected) {
+		return "", ErrEmbeddedContent
+	}
+
+	if err := checkImageDimensions(data); err != nil {
+		return "", err
+	}
+	return detected, nil
+}
+
+// sniffImageType detects the type of an image from its magic bytes, or returns ""
+func sniffImageType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return "image/jpeg"
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return "image/png"
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return "image/gif"
+	case len(data) >= 12 && bytes.HasPrefix(data, []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WEBP")):
+		return "image/webp"
+	}
+
+	// SVG is text: an XML declaration or the root element, after a byte order mark and whitespace
+	text := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF")), " \t\r\n")
+	head := bytes.ToLower(text[:min(len(text), 512)])
+	if bytes.HasPrefix(head, []byte("<svg")) ||
+		(bytes.HasPrefix(head, []byte("<?xml")) || bytes.HasPrefix(head, []byte("<!doctype svg"))) && bytes.Contains(head, []byte("<svg")) {
+		return "image/svg+xml"
+	}
+	return ""
+}
+
+// hasEmbeddedContent reports whether an image of imageType is also readable as another kind of
+// file: markup or script in its metadata, comments or after its end, a PDF header where PDF
+// readers find it, or a ZIP directory at the end
+func hasEmbeddedContent(data []byte, imageType string) bool {
+	for _, region := range textRegions(data, imageType) {
+		if containsActiveContent(region) {
+			return true
+		}
+	}
+	if bytes.Contains(data[:min(len(data), pdfHeaderWindow)], []byte("%PDF-")) {
+		return true
+	}
+	return bytes.Contains(data[max(0, len(data)-zipDirectoryWindow):], []byte("PK\x05\x06"))
+}
+
+// containsActiveContent reports whether region holds one of the activeContentMarkers
+func containsActiveContent(region []byte) bool {
+	// Images are not UTF-8, so only ASCII letters are lowered
+	lower := make([]byte, len(region))
+	for i, b := range region {
+		if 'A' <= b && b <= 'Z' {
+			b += 'a' - 'A'
+		}
+		lower[i] = b
+	}
+	for _, marker := range activeContentMarkers {
+		if bytes.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// textRegions returns the parts of an image other than its compressed image data: metadata,
+// comments and whatever follows the end of the image. Short markers turn up by chance in
+// megabytes of compressed data, so it is not scanned. A file whose structure cannot be followed
+// is returned whole.
+func textRegions(data []byte, imageType string) [][]byte {
+	var regions [][]byte
+	ok := false
+	switch imageType {
+	case "image/jpeg":
+		regions, ok = jpegTextRegions(data)
+	case "image/png":
+		regions, ok = pngTextRegions(data)
+	case "image/gif":
+		regions, ok = gifTextRegions(data)
+	case "image/webp":
+		regions, ok = webpTextRegions(data)
+	}
+	if !ok {
+		return [][]byte{data}
+	}
+	return regions
+}
+
+// jpegTextRegions returns the application (APPn) and comment segments of a JPEG and the bytes
+// after its end, skipping the entropy-coded data of the scans
+func jpegTextRegions(data []byte) ([][]byte, bool) {
+	var regions [][]byte
+	for pos := 2; ; {
+		if pos >= len(data) {
+			return regions, true // Truncated in a scan: nothing follows
+		}
+		if pos+2 > len(data) || data[pos] != 0xFF {
+			return nil, false
+		}
+		marker := data[pos+1]
+		switch {
+		case marker == 0xFF: // Fill byte
+			pos++
+			continue
+		case marker == 0xD9: // End of image
+			return append(regions, data[pos+2:]), true
+		case marker == 0x01 || 0xD0 <= marker && marker <= 0xD7: // Markers without a length
+			pos += 2
+			continue
+		}
+		if pos+4 > len(data) {
+			return nil, false
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, false
+		}
+		if 0xE0 <= marker && marker <= 0xEF || marker == 0xFE {
+			regions = append(regions, data[pos+4:end])
+		}
+		pos = end
+		if marker == 0xDA {
+			// The scan data runs to the next marker: inside it, 0xFF is followed by 0x00 or a
+			// restart marker
+			for pos < len(data) {
+				if data[pos] == 0xFF && pos+1 < len(data) {
+					if next := data[pos+1]; next != 0 && (next < 0xD0 || next > 0xD7) {
+						break
+					}
+				}
+				pos++
+			}
+		}
+	}
+}
+
+// pngTextRegions returns the chunks of a PNG other than the image data (IDAT, and fdAT of
+// animated PNGs) and the bytes after its end
+func pngTextRegions(data []byte) ([][]byte, bool) {
+	var regions [][]byte
+	for pos := uint64(8); pos+12 <= uint64(len(data)); {
+		length := uint64(binary.BigEndian.Uint32(data[pos : pos+4]))
+		chunkType := string(data[pos+4 : pos+8])
+		end := pos + 12 + length // Length, type, data and CRC
+		if end > uint64(len(data)) {
+			return nil, false
+		}
+		if chunkType != "IDAT" && chunkType != "fdAT" {
+			regions = append(regions, data[pos+8:pos+8+length])
+		}
+		pos = end
+		if chunkType == "IEND" {
+			return append(regions, data[pos:]), true
+		}
+	}
+	return nil, false
+}
+
+// gifTextRegions returns the extensions of a GIF (comments, application data) and the bytes
+// after its trailer, skipping the image data
+func gifTextRegions(data []byte) ([][]byte, bool) {
+	if len(data) < 13 {
+		return nil, false
+	}
+	// The header and logical screen descriptor are 13 bytes, then the global color table
+	pos := 13
+	if data[10]&0x80 != 0 {
+		pos += 3 << (data[10]&0x07 + 1)
+	}
+	var regions [][]byte
+	for pos < len(data) {
+		switch data[pos] {
+		case 0x21: // Extension: introducer, label and data sub-blocks
+			end, ok := gifSubBlocksEnd(data, pos+2)
+			if !ok {
+				return nil, false
+			}
+			regions = append(regions, data[pos:end])
+			pos = end
+		case 0x2C: // Image descriptor, local color table, LZW code size and data sub-blocks
+			if pos+10 > len(data) {
+				return nil, false
+			}
+			flags := data[pos+9]
+			pos += 10
+			if flags&0x80 != 0 {
+				pos += 3 << (flags&0x07 + 1)
+			}
+			end, ok := gifSubBlocksEnd(data, pos+1)
+			if !ok {
+				return nil, false
+			}
+			pos = end
+		case 0x3B: // Trailer
+			return append(regions, data[pos+1:]), true
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// gifSubBlocksEnd returns the position after the data sub-blocks starting at pos
+func gifSubBlocksEnd(data []byte, pos int) (int, bool) {
+	for pos < len(data) {
+		size := int(data[pos])
+		pos++
+		if size == 0 {
+			return pos, true
+		}
+		pos += size
+	}
+	return 0, false
+}
+
+// webpTextRegions returns the chunks of a WebP other than the image data (VP8, VP8L, ALPH and
+// animation frames) and the bytes after the RIFF data
+func webpTextRegions(data []byte) ([][]byte, bool) {
+	if len(data) < 12 {
+		return nil, false
+	}
+	riffEnd := 8 + uint64(binary.LittleEndian.Uint32(data[4:8]))
+	if riffEnd > uint64(len(data)) {
+		return nil, false
+	}
+	var regions [][]byte
+	for pos := uint64(12); pos < riffEnd; {
+		if pos+8 > riffEnd {
+			return nil, false
+		}
+		fourCC := string(data[pos : pos+4])
+		size := uint64(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		end := pos + 8 + size + size%2
+		if end > riffEnd {
+			return nil, false
+		}
+		switch fourCC {
+		case "VP8 ", "VP8L", "ALPH", "ANMF":
+		default:
+			regions = append(regions, data[pos+8:end])
+		}
+		pos = end
+	}
+	return append(regions, data[riffEnd:]), true
+}
+
+// checkImageDimensions reads the dimensions from the image header and rejects decompression bombs
+func checkImageDimensions(data []byte) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return ErrUnsupportedContent
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return ErrUnsupportedContent
+	}
+	if config.Width > maxImageSide || config.Height > maxImageSide ||
+		int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return ErrImageTooLarge
+	}
+	return nil
+}
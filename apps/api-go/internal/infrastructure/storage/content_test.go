@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withJPEGSegment inserts a segment after the start of a JPEG
+func withJPEGSegment(data []byte, marker byte, payload []byte) []byte {
+	segment := []byte{0xFF, marker}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(2+len(payload)))
+	segment = append(segment, payload...)
+	return append(append(append([]byte(nil), data[:2]...), segment...), data[2:]...)
+}
+
+// inJPEGScan overwrites bytes in the middle of the entropy-coded data of a JPEG
+func inJPEGScan(t *testing.T, data, content []byte) []byte {
+	t.Helper()
+	sos := bytes.Index(data, []byte{0xFF, 0xDA})
+	require.Positive(t, sos)
+	start := sos + 2 + int(binary.BigEndian.Uint16(data[sos+2:sos+4])) + 16
+	require.Less(t, start+len(content), len(data)-2)
+	out := append([]byte(nil), data...)
+	copy(out[start:], content)
+	return out
+}
+
+// pngChunk encodes a PNG chunk with its CRC
+func pngChunk(chunkType string, payload []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(payload)))
+	chunk = append(chunk, chunkType...)
+	chunk = append(chunk, payload...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+// withPNGChunk inserts a chunk after the IHDR chunk of a PNG
+func withPNGChunk(data []byte, chunk []byte) []byte {
+	const afterIHDR = 8 + 25
+	return append(append(append([]byte(nil), data[:afterIHDR]...), chunk...), data[afterIHDR:]...)
+}
+
+// testGIF builds a 1x1 GIF with a two color global table, the given extensions before the
+// image and imageData as its single data sub-block
+func testGIF(imageData []byte, extensions ...[]byte) []byte {
+	gif := []byte("GIF89a\x01\x00\x01\x00\x80\x00\x00")
+	gif = append(gif, 0, 0, 0, 0xFF, 0xFF, 0xFF)
+	for _, extension := range extensions {
+		gif = append(gif, extension...)
+	}
+	gif = append(gif, 0x2C, 0, 0, 0, 0, 1, 0, 1, 0, 0, 0x02, byte(len(imageData)))
+	gif = append(gif, imageData...)
+	return append(gif, 0x00, 0x3B)
+}
+
+// gifComment encodes a comment extension holding text in a single sub-block
+func gifComment(text string) []byte {
+	return append(append([]byte{0x21, 0xFE, byte(len(text))}, text...), 0x00)
+}
+
+// testWebP returns the 1x1 test WebP with extra chunks after its image data
+func testWebP(t *testing.T, chunks ...[]byte) []byte {
+	t.Helper()
+	simple, err := base64.StdEncoding.DecodeString(testWebPLossless)
+	require.NoError(t, err)
+	return riffWebP(append([][]byte{simple[12:]}, chunks...)...)
+}
+
+func TestSniffImageType(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "jpeg", data: []byte{0xFF, 0xD8, 0xFF, 0xE0}, want: "image/jpeg"},
+		{name: "png", data: []byte("\x89PNG\r\n\x1a\n\x00\x00"), want: "image/png"},
+		{name: "gif87a", data: []byte("GIF87a\x01\x00"), want: "image/gif"},
+		{name: "gif89a", data: []byte("GIF89a\x01\x00"), want: "image/gif"},
+		{name: "webp", data: []byte("RIFF\x04\x00\x00\x00WEBP"), want: "image/webp"},
+		{name: "svg_root", data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), want: "image/svg+xml"},
+		{name: "svg_xml_declaration", data: []byte("<?xml version=\"1.0\"?>\n<SVG/>"), want: "image/svg+xml"},
+		{name: "svg_bom_and_whitespace", data: []byte("\xEF\xBB\xBF \r\n\t<svg/>"), want: "image/svg+xml"},
+		{name: "svg_doctype", data: []byte(`<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN"><svg/>`), want: "image/svg+xml"},
+		{name: "xml_without_svg", data: []byte(`<?xml version="1.0"?><note/>`)},
+		{name: "riff_not_webp", data: []byte("RIFF\x04\x00\x00\x00WAVE")},
+		{name: "truncated_jpeg", data: []byte{0xFF, 0xD8}},
+		{name: "truncated_riff", data: []byte("RIFF\x04\x00")},
+		{name: "text", data: []byte("hello")},
+		{name: "empty", data: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sniffImageType(tt.data))
+		})
+	}
+}
+
+func TestCheckImageContent(t *testing.T) {
+	jpegData := encodeTestImage(t, 400, 300, "image/jpeg")
+	pngData := encodeTestImage(t, 40, 30, "image/png")
+	webpData := testWebP(t)
+
+	tests := []struct {
+		name         string
+		data         []byte
+		declaredType string
+		wantType     string
+		wantErr      error
+	}{
+		{name: "jpeg", data: jpegData, declaredType: "image/jpeg", wantType: "image/jpeg"},
+		{name: "jpg_alias", data: jpegData, declaredType: "image/jpg", wantType: "image/jpeg"},
+		{name: "declared_with_parameters", data: pngData, declaredType: "image/png; charset=binary", wantType: "image/png"},
+		{name: "gif", data: testGIF([]byte{0x4C, 0x01}), declaredType: "image/gif", wantType: "image/gif"},
+		{name: "webp", data: webpData, declaredType: "image/webp", wantType: "image/webp"},
+		{name: "type_mismatch", data: pngData, declaredType: "image/jpeg", wantErr: ErrContentMismatch},
+		{name: "invalid_declared_type", data: pngData, declaredType: "not a type;;", wantErr: ErrContentMismatch},
+		{name: "not_an_image", data: []byte("%!PS-Adobe-3.0"), declaredType: "image/png", wantErr: ErrUnsupportedContent},
+		{name: "svg", data: []byte(`<svg onload="alert(1)"/>`), declaredType: "image/svg+xml", wantErr: ErrSVGNotAllowed},
+		{name: "svg_declared_as_png", data: []byte(`<svg onload="alert(1)"/>`), declaredType: "image/png", wantErr: ErrSVGNotAllowed},
+		{name: "bad_header", data: []byte{0xFF, 0xD8, 0xFF, 0xD9}, declaredType: "image/jpeg", wantErr: ErrUnsupportedContent},
+
+		// Polyglots: markup in metadata, comments or after the image
+		{name: "jpeg_comment_script", data: withJPEGSegment(jpegData, 0xFE, []byte("<SCRIPT>alert(1)</script>")), declaredType: "image/jpeg", wantErr: ErrEmbeddedContent},
+		{name: "jpeg_app_segment_html", data: withJPEGSegment(jpegData, 0xE2, []byte("<html><body>")), declaredType: "image/jpeg", wantErr: ErrEmbeddedContent},
+		{name: "jpeg_after_end", data: append(append([]byte(nil), jpegData...), "<iframe src=x>"...), declaredType: "image/jpeg", wantErr: ErrEmbeddedContent},
+		{name: "png_text_chunk", data: withPNGChunk(pngData, pngChunk("tEXt", []byte("Comment\x00javascript:alert(1)"))), declaredType: "image/png", wantErr: ErrEmbeddedContent},
+		{name: "png_after_end", data: append(append([]byte(nil), pngData...), "<?php system($_GET[0]);"...), declaredType: "image/png", wantErr: ErrEmbeddedContent},
+		{name: "gif_comment", data: testGIF([]byte{0x4C, 0x01}, gifComment("<svg onload=alert(1)>")), declaredType: "image/gif", wantErr: ErrEmbeddedContent},
+		{name: "gif_after_trailer", data: append(testGIF([]byte{0x4C, 0x01}), "<script>"...), declaredType: "image/gif", wantErr: ErrEmbeddedContent},
+		{name: "webp_metadata_chunk", data: testWebP(t, riffChunk("XMP ", []byte("<html>"))), declaredType: "image/webp", wantErr: ErrEmbeddedContent},
+		{name: "webp_after_riff", data: append(append([]byte(nil), webpData...), "<body onload=x>"...), declaredType: "image/webp", wantErr: ErrEmbeddedContent},
+		{name: "pdf_header", data: withJPEGSegment(jpegData, 0xE1, []byte("%PDF-1.7")), declaredType: "image/jpeg", wantErr: ErrEmbeddedContent},
+		{name: "zip_directory", data: append(append([]byte(nil), pngData...), "PK\x05\x06\x00\x00\x00\x00"...), declaredType: "image/png", wantErr: ErrEmbeddedContent},
+
+		// Markers that turn up by chance in compressed image data are not polyglots
+		{name: "marker_in_jpeg_scan", data: inJPEGScan(t, jpegData, []byte("<script")), declaredType: "image/jpeg", wantType: "image/jpeg"},
+		{name: "marker_in_png_image_data", data: withPNGChunk(pngData, pngChunk("IDAT", []byte("<html"))), declaredType: "image/png", wantType: "image/png"},
+		{name: "marker_in_gif_image_data", data: testGIF([]byte("<body")), declaredType: "image/gif", wantType: "image/gif"},
+		{name: "marker_in_webp_image_data", data: testWebP(t, riffChunk("ALPH", []byte("<svg"))), declaredType: "image/webp", wantType: "image/webp"},
+		{name: "malformed_structure_scanned_whole", data: append(withJPEGSegment(jpegData, 0xE0, nil)[:4], "\x00\x00<svg>"...), declaredType: "image/jpeg", wantErr: ErrEmbeddedContent},
+
+		// Decompression bombs
+		{name: "too_many_pixels", data: withPNGSize(pngData, 10000, 10000), declaredType: "image/png", wantErr: ErrImageTooLarge},
+		{name: "side_too_long", data: withPNGSize(pngData, 20000, 10), declaredType: "image/png", wantErr: ErrImageTooLarge},
+		{name: "zero_width", data: withPNGSize(pngData, 0, 10), declaredType: "image/png", wantErr: ErrUnsupportedContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detected, err := CheckImageContent(tt.data, tt.declaredType)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, detected)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, detected)
+		})
+	}
+}
+
+// withPNGSize returns a copy of a PNG whose header declares width x height
+func withPNGSize(data []byte, width, height uint32) []byte {
+	out := append([]byte(nil), data...)
+	// IHDR: type at 12, width and height at 16, CRC of type and data at 29
+	binary.BigEndian.PutUint32(out[16:20], width)
+	binary.BigEndian.PutUint32(out[20:24], height)
+	binary.BigEndian.PutUint32(out[29:33], crc32.ChecksumIEEE(out[12:29]))
+	return out
+}
@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"strings"
+	"time"
+)
+
+const (
+	exifTagOrientation      = 0x0112
+	exifTagExifIFD          = 0x8769
+	exifTagDateTimeOriginal = 0x9003
+
+	// exifDateLayout is the layout of EXIF date tags
+	exifDateLayout = "2006:01:02 15:04:05"
+)
+
+// exifHeader starts the APP1 segment holding EXIF data
+var exifHeader = []byte("Exif\x00\x00")
+
+// exifInfo is the EXIF metadata the image processor uses; everything else is dropped
+type exifInfo struct {
+	Orientation int    // 1-8, zero when absent
+	CaptureDate string // DateTimeOriginal in exifDateLayout, empty when absent or malformed
+}
+
+// readJPEGExif reads the orientation and capture date from the EXIF segment of a JPEG. Missing
+// or malformed EXIF data is not an error: the image is then treated as having none.
+func readJPEGExif(data []byte) exifInfo {
+	tiff := findJPEGExif(data)
+	if tiff == nil {
+		return exifInfo{}
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return exifInfo{}
+	}
+	if order.Uint16(tiff[2:4]) != 42 {
+		return exifInfo{}
+	}
+
+	var info exifInfo
+	ifd0 := order.Uint32(tiff[4:8])
+	var exifIFD uint32
+	walkIFD(tiff, ifd0, order, func(tag uint16, value []byte) {
+		switch tag {
+		case exifTagOrientation:
+			if orientation := int(order.Uint16(value)); orientation >= 1 && orientation <= 8 {
+				info.Orientation = orientation
+			}
+		case exifTagExifIFD:
+			exifIFD = order.Uint32(value)
+		}
+	})
+	if exifIFD != 0 {
+		walkIFD(tiff, exifIFD, order, func(tag uint16, value []byte) {
+			if tag != exifTagDateTimeOriginal {
+				return
+			}
+			// The value field holds the offset of the 20 byte string
+			offset := uint64(order.Uint32(value))
+			if offset+uint64(len(exifDateLayout)) > uint64(len(tiff)) {
+				return
+			}
+			date := string(tiff[offset : offset+uint64(len(exifDateLayout))])
+			if _, err := time.Parse(exifDateLayout, date); err == nil {
+				info.CaptureDate = date
+			}
+		})
+	}
+	return info
+}
+
+// findJPEGExif returns the TIFF structure of the EXIF segment of a JPEG, or nil
+func findJPEGExif(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xFF {
+			return nil
+		}
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return nil // Start of scan or end of image: metadata segments come before
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil
+		}
+		segment := data[pos+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, exifHeader) {
+			tiff := segment[len(exifHeader):]
+			if len(tiff) < 8 {
+				return nil
+			}
+			return tiff
+		}
+		pos = end
+	}
+	return nil
+}
+
+// walkIFD calls visit with the tag and the 4 byte value field of each entry of the IFD at offset
+func walkIFD(tiff []byte, offset uint32, order binary.ByteOrder, visit func(tag uint16, value []byte)) {
+	if uint64(offset)+2 > uint64(len(tiff)) {
+		return
+	}
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	entries := tiff[offset+2:]
+	for i := 0; i < count && (i+1)*12 <= len(entries); i++ {
+		entry := entries[i*12 : (i+1)*12]
+		visit(order.Uint16(entry[0:2]), entry[8:12])
+	}
+}
+
+// applyOrientation returns img with its pixels turned the way EXIF orientation says the image
+// is displayed, so it renders upright once the metadata is gone
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	w, h := bounds.Dx(), bounds.Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w // Orientations 5-8 turn the image a quarter
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // Mirrored horizontally
+				dx, dy = w-1-x, y
+			case 3: // Rotated 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // Mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // Transposed
+				dx, dy = y, x
+			case 6: // Rotated 90° clockwise
+				dx, dy = h-1-y, x
+			case 7: // Transversed
+				dx, dy = h-1-y, w-1-x
+			case 8: // Rotated 90° counter-clockwise
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], src.Pix[src.PixOffset(x, y):src.PixOffset(x, y)+4])
+		}
+	}
+	return dst
+}
+
+// withCaptureDate inserts an EXIF segment holding only DateTimeOriginal after the start of an
+// encoded JPEG. The tag sits in the EXIF sub-IFD, as cameras write it.
+func withCaptureDate(jpegData []byte, captureDate string) []byte {
+	if len(jpegData) < 2 || len(captureDate) != len(exifDateLayout) {
+		return jpegData
+	}
+
+	var tiff bytes.Buffer
+	order := binary.BigEndian
+	write := func(v any) { _ = binary.Write(&tiff, order, v) }
+	tiff.WriteString("MM")
+	write(uint16(42))
+	write(uint32(8)) // IFD0 follows the header
+	// IFD0: a single pointer to the EXIF sub-IFD at 26
+	write(uint16(1))
+	write([]uint16{exifTagExifIFD, 4})
+	write([]uint32{1, 26})
+	write(uint32(0))
+	// EXIF sub-IFD: DateTimeOriginal, its 20 byte value at 44
+	write(uint16(1))
+	write([]uint16{exifTagDateTimeOriginal, 2})
+	write([]uint32{20, 44})
+	write(uint32(0))
+	tiff.WriteString(strings.TrimSpace(captureDate))
+	tiff.WriteByte(0)
+
+	var out bytes.Buffer
+	out.Grow(len(jpegData) + 10 + tiff.Len())
+	out.Write(jpegData[:2]) // Start of image
+	out.Write([]byte{0xFF, 0xE1})
+	_ = binary.Write(&out, order, uint16(2+len(exifHeader)+tiff.Len()))
+	out.Write(exifHeader)
+	out.Write(tiff.Bytes())
+	out.Write(jpegData[2:])
+	return out.Bytes()
+}
@@ -0,0 +1,309 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testCaptureDate = "2024:06:15 14:30:00"
+	// testCameraMake and testGPSMethod are metadata that must not survive processing
+	testCameraMake = "TestCam"
+	testGPSMethod  = "SECRET-LOCATION"
+
+	// Offsets in the TIFF structure of buildTestExif
+	testExifIFDPointer = 8 + 2 + 2*12 + 8 // Value field of the third IFD0 entry
+	testDatePointer    = 70 + 2 + 8       // Value field of the DateTimeOriginal entry
+)
+
+// buildTestExif returns the TIFF structure of an EXIF segment as phones write it: IFD0 with the
+// orientation, the camera make and pointers to the EXIF sub-IFD (holding DateTimeOriginal) and
+// to the GPS IFD
+func buildTestExif(order binary.ByteOrder, orientation int, captureDate string) []byte {
+	var tiff bytes.Buffer
+	write := func(v any) { _ = binary.Write(&tiff, order, v) }
+	if order == binary.LittleEndian {
+		tiff.WriteString("II")
+	} else {
+		tiff.WriteString("MM")
+	}
+	write(uint16(42))
+	write(uint32(8))
+	// IFD0 at 8, 54 bytes
+	write(uint16(4))
+	write([]uint16{exifTagOrientation, 3})
+	write(uint32(1))
+	write([]uint16{uint16(orientation), 0})
+	write([]uint16{0x010F, 2}) // Make
+	write([]uint32{uint32(len(testCameraMake) + 1), 62})
+	write([]uint16{exifTagExifIFD, 4})
+	write([]uint32{1, 70})
+	write([]uint16{0x8825, 4}) // GPS IFD
+	write([]uint32{1, 108})
+	write(uint32(0))
+	// Make at 62, 8 bytes
+	tiff.WriteString(testCameraMake + "\x00")
+	// EXIF sub-IFD at 70, 18 bytes, and its date at 88, 20 bytes
+	write(uint16(1))
+	write([]uint16{exifTagDateTimeOriginal, 2})
+	write([]uint32{20, 88})
+	write(uint32(0))
+	tiff.WriteString(captureDate + "\x00")
+	// GPS IFD at 108, 18 bytes: GPSProcessingMethod at 126
+	write(uint16(1))
+	write([]uint16{0x001B, 7})
+	write([]uint32{uint32(len(testGPSMethod)), 126})
+	write(uint32(0))
+	tiff.WriteString(testGPSMethod)
+	return tiff.Bytes()
+}
+
+// withExifSegment inserts an APP1 segment holding tiff after the start of a JPEG
+func withExifSegment(jpegData, tiff []byte) []byte {
+	var out bytes.Buffer
+	out.Write(jpegData[:2])
+	out.Write([]byte{0xFF, 0xE1})
+	_ = binary.Write(&out, binary.BigEndian, uint16(2+len(exifHeader)+len(tiff)))
+	out.Write(exifHeader)
+	out.Write(tiff)
+	out.Write(jpegData[2:])
+	return out.Bytes()
+}
+
+// orientationTestJPEG encodes a 64x32 JPEG with a red 16x16 block in its top left corner
+func orientationTestJPEG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 64; x++ {
+			if x < 16 && y < 16 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.White)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}))
+	return buf.Bytes()
+}
+
+func TestReadJPEGExif(t *testing.T) {
+	corrupt := func(order binary.ByteOrder, mutate func(tiff []byte) []byte) []byte {
+		return mutate(buildTestExif(order, 6, testCaptureDate))
+	}
+
+	tests := []struct {
+		name string
+		tiff []byte
+		want exifInfo
+	}{
+		{
+			name: "big_endian",
+			tiff: buildTestExif(binary.BigEndian, 6, testCaptureDate),
+			want: exifInfo{Orientation: 6, CaptureDate: testCaptureDate},
+		},
+		{
+			name: "little_endian",
+			tiff: buildTestExif(binary.LittleEndian, 8, testCaptureDate),
+			want: exifInfo{Orientation: 8, CaptureDate: testCaptureDate},
+		},
+		{
+			name: "orientation_out_of_range",
+			tiff: buildTestExif(binary.LittleEndian, 9, testCaptureDate),
+			want: exifInfo{CaptureDate: testCaptureDate},
+		},
+		{
+			name: "malformed_date",
+			tiff: buildTestExif(binary.BigEndian, 3, "2024-06-15T14:30:00Z"),
+			want: exifInfo{Orientation: 3},
+		},
+		{
+			name: "truncated_ifd_keeps_complete_entries",
+			tiff: corrupt(binary.BigEndian, func(tiff []byte) []byte { return tiff[:30] }),
+			want: exifInfo{Orientation: 6},
+		},
+		{
+			name: "truncated_header",
+			tiff: corrupt(binary.LittleEndian, func(tiff []byte) []byte { return tiff[:7] }),
+		},
+		{
+			name: "ifd0_offset_past_end",
+			tiff: corrupt(binary.LittleEndian, func(tiff []byte) []byte {
+				binary.LittleEndian.PutUint32(tiff[4:8], 0xFFFFFFFF)
+				return tiff
+			}),
+		},
+		{
+			name: "exif_ifd_offset_past_end",
+			tiff: corrupt(binary.BigEndian, func(tiff []byte) []byte {
+				binary.BigEndian.PutUint32(tiff[testExifIFDPointer:], 0xFFFFFFFE)
+				return tiff
+			}),
+			want: exifInfo{Orientation: 6},
+		},
+		{
+			name: "date_offset_past_end",
+			tiff: corrupt(binary.LittleEndian, func(tiff []byte) []byte {
+				binary.LittleEndian.PutUint32(tiff[testDatePointer:], uint32(len(tiff)-10))
+				return tiff
+			}),
+			want: exifInfo{Orientation: 6},
+		},
+		{
+			name: "unknown_byte_order",
+			tiff: corrupt(binary.BigEndian, func(tiff []byte) []byte { return append([]byte("XX"), tiff[2:]...) }),
+		},
+		{
+			name: "not_tiff",
+			tiff: corrupt(binary.BigEndian, func(tiff []byte) []byte {
+				binary.BigEndian.PutUint16(tiff[2:4], 43)
+				return tiff
+			}),
+		},
+	}
+
+	jpegData := orientationTestJPEG(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := readJPEGExif(withExifSegment(jpegData, tt.tiff))
+
+			assert.Equal(t, tt.want, info)
+		})
+	}
+}
+
+func TestReadJPEGExif_MalformedSegments(t *testing.T) {
+	jpegData := orientationTestJPEG(t)
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "no_exif", data: jpegData},
+		{name: "not_jpeg", data: []byte("\x89PNG\r\n\x1a\n")},
+		{name: "segment_length_past_end", data: []byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF, 0xFF, 'E', 'x'}},
+		{name: "segment_length_too_small", data: []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01, 0xFF, 0xD9}},
+		{name: "exif_after_scan", data: append(jpegData[:len(jpegData)-2:len(jpegData)-2], withExifSegment([]byte{0xFF, 0xD8}, buildTestExif(binary.BigEndian, 6, testCaptureDate))[2:]...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, exifInfo{}, readJPEGExif(tt.data))
+		})
+	}
+}
+
+func TestImageProcessor_ProcessImage_AppliesOrientation(t *testing.T) {
+	type corner struct{ X, Y int }
+	topLeft, topRight, bottomLeft, bottomRight := corner{0, 0}, corner{1, 0}, corner{0, 1}, corner{1, 1}
+	tests := []struct {
+		orientation int
+		order       binary.ByteOrder
+		wantWidth   int
+		wantHeight  int
+		// wantRed is the corner the red block of the top left corner is displayed in
+		wantRed corner
+	}{
+		{orientation: 1, order: binary.BigEndian, wantWidth: 64, wantHeight: 32, wantRed: topLeft},
+		{orientation: 2, order: binary.LittleEndian, wantWidth: 64, wantHeight: 32, wantRed: topRight},
+		{orientation: 3, order: binary.BigEndian, wantWidth: 64, wantHeight: 32, wantRed: bottomRight},
+		{orientation: 4, order: binary.LittleEndian, wantWidth: 64, wantHeight: 32, wantRed: bottomLeft},
+		{orientation: 5, order: binary.BigEndian, wantWidth: 32, wantHeight: 64, wantRed: topLeft},
+		{orientation: 6, order: binary.LittleEndian, wantWidth: 32, wantHeight: 64, wantRed: topRight},
+		{orientation: 7, order: binary.BigEndian, wantWidth: 32, wantHeight: 64, wantRed: bottomRight},
+		{orientation: 8, order: binary.LittleEndian, wantWidth: 32, wantHeight: 64, wantRed: bottomLeft},
+	}
+
+	processor := NewImageProcessor(1920, 1920, 95)
+	jpegData := orientationTestJPEG(t)
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("orientation_%d", tt.orientation), func(t *testing.T) {
+			// Arrange
+			data := withExifSegment(jpegData, buildTestExif(tt.order, tt.orientation, testCaptureDate))
+
+			// Act
+			processed, err := processor.ProcessImage(data, "image/jpeg", ProcessOptions{})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantWidth, processed.Width)
+			assert.Equal(t, tt.wantHeight, processed.Height)
+			img, err := jpeg.Decode(bytes.NewReader(processed.Data))
+			require.NoError(t, err)
+			require.Equal(t, image.Rect(0, 0, tt.wantWidth, tt.wantHeight), img.Bounds())
+			for _, c := range []corner{topLeft, topRight, bottomLeft, bottomRight} {
+				// The center of the 16x16 block in that corner
+				x := 8 + c.X*(tt.wantWidth-16)
+				y := 8 + c.Y*(tt.wantHeight-16)
+				r, g, _, _ := img.At(x, y).RGBA()
+				isRed := r > 0xC000 && g < 0x4000
+				assert.Equal(t, c == tt.wantRed, isRed, "corner %v red", c)
+			}
+			assert.Equal(t, exifInfo{}, readJPEGExif(processed.Data), "The orientation is not stored again")
+		})
+	}
+}
+
+func TestImageProcessor_ProcessImage_StripsMetadata(t *testing.T) {
+	tests := []struct {
+		name            string
+		order           binary.ByteOrder
+		opts            ProcessOptions
+		wantCaptureDate string
+	}{
+		{name: "big_endian", order: binary.BigEndian},
+		{name: "little_endian", order: binary.LittleEndian},
+		{name: "keep_capture_date_big_endian", order: binary.BigEndian, opts: ProcessOptions{KeepCaptureDate: true}, wantCaptureDate: testCaptureDate},
+		{name: "keep_capture_date_little_endian", order: binary.LittleEndian, opts: ProcessOptions{KeepCaptureDate: true}, wantCaptureDate: testCaptureDate},
+	}
+
+	// Wide enough for a variant, which never carries the capture date
+	processor := NewImageProcessor(1920, 1920, 90)
+	jpegData := encodeTestImage(t, 400, 300, "image/jpeg")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			data := withExifSegment(jpegData, buildTestExif(tt.order, 1, testCaptureDate))
+			require.True(t, bytes.Contains(data, []byte(testGPSMethod)))
+
+			// Act
+			processed, err := processor.ProcessImage(data, "image/jpeg", tt.opts)
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, processed.Variants, 1)
+			for _, encoded := range [][]byte{processed.Data, processed.Variants[0].Data} {
+				assert.False(t, bytes.Contains(encoded, []byte(testGPSMethod)), "GPS data is removed")
+				assert.False(t, bytes.Contains(encoded, []byte(testCameraMake)), "Camera details are removed")
+			}
+			assert.Equal(t, exifInfo{CaptureDate: tt.wantCaptureDate}, readJPEGExif(processed.Data))
+			assert.Equal(t, exifInfo{}, readJPEGExif(processed.Variants[0].Data))
+			_, err = jpeg.Decode(bytes.NewReader(processed.Data))
+			assert.NoError(t, err, "The capture date segment keeps the JPEG valid")
+		})
+	}
+}
+
+func TestImageProcessor_ProcessImage_IgnoresMalformedExif(t *testing.T) {
+	// Arrange
+	processor := NewImageProcessor(1920, 1920, 90)
+	tiff := buildTestExif(binary.BigEndian, 6, testCaptureDate)
+	binary.BigEndian.PutUint32(tiff[4:8], 0xFFFFFFFF)
+	data := withExifSegment(orientationTestJPEG(t), tiff)
+
+	// Act
+	processed, err := processor.ProcessImage(data, "image/jpeg", ProcessOptions{KeepCaptureDate: true})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 64, processed.Width, "Without readable EXIF the image is kept as encoded")
+	assert.Equal(t, exifInfo{}, readJPEGExif(processed.Data))
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacred-vows/api-go/internal/infrastructure/config"
+)
+
+// NewFromConfig creates the asset storage: GCS when a GCS bucket is configured (production),
+// otherwise S3-compatible storage such as MinIO (local).
+func NewFromConfig(ctx context.Context, cfg config.StorageConfig) (SignedURLStorage, error) {
+	if cfg.GCSBucket != "" {
+		return NewGCSStorage(ctx, cfg.GCSBucket, "", cfg.MaxFileSize, cfg.AllowedTypes)
+	}
+	if cfg.S3Endpoint != "" && cfg.S3AccessKeyID != "" && cfg.S3SecretAccessKey != "" && cfg.S3Bucket != "" {
+		return NewS3Storage(ctx, S3Config{
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			Bucket:          cfg.S3Bucket,
+			Endpoint:        cfg.S3Endpoint,
+			PublicEndpoint:  cfg.S3PublicEndpoint,
+			Region:          cfg.S3Region,
+		}, cfg.MaxFileSize, cfg.AllowedTypes)
+	}
+	return nil, fmt.Errorf("storage not configured. For production, set GCS_ASSETS_BUCKET. For local, configure S3_ENDPOINT, S3_ACCESS_KEY_ID, S3_SECRET_ACCESS_KEY, and S3_ASSETS_BUCKET")
+}
@@ -1,9 +1,11 @@
 package storage
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"mime"
 	"os"
 	"path/filepath"
@@ -81,7 +83,33 @@ func (s *FileStorage) SaveFile(filename string, originalName string, mimeType st
 
 func (s *FileStorage) DeleteFile(filename string) error {
 	fullPath := filepath.Join(s.uploadPath, filename)
-	return os.Remove(fullPath)
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *FileStorage) ListFiles(ctx context.Context) ([]fs.FileInfo, error) {
+	entries, err := os.ReadDir(s.uploadPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upload directory: %w", err)
+	}
+
+	files := make([]fs.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // Deleted while listing
+		}
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, info)
+	}
+	return files, nil
 }
 
 func (s *FileStorage) isAllowedType(mimeType string) bool {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"mime"
 	"net/http"
 	"strings"
@@ -13,6 +14,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"google.golang.org/api/iam/v1"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -86,7 +88,40 @@ func (s *GCSStorage) DeleteFile(filename string) error {
 	ctx := context.Background()
 	bucket := s.client.Bucket(s.bucketName)
 	obj := bucket.Object(filename)
-	return obj.Delete(ctx)
+	if err := obj.Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *GCSStorage) ListFiles(ctx context.Context) ([]fs.FileInfo, error) {
+	var files []fs.FileInfo
+	it := s.client.Bucket(s.bucketName).Objects(ctx, &storage.Query{Delimiter: "/"})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list bucket: %w", err)
+		}
+		if attrs.Prefix != "" {
+			continue // A "directory" of nested objects
+		}
+		files = append(files, objectInfo{name: attrs.Name, size: attrs.Size, modTime: attrs.Updated})
+	}
+	return files, nil
+}
+
+func (s *GCSStorage) OpenFile(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	reader, err := s.client.Bucket(s.bucketName).Object(objectName).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrFileNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	return reader, nil
 }
 
 func (s *GCSStorage) GenerateSignedURL(ctx context.Context, objectName string, method string, expiresIn time.Duration) (string, error) {
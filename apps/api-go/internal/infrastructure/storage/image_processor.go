@@ -2,91 +2,169 @@ package storage
 
 import (
 	"bytes"
+	"fmt"
 	"image"
 	"image/jpeg"
 	"image/png"
-	"io"
+
+	"golang.org/x/image/draw"
 )
 
-// Note: WebP support requires golang.org/x/image/webp
-// Resize support requires golang.org/x/image/draw
-// These are optional - if not available, images will be processed without resizing/webp support
+// ImageVariantSpec names a width the image processor generates a smaller copy at
+type ImageVariantSpec struct {
+	Name  string
+	Width int
+}
+
+// DefaultImageVariants are the responsive widths generated for uploaded photos, used for srcset
+var DefaultImageVariants = []ImageVariantSpec{
+	{Name: "thumbnail", Width: 320},
+	{Name: "medium", Width: 800},
+	{Name: "large", Width: 1600},
+}
 
 // ImageProcessor handles image resizing and compression
 type ImageProcessor struct {
 	maxWidth  int
 	maxHeight int
 	quality   int
+	variants  []ImageVariantSpec
 }
 
-// NewImageProcessor creates a new image processor
+// NewImageProcessor creates a new image processor generating DefaultImageVariants
 func NewImageProcessor(maxWidth, maxHeight, quality int) *ImageProcessor {
+	if quality <= 0 || quality > 100 {
+		quality = jpeg.DefaultQuality
+	}
 	return &ImageProcessor{
 		maxWidth:  maxWidth,
 		maxHeight: maxHeight,
 		quality:   quality,
+		variants:  DefaultImageVariants,
 	}
 }
 
-// ProcessImage resizes and compresses an image
-// Returns processed image data, new size, and error
-func (p *ImageProcessor) ProcessImage(reader io.Reader, mimeType string, originalSize int64) (io.Reader, int64, error) {
-	// Decode image
+// ProcessOptions are the per-upload choices of the uploader
+type ProcessOptions struct {
+	// KeepCaptureDate keeps the date the photo was taken (EXIF DateTimeOriginal) in the processed
+	// JPEG. All other metadata, GPS coordinates and camera details included, is always removed.
+	KeepCaptureDate bool
+}
+
+// ProcessedImage is an uploaded image after processing
+type ProcessedImage struct {
+	Data   []byte
+	Width  int // Zero when the type is not processed
+	Height int
+	// Variants are narrower copies, narrowest first. Widths the image does not exceed are skipped.
+	Variants []ImageVariant
+}
+
+// ImageVariant is a downscaled copy of a processed image, in the same format
+type ImageVariant struct {
+	Name   string
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// ProcessImage turns a JPEG upright according to its EXIF orientation, downscales a JPEG or PNG
+// to fit the maximum dimensions, re-encodes it and generates its variants. Re-encoding drops all
+// metadata; see ProcessOptions for the capture date. WebP, which cannot be encoded, only has its
+// metadata chunks removed, and GIF, which may be animated, is returned unchanged; neither gets
+// dimensions or variants.
+func (p *ImageProcessor) ProcessImage(data []byte, mimeType string, opts ProcessOptions) (*ProcessedImage, error) {
 	var img image.Image
+	var exif exifInfo
 	var err error
 
+	switch mimeType {
+	case "image/jpeg", "image/jpg", "image/png":
+		// Reject decompression bombs before decoding allocates the pixels
+		if err := checkImageDimensions(data); err != nil {
+			return nil, err
+		}
+	}
+
 	switch mimeType {
 	case "image/jpeg", "image/jpg":
-		img, err = jpeg.Decode(reader)
+		exif = readJPEGExif(data)
+		img, err = jpeg.Decode(bytes.NewReader(data))
 	case "image/png":
-		img, err = png.Decode(reader)
+		img, err = png.Decode(bytes.NewReader(data))
 	case "image/webp":
-		// WebP decoding requires golang.org/x/image/webp
-		// For now, return original if webp decoding fails
-		return reader, originalSize, nil
+		stripped, err := stripWebPMetadata(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode image: %w", err)
+		}
+		return &ProcessedImage{Data: stripped}, nil
 	default:
-		// For unsupported types, return original
-		return reader, originalSize, nil
+		return &ProcessedImage{Data: data}, nil
 	}
-
 	if err != nil {
-		return nil, 0, err
+		return nil, fmt.Errorf("failed to decode image: %w", err)
 	}
+	img = applyOrientation(img, exif.Orientation)
 
-	// Resize if needed
 	bounds := img.Bounds()
-	width := bounds.Dx()
-	height := bounds.Dy()
-
+	width, height := bounds.Dx(), bounds.Dy()
 	if (p.maxWidth > 0 && width > p.maxWidth) || (p.maxHeight > 0 && height > p.maxHeight) {
-		// Resize requires golang.org/x/image/draw
-		// For now, skip resizing if dimensions exceed limits but library not available
-		// TODO: Add resize support when golang.org/x/image/draw is available
-		// For now, we'll just compress without resizing
+		width, height = calculateDimensions(width, height, p.maxWidth, p.maxHeight)
+		img = resize(img, width, height)
 	}
 
-	// Encode image
+	encoded, err := p.encode(img, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	if opts.KeepCaptureDate && exif.CaptureDate != "" {
+		encoded = withCaptureDate(encoded, exif.CaptureDate)
+	}
+	processed := &ProcessedImage{Data: encoded, Width: width, Height: height}
+
+	for _, spec := range p.variants {
+		if spec.Width >= width {
+			continue
+		}
+		variantWidth, variantHeight := calculateDimensions(width, height, spec.Width, 0)
+		variantData, err := p.encode(resize(img, variantWidth, variantHeight), mimeType)
+		if err != nil {
+			return nil, err
+		}
+		processed.Variants = append(processed.Variants, ImageVariant{
+			Name:   spec.Name,
+			Width:  variantWidth,
+			Height: variantHeight,
+			Data:   variantData,
+		})
+	}
+
+	return processed, nil
+}
+
+// encode writes img in the format of mimeType
+func (p *ImageProcessor) encode(img image.Image, mimeType string) ([]byte, error) {
 	var buf bytes.Buffer
+	var err error
 	switch mimeType {
 	case "image/jpeg", "image/jpg":
 		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: p.quality})
-	case "image/png":
+	default:
 		encoder := &png.Encoder{CompressionLevel: png.BestCompression}
 		err = encoder.Encode(&buf, img)
-	case "image/webp":
-		// WebP encoding requires golang.org/x/image/webp
-		// For now, return original
-		// TODO: Add webp encoding support when library is available
-		return reader, originalSize, nil
-	default:
-		return reader, originalSize, nil
 	}
-
 	if err != nil {
-		return nil, 0, err
+		return nil, fmt.Errorf("failed to encode image: %w", err)
 	}
+	return buf.Bytes(), nil
+}
 
-	return bytes.NewReader(buf.Bytes()), int64(buf.Len()), nil
+// resize scales img to width x height with the Catmull-Rom resampler, which keeps photos sharp
+// when downscaling
+func resize(img image.Image, width, height int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return dst
 }
 
 // calculateDimensions calculates new dimensions maintaining aspect ratio
@@ -119,5 +197,6 @@ func calculateDimensions(width, height, maxWidth, maxHeight int) (int, int) {
 		newWidth = int(float64(maxHeight) * aspectRatio)
 	}
 
-	return newWidth, newHeight
+	// Very thin images must keep at least one pixel on each side
+	return max(newWidth, 1), max(newHeight, 1)
 }
@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestImage returns a width x height image with a red top left pixel on a white background,
+// so tests can tell where the top left corner ends up
+func newTestImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	return img
+}
+
+// encodeTestImage encodes a width x height test image as mimeType
+func encodeTestImage(t *testing.T, width, height int, mimeType string) []byte {
+	t.Helper()
+	img := newTestImage(width, height)
+	var buf bytes.Buffer
+	var err error
+	switch mimeType {
+	case "image/jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95})
+	case "image/png":
+		err = png.Encode(&buf, img)
+	case "image/gif":
+		err = gif.Encode(&buf, img, nil)
+	default:
+		t.Fatalf("unsupported test image type %s", mimeType)
+	}
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+// decodedSize returns the dimensions of an encoded image
+func decodedSize(t *testing.T, data []byte) (int, int) {
+	t.Helper()
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	return config.Width, config.Height
+}
+
+func TestImageProcessor_ProcessImage(t *testing.T) {
+	type size struct{ Width, Height int }
+	tests := []struct {
+		name         string
+		width        int
+		height       int
+		mimeType     string
+		maxWidth     int
+		maxHeight    int
+		wantSize     size
+		wantVariants map[string]size
+	}{
+		{
+			name:     "landscape_jpeg_downscaled_with_all_variants",
+			width:    2000,
+			height:   1000,
+			mimeType: "image/jpeg",
+			maxWidth: 1920, maxHeight: 1920,
+			wantSize: size{1920, 960},
+			wantVariants: map[string]size{
+				"thumbnail": {320, 160},
+				"medium":    {800, 400},
+				"large":     {1600, 800},
+			},
+		},
+		{
+			name:     "portrait_png_limited_by_height",
+			width:    1000,
+			height:   3000,
+			mimeType: "image/png",
+			maxWidth: 1920, maxHeight: 1920,
+			wantSize: size{640, 1920},
+			wantVariants: map[string]size{
+				"thumbnail": {320, 960},
+			},
+		},
+		{
+			name:     "small_jpeg_kept_without_variants",
+			width:    300,
+			height:   200,
+			mimeType: "image/jpeg",
+			maxWidth: 1920, maxHeight: 1920,
+			wantSize: size{300, 200},
+		},
+		{
+			name:     "variant_equal_to_width_skipped",
+			width:    800,
+			height:   600,
+			mimeType: "image/jpeg",
+			wantSize: size{800, 600},
+			wantVariants: map[string]size{
+				"thumbnail": {320, 240},
+			},
+		},
+		{
+			name:     "no_limits_keeps_size",
+			width:    1700,
+			height:   100,
+			mimeType: "image/png",
+			wantSize: size{1700, 100},
+			wantVariants: map[string]size{
+				"thumbnail": {320, 18},
+				"medium":    {800, 47},
+				"large":     {1600, 94},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			processor := NewImageProcessor(tt.maxWidth, tt.maxHeight, 85)
+			data := encodeTestImage(t, tt.width, tt.height, tt.mimeType)
+
+			// Act
+			processed, err := processor.ProcessImage(data, tt.mimeType, ProcessOptions{})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSize, size{processed.Width, processed.Height})
+			width, height := decodedSize(t, processed.Data)
+			assert.Equal(t, tt.wantSize, size{width, height}, "Stored dimensions match the encoded image")
+
+			require.Len(t, processed.Variants, len(tt.wantVariants))
+			previous := 0
+			for _, variant := range processed.Variants {
+				want, ok := tt.wantVariants[variant.Name]
+				require.True(t, ok, "unexpected variant %s", variant.Name)
+				assert.Equal(t, want, size{variant.Width, variant.Height})
+				width, height := decodedSize(t, variant.Data)
+				assert.Equal(t, want, size{width, height}, "Variant %s dimensions match its encoded image", variant.Name)
+				assert.Greater(t, variant.Width, previous, "Variants are ordered narrowest first")
+				previous = variant.Width
+			}
+		})
+	}
+}
+
+func TestImageProcessor_ProcessImage_PassesThroughGIF(t *testing.T) {
+	// Arrange
+	processor := NewImageProcessor(100, 100, 85)
+	data := encodeTestImage(t, 400, 300, "image/gif")
+
+	// Act
+	processed, err := processor.ProcessImage(data, "image/gif", ProcessOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, data, processed.Data)
+	assert.Zero(t, processed.Width)
+	assert.Empty(t, processed.Variants)
+}
+
+func TestImageProcessor_ProcessImage_RejectsUndecodable(t *testing.T) {
+	processor := NewImageProcessor(1920, 1920, 85)
+
+	_, err := processor.ProcessImage([]byte("not an image"), "image/jpeg", ProcessOptions{})
+
+	assert.Error(t, err)
+}
+
+func TestCalculateDimensions(t *testing.T) {
+	tests := []struct {
+		name                      string
+		width, height, maxW, maxH int
+		wantWidth, wantHeight     int
+	}{
+		{name: "no_limits", width: 4000, height: 3000, wantWidth: 4000, wantHeight: 3000},
+		{name: "width_bound", width: 4000, height: 3000, maxW: 1920, maxH: 1920, wantWidth: 1920, wantHeight: 1440},
+		{name: "height_bound", width: 3000, height: 4000, maxW: 1920, maxH: 1920, wantWidth: 1440, wantHeight: 1920},
+		{name: "width_only", width: 4000, height: 3000, maxW: 800, wantWidth: 800, wantHeight: 600},
+		{name: "height_only", width: 4000, height: 3000, maxH: 300, wantWidth: 400, wantHeight: 300},
+		{name: "thin_strip_keeps_one_pixel", width: 10000, height: 2, maxW: 320, wantWidth: 320, wantHeight: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := calculateDimensions(tt.width, tt.height, tt.maxW, tt.maxH)
+
+			assert.Equal(t, tt.wantWidth, width)
+			assert.Equal(t, tt.wantHeight, height)
+		})
+	}
+}
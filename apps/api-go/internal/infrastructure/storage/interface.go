@@ -2,19 +2,45 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"io"
+	"io/fs"
 	"time"
 )
 
+// ErrFileNotFound is returned when opening a file that is not in the store
+var ErrFileNotFound = errors.New("file not found")
+
 // Storage defines the interface for file storage operations
 type Storage interface {
 	SaveFile(filename string, originalName string, mimeType string, size int64, reader io.Reader) (*UploadedFile, error)
+	// DeleteFile removes the file; deleting a file that does not exist is not an error
 	DeleteFile(filename string) error
 	ValidateFile(mimeType string, size int64) error
+	// ListFiles lists the files at the top level of the store, where assets are saved. Entries
+	// under a prefix ("dir/file") are not listed, so other data sharing the bucket is left alone.
+	ListFiles(ctx context.Context) ([]fs.FileInfo, error)
 }
 
 // SignedURLStorage extends Storage with signed URL generation
 type SignedURLStorage interface {
 	Storage
 	GenerateSignedURL(ctx context.Context, objectName string, method string, expiresIn time.Duration) (string, error)
+	// OpenFile reads an object, such as one uploaded directly through a signed URL; the caller
+	// closes it. A missing object is ErrFileNotFound.
+	OpenFile(ctx context.Context, objectName string) (io.ReadCloser, error)
+}
+
+// objectInfo describes a bucket object as an fs.FileInfo
+type objectInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
 }
+
+func (o objectInfo) Name() string       { return o.name }
+func (o objectInfo) Size() int64        { return o.size }
+func (o objectInfo) Mode() fs.FileMode  { return 0o644 }
+func (o objectInfo) ModTime() time.Time { return o.modTime }
+func (o objectInfo) IsDir() bool        { return false }
+func (o objectInfo) Sys() any           { return nil }
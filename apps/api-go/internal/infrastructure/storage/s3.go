@@ -5,14 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"mime"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 // S3Storage implements Storage and SignedURLStorage using S3-compatible storage (MinIO, R2, etc.)
@@ -140,13 +144,61 @@ func (s *S3Storage) DeleteFile(filename string) error {
 	return err
 }
 
-func (s *S3Storage) GenerateSignedURL(ctx context.Context, objectName string, method string, expiresIn time.Duration) (string, error) {
-	request, err := s.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+func (s *S3Storage) ListFiles(ctx context.Context) ([]fs.FileInfo, error) {
+	var files []fs.FileInfo
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(s.bucketName),
+		Delimiter: aws.String("/"),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list bucket: %w", err)
+		}
+		for _, object := range page.Contents {
+			files = append(files, objectInfo{
+				name:    aws.ToString(object.Key),
+				size:    aws.ToInt64(object.Size),
+				modTime: aws.ToTime(object.LastModified),
+			})
+		}
+	}
+	return files, nil
+}
+
+func (s *S3Storage) OpenFile(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(s.bucketName),
 		Key:    aws.String(objectName),
-	}, func(opts *s3.PresignOptions) {
-		opts.Expires = expiresIn
 	})
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, ErrFileNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	return output.Body, nil
+}
+
+func (s *S3Storage) GenerateSignedURL(ctx context.Context, objectName string, method string, expiresIn time.Duration) (string, error) {
+	expires := func(opts *s3.PresignOptions) {
+		opts.Expires = expiresIn
+	}
+	var request *v4.PresignedHTTPRequest
+	var err error
+	if method == http.MethodPut {
+		// Direct uploads from the browser
+		request, err = s.presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(s.bucketName),
+			Key:    aws.String(objectName),
+		}, expires)
+	} else {
+		request, err = s.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(s.bucketName),
+			Key:    aws.String(objectName),
+		}, expires)
+	}
 
 	if err != nil {
 		return "", fmt.Errorf("failed to generate presigned URL: %w", err)
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	// webpFlagEXIF and webpFlagXMP are the VP8X header flags announcing the metadata chunks
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+var errMalformedWebP = errors.New("malformed WebP")
+
+// stripWebPMetadata returns a WebP without its EXIF and XMP chunks, which carry GPS coordinates
+// and camera details, and with their VP8X flags cleared. The image data is copied as is, so
+// animations and the color profile are kept.
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || !bytes.HasPrefix(data, []byte("RIFF")) || !bytes.Equal(data[8:12], []byte("WEBP")) {
+		return nil, errMalformedWebP
+	}
+	// The RIFF size counts from the form type; trailing bytes past it are not part of the image
+	riffEnd := 8 + uint64(binary.LittleEndian.Uint32(data[4:8]))
+	if riffEnd > uint64(len(data)) {
+		return nil, errMalformedWebP
+	}
+
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+	for pos := uint64(12); pos < riffEnd; {
+		if pos+8 > riffEnd {
+			return nil, errMalformedWebP
+		}
+		fourCC := string(data[pos : pos+4])
+		size := uint64(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		end := pos + 8 + size + size%2 // Chunks are padded to an even size
+		if end > riffEnd {
+			return nil, errMalformedWebP
+		}
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			start := len(out)
+			out = append(out, data[pos:end]...)
+			if size > 0 {
+				out[start+8] &^= webpFlagEXIF | webpFlagXMP
+			}
+		default:
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out, nil
+}
@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testWebPLossless is a 1x1 lossless WebP with only its VP8L chunk
+const testWebPLossless = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+// riffChunk encodes a RIFF chunk, padded to an even size
+func riffChunk(fourCC string, payload []byte) []byte {
+	chunk := append([]byte(fourCC), binary.LittleEndian.AppendUint32(nil, uint32(len(payload)))...)
+	chunk = append(chunk, payload...)
+	if len(payload)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+// riffWebP wraps chunks in a RIFF WEBP header
+func riffWebP(chunks ...[]byte) []byte {
+	body := []byte("WEBP")
+	for _, chunk := range chunks {
+		body = append(body, chunk...)
+	}
+	return append(append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...), body...)
+}
+
+// extendedTestWebP returns the 1x1 test WebP in the extended format with EXIF (odd sized, so
+// padded) and XMP chunks announced in its VP8X flags
+func extendedTestWebP(t *testing.T) []byte {
+	t.Helper()
+	simple, err := base64.StdEncoding.DecodeString(testWebPLossless)
+	require.NoError(t, err)
+	vp8l := simple[12:]
+	vp8x := make([]byte, 10) // Flags, reserved, canvas width and height minus one
+	vp8x[0] = webpFlagEXIF | webpFlagXMP
+	exif := append(append([]byte(nil), buildTestExif(binary.LittleEndian, 6, testCaptureDate)...), 'x')
+	xmp := []byte(`<x:xmpmeta><exif:GPSLatitude>48,51.5N</exif:GPSLatitude></x:xmpmeta>`)
+	return riffWebP(riffChunk("VP8X", vp8x), vp8l, riffChunk("EXIF", exif), riffChunk("XMP ", xmp))
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	// Arrange
+	data := extendedTestWebP(t)
+	require.True(t, bytes.Contains(data, []byte(testGPSMethod)))
+
+	// Act
+	stripped, err := stripWebPMetadata(data)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(stripped, []byte("EXIF")))
+	assert.False(t, bytes.Contains(stripped, []byte("XMP ")))
+	assert.False(t, bytes.Contains(stripped, []byte(testGPSMethod)))
+	assert.False(t, bytes.Contains(stripped, []byte("GPSLatitude")))
+	assert.Equal(t, uint32(len(stripped)-8), binary.LittleEndian.Uint32(stripped[4:8]), "RIFF size")
+	assert.Equal(t, []byte("VP8X"), stripped[12:16])
+	assert.Zero(t, stripped[20]&(webpFlagEXIF|webpFlagXMP), "Metadata flags are cleared")
+
+	img, format, err := image.Decode(bytes.NewReader(stripped))
+	require.NoError(t, err)
+	assert.Equal(t, "webp", format)
+	assert.Equal(t, image.Rect(0, 0, 1, 1), img.Bounds())
+}
+
+func TestStripWebPMetadata_KeepsSimpleWebP(t *testing.T) {
+	data, err := base64.StdEncoding.DecodeString(testWebPLossless)
+	require.NoError(t, err)
+
+	stripped, err := stripWebPMetadata(data)
+
+	require.NoError(t, err)
+	assert.Equal(t, data, stripped)
+}
+
+func TestStripWebPMetadata_RejectsMalformed(t *testing.T) {
+	valid := func(t *testing.T) []byte { return extendedTestWebP(t) }
+	tests := []struct {
+		name   string
+		mutate func(data []byte) []byte
+	}{
+		{name: "not_riff", mutate: func(data []byte) []byte { return append([]byte("RIFX"), data[4:]...) }},
+		{name: "not_webp", mutate: func(data []byte) []byte { copy(data[8:12], "WAVE"); return data }},
+		{name: "too_short", mutate: func(data []byte) []byte { return data[:10] }},
+		{
+			name: "riff_size_past_end",
+			mutate: func(data []byte) []byte {
+				binary.LittleEndian.PutUint32(data[4:8], uint32(len(data)))
+				return data
+			},
+		},
+		{
+			name: "chunk_size_past_end",
+			mutate: func(data []byte) []byte {
+				binary.LittleEndian.PutUint32(data[16:20], 0xFFFFFFF0)
+				return data
+			},
+		},
+		{
+			name: "truncated_chunk_header",
+			mutate: func(data []byte) []byte {
+				data = append(data, 'E', 'X')
+				binary.LittleEndian.PutUint32(data[4:8], uint32(len(data)-8))
+				return data
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := stripWebPMetadata(tt.mutate(valid(t)))
+
+			assert.ErrorIs(t, err, errMalformedWebP)
+		})
+	}
+}
+
+func TestImageProcessor_ProcessImage_StripsWebPMetadata(t *testing.T) {
+	processor := NewImageProcessor(1920, 1920, 85)
+
+	processed, err := processor.ProcessImage(extendedTestWebP(t), "image/webp", ProcessOptions{KeepCaptureDate: true})
+
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(processed.Data, []byte(testGPSMethod)))
+	assert.Zero(t, processed.Width)
+	assert.Empty(t, processed.Variants)
+
+	_, err = processor.ProcessImage([]byte("RIFF\x00\x00\x00\x00WEBP"), "image/webp", ProcessOptions{})
+	require.NoError(t, err, "An empty RIFF body has nothing to strip")
+	_, err = processor.ProcessImage([]byte("RIFF\xff\x00\x00\x00WEBP"), "image/webp", ProcessOptions{})
+	assert.Error(t, err)
+}
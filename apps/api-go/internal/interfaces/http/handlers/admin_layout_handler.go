@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// AdminLayoutHandler manages the layout catalog. Its routes sit behind RequireAdmin.
+type AdminLayoutHandler struct {
+	listUC      *layout.ListLayoutsForAdminUseCase
+	createUC    *layout.CreateLayoutUseCase
+	updateUC    *layout.UpdateLayoutUseCase
+	setActiveUC *layout.SetLayoutActiveUseCase
+	retireUC    *layout.RetireLayoutUseCase
+	auditUC     *layout.ListLayoutAuditUseCase
+}
+
+func NewAdminLayoutHandler(
+	listUC *layout.ListLayoutsForAdminUseCase,
+	createUC *layout.CreateLayoutUseCase,
+	updateUC *layout.UpdateLayoutUseCase,
+	setActiveUC *layout.SetLayoutActiveUseCase,
+	retireUC *layout.RetireLayoutUseCase,
+	auditUC *layout.ListLayoutAuditUseCase,
+) *AdminLayoutHandler {
+	return &AdminLayoutHandler{
+		listUC:      listUC,
+		createUC:    createUC,
+		updateUC:    updateUC,
+		setActiveUC: setActiveUC,
+		retireUC:    retireUC,
+		auditUC:     auditUC,
+	}
+}
+
+type CreateLayoutRequest struct {
+	ID           string           `json:"id" binding:"required" example:"garden-party"`
+	Name         string           `json:"name" binding:"required" example:"Garden Party"`
+	Description  *string          `json:"description" example:"Soft florals for daytime weddings"`
+	PreviewImage *string          `json:"previewImage" example:"/layouts/garden-party/preview.jpg"`
+	Tags         []string         `json:"tags" example:"floral,daytime"`
+	Version      string           `json:"version" example:"1.0.0"`
+	Config       *json.RawMessage `json:"config" swaggertype:"object"`
+	Manifest     *json.RawMessage `json:"manifest" swaggertype:"object"`
+	// IsActive lists the layout right away; otherwise it is created inactive
+	IsActive bool `json:"isActive" example:"false"`
+}
+
+// UpdateLayoutRequest changes only the fields that are present
+type UpdateLayoutRequest struct {
+	Name         *string          `json:"name" example:"Garden Party"`
+	Description  *string          `json:"description" example:"Soft florals for daytime weddings"`
+	PreviewImage *string          `json:"previewImage" example:"/layouts/garden-party/preview.jpg"`
+	Tags         *[]string        `json:"tags" example:"floral,daytime"`
+	Version      *string          `json:"version" example:"1.1.0"`
+	Config       *json.RawMessage `json:"config" swaggertype:"object"`
+	Manifest     *json.RawMessage `json:"manifest" swaggertype:"object"`
+}
+
+type AdminLayoutDTO struct {
+	ID           string           `json:"id" example:"garden-party"`
+	Name         string           `json:"name" example:"Garden Party"`
+	Description  *string          `json:"description,omitempty" example:"Soft florals for daytime weddings"`
+	PreviewImage *string          `json:"previewImage,omitempty" example:"/layouts/garden-party/preview.jpg"`
+	Tags         []string         `json:"tags" example:"floral,daytime"`
+	Version      string           `json:"version" example:"1.0.0"`
+	Config       *json.RawMessage `json:"config,omitempty" swaggertype:"object"`
+	Manifest     *json.RawMessage `json:"manifest,omitempty" swaggertype:"object"`
+	IsActive     bool             `json:"isActive" example:"true"`
+	RetiredAt    string           `json:"retiredAt,omitempty" example:"2024-06-01T00:00:00Z"`
+	CreatedAt    string           `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt    string           `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type AdminLayoutResponse struct {
+	Layout *AdminLayoutDTO `json:"layout"`
+	// Changes lists the fields an update changed
+	Changes []string `json:"changes,omitempty" example:"manifest,version"`
+}
+
+type AdminLayoutsResponse struct {
+	Layouts []AdminLayoutDTO `json:"layouts"`
+}
+
+type AuditEntryDTO struct {
+	ID           string   `json:"id" example:"2bZ9c1kV8dPqL3nR7tYw0xJ5mHs"`
+	ActorID      string   `json:"actorId" example:"user123"`
+	Action       string   `json:"action" example:"layout.update"`
+	ResourceType string   `json:"resourceType" example:"layout"`
+	ResourceID   string   `json:"resourceId" example:"garden-party"`
+	Changes      []string `json:"changes" example:"manifest"`
+	CreatedAt    string   `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type AuditEntriesResponse struct {
+	Entries    []AuditEntryDTO `json:"entries"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+}
+
+func toAdminLayoutDTO(dto *layout.LayoutAdminDTO) *AdminLayoutDTO {
+	out := &AdminLayoutDTO{
+		ID:           dto.ID,
+		Name:         dto.Name,
+		Description:  dto.Description,
+		PreviewImage: dto.PreviewImage,
+		Tags:         dto.Tags,
+		Version:      dto.Version,
+		Config:       dto.Config,
+		Manifest:     dto.Manifest,
+		IsActive:     dto.IsActive,
+		CreatedAt:    dto.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    dto.UpdatedAt.Format(time.RFC3339),
+	}
+	if dto.RetiredAt != nil {
+		out.RetiredAt = dto.RetiredAt.Format(time.RFC3339)
+	}
+	return out
+}
+
+// GetAll lists every layout, including inactive and retired ones
+// @Summary      List layouts (admin)
+// @Description  List every layout with its config and manifest, including inactive and retired layouts. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  AdminLayoutsResponse  "Layouts"
+// @Failure      401  {object}  ErrorResponse         "Authentication required"
+// @Failure      403  {object}  ErrorResponse         "Admin access required"
+// @Router       /admin/layouts [get]
+func (h *AdminLayoutHandler) GetAll(c *gin.Context) {
+	output, err := h.listUC.Execute(c.Request.Context())
+	if err != nil {
+		writeAdminLayoutError(c, err, "Failed to get layouts")
+		return
+	}
+
+	layouts := make([]AdminLayoutDTO, len(output.Layouts))
+	for i, dto := range output.Layouts {
+		layouts[i] = *toAdminLayoutDTO(dto)
+	}
+	c.JSON(http.StatusOK, AdminLayoutsResponse{Layouts: layouts})
+}
+
+// Create adds a layout to the catalog
+// @Summary      Create layout (admin)
+// @Description  Add a layout and its manifest. The manifest must be an object whose id (if set) matches the layout; its dataSchema must compile and its migrations must parse. New layouts are inactive unless isActive is set. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      CreateLayoutRequest  true  "Layout to create"
+// @Success      201      {object}  AdminLayoutResponse  "Layout created"
+// @Failure      400      {object}  ErrorResponse        "Invalid layout or manifest; details point at the offending fields"
+// @Failure      401      {object}  ErrorResponse        "Authentication required"
+// @Failure      403      {object}  ErrorResponse        "Admin access required"
+// @Failure      409      {object}  ErrorResponse        "Layout already exists"
+// @Router       /admin/layouts [post]
+func (h *AdminLayoutHandler) Create(c *gin.Context) {
+	var req CreateLayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	output, err := h.createUC.Execute(c.Request.Context(), layout.CreateLayoutInput{
+		ID:           req.ID,
+		Name:         req.Name,
+		Description:  req.Description,
+		PreviewImage: req.PreviewImage,
+		Tags:         req.Tags,
+		Version:      req.Version,
+		Config:       req.Config,
+		Manifest:     req.Manifest,
+		IsActive:     req.IsActive,
+		ActorID:      c.GetString("userID"),
+	})
+	if err != nil {
+		writeAdminLayoutError(c, err, "Failed to create layout")
+		return
+	}
+
+	c.JSON(http.StatusCreated, AdminLayoutResponse{Layout: toAdminLayoutDTO(output.Layout)})
+}
+
+// Update changes a layout's metadata, config or manifest
+// @Summary      Update layout (admin)
+// @Description  Change the fields present in the request. The manifest is checked as on create. Use activate, deactivate and retire to change availability. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string               true  "Layout ID"
+// @Param        request  body      UpdateLayoutRequest  true  "Fields to change"
+// @Success      200      {object}  AdminLayoutResponse  "Layout updated"
+// @Failure      400      {object}  ErrorResponse        "Invalid layout or manifest; details point at the offending fields"
+// @Failure      401      {object}  ErrorResponse        "Authentication required"
+// @Failure      403      {object}  ErrorResponse        "Admin access required"
+// @Failure      404      {object}  ErrorResponse        "Layout not found"
+// @Router       /admin/layouts/{id} [put]
+func (h *AdminLayoutHandler) Update(c *gin.Context) {
+	var req UpdateLayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	output, err := h.updateUC.Execute(c.Request.Context(), layout.UpdateLayoutInput{
+		ID:           c.Param("id"),
+		Name:         req.Name,
+		Description:  req.Description,
+		PreviewImage: req.PreviewImage,
+		Tags:         req.Tags,
+		Version:      req.Version,
+		Config:       req.Config,
+		Manifest:     req.Manifest,
+		ActorID:      c.GetString("userID"),
+	})
+	if err != nil {
+		writeAdminLayoutError(c, err, "Failed to update layout")
+		return
+	}
+
+	c.JSON(http.StatusOK, AdminLayoutResponse{Layout: toAdminLayoutDTO(output.Layout), Changes: output.Changes})
+}
+
+// Activate lists a layout in the catalog
+// @Summary      Activate layout (admin)
+// @Description  Make a layout available for new invitations. Retired layouts cannot be activated. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string               true  "Layout ID"
+// @Success      200  {object}  AdminLayoutResponse  "Layout activated"
+// @Failure      401  {object}  ErrorResponse        "Authentication required"
+// @Failure      403  {object}  ErrorResponse        "Admin access required"
+// @Failure      404  {object}  ErrorResponse        "Layout not found"
+// @Failure      409  {object}  ErrorResponse        "Layout is retired"
+// @Router       /admin/layouts/{id}/activate [post]
+func (h *AdminLayoutHandler) Activate(c *gin.Context) {
+	h.setActive(c, true)
+}
+
+// Deactivate hides a layout from the catalog
+// @Summary      Deactivate layout (admin)
+// @Description  Hide a layout from the catalog and from new invitations. Invitations already using it keep working. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string               true  "Layout ID"
+// @Success      200  {object}  AdminLayoutResponse  "Layout deactivated"
+// @Failure      401  {object}  ErrorResponse        "Authentication required"
+// @Failure      403  {object}  ErrorResponse        "Admin access required"
+// @Failure      404  {object}  ErrorResponse        "Layout not found"
+// @Router       /admin/layouts/{id}/deactivate [post]
+func (h *AdminLayoutHandler) Deactivate(c *gin.Context) {
+	h.setActive(c, false)
+}
+
+func (h *AdminLayoutHandler) setActive(c *gin.Context, active bool) {
+	output, err := h.setActiveUC.Execute(c.Request.Context(), layout.SetLayoutActiveInput{
+		ID:      c.Param("id"),
+		Active:  active,
+		ActorID: c.GetString("userID"),
+	})
+	if err != nil {
+		writeAdminLayoutError(c, err, "Failed to update layout")
+		return
+	}
+
+	c.JSON(http.StatusOK, AdminLayoutResponse{Layout: toAdminLayoutDTO(output.Layout)})
+}
+
+// Retire withdraws a layout for good
+// @Summary      Retire layout (admin)
+// @Description  Withdraw a layout for good. The layout is kept so invitations using it keep rendering, but it cannot be chosen for new invitations or reactivated. Retiring a retired layout changes nothing. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string               true  "Layout ID"
+// @Success      200  {object}  AdminLayoutResponse  "Layout retired"
+// @Failure      401  {object}  ErrorResponse        "Authentication required"
+// @Failure      403  {object}  ErrorResponse        "Admin access required"
+// @Failure      404  {object}  ErrorResponse        "Layout not found"
+// @Router       /admin/layouts/{id} [delete]
+func (h *AdminLayoutHandler) Retire(c *gin.Context) {
+	output, err := h.retireUC.Execute(c.Request.Context(), c.Param("id"), c.GetString("userID"))
+	if err != nil {
+		writeAdminLayoutError(c, err, "Failed to retire layout")
+		return
+	}
+
+	c.JSON(http.StatusOK, AdminLayoutResponse{Layout: toAdminLayoutDTO(output.Layout)})
+}
+
+// GetAudit lists the audit log of a layout
+// @Summary      Layout audit log (admin)
+// @Description  List who changed a layout and how, newest first. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string  true   "Layout ID"
+// @Param        limit    query     int     false  "Page size (1-100, default 50)"
+// @Param        cursor   query     string  false  "nextCursor from the previous page"
+// @Param        sort     query     string  false  "createdAt, prefix - for descending (default -createdAt)"
+// @Param        action   query     string  false  "Only entries with this action, e.g. layout.update"
+// @Param        actorId  query     string  false  "Only entries by this user"
+// @Success      200      {object}  AuditEntriesResponse  "Audit entries"
+// @Failure      400      {object}  ErrorResponse         "Invalid pagination parameters"
+// @Failure      401      {object}  ErrorResponse         "Authentication required"
+// @Failure      403      {object}  ErrorResponse         "Admin access required"
+// @Router       /admin/layouts/{id}/audit [get]
+func (h *AdminLayoutHandler) GetAudit(c *gin.Context) {
+	opts, err := parseListOptions(c, "action", "actorId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := h.auditUC.Execute(c.Request.Context(), c.Param("id"), opts)
+	if err != nil {
+		writeAdminLayoutError(c, err, "Failed to get audit log")
+		return
+	}
+
+	entries := make([]AuditEntryDTO, len(output.Entries))
+	for i, entry := range output.Entries {
+		entries[i] = AuditEntryDTO{
+			ID:           entry.ID,
+			ActorID:      entry.ActorID,
+			Action:       entry.Action,
+			ResourceType: entry.ResourceType,
+			ResourceID:   entry.ResourceID,
+			Changes:      entry.Changes,
+			CreatedAt:    entry.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	c.JSON(http.StatusOK, AuditEntriesResponse{Entries: entries, NextCursor: output.NextCursor})
+}
+
+func writeAdminLayoutError(c *gin.Context, err error, fallback string) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		c.JSON(appErr.Code, appErr.ToResponse())
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/http/middleware"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryUserRepository holds users by ID for RequireAdmin
+type memoryUserRepository struct {
+	users map[string]*domain.User
+}
+
+func (r *memoryUserRepository) Create(ctx context.Context, user *domain.User) error { return nil }
+func (r *memoryUserRepository) FindByID(ctx context.Context, id string) (*domain.User, error) {
+	return r.users[id], nil
+}
+func (r *memoryUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return nil, nil
+}
+func (r *memoryUserRepository) Update(ctx context.Context, user *domain.User) error { return nil }
+func (r *memoryUserRepository) UpdateRole(ctx context.Context, id string, role domain.UserRole) error {
+	return nil
+}
+func (r *memoryUserRepository) Delete(ctx context.Context, id string) error { return nil }
+
+type memoryLayoutRepository struct {
+	layouts map[string]*domain.Layout
+	audit   *memoryAuditRepository
+}
+
+func (r *memoryLayoutRepository) Create(ctx context.Context, l *domain.Layout, audit *domain.AuditEntry) error {
+	r.layouts[l.ID] = l
+	return r.record(ctx, audit)
+}
+func (r *memoryLayoutRepository) FindByID(ctx context.Context, id string) (*domain.Layout, error) {
+	return r.layouts[id], nil
+}
+func (r *memoryLayoutRepository) FindAll(ctx context.Context) ([]*domain.Layout, error) {
+	layouts := []*domain.Layout{}
+	for _, l := range r.layouts {
+		if l.IsActive {
+			layouts = append(layouts, l)
+		}
+	}
+	return layouts, nil
+}
+func (r *memoryLayoutRepository) FindAllIncludingInactive(ctx context.Context) ([]*domain.Layout, error) {
+	layouts := make([]*domain.Layout, 0, len(r.layouts))
+	for _, l := range r.layouts {
+		layouts = append(layouts, l)
+	}
+	return layouts, nil
+}
+func (r *memoryLayoutRepository) FindVersion(ctx context.Context, id, version string) (*domain.Layout, error) {
+	return nil, nil
+}
+func (r *memoryLayoutRepository) Update(ctx context.Context, l *domain.Layout, audit *domain.AuditEntry) error {
+	r.layouts[l.ID] = l
+	return r.record(ctx, audit)
+}
+
+func (r *memoryLayoutRepository) record(ctx context.Context, audit *domain.AuditEntry) error {
+	if audit == nil || r.audit == nil {
+		return nil
+	}
+	return r.audit.Create(ctx, audit)
+}
+func (r *memoryLayoutRepository) Delete(ctx context.Context, id string) error {
+	delete(r.layouts, id)
+	return nil
+}
+
+type memoryAuditRepository struct {
+	entries []*domain.AuditEntry
+}
+
+func (r *memoryAuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+func (r *memoryAuditRepository) FindByResource(ctx context.Context, resourceType, resourceID string, opts repository.ListOptions) (*repository.Page[*domain.AuditEntry], error) {
+	page := &repository.Page[*domain.AuditEntry]{}
+	for _, entry := range r.entries {
+		if entry.ResourceType == resourceType && entry.ResourceID == resourceID {
+			page.Items = append(page.Items, entry)
+		}
+	}
+	return page, nil
+}
+
+func newAdminLayoutEngine(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	auditRepo := &memoryAuditRepository{}
+	layoutRepo := &memoryLayoutRepository{layouts: map[string]*domain.Layout{}, audit: auditRepo}
+	audit := layout.NewAuditRecorder()
+	handler := NewAdminLayoutHandler(
+		layout.NewListLayoutsForAdminUseCase(layoutRepo),
+		layout.NewCreateLayoutUseCase(layoutRepo, audit, nil),
+		layout.NewUpdateLayoutUseCase(layoutRepo, audit, nil),
+		layout.NewSetLayoutActiveUseCase(layoutRepo, audit, nil),
+		layout.NewRetireLayoutUseCase(layoutRepo, audit, nil),
+		layout.NewListLayoutAuditUseCase(auditRepo),
+	)
+	userRepo := &memoryUserRepository{users: map[string]*domain.User{
+		"admin-1": {ID: "admin-1", Role: domain.UserRoleAdmin},
+		"user-1":  {ID: "user-1", Role: domain.UserRoleUser},
+	}}
+
+	engine := gin.New()
+	// Stands in for AuthenticateToken
+	authenticate := func(c *gin.Context) {
+		if userID := c.GetHeader("X-Test-User"); userID != "" {
+			c.Set("userID", userID)
+		}
+	}
+	admin := engine.Group("/admin/layouts", authenticate, middleware.RequireAdmin(userRepo))
+	admin.GET("", handler.GetAll)
+	admin.POST("", handler.Create)
+	admin.PUT("/:id", handler.Update)
+	admin.POST("/:id/activate", handler.Activate)
+	admin.POST("/:id/deactivate", handler.Deactivate)
+	admin.DELETE("/:id", handler.Retire)
+	admin.GET("/:id/audit", handler.GetAudit)
+	return engine
+}
+
+func serveAdmin(engine *gin.Engine, method, path, userID, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		req.Header.Set("X-Test-User", userID)
+	}
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestAdminLayoutHandler_RequiresAdmin(t *testing.T) {
+	engine := newAdminLayoutEngine(t)
+
+	tests := []struct {
+		name     string
+		userID   string
+		wantCode int
+	}{
+		{name: "anonymous", wantCode: http.StatusUnauthorized},
+		{name: "regular user", userID: "user-1", wantCode: http.StatusForbidden},
+		{name: "unknown user", userID: "ghost", wantCode: http.StatusForbidden},
+		{name: "admin", userID: "admin-1", wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveAdmin(engine, http.MethodGet, "/admin/layouts", tt.userID, "")
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestAdminLayoutHandler_Lifecycle_IsAudited(t *testing.T) {
+	engine := newAdminLayoutEngine(t)
+
+	w := serveAdmin(engine, http.MethodPost, "/admin/layouts", "admin-1",
+		`{"id":"garden-party","name":"Garden Party","version":"1.0.0","manifest":{"id":"garden-party","sections":[]}}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = serveAdmin(engine, http.MethodPut, "/admin/layouts/garden-party", "admin-1", `{"version":"1.1.0"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveAdmin(engine, http.MethodPost, "/admin/layouts/garden-party/activate", "admin-1", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveAdmin(engine, http.MethodDelete, "/admin/layouts/garden-party", "admin-1", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var retired AdminLayoutResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &retired))
+	assert.False(t, retired.Layout.IsActive)
+	assert.NotEmpty(t, retired.Layout.RetiredAt)
+
+	w = serveAdmin(engine, http.MethodPost, "/admin/layouts/garden-party/activate", "admin-1", "")
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = serveAdmin(engine, http.MethodGet, "/admin/layouts/garden-party/audit", "admin-1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var audit AuditEntriesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &audit))
+	actions := make([]string, len(audit.Entries))
+	for i, entry := range audit.Entries {
+		actions[i] = entry.Action
+		assert.Equal(t, "admin-1", entry.ActorID)
+	}
+	assert.Equal(t, []string{"layout.create", "layout.update", "layout.activate", "layout.retire"}, actions)
+}
+
+func TestAdminLayoutHandler_Create_InvalidManifest_ReturnsDetails(t *testing.T) {
+	engine := newAdminLayoutEngine(t)
+
+	w := serveAdmin(engine, http.MethodPost, "/admin/layouts", "admin-1",
+		`{"id":"garden-party","name":"Garden Party","manifest":{"id":"other"}}`)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Details, 1)
+	assert.Equal(t, "/manifest/id", resp.Details[0].Field)
+}
@@ -48,6 +48,7 @@ type AnalyticsResponse struct {
 	Views        int            `json:"views" example:"100"`
 	RSVPs        int            `json:"rsvps" example:"25"`
 	Analytics    []AnalyticsDTO `json:"analytics"`
+	NextCursor   string         `json:"nextCursor,omitempty"`
 }
 
 // TrackView tracks an invitation view
@@ -94,13 +95,24 @@ func (h *AnalyticsHandler) TrackView(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        invitationId  path      string              true  "Invitation ID"
+// @Param        limit         query     int                 false "Page size of events (1-100, default 50)"
+// @Param        cursor        query     string              false "nextCursor from the previous page"
+// @Param        sort          query     string              false "timestamp, prefix - for descending (default -timestamp)"
+// @Param        type          query     string              false "Only events of this type (view, rsvp)"
 // @Success      200           {object}  AnalyticsResponse   "Analytics data"
+// @Failure      400           {object}  ErrorResponse       "Invalid pagination parameters"
 // @Failure      404           {object}  ErrorResponse       "Invitation not found"
 // @Failure      500           {object}  ErrorResponse       "Internal server error"
 // @Router       /analytics/{invitationId} [get]
 func (h *AnalyticsHandler) GetByInvitation(c *gin.Context) {
 	invitationID := c.Param("invitationId")
-	output, err := h.getByInvitationUC.Execute(c.Request.Context(), invitationID)
+	opts, err := parseListOptions(c, "type")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := h.getByInvitationUC.Execute(c.Request.Context(), invitationID, opts)
 	if err != nil {
 		appErr, ok := err.(*errors.AppError)
 		if ok {
@@ -111,10 +123,10 @@ func (h *AnalyticsHandler) GetByInvitation(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusOK, withNextCursor(gin.H{
 		"invitationId": output.InvitationID,
 		"views":        output.Views,
 		"rsvps":        output.RSVPs,
 		"analytics":    output.Analytics,
-	})
+	}, output.NextCursor))
 }
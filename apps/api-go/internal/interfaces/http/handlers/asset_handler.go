@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"bytes"
+	stderrors "errors"
+	"io"
 	"net/http"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,36 +19,48 @@ import (
 type AssetHandler struct {
 	uploadUC            *asset.UploadAssetUseCase
 	getAllUC            *asset.GetAllAssetsUseCase
-	deleteUC            *asset.DeleteAssetUseCase
+	deleteUC            *asset.DeleteAssetUseCase // Only rolls back records whose upload failed
+	trashUC             *asset.TrashAssetUseCase
+	listTrashUC         *asset.ListTrashedAssetsUseCase
+	restoreUC           *asset.RestoreAssetUseCase
 	deleteByURLsUC      *asset.DeleteAssetsByURLsUseCase
 	getByURLsUC         *asset.GetAssetsByURLsUseCase
 	fileStorage         storage.Storage
 	gcsStorage          storage.SignedURLStorage // Optional, for signed URL generation
 	signedURLExpiration time.Duration
 	imageProcessor      *storage.ImageProcessor // Optional, for image optimization
+	maxFileSize         int64                   // Bounds reads of directly uploaded objects
 }
 
 func NewAssetHandler(
 	uploadUC *asset.UploadAssetUseCase,
 	getAllUC *asset.GetAllAssetsUseCase,
 	deleteUC *asset.DeleteAssetUseCase,
+	trashUC *asset.TrashAssetUseCase,
+	listTrashUC *asset.ListTrashedAssetsUseCase,
+	restoreUC *asset.RestoreAssetUseCase,
 	deleteByURLsUC *asset.DeleteAssetsByURLsUseCase,
 	getByURLsUC *asset.GetAssetsByURLsUseCase,
 	fileStorage storage.Storage,
 	gcsStorage storage.SignedURLStorage, // Optional, can be nil
 	signedURLExpiration time.Duration,
 	imageProcessor *storage.ImageProcessor, // Optional, can be nil
+	maxFileSize int64,
 ) *AssetHandler {
 	return &AssetHandler{
 		uploadUC:            uploadUC,
 		getAllUC:            getAllUC,
 		deleteUC:            deleteUC,
+		trashUC:             trashUC,
+		listTrashUC:         listTrashUC,
+		restoreUC:           restoreUC,
 		deleteByURLsUC:      deleteByURLsUC,
 		getByURLsUC:         getByURLsUC,
 		fileStorage:         fileStorage,
 		gcsStorage:          gcsStorage,
 		signedURLExpiration: signedURLExpiration,
 		imageProcessor:      imageProcessor,
+		maxFileSize:         maxFileSize,
 	}
 }
 
@@ -61,7 +76,24 @@ type AssetDTO struct {
 	Size         int64  `json:"size" example:"1024000"`
 	MimeType     string `json:"mimetype" example:"image/jpeg"`
 	UserID       string `json:"userId" example:"user123"`
-	CreatedAt    string `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	// Width, Height and Variants are only set for processed images
+	Width     int               `json:"width,omitempty" example:"4000"`
+	Height    int               `json:"height,omitempty" example:"3000"`
+	Variants  []AssetVariantDTO `json:"variants,omitempty"`
+	CreatedAt string            `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	// DeletedAt and PurgeAt are only set for assets in the trash
+	DeletedAt *string `json:"deletedAt,omitempty" example:"2024-01-01T00:00:00Z"`
+	PurgeAt   *string `json:"purgeAt,omitempty" example:"2024-01-31T00:00:00Z"`
+}
+
+// AssetVariantDTO is a downscaled copy of an image asset, for srcset
+type AssetVariantDTO struct {
+	Name     string `json:"name" example:"medium"`
+	Filename string `json:"filename" example:"abc123_medium.jpg"`
+	URL      string `json:"url" example:"/uploads/abc123_medium.jpg"`
+	Width    int    `json:"width" example:"1200"`
+	Height   int    `json:"height" example:"900"`
+	Size     int64  `json:"size" example:"180000"`
 }
 
 type UploadAssetResponse struct {
@@ -69,8 +101,14 @@ type UploadAssetResponse struct {
 	Asset *AssetDTO `json:"asset"`
 }
 
+type AssetResponse struct {
+	Message string    `json:"message" example:"Asset restored"`
+	Asset   *AssetDTO `json:"asset"`
+}
+
 type AssetsResponse struct {
-	Assets []AssetDTO `json:"assets"`
+	Assets     []AssetDTO `json:"assets"`
+	NextCursor string     `json:"nextCursor,omitempty"`
 }
 
 // Upload uploads a new asset file
@@ -80,7 +118,8 @@ type AssetsResponse struct {
 // @Accept       multipart/form-data
 // @Produce      json
 // @Security     BearerAuth
-// @Param        image  formData  file    true  "Image file to upload"
+// @Param        image            formData  file    true   "Image file to upload"
+// @Param        keepCaptureDate  formData  bool    false  "Keep the date the photo was taken; all other metadata is removed"
 // @Success      200    {object}  UploadAssetResponse  "Asset uploaded successfully"
 // @Failure      400    {object}  ErrorResponse       "Invalid file or request"
 // @Failure      401    {object}  ErrorResponse       "Authentication required"
@@ -94,8 +133,15 @@ func (h *AssetHandler) Upload(c *gin.Context) {
 		return
 	}
 
+	// Never parse more of the request than the largest allowed file and its form encoding
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxFileSize+multipartOverhead)
 	file, err := c.FormFile("image")
 	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errUploadTooLarge.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
 		return
 	}
@@ -108,80 +154,147 @@ func (h *AssetHandler) Upload(c *gin.Context) {
 	}
 	defer src.Close()
 
-	// Read file content into buffer (max 10MB, acceptable for images)
-	fileContent := make([]byte, file.Size)
-	_, err = src.Read(fileContent)
+	fileContent, err := readUpload(src, h.maxFileSize)
 	if err != nil {
+		if stderrors.Is(err, errUploadTooLarge) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
 		return
 	}
 
-	// Validate file
-	mimeType := file.Header.Get("Content-Type")
-	if err := h.fileStorage.ValidateFile(mimeType, file.Size); err != nil {
+	// Validate file by its content; the declared Content-Type must agree with it
+	mimeType, err := storage.CheckImageContent(fileContent, file.Header.Get("Content-Type"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := h.fileStorage.ValidateFile(mimeType, int64(len(fileContent))); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	opts := storage.ProcessOptions{KeepCaptureDate: c.PostForm("keepCaptureDate") == "true"}
+	h.storeAsset(c, userID.(string), "", file.Filename, mimeType, fileContent, opts)
+}
 
-	// Process image if processor is available
-	processedContent := bytes.NewReader(fileContent)
-	finalSize := file.Size
+// multipartOverhead is the room left in an upload request for the multipart boundaries and headers
+const multipartOverhead = 64 << 10
+
+var errUploadTooLarge = stderrors.New("file size exceeds limit")
+
+// readUpload reads a whole upload, failing with errUploadTooLarge past limit bytes. Unlike a
+// single Read, it does not stop short and does not trust the declared size.
+func readUpload(r io.Reader, limit int64) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > limit {
+		return nil, errUploadTooLarge
+	}
+	return content, nil
+}
+
+// storeAsset processes an uploaded image, creates its record under filename (empty generates
+// one), saves the files and responds with the asset. It reports whether the asset was stored.
+func (h *AssetHandler) storeAsset(c *gin.Context, userID, filename, originalName, mimeType string, content []byte, opts storage.ProcessOptions) bool {
+	// Process image if processor is available: turn it upright, strip its metadata, downscale it
+	// and generate the srcset variants
+	processed := &storage.ProcessedImage{Data: content}
 	if h.imageProcessor != nil {
-		processedReader, newSize, err := h.imageProcessor.ProcessImage(bytes.NewReader(fileContent), mimeType, file.Size)
-		if err == nil && newSize > 0 {
-			processedContent = processedReader.(*bytes.Reader)
-			finalSize = newSize
+		result, err := h.imageProcessor.ProcessImage(content, mimeType, opts)
+		if err != nil {
+			// The original cannot be stored instead: its metadata (GPS location) would be kept
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid image"})
+			return false
+		}
+		processed = result
+	}
+	variants := make([]asset.UploadVariantInput, len(processed.Variants))
+	for i, variant := range processed.Variants {
+		variants[i] = asset.UploadVariantInput{
+			Name:   variant.Name,
+			Width:  variant.Width,
+			Height: variant.Height,
+			Size:   int64(len(variant.Data)),
 		}
-		// If processing fails, use original content
 	}
 
 	// Generate filename and create asset record first
 	output, err := h.uploadUC.Execute(c.Request.Context(), asset.UploadAssetInput{
-		Filename:     "", // Will be generated by use case
-		OriginalName: file.Filename,
-		Size:         finalSize,
+		Filename:     filename, // Generated by use case when empty
+		OriginalName: originalName,
+		Size:         int64(len(processed.Data)),
 		MimeType:     mimeType,
-		UserID:       userID.(string),
+		UserID:       userID,
+		Width:        processed.Width,
+		Height:       processed.Height,
+		Variants:     variants,
 	})
 
 	if err != nil {
 		appErr, ok := err.(*errors.AppError)
 		if ok {
 			c.JSON(appErr.Code, appErr.ToResponse())
-			return
+			return false
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Upload failed"})
-		return
+		return false
 	}
 
-	// Reset reader to beginning
-	processedContent.Seek(0, 0)
-
-	// Save file to storage using the filename from use case and the processed content
-	_, err = h.fileStorage.SaveFile(output.Filename, file.Filename, mimeType, finalSize, processedContent)
-	if err != nil {
+	// Save the files to storage using the filenames from use case and the processed content
+	if err := h.saveAssetFiles(output, originalName, mimeType, processed); err != nil {
 		// Clean up database record
 		h.deleteUC.Execute(c.Request.Context(), output.URL)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Upload failed"})
-		return
+		return false
 	}
 
-	// Generate signed URL for GCS or S3 assets if using signed URL storage
-	assetURL := output.URL
-	if h.gcsStorage != nil {
-		// For GCS/S3, use the filename (object key) to generate signed URL
-		signedURL, err := h.gcsStorage.GenerateSignedURL(c.Request.Context(), output.Filename, "GET", h.signedURLExpiration)
-		if err == nil {
-			assetURL = signedURL
-			// Update the asset DTO with the signed URL
-			output.Asset.URL = signedURL
-		}
-	}
+	// Generate signed URLs for GCS or S3 assets if using signed URL storage
+	h.signAssetURLs(c, output.Asset)
 
 	c.JSON(http.StatusOK, gin.H{
-		"url":   assetURL,
+		"url":   output.Asset.URL,
 		"asset": output.Asset,
 	})
+	return true
+}
+
+// saveAssetFiles stores the processed image and its variants. When one fails, the files saved
+// before it are deleted.
+func (h *AssetHandler) saveAssetFiles(output *asset.UploadAssetOutput, originalName, mimeType string, processed *storage.ProcessedImage) error {
+	if _, err := h.fileStorage.SaveFile(output.Filename, originalName, mimeType, int64(len(processed.Data)), bytes.NewReader(processed.Data)); err != nil {
+		return err
+	}
+	for i, variant := range processed.Variants {
+		filename := output.Asset.Variants[i].Filename
+		if _, err := h.fileStorage.SaveFile(filename, originalName, mimeType, int64(len(variant.Data)), bytes.NewReader(variant.Data)); err != nil {
+			h.fileStorage.DeleteFile(output.Filename)
+			for _, saved := range output.Asset.Variants[:i] {
+				h.fileStorage.DeleteFile(saved.Filename)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// signAssetURLs replaces the URLs of the asset and its variants with signed URLs when the
+// storage is private (GCS or S3); the object keys are the filenames
+func (h *AssetHandler) signAssetURLs(c *gin.Context, dto *asset.AssetDTO) {
+	if h.gcsStorage == nil {
+		return
+	}
+	if signedURL, err := h.gcsStorage.GenerateSignedURL(c.Request.Context(), dto.Filename, "GET", h.signedURLExpiration); err == nil {
+		dto.URL = signedURL
+	}
+	for i := range dto.Variants {
+		if signedURL, err := h.gcsStorage.GenerateSignedURL(c.Request.Context(), dto.Variants[i].Filename, "GET", h.signedURLExpiration); err == nil {
+			dto.Variants[i].URL = signedURL
+		}
+	}
 }
 
 // GetAll retrieves all assets for the current user
@@ -191,7 +304,12 @@ func (h *AssetHandler) Upload(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        limit     query     int     false  "Page size (1-100, default 50)"
+// @Param        cursor    query     string  false  "nextCursor from the previous page"
+// @Param        sort      query     string  false  "createdAt or size, prefix - for descending (default -createdAt)"
+// @Param        mimeType  query     string  false  "Only assets of this MIME type"
 // @Success      200  {object}  AssetsResponse   "List of assets"
+// @Failure      400  {object}  ErrorResponse   "Invalid pagination parameters"
 // @Failure      401  {object}  ErrorResponse   "Authentication required"
 // @Failure      500  {object}  ErrorResponse   "Internal server error"
 // @Router       /assets [get]
@@ -203,7 +321,13 @@ func (h *AssetHandler) GetAll(c *gin.Context) {
 		return
 	}
 
-	output, err := h.getAllUC.Execute(c.Request.Context(), userID.(string))
+	opts, err := parseListOptions(c, "mimeType")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := h.getAllUC.Execute(c.Request.Context(), userID.(string), opts)
 	if err != nil {
 		appErr, ok := err.(*errors.AppError)
 		if ok {
@@ -215,31 +339,25 @@ func (h *AssetHandler) GetAll(c *gin.Context) {
 	}
 
 	// Generate signed URLs for GCS/S3 assets if using signed URL storage
-	if h.gcsStorage != nil {
-		for i := range output.Assets {
-			// For GCS/S3, use the filename to generate signed URL
-			filename := output.Assets[i].Filename
-			signedURL, err := h.gcsStorage.GenerateSignedURL(c.Request.Context(), filename, "GET", h.signedURLExpiration)
-			if err == nil {
-				output.Assets[i].URL = signedURL
-			}
-		}
+	for _, dto := range output.Assets {
+		h.signAssetURLs(c, dto)
 	}
 
-	c.JSON(http.StatusOK, gin.H{"assets": output.Assets})
+	c.JSON(http.StatusOK, withNextCursor(gin.H{"assets": output.Assets}, output.NextCursor))
 }
 
-// Delete deletes an asset
+// Delete moves an asset to the trash
 // @Summary      Delete asset
-// @Description  Delete an asset by URL. Authentication is required.
+// @Description  Move one of the caller's assets to the trash by URL. The file is kept until purgeAt and the asset can be restored until then. Authentication is required.
 // @Tags         assets
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        request  body      DeleteAssetRequest  true  "Asset URL to delete"
-// @Success      200      {object}  MessageResponse     "Asset deleted"
+// @Success      200      {object}  AssetResponse       "Asset moved to trash"
 // @Failure      400      {object}  ErrorResponse       "Invalid request"
 // @Failure      401      {object}  ErrorResponse       "Authentication required"
+// @Failure      403      {object}  ErrorResponse       "Asset belongs to another user"
 // @Failure      404      {object}  ErrorResponse       "Asset not found"
 // @Router       /assets/delete [delete]
 func (h *AssetHandler) Delete(c *gin.Context) {
@@ -256,8 +374,7 @@ func (h *AssetHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	// Find asset to get filename for storage deletion
-	asset, err := h.deleteUC.Execute(c.Request.Context(), req.URL)
+	trashed, err := h.trashUC.Execute(c.Request.Context(), req.URL, userID.(string))
 	if err != nil {
 		appErr, ok := err.(*errors.AppError)
 		if ok {
@@ -268,15 +385,84 @@ func (h *AssetHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	// Delete from storage using the filename
-	filename := asset.Filename
-	if err := h.fileStorage.DeleteFile(filename); err != nil {
-		// Log error but don't fail - asset is already deleted from DB
-		c.JSON(http.StatusOK, gin.H{"message": "Asset deleted", "warning": "Storage deletion may have failed"})
+	c.JSON(http.StatusOK, gin.H{"message": "Asset moved to trash", "asset": trashed})
+}
+
+// GetTrash lists the caller's trashed assets
+// @Summary      List trashed assets
+// @Description  List the current user's assets in the trash, newest first, with the time each will be purged. Authentication is required.
+// @Tags         assets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit     query     int     false  "Page size (1-100, default 50)"
+// @Param        cursor    query     string  false  "nextCursor from the previous page"
+// @Param        sort      query     string  false  "deletedAt, prefix - for descending (default -deletedAt)"
+// @Success      200  {object}  AssetsResponse  "Trashed assets"
+// @Failure      400  {object}  ErrorResponse   "Invalid pagination parameters"
+// @Failure      401  {object}  ErrorResponse   "Authentication required"
+// @Router       /assets/trash [get]
+func (h *AssetHandler) GetTrash(c *gin.Context) {
+	// Require authentication
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	opts, err := parseListOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := h.listTrashUC.Execute(c.Request.Context(), userID.(string), opts)
+	if err != nil {
+		appErr, ok := err.(*errors.AppError)
+		if ok {
+			c.JSON(appErr.Code, appErr.ToResponse())
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get trashed assets"})
+		return
+	}
+
+	c.JSON(http.StatusOK, withNextCursor(gin.H{"assets": output.Assets}, output.NextCursor))
+}
+
+// RestoreFromTrash takes an asset back out of the trash
+// @Summary      Restore trashed asset
+// @Description  Restore one of the current user's assets from the trash. Authentication is required.
+// @Tags         assets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Asset ID"
+// @Success      200  {object}  AssetResponse  "Restored asset"
+// @Failure      401  {object}  ErrorResponse  "Authentication required"
+// @Failure      403  {object}  ErrorResponse  "Asset belongs to another user"
+// @Failure      404  {object}  ErrorResponse  "Asset not in trash"
+// @Router       /assets/{id}/restore [post]
+func (h *AssetHandler) RestoreFromTrash(c *gin.Context) {
+	// Require authentication
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Asset deleted"})
+	restored, err := h.restoreUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		appErr, ok := err.(*errors.AppError)
+		if ok {
+			c.JSON(appErr.Code, appErr.ToResponse())
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore asset"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Asset restored", "asset": restored})
 }
 
 type GenerateSignedURLRequest struct {
@@ -287,7 +473,7 @@ type GenerateSignedURLRequest struct {
 
 type GenerateSignedURLResponse struct {
 	SignedURL string `json:"signedUrl" example:"https://storage.googleapis.com/..."`
-	ObjectKey string `json:"objectKey" example:"abc123.jpg"`
+	ObjectKey string `json:"objectKey" example:"pending/user123/abc123.jpg"`
 	ExpiresIn int    `json:"expiresIn" example:"3600"`
 }
 
@@ -301,9 +487,17 @@ type GenerateSignedURLResponse struct {
 // @Param        request  body      GenerateSignedURLRequest  true  "Upload request"
 // @Success      200      {object}  GenerateSignedURLResponse  "Signed URL generated"
 // @Failure      400      {object}  ErrorResponse             "Invalid request"
+// @Failure      401      {object}  ErrorResponse             "Authentication required"
 // @Failure      500      {object}  ErrorResponse             "Internal server error"
 // @Router       /assets/upload-url [post]
 func (h *AssetHandler) GenerateSignedURL(c *gin.Context) {
+	// Require authentication
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
 	if h.gcsStorage == nil {
 		c.JSON(http.StatusNotImplemented, gin.H{"error": "Signed URL generation not available"})
 		return
@@ -321,13 +515,14 @@ func (h *AssetHandler) GenerateSignedURL(c *gin.Context) {
 		return
 	}
 
-	// Generate unique filename
+	// Generate a unique object key under the caller's pending prefix, so finalizing can tell
+	// whose upload it is
 	ext := filepath.Ext(req.Filename)
-	uniqueFilename := ksuid.New().String() + ext
+	objectKey := pendingUploadPrefix(userID.(string)) + ksuid.New().String() + ext
 
 	// Generate signed URL (valid for 1 hour)
 	expiresIn := 1 * time.Hour
-	signedURL, err := h.gcsStorage.GenerateSignedURL(c.Request.Context(), uniqueFilename, "PUT", expiresIn)
+	signedURL, err := h.gcsStorage.GenerateSignedURL(c.Request.Context(), objectKey, "PUT", expiresIn)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate signed URL"})
 		return
@@ -335,11 +530,119 @@ func (h *AssetHandler) GenerateSignedURL(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"signedUrl": signedURL,
-		"objectKey": uniqueFilename,
+		"objectKey": objectKey,
 		"expiresIn": int(expiresIn.Seconds()),
 	})
 }
 
+type FinalizeUploadRequest struct {
+	ObjectKey       string `json:"objectKey" binding:"required" example:"pending/user123/abc123.jpg"`
+	OriginalName    string `json:"originalName" binding:"required" example:"photo.jpg"`
+	MimeType        string `json:"mimeType" binding:"required" example:"image/jpeg"`
+	KeepCaptureDate bool   `json:"keepCaptureDate" example:"false"`
+}
+
+// FinalizeUpload creates the asset for a file uploaded directly through a signed URL
+// @Summary      Finalize direct upload
+// @Description  Process a file PUT to a signed upload URL as a regular upload (orientation, metadata stripping, resizing, variants) and create its asset. Authentication is required.
+// @Tags         assets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      FinalizeUploadRequest  true  "Uploaded object"
+// @Success      200      {object}  UploadAssetResponse   "Asset created"
+// @Failure      400      {object}  ErrorResponse         "Invalid request or file"
+// @Failure      401      {object}  ErrorResponse         "Authentication required"
+// @Failure      403      {object}  ErrorResponse         "Upload belongs to another user"
+// @Failure      404      {object}  ErrorResponse         "Uploaded object not found"
+// @Failure      409      {object}  ErrorResponse         "Upload already finalized"
+// @Failure      500      {object}  ErrorResponse         "Internal server error"
+// @Router       /assets/finalize [post]
+func (h *AssetHandler) FinalizeUpload(c *gin.Context) {
+	// Require authentication
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	if h.gcsStorage == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "Signed URL uploads not available"})
+		return
+	}
+
+	var req FinalizeUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	// Only the caller's own pending uploads can be finalized; the asset files are stored under
+	// the name without the prefix, so finalizing never overwrites or deletes an existing asset
+	filename, ok := strings.CutPrefix(req.ObjectKey, pendingUploadPrefix(userID.(string)))
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Upload belongs to another user"})
+		return
+	}
+	if !isGeneratedFilename(filename) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid object key"})
+		return
+	}
+
+	reader, err := h.gcsStorage.OpenFile(c.Request.Context(), req.ObjectKey)
+	if stderrors.Is(err, storage.ErrFileNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Uploaded object not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read upload"})
+		return
+	}
+	defer reader.Close()
+
+	// The signed URL does not bound the size, so the read is capped here
+	content, err := readUpload(reader, h.maxFileSize)
+	if err != nil && !stderrors.Is(err, errUploadTooLarge) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read upload"})
+		return
+	}
+	// Rejected objects are deleted, so they cannot be served from the bucket either
+	mimeType := req.MimeType
+	if err == nil {
+		mimeType, err = storage.CheckImageContent(content, req.MimeType)
+	}
+	if err == nil {
+		err = h.fileStorage.ValidateFile(mimeType, int64(len(content)))
+	}
+	if err != nil {
+		h.fileStorage.DeleteFile(req.ObjectKey)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	opts := storage.ProcessOptions{KeepCaptureDate: req.KeepCaptureDate}
+	if h.storeAsset(c, userID.(string), filename, req.OriginalName, mimeType, content, opts) {
+		h.fileStorage.DeleteFile(req.ObjectKey)
+	}
+}
+
+// pendingUploadPrefix is where a user's direct uploads wait to be finalized. ListFiles does not
+// list prefixed objects, so abandoned uploads are deleted after a day by the pending/ lifecycle
+// rule of the assets bucket (infra/terraform/modules/gcp-resources).
+func pendingUploadPrefix(userID string) string {
+	return "pending/" + userID + "/"
+}
+
+// isGeneratedFilename reports whether name is a KSUID with an extension, as GenerateSignedURL
+// issues them. Variant names (2bZ...k_medium.jpg) and other top-level objects are not.
+func isGeneratedFilename(name string) bool {
+	ext := filepath.Ext(name)
+	if strings.ContainsAny(ext, "/\\") {
+		return false
+	}
+	_, err := ksuid.Parse(strings.TrimSuffix(name, ext))
+	return err == nil
+}
+
 type CountAssetsByURLsRequest struct {
 	URLs []string `json:"urls" binding:"required"`
 }
@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/infrastructure/storage"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/asset"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// uploadRequest builds a multipart upload of content declared as contentType
+func uploadRequest(t *testing.T, content []byte, contentType string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="image"; filename="photo"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/assets/upload", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// pngOfSize encodes a 1x1 PNG and rewrites its header to declare width x height
+func pngOfSize(t *testing.T, width, height uint32) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))))
+	data := buf.Bytes()
+	// IHDR: length at 8, type at 12, width and height at 16, CRC of type and data at 29
+	binary.BigEndian.PutUint32(data[16:20], width)
+	binary.BigEndian.PutUint32(data[20:24], height)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestAssetHandler_Upload_RejectsUnsafeContent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validPNG := pngOfSize(t, 1, 1)
+
+	tests := []struct {
+		name        string
+		content     []byte
+		contentType string
+		wantError   string
+	}{
+		{
+			name:        "larger than the limit",
+			content:     append(validPNG, make([]byte, 4096)...),
+			contentType: "image/png",
+			wantError:   "file size exceeds limit",
+		},
+		{
+			name:        "content of another type than declared",
+			content:     validPNG,
+			contentType: "image/jpeg",
+			wantError:   "file content does not match its type",
+		},
+		{
+			name:        "not an image",
+			content:     []byte("just some text"),
+			contentType: "image/png",
+			wantError:   "file content is not a supported image",
+		},
+		{
+			name:        "SVG",
+			content:     []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"/>`),
+			contentType: "image/svg+xml",
+			wantError:   "SVG images are not allowed",
+		},
+		{
+			name:        "image that is also HTML",
+			content:     append(pngOfSize(t, 1, 1), []byte("<SCRIPT>alert(1)</SCRIPT>")...),
+			contentType: "image/png",
+			wantError:   "file contains embedded content",
+		},
+		{
+			name:        "image that is also a ZIP archive",
+			content:     append(pngOfSize(t, 1, 1), []byte("PK\x05\x06\x00\x00\x00\x00")...),
+			contentType: "image/png",
+			wantError:   "file contains embedded content",
+		},
+		{
+			name:        "decompression bomb",
+			content:     pngOfSize(t, 10000, 10000),
+			contentType: "image/png",
+			wantError:   "image dimensions exceed limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = uploadRequest(t, tt.content, tt.contentType)
+			c.Set("userID", "user-123")
+
+			// The content is rejected before storage or use cases are involved
+			handler := &AssetHandler{maxFileSize: 1024}
+
+			// Act
+			handler.Upload(c)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var body map[string]string
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantError, body["error"])
+		})
+	}
+}
+
+// memoryBucket is a signed URL store holding objects by key and recording deletions
+type memoryBucket struct {
+	objects map[string][]byte
+	deleted []string
+}
+
+func (b *memoryBucket) SaveFile(filename string, originalName string, mimeType string, size int64, reader io.Reader) (*storage.UploadedFile, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	b.objects[filename] = data
+	return &storage.UploadedFile{Filename: filename, OriginalName: originalName, Size: size, MimeType: mimeType}, nil
+}
+func (b *memoryBucket) DeleteFile(filename string) error {
+	b.deleted = append(b.deleted, filename)
+	delete(b.objects, filename)
+	return nil
+}
+func (b *memoryBucket) ValidateFile(mimeType string, size int64) error       { return nil }
+func (b *memoryBucket) ListFiles(ctx context.Context) ([]fs.FileInfo, error) { return nil, nil }
+func (b *memoryBucket) GenerateSignedURL(ctx context.Context, objectName string, method string, expiresIn time.Duration) (string, error) {
+	return "https://bucket.example/" + objectName + "?method=" + method, nil
+}
+func (b *memoryBucket) OpenFile(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	data, ok := b.objects[objectName]
+	if !ok {
+		return nil, storage.ErrFileNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// memoryAssetRepository holds assets by URL for the upload use case
+type memoryAssetRepository struct {
+	assets map[string]*domain.Asset
+}
+
+func (r *memoryAssetRepository) Create(ctx context.Context, a *domain.Asset) error {
+	r.assets[a.URL] = a
+	return nil
+}
+func (r *memoryAssetRepository) FindByID(ctx context.Context, id string) (*domain.Asset, error) {
+	return nil, nil
+}
+func (r *memoryAssetRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+	return &repository.Page[*domain.Asset]{}, nil
+}
+func (r *memoryAssetRepository) FindByURL(ctx context.Context, url string) (*domain.Asset, error) {
+	return r.assets[url], nil
+}
+func (r *memoryAssetRepository) FindByURLs(ctx context.Context, urls []string) ([]*domain.Asset, error) {
+	return nil, nil
+}
+func (r *memoryAssetRepository) Delete(ctx context.Context, id string) error           { return nil }
+func (r *memoryAssetRepository) DeleteByURL(ctx context.Context, url string) error     { return nil }
+func (r *memoryAssetRepository) RestoreFromTrash(ctx context.Context, id string) error { return nil }
+func (r *memoryAssetRepository) MoveToTrash(ctx context.Context, id string, deletedAt time.Time) error {
+	return nil
+}
+func (r *memoryAssetRepository) FindTrashedByID(ctx context.Context, id string) (*domain.Asset, error) {
+	return nil, nil
+}
+func (r *memoryAssetRepository) FindTrashedByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+	return &repository.Page[*domain.Asset]{}, nil
+}
+func (r *memoryAssetRepository) FindTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Asset, error) {
+	return nil, nil
+}
+func (r *memoryAssetRepository) FindAllIncludingTrashed(ctx context.Context, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+	return &repository.Page[*domain.Asset]{}, nil
+}
+func (r *memoryAssetRepository) FindUsedInInvitations(ctx context.Context, assetID string) ([]string, error) {
+	return nil, nil
+}
+func (r *memoryAssetRepository) TrackUsage(ctx context.Context, assetID, invitationID string) error {
+	return nil
+}
+func (r *memoryAssetRepository) UntrackUsage(ctx context.Context, assetID, invitationID string) error {
+	return nil
+}
+func (r *memoryAssetRepository) UntrackAllUsage(ctx context.Context, invitationID string) error {
+	return nil
+}
+
+func TestAssetHandler_FinalizeUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	name := ksuid.New().String() + ".png"
+	content := pngOfSize(t, 1, 1)
+
+	tests := []struct {
+		name      string
+		objectKey string
+		// finalized has the upload's asset recorded already
+		finalized bool
+		wantCode  int
+		validate  func(t *testing.T, bucket *memoryBucket, assets *memoryAssetRepository)
+	}{
+		{
+			name:      "own upload is stored at the top level",
+			objectKey: "pending/user-1/" + name,
+			wantCode:  http.StatusOK,
+			validate: func(t *testing.T, bucket *memoryBucket, assets *memoryAssetRepository) {
+				require.Contains(t, assets.assets, "/uploads/"+name)
+				assert.Equal(t, "user-1", assets.assets["/uploads/"+name].UserID)
+				assert.Equal(t, content, bucket.objects[name])
+				assert.Equal(t, []string{"pending/user-1/" + name}, bucket.deleted, "Only the pending upload is deleted")
+			},
+		},
+		{name: "upload of another user", objectKey: "pending/user-2/" + name, wantCode: http.StatusForbidden},
+		{name: "top-level object", objectKey: name, wantCode: http.StatusForbidden},
+		{name: "variant name", objectKey: "pending/user-1/" + strings.TrimSuffix(name, ".png") + "_medium.png", wantCode: http.StatusBadRequest},
+		{name: "nested key", objectKey: "pending/user-1/other/" + name, wantCode: http.StatusBadRequest},
+		{name: "already finalized", objectKey: "pending/user-1/" + name, finalized: true, wantCode: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			bucket := &memoryBucket{objects: map[string][]byte{
+				name:                           []byte("existing asset"),
+				"pending/user-1/" + name:       content,
+				"pending/user-2/" + name:       content,
+				"pending/user-1/other/" + name: content,
+			}}
+			assets := &memoryAssetRepository{assets: map[string]*domain.Asset{}}
+			if tt.finalized {
+				assets.assets["/uploads/"+name] = &domain.Asset{URL: "/uploads/" + name, Filename: name, UserID: "user-2"}
+			} else {
+				delete(bucket.objects, name)
+			}
+			handler := &AssetHandler{
+				uploadUC:    asset.NewUploadAssetUseCase(assets, 1024, nil),
+				fileStorage: bucket,
+				gcsStorage:  bucket,
+				maxFileSize: 1024,
+			}
+
+			body, err := json.Marshal(FinalizeUploadRequest{ObjectKey: tt.objectKey, OriginalName: "photo.png", MimeType: "image/png"})
+			require.NoError(t, err)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/assets/finalize", bytes.NewReader(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("userID", "user-1")
+
+			// Act
+			handler.FinalizeUpload(c)
+
+			// Assert
+			assert.Equal(t, tt.wantCode, w.Code, w.Body.String())
+			if tt.validate != nil {
+				tt.validate(t, bucket, assets)
+				return
+			}
+			assert.Empty(t, bucket.deleted, "Nothing is deleted when finalizing fails")
+			if tt.finalized {
+				assert.Equal(t, []byte("existing asset"), bucket.objects[name], "The finalized asset is not overwritten")
+			}
+		})
+	}
+}
+
+func TestAssetHandler_GenerateSignedURL_IssuesKeysUnderUserPrefix(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	bucket := &memoryBucket{objects: map[string][]byte{}}
+	handler := &AssetHandler{fileStorage: &memoryBucket{}, gcsStorage: bucket}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/assets/upload-url", strings.NewReader(`{"filename":"photo.jpg","mimeType":"image/jpeg","size":1000}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "user-1")
+
+	// Act
+	handler.GenerateSignedURL(c)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	var response GenerateSignedURLResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	filename, ok := strings.CutPrefix(response.ObjectKey, "pending/user-1/")
+	require.True(t, ok, "unexpected object key %s", response.ObjectKey)
+	assert.True(t, isGeneratedFilename(filename))
+	assert.True(t, strings.HasSuffix(filename, ".jpg"))
+}
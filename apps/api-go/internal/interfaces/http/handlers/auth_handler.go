@@ -94,6 +94,7 @@ type UserDTO struct {
 	ID    string  `json:"id" example:"1234567890"`
 	Email string  `json:"email" example:"user@example.com"`
 	Name  *string `json:"name,omitempty" example:"John Doe"`
+	Role  string  `json:"role" example:"user"`
 }
 
 type UserResponse struct {
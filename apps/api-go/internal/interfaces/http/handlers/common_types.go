@@ -4,6 +4,14 @@ package handlers
 // @Description Standard error response format
 type ErrorResponse struct {
 	Error string `json:"error" example:"Error message"`
+	// Details lists field-level problems, e.g. invitation data that does not match the layout
+	Details []FieldErrorResponse `json:"details,omitempty"`
+}
+
+// FieldErrorResponse describes a problem with one field of the request
+type FieldErrorResponse struct {
+	Field   string `json:"field" example:"/data/layoutConfig/sections/0/id"`
+	Message string `json:"message" example:"must be one of \"hero\", \"couple\""`
 }
 
 // MessageResponse represents a standard message response
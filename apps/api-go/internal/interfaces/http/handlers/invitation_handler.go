@@ -2,11 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	stderrors "errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/sacred-vows/api-go/internal/infrastructure/storage"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/http/middleware"
 	"github.com/sacred-vows/api-go/internal/usecase/invitation"
 	"github.com/sacred-vows/api-go/pkg/errors"
 	"github.com/sacred-vows/api-go/pkg/logger"
@@ -59,15 +63,31 @@ func toHandlerInvitationDTO(dto *invitation.InvitationDTO) *InvitationDTO {
 	createdAt := dto.CreatedAt.Format(time.RFC3339)
 	updatedAt := dto.UpdatedAt.Format(time.RFC3339)
 	return &InvitationDTO{
-		ID:        dto.ID,
-		LayoutID:  dto.LayoutID,
-		Data:      JSONDataFromRawMessage(dto.Data),
-		Title:     dto.Title,
-		Status:    dto.Status,
-		UserID:    dto.UserID,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		ID:       dto.ID,
+		LayoutID: dto.LayoutID,
+		Data:     JSONDataFromRawMessage(dto.Data),
+
+		LayoutVersion: dto.LayoutVersion,
+		Title:         dto.Title,
+		Status:        dto.Status,
+		UserID:        dto.UserID,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+
+		Translations:  dto.Translations,
+		DefaultLocale: dto.DefaultLocale,
+		Version:       dto.Version,
+		DeletedAt:     formatOptionalTime(dto.DeletedAt),
+		PurgeAt:       formatOptionalTime(dto.PurgeAt),
+	}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
 	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
 }
 
 // toHandlerInvitationPreviewDTO converts use case InvitationPreviewDTO to handler InvitationPreviewDTO
@@ -88,9 +108,14 @@ type InvitationHandler struct {
 	getAllUC     *invitation.GetAllInvitationsUseCase
 	getPreviewUC *invitation.GetInvitationPreviewUseCase
 	updateUC     *invitation.UpdateInvitationUseCase
+	patchUC      *invitation.PatchInvitationUseCase
 	deleteUC     *invitation.DeleteInvitationUseCase
+	listTrashUC  *invitation.ListTrashedInvitationsUseCase
+	restoreUC    *invitation.RestoreInvitationUseCase
+	duplicateUC  *invitation.DuplicateInvitationUseCase
+	switchUC     *invitation.SwitchLayoutUseCase
+	upgradeUC    *invitation.UpgradeLayoutVersionUseCase
 	migrateUC    *invitation.MigrateInvitationsUseCase
-	fileStorage  storage.Storage // For deleting assets from storage
 }
 
 func NewInvitationHandler(
@@ -99,9 +124,14 @@ func NewInvitationHandler(
 	getAllUC *invitation.GetAllInvitationsUseCase,
 	getPreviewUC *invitation.GetInvitationPreviewUseCase,
 	updateUC *invitation.UpdateInvitationUseCase,
+	patchUC *invitation.PatchInvitationUseCase,
 	deleteUC *invitation.DeleteInvitationUseCase,
+	listTrashUC *invitation.ListTrashedInvitationsUseCase,
+	restoreUC *invitation.RestoreInvitationUseCase,
+	duplicateUC *invitation.DuplicateInvitationUseCase,
+	switchUC *invitation.SwitchLayoutUseCase,
+	upgradeUC *invitation.UpgradeLayoutVersionUseCase,
 	migrateUC *invitation.MigrateInvitationsUseCase,
-	fileStorage storage.Storage,
 ) *InvitationHandler {
 	return &InvitationHandler{
 		createUC:     createUC,
@@ -109,9 +139,14 @@ func NewInvitationHandler(
 		getAllUC:     getAllUC,
 		getPreviewUC: getPreviewUC,
 		updateUC:     updateUC,
+		patchUC:      patchUC,
 		deleteUC:     deleteUC,
+		listTrashUC:  listTrashUC,
+		restoreUC:    restoreUC,
+		duplicateUC:  duplicateUC,
+		switchUC:     switchUC,
+		upgradeUC:    upgradeUC,
 		migrateUC:    migrateUC,
-		fileStorage:  fileStorage,
 	}
 }
 
@@ -119,25 +154,54 @@ type CreateInvitationRequest struct {
 	LayoutID string   `json:"layoutId" example:"classic-scroll" binding:"required"`
 	Data     JSONData `json:"data" swagtype:"string" example:"{\"bride\":\"Jane\",\"groom\":\"John\"}" binding:"required"`
 	Title    string   `json:"title" example:"Our Wedding"`
+	// Optional per-locale translations, e.g. {"en": {...}, "hi": {...}}
+	Translations  map[string]json.RawMessage `json:"translations,omitempty"`
+	DefaultLocale string                     `json:"defaultLocale,omitempty" example:"en"`
 }
 
 type UpdateInvitationRequest struct {
-	Data         *JSONData               `json:"data" swagtype:"string" example:"{\"bride\":\"Jane\",\"groom\":\"John\"}"`
+	Data *JSONData `json:"data" swagtype:"string" example:"{\"bride\":\"Jane\",\"groom\":\"John\"}"`
+	// LayoutID must be the current layout; use switch-layout to move to another one
 	LayoutID     *string                 `json:"layoutId" example:"classic-scroll"`
 	LayoutConfig *map[string]interface{} `json:"layoutConfig,omitempty"`
 	Title        *string                 `json:"title" example:"Our Wedding"`
 	Status       *string                 `json:"status" example:"published"`
+	// Translations replaces all translations when present; {} makes the site single-language
+	Translations  *map[string]json.RawMessage `json:"translations,omitempty"`
+	DefaultLocale *string                     `json:"defaultLocale,omitempty" example:"en"`
+	// Version the edit is based on; alternative to the If-Match header
+	Version *int `json:"version,omitempty" example:"3"`
+}
+
+// VersionConflictResponse is returned with 409 when an update is based on a stale version
+type VersionConflictResponse struct {
+	Error          string `json:"error" example:"Invitation was modified by another session"`
+	CurrentVersion int    `json:"currentVersion" example:"4"`
 }
 
 type InvitationDTO struct {
-	ID        string   `json:"id" example:"1234567890"`
-	LayoutID  string   `json:"layoutId" example:"classic-scroll"`
-	Data      JSONData `json:"data" swagtype:"string" example:"{\"bride\":\"Jane\",\"groom\":\"John\"}"`
-	Title     *string  `json:"title,omitempty" example:"Our Wedding"`
-	Status    *string  `json:"status,omitempty" example:"published"`
-	UserID    string   `json:"userId" example:"user123"`
-	CreatedAt string   `json:"createdAt" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt string   `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+	ID       string `json:"id" example:"1234567890"`
+	LayoutID string `json:"layoutId" example:"classic-scroll"`
+	// LayoutVersion is the layout version the invitation renders with; empty means the current one
+	LayoutVersion string   `json:"layoutVersion,omitempty" example:"1.1.0"`
+	Data          JSONData `json:"data" swagtype:"string" example:"{\"bride\":\"Jane\",\"groom\":\"John\"}"`
+	Title         *string  `json:"title,omitempty" example:"Our Wedding"`
+	Status        *string  `json:"status,omitempty" example:"published"`
+	UserID        string   `json:"userId" example:"user123"`
+	CreatedAt     string   `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt     string   `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+
+	Translations  map[string]json.RawMessage `json:"translations,omitempty"`
+	DefaultLocale string                     `json:"defaultLocale,omitempty" example:"en"`
+	Version       int                        `json:"version" example:"3"`
+	// DeletedAt and PurgeAt are only set for invitations in the trash
+	DeletedAt *string `json:"deletedAt,omitempty" example:"2024-01-01T00:00:00Z"`
+	PurgeAt   *string `json:"purgeAt,omitempty" example:"2024-01-31T00:00:00Z"`
+}
+
+type DeleteInvitationResponse struct {
+	Message string `json:"message" example:"Invitation moved to trash"`
+	PurgeAt string `json:"purgeAt" example:"2024-01-31T00:00:00Z"`
 }
 
 type InvitationPreviewDTO struct {
@@ -152,6 +216,7 @@ type InvitationResponse struct {
 
 type InvitationsResponse struct {
 	Invitations []InvitationDTO `json:"invitations"`
+	NextCursor  string          `json:"nextCursor,omitempty"`
 }
 
 // GetAll retrieves all invitations for the current user
@@ -161,36 +226,33 @@ type InvitationsResponse struct {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        limit     query     int     false  "Page size (1-100, default 50)"
+// @Param        cursor    query     string  false  "nextCursor from the previous page"
+// @Param        sort      query     string  false  "createdAt or updatedAt, prefix - for descending (default -updatedAt)"
+// @Param        layoutId  query     string  false  "Only invitations using this layout"
+// @Param        status    query     string  false  "Only invitations in this status (draft, published, unpublished, archived)"
 // @Success      200  {object}  InvitationsResponse  "List of invitations"
+// @Failure      400  {object}  ErrorResponse        "Invalid pagination parameters"
 // @Failure      500  {object}  ErrorResponse        "Internal server error"
 // @Router       /invitations [get]
 func (h *InvitationHandler) GetAll(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	userID, exists := c.Get("userID")
-
-	// If Authorization header is present but userID is not set, token validation failed
-	// This means user tried to authenticate but token is invalid - return error
-	if authHeader != "" && (!exists || userID == nil) {
-		logger.GetLogger().Warn("GetAll invitations: Invalid token provided",
-			zap.String("authHeader", authHeader[:min(30, len(authHeader))]),
-		)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+	callerID, ok := invitationCallerID(c)
+	if !ok {
 		return
 	}
 
-	// Only use anonymous if no Authorization header was provided
-	if !exists || userID == nil {
-		userID = "anonymous"
-	}
-
 	// Log for debugging
 	logger.GetLogger().Info("GetAll invitations",
-		zap.String("userID", userID.(string)),
-		zap.Bool("fromContext", exists),
-		zap.Bool("hasAuthHeader", authHeader != ""),
+		zap.String("userID", callerID),
 	)
 
-	output, err := h.getAllUC.Execute(c.Request.Context(), userID.(string))
+	opts, err := parseListOptions(c, "layoutId", "status")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := h.getAllUC.Execute(c.Request.Context(), callerID, opts)
 	if err != nil {
 		appErr, ok := err.(*errors.AppError)
 		if ok {
@@ -206,7 +268,7 @@ func (h *InvitationHandler) GetAll(c *gin.Context) {
 	for i, inv := range output.Invitations {
 		handlerInvitations[i] = *toHandlerInvitationDTO(inv)
 	}
-	c.JSON(http.StatusOK, gin.H{"invitations": handlerInvitations})
+	c.JSON(http.StatusOK, withNextCursor(gin.H{"invitations": handlerInvitations}, output.NextCursor))
 }
 
 // GetPreview retrieves a public invitation preview
@@ -266,6 +328,7 @@ func (h *InvitationHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	setInvitationETag(c, output.Invitation.Version)
 	c.JSON(http.StatusOK, gin.H{"invitation": toHandlerInvitationDTO(output.Invitation)})
 }
 
@@ -278,7 +341,7 @@ func (h *InvitationHandler) GetByID(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        request  body      CreateInvitationRequest  true  "Invitation data"
 // @Success      201      {object}  InvitationResponse       "Invitation created"
-// @Failure      400      {object}  ErrorResponse           "Invalid request"
+// @Failure      400      {object}  ErrorResponse           "Invalid request, or data that does not match the layout (details lists the fields)"
 // @Failure      500      {object}  ErrorResponse           "Internal server error"
 // @Router       /invitations [post]
 func (h *InvitationHandler) Create(c *gin.Context) {
@@ -291,24 +354,13 @@ func (h *InvitationHandler) Create(c *gin.Context) {
 		return
 	}
 
-	authHeader := c.GetHeader("Authorization")
-	userID, exists := c.Get("userID")
-
-	// If Authorization header is present but userID is not set, token validation failed
-	if authHeader != "" && (!exists || userID == nil) {
-		logger.GetLogger().Warn("Create invitation: Invalid token provided")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+	callerID, ok := invitationCallerID(c)
+	if !ok {
 		return
 	}
 
-	// Only use anonymous if no Authorization header was provided
-	if !exists || userID == nil {
-		userID = "anonymous"
-	}
-
 	logger.GetLogger().Info("Create invitation",
-		zap.String("userID", userID.(string)),
-		zap.Bool("hasAuthHeader", authHeader != ""),
+		zap.String("userID", callerID),
 	)
 
 	var titlePtr *string
@@ -320,7 +372,10 @@ func (h *InvitationHandler) Create(c *gin.Context) {
 		LayoutID: req.LayoutID,
 		Data:     req.Data.ToRawMessage(),
 		Title:    titlePtr,
-		UserID:   userID.(string),
+		UserID:   callerID,
+
+		Translations:  req.Translations,
+		DefaultLocale: req.DefaultLocale,
 	})
 
 	if err != nil {
@@ -333,22 +388,28 @@ func (h *InvitationHandler) Create(c *gin.Context) {
 		return
 	}
 
+	setInvitationETag(c, output.Invitation.Version)
 	c.JSON(http.StatusCreated, gin.H{"invitation": toHandlerInvitationDTO(output.Invitation)})
 }
 
 // Update updates an existing invitation
 // @Summary      Update invitation
-// @Description  Update an existing invitation. Supports optional authentication (anonymous users are supported).
+// @Description  Update one of the caller's invitations. Requires the version the edit is based on, via If-Match (the ETag returned by GET) or the version field; a stale version returns 409 with the current version. Supports optional authentication (anonymous users are supported).
 // @Tags         invitations
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id       path      string                  true  "Invitation ID"
-// @Param        request  body      UpdateInvitationRequest  true  "Updated invitation data"
-// @Success      200      {object}  InvitationResponse       "Invitation updated"
-// @Failure      400      {object}  ErrorResponse           "Invalid request"
-// @Failure      404      {object}  ErrorResponse           "Invitation not found"
-// @Failure      500      {object}  ErrorResponse           "Internal server error"
+// @Param        id        path      string                   true   "Invitation ID"
+// @Param        If-Match  header    string                   false  "ETag of the version being edited, e.g. \"3\""
+// @Param        request   body      UpdateInvitationRequest  true   "Updated invitation data"
+// @Success      200       {object}  InvitationResponse       "Invitation updated"
+// @Failure      400       {object}  ErrorResponse            "Invalid request, or data that does not match the layout (details lists the fields)"
+// @Failure      401       {object}  ErrorResponse            "Invalid token or missing guest session"
+// @Failure      403       {object}  ErrorResponse            "Invitation belongs to another user"
+// @Failure      404       {object}  ErrorResponse            "Invitation not found"
+// @Failure      409       {object}  VersionConflictResponse  "Invitation was modified by another session"
+// @Failure      428       {object}  ErrorResponse            "If-Match header or version field required"
+// @Failure      500       {object}  ErrorResponse            "Internal server error"
 // @Router       /invitations/{id} [put]
 func (h *InvitationHandler) Update(c *gin.Context) {
 	id := c.Param("id")
@@ -358,25 +419,19 @@ func (h *InvitationHandler) Update(c *gin.Context) {
 		return
 	}
 
-	authHeader := c.GetHeader("Authorization")
-	userID, exists := c.Get("userID")
-
-	// If Authorization header is present but userID is not set, token validation failed
-	if authHeader != "" && (!exists || userID == nil) {
-		logger.GetLogger().Warn("Update invitation: Invalid token provided", zap.String("invitationID", id))
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+	expectedVersion, ok := expectedInvitationVersion(c, req.Version)
+	if !ok {
 		return
 	}
 
-	// Only use anonymous if no Authorization header was provided
-	if !exists || userID == nil {
-		userID = "anonymous"
+	callerID, ok := invitationCallerID(c)
+	if !ok {
+		return
 	}
 
 	logger.GetLogger().Info("Update invitation",
 		zap.String("invitationID", id),
-		zap.String("userID", userID.(string)),
-		zap.Bool("hasAuthHeader", authHeader != ""),
+		zap.String("userID", callerID),
 	)
 
 	var dataPtr *json.RawMessage
@@ -403,9 +458,98 @@ func (h *InvitationHandler) Update(c *gin.Context) {
 		LayoutConfig: layoutConfigPtr,
 		Title:        req.Title,
 		Status:       req.Status,
+
+		Translations:  req.Translations,
+		DefaultLocale: req.DefaultLocale,
+		AuthorID:      callerID,
+
+		ExpectedVersion: expectedVersion,
 	})
 
 	if err != nil {
+		if writeVersionConflict(c, err) {
+			return
+		}
+		appErr, ok := err.(*errors.AppError)
+		if ok {
+			c.JSON(appErr.Code, appErr.ToResponse())
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update invitation"})
+		return
+	}
+
+	setInvitationETag(c, output.Invitation.Version)
+	c.JSON(http.StatusOK, gin.H{"invitation": toHandlerInvitationDTO(output.Invitation)})
+}
+
+// Patch applies a JSON Patch or merge patch to the invitation data
+// @Summary      Patch invitation data
+// @Description  Apply an RFC 6902 JSON Patch (application/json-patch+json) or RFC 7396 merge patch (application/merge-patch+json) to the data of one of the caller's invitations. Requires If-Match with the version the patch was computed against. Supports optional authentication (anonymous users are supported).
+// @Tags         invitations
+// @Accept       application/json-patch+json,application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string                   true  "Invitation ID"
+// @Param        If-Match  header    string                   true  "ETag of the version being patched, e.g. \"3\""
+// @Param        request   body      string                   true  "Patch document"
+// @Success      200       {object}  InvitationResponse       "Invitation updated"
+// @Failure      400       {object}  ErrorResponse            "Invalid patch, or patched data that does not match the layout (details lists the fields)"
+// @Failure      401       {object}  ErrorResponse            "Invalid token or missing guest session"
+// @Failure      403       {object}  ErrorResponse            "Invitation belongs to another user"
+// @Failure      404       {object}  ErrorResponse            "Invitation not found"
+// @Failure      409       {object}  VersionConflictResponse  "Invitation was modified by another session, or a test operation failed"
+// @Failure      415       {object}  ErrorResponse            "Unsupported patch content type"
+// @Failure      428       {object}  ErrorResponse            "If-Match header required"
+// @Failure      500       {object}  ErrorResponse            "Internal server error"
+// @Router       /invitations/{id} [patch]
+func (h *InvitationHandler) Patch(c *gin.Context) {
+	id := c.Param("id")
+
+	var format invitation.PatchFormat
+	switch c.ContentType() {
+	case "application/json-patch+json":
+		format = invitation.PatchFormatJSONPatch
+	case "application/merge-patch+json":
+		format = invitation.PatchFormatMergePatch
+	default:
+		c.JSON(http.StatusUnsupportedMediaType, ErrorResponse{Error: "Content-Type must be application/json-patch+json or application/merge-patch+json"})
+		return
+	}
+
+	expectedVersion, ok := expectedInvitationVersion(c, nil)
+	if !ok {
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil || len(body) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	callerID, ok := invitationCallerID(c)
+	if !ok {
+		return
+	}
+
+	logger.GetLogger().Info("Patch invitation",
+		zap.String("invitationID", id),
+		zap.String("userID", callerID),
+		zap.String("format", string(format)),
+	)
+
+	output, err := h.patchUC.Execute(c.Request.Context(), invitation.PatchInvitationInput{
+		ID:              id,
+		Format:          format,
+		Patch:           json.RawMessage(body),
+		AuthorID:        callerID,
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		if writeVersionConflict(c, err) {
+			return
+		}
 		appErr, ok := err.(*errors.AppError)
 		if ok {
 			c.JSON(appErr.Code, appErr.ToResponse())
@@ -415,45 +559,36 @@ func (h *InvitationHandler) Update(c *gin.Context) {
 		return
 	}
 
+	setInvitationETag(c, output.Invitation.Version)
 	c.JSON(http.StatusOK, gin.H{"invitation": toHandlerInvitationDTO(output.Invitation)})
 }
 
-// Delete deletes an invitation
+// Delete moves an invitation to the trash
 // @Summary      Delete invitation
-// @Description  Delete an invitation by ID. Supports optional authentication (anonymous users are supported).
+// @Description  Move one of the caller's invitations to the trash. It can be restored until purgeAt, after which it and its unused assets are deleted permanently. Supports optional authentication (anonymous users are supported).
 // @Tags         invitations
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Invitation ID"
-// @Success      200  {object}  MessageResponse  "Invitation deleted"
-// @Failure      404  {object}  ErrorResponse   "Invitation not found"
+// @Success      200  {object}  DeleteInvitationResponse  "Invitation moved to trash"
+// @Failure      401  {object}  ErrorResponse             "Invalid token or missing guest session"
+// @Failure      403  {object}  ErrorResponse             "Invitation belongs to another user"
+// @Failure      404  {object}  ErrorResponse             "Invitation not found"
 // @Router       /invitations/{id} [delete]
 func (h *InvitationHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
-
-	authHeader := c.GetHeader("Authorization")
-	userID, exists := c.Get("userID")
-
-	// If Authorization header is present but userID is not set, token validation failed
-	if authHeader != "" && (!exists || userID == nil) {
-		logger.GetLogger().Warn("Delete invitation: Invalid token provided", zap.String("invitationID", id))
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+	callerID, ok := invitationCallerID(c)
+	if !ok {
 		return
 	}
 
 	logger.GetLogger().Info("Delete invitation",
 		zap.String("invitationID", id),
-		zap.String("userID", func() string {
-			if exists && userID != nil {
-				return userID.(string)
-			}
-			return "anonymous"
-		}()),
-		zap.Bool("hasAuthHeader", authHeader != ""),
+		zap.String("userID", callerID),
 	)
 
-	output, err := h.deleteUC.Execute(c.Request.Context(), id)
+	output, err := h.deleteUC.Execute(c.Request.Context(), id, callerID)
 	if err != nil {
 		appErr, ok := err.(*errors.AppError)
 		if ok {
@@ -464,27 +599,338 @@ func (h *InvitationHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	// Delete assets from storage
-	if h.fileStorage != nil && len(output.DeletedAssets) > 0 {
-		for _, deletedAsset := range output.DeletedAssets {
-			// Delete from storage using filename
-			if err := h.fileStorage.DeleteFile(deletedAsset.Filename); err != nil {
-				// Log error but don't fail - asset is already deleted from DB
-				logger.GetLogger().Warn("Failed to delete asset from storage",
-					zap.String("filename", deletedAsset.Filename),
-					zap.Error(err))
-			}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invitation moved to trash",
+		"purgeAt": output.PurgeAt.Format(time.RFC3339),
+	})
+}
+
+// GetTrash lists the caller's trashed invitations
+// @Summary      List trashed invitations
+// @Description  List the caller's invitations in the trash, newest first, with the time each will be purged. Supports optional authentication (anonymous users are supported).
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit     query     int     false  "Page size (1-100, default 50)"
+// @Param        cursor    query     string  false  "nextCursor from the previous page"
+// @Param        sort      query     string  false  "deletedAt, prefix - for descending (default -deletedAt)"
+// @Success      200  {object}  InvitationsResponse  "Trashed invitations"
+// @Failure      400  {object}  ErrorResponse        "Invalid pagination parameters"
+// @Failure      401  {object}  ErrorResponse        "Invalid token or missing guest session"
+// @Router       /invitations/trash [get]
+func (h *InvitationHandler) GetTrash(c *gin.Context) {
+	callerID, ok := invitationCallerID(c)
+	if !ok {
+		return
+	}
+
+	opts, err := parseListOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := h.listTrashUC.Execute(c.Request.Context(), callerID, opts)
+	if err != nil {
+		appErr, ok := err.(*errors.AppError)
+		if ok {
+			c.JSON(appErr.Code, appErr.ToResponse())
+			return
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get trashed invitations"})
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message":       "Invitation deleted",
-		"deletedAssets": len(output.DeletedAssets),
+	handlerInvitations := make([]InvitationDTO, len(output.Invitations))
+	for i, inv := range output.Invitations {
+		handlerInvitations[i] = *toHandlerInvitationDTO(inv)
+	}
+	c.JSON(http.StatusOK, withNextCursor(gin.H{"invitations": handlerInvitations}, output.NextCursor))
+}
+
+// RestoreFromTrash takes an invitation back out of the trash
+// @Summary      Restore trashed invitation
+// @Description  Restore one of the caller's invitations from the trash. Supports optional authentication (anonymous users are supported).
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Invitation ID"
+// @Success      200  {object}  InvitationResponse  "Restored invitation"
+// @Failure      403  {object}  ErrorResponse       "Invitation belongs to another user"
+// @Failure      404  {object}  ErrorResponse       "Invitation not in trash"
+// @Router       /invitations/{id}/restore [post]
+func (h *InvitationHandler) RestoreFromTrash(c *gin.Context) {
+	callerID, ok := invitationCallerID(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.restoreUC.Execute(c.Request.Context(), c.Param("id"), callerID)
+	if err != nil {
+		appErr, ok := err.(*errors.AppError)
+		if ok {
+			c.JSON(appErr.Code, appErr.ToResponse())
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore invitation"})
+		return
+	}
+
+	setInvitationETag(c, output.Version)
+	c.JSON(http.StatusOK, gin.H{"invitation": toHandlerInvitationDTO(output)})
+}
+
+type DuplicateInvitationRequest struct {
+	// LayoutID switches the copy to another layout; the source layout is kept when empty
+	LayoutID string `json:"layoutId" example:"editorial-elegance"`
+	// Title of the copy; defaults to the source title followed by " (copy)"
+	Title *string `json:"title" example:"Our Wedding (copy)"`
+	// StripPersonalData leaves out names, wedding party, dates, venue, RSVP contacts, photos and videos
+	StripPersonalData bool `json:"stripPersonalData" example:"false"`
+}
+
+// Duplicate copies an invitation into a new draft
+// @Summary      Duplicate invitation
+// @Description  Copy one of the caller's invitations into a new draft, optionally on another layout and without personal details (names, wedding party, dates, venue, RSVP contacts, photos and videos). The copy has its own version and revision history and is not published. Supports optional authentication (anonymous users are supported).
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                      true   "Invitation ID"
+// @Param        request  body      DuplicateInvitationRequest  false  "Duplicate options"
+// @Success      201      {object}  InvitationResponse          "Copy created"
+// @Failure      400      {object}  ErrorResponse               "Invalid request, or data that does not match the target layout"
+// @Failure      403      {object}  ErrorResponse               "Invitation belongs to another user"
+// @Failure      404      {object}  ErrorResponse               "Invitation not found"
+// @Router       /invitations/{id}/duplicate [post]
+func (h *InvitationHandler) Duplicate(c *gin.Context) {
+	callerID, ok := invitationCallerID(c)
+	if !ok {
+		return
+	}
+
+	var req DuplicateInvitationRequest
+	// The body is optional; an empty body duplicates with the defaults
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+	}
+
+	output, err := h.duplicateUC.Execute(c.Request.Context(), invitation.DuplicateInvitationInput{
+		ID:                c.Param("id"),
+		UserID:            callerID,
+		LayoutID:          req.LayoutID,
+		Title:             req.Title,
+		StripPersonalData: req.StripPersonalData,
+	})
+	if err != nil {
+		appErr, ok := err.(*errors.AppError)
+		if ok {
+			c.JSON(appErr.Code, appErr.ToResponse())
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to duplicate invitation"})
+		return
+	}
+
+	setInvitationETag(c, output.Invitation.Version)
+	c.JSON(http.StatusCreated, gin.H{"invitation": toHandlerInvitationDTO(output.Invitation)})
+}
+
+type SwitchLayoutRequest struct {
+	LayoutID string `json:"layoutId" binding:"required" example:"editorial-elegance"`
+	// DryRun previews the migrated data and report without saving
+	DryRun bool `json:"dryRun" example:"true"`
+	// Version is an alternative to If-Match when applying the switch
+	Version *int `json:"version,omitempty" example:"3"`
+}
+
+type ContentNotCarriedOverResponse struct {
+	Path   string `json:"path" example:"/layoutConfig/sections/3"`
+	Reason string `json:"reason" example:"section \"fathers-letter\" is not available in layout \"editorial-elegance\""`
+}
+
+type LayoutMigrationReportResponse struct {
+	FromLayoutID   string                          `json:"fromLayoutId" example:"classic-scroll"`
+	ToLayoutID     string                          `json:"toLayoutId" example:"editorial-elegance"`
+	AddedSections  []string                        `json:"addedSections" example:"editorial-intro,wedding-party"`
+	NotCarriedOver []ContentNotCarriedOverResponse `json:"notCarriedOver"`
+}
+
+type SwitchLayoutResponse struct {
+	// Invitation is the updated invitation; omitted on a dry run
+	Invitation *InvitationDTO                 `json:"invitation,omitempty"`
+	Data       JSONData                       `json:"data" swagtype:"string" example:"{\"layoutConfig\":{}}"`
+	Report     *LayoutMigrationReportResponse `json:"report"`
+}
+
+// SwitchLayout moves an invitation to another layout
+// @Summary      Switch invitation layout
+// @Description  Move one of the caller's invitations to another layout, carrying sections, theme and content over with the migration the new layout's manifest declares. With dryRun the migrated data and report are returned without saving; otherwise the version the switch is based on is required via If-Match or the version field. The report lists content the new layout cannot show. Supports optional authentication (anonymous users are supported).
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string                   true   "Invitation ID"
+// @Param        If-Match  header    string                   false  "ETag of the version being switched, e.g. \"3\""
+// @Param        request   body      SwitchLayoutRequest      true   "Target layout"
+// @Success      200       {object}  SwitchLayoutResponse     "Migrated data and report"
+// @Failure      400       {object}  ErrorResponse            "Invalid request, unknown layout or migrated data that does not match the layout"
+// @Failure      403       {object}  ErrorResponse            "Invitation belongs to another user"
+// @Failure      404       {object}  ErrorResponse            "Invitation not found"
+// @Failure      409       {object}  VersionConflictResponse  "Invitation was modified by another session"
+// @Failure      428       {object}  ErrorResponse            "If-Match header or version field required"
+// @Router       /invitations/{id}/switch-layout [post]
+func (h *InvitationHandler) SwitchLayout(c *gin.Context) {
+	callerID, ok := invitationCallerID(c)
+	if !ok {
+		return
+	}
+
+	var req SwitchLayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	// A preview changes nothing, so it does not need a version
+	var expectedVersion *int
+	if !req.DryRun {
+		if expectedVersion, ok = expectedInvitationVersion(c, req.Version); !ok {
+			return
+		}
+	}
+
+	output, err := h.switchUC.Execute(c.Request.Context(), invitation.SwitchLayoutInput{
+		ID:              c.Param("id"),
+		UserID:          callerID,
+		LayoutID:        req.LayoutID,
+		DryRun:          req.DryRun,
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		if writeVersionConflict(c, err) {
+			return
+		}
+		appErr, ok := err.(*errors.AppError)
+		if ok {
+			c.JSON(appErr.Code, appErr.ToResponse())
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to switch layout"})
+		return
+	}
+
+	report := &LayoutMigrationReportResponse{
+		FromLayoutID:   output.Report.FromLayoutID,
+		ToLayoutID:     output.Report.ToLayoutID,
+		AddedSections:  output.Report.AddedSections,
+		NotCarriedOver: make([]ContentNotCarriedOverResponse, len(output.Report.NotCarriedOver)),
+	}
+	for i, lost := range output.Report.NotCarriedOver {
+		report.NotCarriedOver[i] = ContentNotCarriedOverResponse{Path: lost.Path, Reason: lost.Reason}
+	}
+
+	if output.Invitation != nil {
+		setInvitationETag(c, output.Invitation.Version)
+	}
+	c.JSON(http.StatusOK, SwitchLayoutResponse{
+		Invitation: toHandlerInvitationDTO(output.Invitation),
+		Data:       JSONDataFromRawMessage(output.Data),
+		Report:     report,
+	})
+}
+
+type UpgradeLayoutRequest struct {
+	// DryRun checks the data against the latest layout version without saving
+	DryRun bool `json:"dryRun" example:"true"`
+	// Version is an alternative to If-Match when applying the upgrade
+	Version *int `json:"version,omitempty" example:"3"`
+}
+
+type UpgradeLayoutResponse struct {
+	// Invitation is the updated invitation; omitted on a dry run
+	Invitation  *InvitationDTO `json:"invitation,omitempty"`
+	FromVersion string         `json:"fromVersion" example:"1.0.0"`
+	ToVersion   string         `json:"toVersion" example:"1.1.0"`
+}
+
+// UpgradeLayout moves an invitation to the latest version of its layout
+// @Summary      Upgrade invitation layout version
+// @Description  Invitations keep rendering with the layout version they were created or switched on. This opts one of the caller's invitations into the latest version of its layout. With dryRun the data is checked against the latest version without saving; otherwise the version the upgrade is based on is required via If-Match or the version field. Supports optional authentication (anonymous users are supported).
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string                   true   "Invitation ID"
+// @Param        If-Match  header    string                   false  "ETag of the version being upgraded, e.g. \"3\""
+// @Param        request   body      UpgradeLayoutRequest     false  "Upgrade options"
+// @Success      200       {object}  UpgradeLayoutResponse    "Upgraded invitation and versions"
+// @Failure      400       {object}  ErrorResponse            "Already on the latest version, or data that does not match it"
+// @Failure      403       {object}  ErrorResponse            "Invitation belongs to another user"
+// @Failure      404       {object}  ErrorResponse            "Invitation not found"
+// @Failure      409       {object}  VersionConflictResponse  "Invitation was modified by another session"
+// @Failure      428       {object}  ErrorResponse            "If-Match header or version field required"
+// @Router       /invitations/{id}/layout/upgrade [post]
+func (h *InvitationHandler) UpgradeLayout(c *gin.Context) {
+	callerID, ok := invitationCallerID(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional: an empty POST applies the upgrade with If-Match
+	var req UpgradeLayoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+	}
+
+	// A preview changes nothing, so it does not need a version
+	var expectedVersion *int
+	if !req.DryRun {
+		if expectedVersion, ok = expectedInvitationVersion(c, req.Version); !ok {
+			return
+		}
+	}
+
+	output, err := h.upgradeUC.Execute(c.Request.Context(), invitation.UpgradeLayoutVersionInput{
+		ID:              c.Param("id"),
+		UserID:          callerID,
+		DryRun:          req.DryRun,
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		if writeVersionConflict(c, err) {
+			return
+		}
+		appErr, ok := err.(*errors.AppError)
+		if ok {
+			c.JSON(appErr.Code, appErr.ToResponse())
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upgrade layout"})
+		return
+	}
+
+	if output.Invitation != nil {
+		setInvitationETag(c, output.Invitation.Version)
+	}
+	c.JSON(http.StatusOK, UpgradeLayoutResponse{
+		Invitation:  toHandlerInvitationDTO(output.Invitation),
+		FromVersion: output.FromVersion,
+		ToVersion:   output.ToVersion,
 	})
 }
 
 type MigrateInvitationsRequest struct {
-	FromUserID string `json:"fromUserID" binding:"required" example:"anonymous"`
+	// FromUserID is optional; when set it must be the caller's own guest session ID
+	FromUserID string `json:"fromUserID" example:"guest_2bZ8yQm6a8K3k1bTQeYxk2m0TfA"`
 	ToUserID   string `json:"toUserID" binding:"required" example:"user123"`
 }
 
@@ -492,16 +938,17 @@ type MigrateInvitationsResponse struct {
 	MigratedCount int `json:"migratedCount" example:"5"`
 }
 
-// MigrateInvitations migrates invitations from one user ID to another
+// MigrateInvitations claims the caller's guest session drafts for their account
 // @Summary      Migrate invitations
-// @Description  Migrate invitations from one user ID to another. Useful for migrating anonymous invitations to a user account.
+// @Description  Move the drafts created in the caller's guest session (identified by the guest_session cookie or X-Guest-Session header) to their account, typically right after signup. Only the caller's own guest session can be claimed.
 // @Tags         invitations
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        request  body      MigrateInvitationsRequest  true  "Migration request"
 // @Success      200      {object}  MigrateInvitationsResponse  "Migration successful"
-// @Failure      400      {object}  ErrorResponse              "Invalid request"
+// @Failure      400      {object}  ErrorResponse              "Invalid request or no guest session"
+// @Failure      403      {object}  ErrorResponse              "Not the caller's account or guest session"
 // @Failure      500      {object}  ErrorResponse              "Internal server error"
 // @Router       /invitations/migrate [post]
 func (h *InvitationHandler) MigrateInvitations(c *gin.Context) {
@@ -524,8 +971,19 @@ func (h *InvitationHandler) MigrateInvitations(c *gin.Context) {
 		return
 	}
 
+	// Drafts can only be claimed from the guest session this browser holds
+	guestID, ok := guestSessionID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No guest session to migrate"})
+		return
+	}
+	if req.FromUserID != "" && req.FromUserID != guestID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Can only migrate invitations from your own guest session"})
+		return
+	}
+
 	output, err := h.migrateUC.Execute(c.Request.Context(), invitation.MigrateInvitationsInput{
-		FromUserID: req.FromUserID,
+		FromUserID: guestID,
 		ToUserID:   req.ToUserID,
 	})
 
@@ -539,7 +997,82 @@ func (h *InvitationHandler) MigrateInvitations(c *gin.Context) {
 		return
 	}
 
+	// The session's drafts now belong to the account
+	middleware.ClearGuestSession(c)
+
 	c.JSON(http.StatusOK, gin.H{
 		"migratedCount": output.MigratedCount,
 	})
 }
+
+// invitationCallerID identifies the caller by user ID, or by guest session without an Authorization
+// header. It writes a 401 and returns false when neither is usable.
+func invitationCallerID(c *gin.Context) (string, bool) {
+	userID, exists := c.Get("userID")
+	if exists && userID != nil {
+		return userID.(string), true
+	}
+	if c.GetHeader("Authorization") != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+		return "", false
+	}
+	guestID, ok := guestSessionID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Guest session required"})
+		return "", false
+	}
+	return guestID, true
+}
+
+// guestSessionID returns the anonymous session ID resolved by middleware.GuestSession
+func guestSessionID(c *gin.Context) (string, bool) {
+	guestID, exists := c.Get("guestSessionID")
+	if !exists {
+		return "", false
+	}
+	id, ok := guestID.(string)
+	return id, ok && id != ""
+}
+
+// setInvitationETag exposes the invitation version as a strong ETag for use in If-Match
+func setInvitationETag(c *gin.Context, version int) {
+	c.Header("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
+// expectedInvitationVersion reads the version an update is based on from If-Match or the body.
+// It writes 428 when neither is given and 400 when If-Match is malformed. If-Match: * opts out.
+func expectedInvitationVersion(c *gin.Context, bodyVersion *int) (*int, bool) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" {
+		if bodyVersion == nil {
+			c.JSON(http.StatusPreconditionRequired, ErrorResponse{Error: "If-Match header or version field required"})
+			return nil, false
+		}
+		return bodyVersion, true
+	}
+	if ifMatch == "*" {
+		return nil, true
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`)
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid If-Match header"})
+		return nil, false
+	}
+	return &version, true
+}
+
+// writeVersionConflict writes 409 with the current version when err is a version conflict
+func writeVersionConflict(c *gin.Context, err error) bool {
+	var conflict *domain.VersionConflictError
+	if !stderrors.As(err, &conflict) {
+		return false
+	}
+	setInvitationETag(c, conflict.CurrentVersion)
+	c.JSON(http.StatusConflict, VersionConflictResponse{
+		Error:          "Invitation was modified by another session",
+		CurrentVersion: conflict.CurrentVersion,
+	})
+	return true
+}
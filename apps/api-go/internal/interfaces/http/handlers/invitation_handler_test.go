@@ -41,3 +41,250 @@ func TestInvitationHandler_Create_InvalidRequest_ReturnsBadRequest(t *testing.T)
 // Note: Full handler tests require mocking use cases which is complex.
 // The Create test above verifies request validation works correctly.
 // For comprehensive handler testing, use integration tests with real dependencies.
+
+func TestInvitationHandler_Update_RequiresVersion(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	req := httptest.NewRequest(http.MethodPut, "/invitations/inv-1", bytes.NewBufferString(`{"title":"Our Wedding"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: "inv-1"}}
+
+	// The precondition is checked before any use case runs
+	handler := &InvitationHandler{}
+
+	// Act
+	handler.Update(c)
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+}
+
+func TestInvitationRevisionHandler_Restore_RequiresVersion(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/invitations/inv-1/revisions/2/restore", nil)
+	c.Params = gin.Params{{Key: "id", Value: "inv-1"}, {Key: "rev", Value: "2"}}
+	c.Set("userID", "user-123")
+
+	// The precondition is checked before any use case runs
+	handler := &InvitationRevisionHandler{}
+
+	// Act
+	handler.Restore(c)
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+}
+
+func TestInvitationHandler_Patch_RequestValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		contentType string
+		ifMatch     string
+		wantCode    int
+	}{
+		{"plain json is rejected", "application/json", `"1"`, http.StatusUnsupportedMediaType},
+		{"json patch requires If-Match", "application/json-patch+json", "", http.StatusPreconditionRequired},
+		{"merge patch requires If-Match", "application/merge-patch+json; charset=utf-8", "", http.StatusPreconditionRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodPatch, "/invitations/inv-1", bytes.NewBufferString(`{"title":"Our Wedding"}`))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: "inv-1"}}
+
+			// Both checks run before any use case
+			handler := &InvitationHandler{}
+
+			// Act
+			handler.Patch(c)
+
+			// Assert
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestExpectedInvitationVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bodyVersion := 7
+
+	tests := []struct {
+		name        string
+		ifMatch     string
+		bodyVersion *int
+		wantOK      bool
+		wantVersion *int
+		wantCode    int
+	}{
+		{"strong etag", `"3"`, nil, true, intPtr(3), 0},
+		{"weak etag", `W/"3"`, nil, true, intPtr(3), 0},
+		{"header wins over body", `"3"`, &bodyVersion, true, intPtr(3), 0},
+		{"body version", "", &bodyVersion, true, intPtr(7), 0},
+		{"wildcard skips the check", "*", nil, true, nil, 0},
+		{"missing", "", nil, false, nil, http.StatusPreconditionRequired},
+		{"malformed", `"abc"`, nil, false, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/invitations/inv-1", nil)
+			if tt.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			version, ok := expectedInvitationVersion(c, tt.bodyVersion)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantVersion, version)
+			if !tt.wantOK {
+				assert.Equal(t, tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestInvitationHandler_MigrateInvitations_OnlyOwnGuestSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		body         string
+		guestSession string
+		wantCode     int
+	}{
+		{"no guest session", `{"toUserID":"user-123"}`, "", http.StatusBadRequest},
+		{"another browser's session", `{"fromUserID":"guest_other","toUserID":"user-123"}`, "guest_mine", http.StatusForbidden},
+		{"another account", `{"toUserID":"user-456"}`, "guest_mine", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodPost, "/invitations/migrate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Set("userID", "user-123")
+			if tt.guestSession != "" {
+				c.Set("guestSessionID", tt.guestSession)
+			}
+
+			// Ownership is checked before any use case runs
+			handler := &InvitationHandler{}
+
+			// Act
+			handler.MigrateInvitations(c)
+
+			// Assert
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestInvitationHandler_GetAll_RequiresGuestSessionWhenLoggedOut(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/invitations", nil)
+
+	handler := &InvitationHandler{}
+
+	// Act
+	handler.GetAll(c)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "Logged-out callers must not fall back to a shared account")
+}
+
+func TestInvitationHandler_Duplicate_RequestValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		userID   string
+		body     string
+		wantCode int
+	}{
+		{name: "logged out without guest session", body: `{}`, wantCode: http.StatusUnauthorized},
+		{name: "malformed body", userID: "user-123", body: `{"layoutId":`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/invitations/inv-1/duplicate", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: "inv-1"}}
+			if tt.userID != "" {
+				c.Set("userID", tt.userID)
+			}
+
+			// Validation fails before any use case is called
+			handler := &InvitationHandler{}
+
+			handler.Duplicate(c)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestInvitationHandler_SwitchLayout_RequestValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		userID   string
+		body     string
+		wantCode int
+	}{
+		{name: "logged out without guest session", body: `{"layoutId":"editorial-elegance"}`, wantCode: http.StatusUnauthorized},
+		{name: "missing layout", userID: "user-123", body: `{"dryRun":true}`, wantCode: http.StatusBadRequest},
+		{name: "apply without version", userID: "user-123", body: `{"layoutId":"editorial-elegance"}`, wantCode: http.StatusPreconditionRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/invitations/inv-1/switch-layout", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: "inv-1"}}
+			if tt.userID != "" {
+				c.Set("userID", tt.userID)
+			}
+
+			// Validation fails before any use case is called
+			handler := &InvitationHandler{}
+
+			handler.SwitchLayout(c)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
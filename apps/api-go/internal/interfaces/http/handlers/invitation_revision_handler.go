@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/usecase/invitation"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type InvitationRevisionHandler struct {
+	listUC    *invitation.ListInvitationRevisionsUseCase
+	getUC     *invitation.GetInvitationRevisionUseCase
+	restoreUC *invitation.RestoreInvitationRevisionUseCase
+}
+
+func NewInvitationRevisionHandler(
+	listUC *invitation.ListInvitationRevisionsUseCase,
+	getUC *invitation.GetInvitationRevisionUseCase,
+	restoreUC *invitation.RestoreInvitationRevisionUseCase,
+) *InvitationRevisionHandler {
+	return &InvitationRevisionHandler{
+		listUC:    listUC,
+		getUC:     getUC,
+		restoreUC: restoreUC,
+	}
+}
+
+type InvitationRevisionDTO struct {
+	Revision      int      `json:"revision" example:"3"`
+	LayoutID      string   `json:"layoutId" example:"classic-scroll"`
+	Data          JSONData `json:"data,omitempty" swagtype:"string" example:"{\"bride\":\"Jane\",\"groom\":\"John\"}"`
+	DefaultLocale string   `json:"defaultLocale,omitempty" example:"en"`
+	AuthorID      string   `json:"authorId" example:"user123"`
+	SizeBytes     int      `json:"sizeBytes" example:"2048"`
+	RestoredFrom  int      `json:"restoredFrom,omitempty" example:"1"`
+	CreatedAt     string   `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+
+	Translations map[string]json.RawMessage `json:"translations,omitempty"`
+}
+
+type InvitationRevisionsResponse struct {
+	Revisions []InvitationRevisionDTO `json:"revisions"`
+}
+
+type InvitationRevisionResponse struct {
+	Revision *InvitationRevisionDTO `json:"revision"`
+}
+
+func toHandlerInvitationRevisionDTO(dto *invitation.InvitationRevisionDTO) InvitationRevisionDTO {
+	out := InvitationRevisionDTO{
+		Revision:      dto.Revision,
+		LayoutID:      dto.LayoutID,
+		DefaultLocale: dto.DefaultLocale,
+		AuthorID:      dto.AuthorID,
+		SizeBytes:     dto.SizeBytes,
+		RestoredFrom:  dto.RestoredFrom,
+		CreatedAt:     dto.CreatedAt.Format(time.RFC3339),
+
+		Translations: dto.Translations,
+	}
+	if len(dto.Data) > 0 {
+		out.Data = JSONDataFromRawMessage(dto.Data)
+	}
+	return out
+}
+
+// List lists the revision history of an invitation
+// @Summary      List invitation revisions
+// @Description  List the saved revisions of one of the caller's invitations, newest first. Revision content is omitted; fetch a single revision to see it. Supports optional authentication (anonymous users are supported).
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string                       true  "Invitation ID"
+// @Success      200  {object}  InvitationRevisionsResponse  "Revision history"
+// @Failure      401  {object}  ErrorResponse                "Authentication or guest session required"
+// @Failure      403  {object}  ErrorResponse                "Invitation belongs to another user"
+// @Failure      404  {object}  ErrorResponse                "Invitation not found"
+// @Failure      500  {object}  ErrorResponse                "Internal server error"
+// @Router       /invitations/{id}/revisions [get]
+func (h *InvitationRevisionHandler) List(c *gin.Context) {
+	callerID, ok := invitationCallerID(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.listUC.Execute(c.Request.Context(), c.Param("id"), callerID)
+	if err != nil {
+		writeRevisionError(c, err)
+		return
+	}
+
+	revisions := make([]InvitationRevisionDTO, len(output.Revisions))
+	for i, rev := range output.Revisions {
+		revisions[i] = toHandlerInvitationRevisionDTO(rev)
+	}
+	c.JSON(http.StatusOK, InvitationRevisionsResponse{Revisions: revisions})
+}
+
+// Get returns a single revision including its content
+// @Summary      Get invitation revision
+// @Description  Get a single revision of one of the caller's invitations, including its data snapshot. Supports optional authentication (anonymous users are supported).
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string                      true  "Invitation ID"
+// @Param        rev  path      int                         true  "Revision number"
+// @Success      200  {object}  InvitationRevisionResponse  "Revision"
+// @Failure      400  {object}  ErrorResponse               "Invalid revision number"
+// @Failure      401  {object}  ErrorResponse               "Authentication or guest session required"
+// @Failure      403  {object}  ErrorResponse               "Invitation belongs to another user"
+// @Failure      404  {object}  ErrorResponse               "Invitation or revision not found"
+// @Router       /invitations/{id}/revisions/{rev} [get]
+func (h *InvitationRevisionHandler) Get(c *gin.Context) {
+	rev, ok := parseRevisionParam(c)
+	if !ok {
+		return
+	}
+	callerID, ok := invitationCallerID(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.getUC.Execute(c.Request.Context(), c.Param("id"), callerID, rev)
+	if err != nil {
+		writeRevisionError(c, err)
+		return
+	}
+
+	dto := toHandlerInvitationRevisionDTO(output.Revision)
+	c.JSON(http.StatusOK, InvitationRevisionResponse{Revision: &dto})
+}
+
+// Restore restores an invitation to an earlier revision
+// @Summary      Restore invitation revision
+// @Description  Replace the content of one of the caller's invitations with that of an earlier revision. The restore is recorded as a new revision. Requires If-Match with the version being replaced (the ETag returned by GET); a stale version returns 409 with the current version. Supports optional authentication (anonymous users are supported).
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string                   true   "Invitation ID"
+// @Param        rev       path      int                      true   "Revision number"
+// @Param        If-Match  header    string                   true   "ETag of the version being replaced, e.g. \"3\""
+// @Success      200       {object}  InvitationResponse       "Invitation restored"
+// @Failure      400       {object}  ErrorResponse            "Invalid revision number or If-Match header"
+// @Failure      401       {object}  ErrorResponse            "Authentication or guest session required"
+// @Failure      403       {object}  ErrorResponse            "Invitation belongs to another user"
+// @Failure      404       {object}  ErrorResponse            "Invitation or revision not found"
+// @Failure      409       {object}  VersionConflictResponse  "Invitation was modified by another session"
+// @Failure      428       {object}  ErrorResponse            "If-Match header required"
+// @Failure      500       {object}  ErrorResponse            "Internal server error"
+// @Router       /invitations/{id}/revisions/{rev}/restore [post]
+func (h *InvitationRevisionHandler) Restore(c *gin.Context) {
+	id := c.Param("id")
+	rev, ok := parseRevisionParam(c)
+	if !ok {
+		return
+	}
+
+	expectedVersion, ok := expectedInvitationVersion(c, nil)
+	if !ok {
+		return
+	}
+
+	callerID, ok := invitationCallerID(c)
+	if !ok {
+		return
+	}
+
+	logger.GetLogger().Info("Restore invitation revision",
+		zap.String("invitationID", id),
+		zap.Int("revision", rev),
+		zap.String("userID", callerID),
+	)
+
+	output, err := h.restoreUC.Execute(c.Request.Context(), invitation.RestoreInvitationRevisionInput{
+		InvitationID:    id,
+		Revision:        rev,
+		AuthorID:        callerID,
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		if writeVersionConflict(c, err) {
+			return
+		}
+		writeRevisionError(c, err)
+		return
+	}
+
+	setInvitationETag(c, output.Invitation.Version)
+	c.JSON(http.StatusOK, gin.H{"invitation": toHandlerInvitationDTO(output.Invitation)})
+}
+
+func parseRevisionParam(c *gin.Context) (int, bool) {
+	rev, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || rev <= 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid revision number"})
+		return 0, false
+	}
+	return rev, true
+}
+
+func writeRevisionError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		c.JSON(appErr.Code, appErr.ToResponse())
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+}
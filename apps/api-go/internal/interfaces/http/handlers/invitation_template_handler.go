@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/usecase/invitation"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+type InvitationTemplateHandler struct {
+	saveUC       *invitation.SaveAsTemplateUseCase
+	listUC       *invitation.ListTemplatesUseCase
+	getUC        *invitation.GetTemplateUseCase
+	deleteUC     *invitation.DeleteTemplateUseCase
+	createFromUC *invitation.CreateFromTemplateUseCase
+}
+
+func NewInvitationTemplateHandler(
+	saveUC *invitation.SaveAsTemplateUseCase,
+	listUC *invitation.ListTemplatesUseCase,
+	getUC *invitation.GetTemplateUseCase,
+	deleteUC *invitation.DeleteTemplateUseCase,
+	createFromUC *invitation.CreateFromTemplateUseCase,
+) *InvitationTemplateHandler {
+	return &InvitationTemplateHandler{
+		saveUC:       saveUC,
+		listUC:       listUC,
+		getUC:        getUC,
+		deleteUC:     deleteUC,
+		createFromUC: createFromUC,
+	}
+}
+
+type SaveTemplateRequest struct {
+	InvitationID string `json:"invitationId" binding:"required" example:"2bZ8yQm6a8K3k1bTQeYxk2m0TfA"`
+	// Name defaults to the invitation title
+	Name        string `json:"name" example:"Two-day Hindu wedding"`
+	Description string `json:"description" example:"Tilak, haldi and mehandi on day one"`
+	// StripPersonalData leaves out names, wedding party, dates, venue, RSVP contacts, photos and videos
+	StripPersonalData bool `json:"stripPersonalData" example:"true"`
+}
+
+type CreateFromTemplateRequest struct {
+	Title *string `json:"title" example:"Smith wedding"`
+}
+
+type InvitationTemplateDTO struct {
+	ID                 string   `json:"id" example:"2bZ9c1kV8dPqL3nR7tYw0xJ5mHs"`
+	UserID             string   `json:"userId" example:"user123"`
+	Name               string   `json:"name" example:"Two-day Hindu wedding"`
+	Description        string   `json:"description,omitempty" example:"Tilak, haldi and mehandi on day one"`
+	LayoutID           string   `json:"layoutId" example:"classic-scroll"`
+	Data               JSONData `json:"data" swagtype:"string" example:"{\"events\":{}}"`
+	DefaultLocale      string   `json:"defaultLocale,omitempty" example:"en"`
+	SourceInvitationID string   `json:"sourceInvitationId,omitempty" example:"2bZ8yQm6a8K3k1bTQeYxk2m0TfA"`
+	CreatedAt          string   `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt          string   `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+
+	Translations map[string]json.RawMessage `json:"translations,omitempty"`
+}
+
+type InvitationTemplateResponse struct {
+	Template *InvitationTemplateDTO `json:"template"`
+}
+
+type InvitationTemplatesResponse struct {
+	Templates  []InvitationTemplateDTO `json:"templates"`
+	NextCursor string                  `json:"nextCursor,omitempty"`
+}
+
+func toHandlerInvitationTemplateDTO(dto *invitation.InvitationTemplateDTO) *InvitationTemplateDTO {
+	return &InvitationTemplateDTO{
+		ID:                 dto.ID,
+		UserID:             dto.UserID,
+		Name:               dto.Name,
+		Description:        dto.Description,
+		LayoutID:           dto.LayoutID,
+		Data:               JSONDataFromRawMessage(dto.Data),
+		DefaultLocale:      dto.DefaultLocale,
+		SourceInvitationID: dto.SourceInvitationID,
+		CreatedAt:          dto.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          dto.UpdatedAt.Format(time.RFC3339),
+
+		Translations: dto.Translations,
+	}
+}
+
+// Save saves one of the caller's invitations as a template
+// @Summary      Save invitation as template
+// @Description  Save a copy of one of the current user's invitations as a personal template, optionally without personal details. Later edits to the invitation do not change the template. Authentication is required.
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      SaveTemplateRequest         true  "Template to save"
+// @Success      201      {object}  InvitationTemplateResponse  "Template saved"
+// @Failure      400      {object}  ErrorResponse               "Invalid request or missing name"
+// @Failure      401      {object}  ErrorResponse               "Authentication required"
+// @Failure      403      {object}  ErrorResponse               "Invitation belongs to another user"
+// @Failure      404      {object}  ErrorResponse               "Invitation not found"
+// @Router       /templates [post]
+func (h *InvitationTemplateHandler) Save(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req SaveTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	output, err := h.saveUC.Execute(c.Request.Context(), invitation.SaveAsTemplateInput{
+		InvitationID:      req.InvitationID,
+		UserID:            userID.(string),
+		Name:              req.Name,
+		Description:       req.Description,
+		StripPersonalData: req.StripPersonalData,
+	})
+	if err != nil {
+		writeTemplateError(c, err, "Failed to save template")
+		return
+	}
+
+	c.JSON(http.StatusCreated, InvitationTemplateResponse{Template: toHandlerInvitationTemplateDTO(output)})
+}
+
+// GetAll lists the caller's templates
+// @Summary      List templates
+// @Description  List the current user's invitation templates. Authentication is required.
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit     query     int     false  "Page size (1-100, default 50)"
+// @Param        cursor    query     string  false  "nextCursor from the previous page"
+// @Param        sort      query     string  false  "createdAt or name, prefix - for descending (default -createdAt)"
+// @Param        layoutId  query     string  false  "Only templates using this layout"
+// @Success      200  {object}  InvitationTemplatesResponse  "List of templates"
+// @Failure      400  {object}  ErrorResponse                "Invalid pagination parameters"
+// @Failure      401  {object}  ErrorResponse                "Authentication required"
+// @Router       /templates [get]
+func (h *InvitationTemplateHandler) GetAll(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	opts, err := parseListOptions(c, "layoutId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := h.listUC.Execute(c.Request.Context(), userID.(string), opts)
+	if err != nil {
+		writeTemplateError(c, err, "Failed to get templates")
+		return
+	}
+
+	templates := make([]InvitationTemplateDTO, len(output.Templates))
+	for i, template := range output.Templates {
+		templates[i] = *toHandlerInvitationTemplateDTO(template)
+	}
+	c.JSON(http.StatusOK, InvitationTemplatesResponse{Templates: templates, NextCursor: output.NextCursor})
+}
+
+// GetByID returns one of the caller's templates
+// @Summary      Get template
+// @Description  Get one of the current user's invitation templates. Authentication is required.
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string                      true  "Template ID"
+// @Success      200  {object}  InvitationTemplateResponse  "Template"
+// @Failure      401  {object}  ErrorResponse               "Authentication required"
+// @Failure      403  {object}  ErrorResponse               "Template belongs to another user"
+// @Failure      404  {object}  ErrorResponse               "Template not found"
+// @Router       /templates/{id} [get]
+func (h *InvitationTemplateHandler) GetByID(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	output, err := h.getUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		writeTemplateError(c, err, "Failed to get template")
+		return
+	}
+
+	c.JSON(http.StatusOK, InvitationTemplateResponse{Template: toHandlerInvitationTemplateDTO(output)})
+}
+
+// Delete permanently deletes one of the caller's templates
+// @Summary      Delete template
+// @Description  Permanently delete one of the current user's invitation templates. Invitations created from it are not affected. Authentication is required.
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Template ID"
+// @Success      200  {object}  MessageResponse  "Template deleted"
+// @Failure      401  {object}  ErrorResponse    "Authentication required"
+// @Failure      403  {object}  ErrorResponse    "Template belongs to another user"
+// @Failure      404  {object}  ErrorResponse    "Template not found"
+// @Router       /templates/{id} [delete]
+func (h *InvitationTemplateHandler) Delete(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	if err := h.deleteUC.Execute(c.Request.Context(), c.Param("id"), userID.(string)); err != nil {
+		writeTemplateError(c, err, "Failed to delete template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Template deleted"})
+}
+
+// CreateInvitation starts a new invitation from one of the caller's templates
+// @Summary      Create invitation from template
+// @Description  Create a new draft invitation with the layout and content of one of the current user's templates. Authentication is required.
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                     true   "Template ID"
+// @Param        request  body      CreateFromTemplateRequest  false  "Invitation options"
+// @Success      201      {object}  InvitationResponse         "Invitation created"
+// @Failure      400      {object}  ErrorResponse              "Invalid request"
+// @Failure      401      {object}  ErrorResponse              "Authentication required"
+// @Failure      403      {object}  ErrorResponse              "Template belongs to another user"
+// @Failure      404      {object}  ErrorResponse              "Template not found"
+// @Router       /templates/{id}/invitations [post]
+func (h *InvitationTemplateHandler) CreateInvitation(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req CreateFromTemplateRequest
+	// The body is optional; without a title the invitation starts untitled
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+	}
+
+	output, err := h.createFromUC.Execute(c.Request.Context(), invitation.CreateFromTemplateInput{
+		TemplateID: c.Param("id"),
+		UserID:     userID.(string),
+		Title:      req.Title,
+	})
+	if err != nil {
+		writeTemplateError(c, err, "Failed to create invitation")
+		return
+	}
+
+	setInvitationETag(c, output.Invitation.Version)
+	c.JSON(http.StatusCreated, gin.H{"invitation": toHandlerInvitationDTO(output.Invitation)})
+}
+
+func writeTemplateError(c *gin.Context, err error, fallback string) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		c.JSON(appErr.Code, appErr.ToResponse())
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
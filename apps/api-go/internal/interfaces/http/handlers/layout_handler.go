@@ -1,9 +1,16 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 	"github.com/sacred-vows/api-go/internal/usecase/layout"
 	"github.com/sacred-vows/api-go/pkg/errors"
 )
@@ -13,31 +20,40 @@ type LayoutHandler struct {
 	getByIDUC      *layout.GetLayoutByIDUseCase
 	getManifestUC  *layout.GetLayoutManifestUseCase
 	getManifestsUC *layout.GetManifestsUseCase
+	cacheControl   string
 }
 
+// NewLayoutHandler serves the layout endpoints with a Cache-Control max-age of cacheMaxAge
 func NewLayoutHandler(
 	getAllUC *layout.GetAllLayoutsUseCase,
 	getByIDUC *layout.GetLayoutByIDUseCase,
 	getManifestUC *layout.GetLayoutManifestUseCase,
 	getManifestsUC *layout.GetManifestsUseCase,
+	cacheMaxAge time.Duration,
 ) *LayoutHandler {
 	return &LayoutHandler{
 		getAllUC:       getAllUC,
 		getByIDUC:      getByIDUC,
 		getManifestUC:  getManifestUC,
 		getManifestsUC: getManifestsUC,
+		cacheControl:   fmt.Sprintf("public, max-age=%d", int(cacheMaxAge.Seconds())),
 	}
 }
 
 // GetAll retrieves all available layouts
 // @Summary      List layouts
-// @Description  Get all available layouts with optional filtering by category and featured status.
+// @Description  Get all available layouts with optional filtering by category, featured status and search terms, sorted by popularity, creation date or name. Responses carry a strong ETag; a matching If-None-Match returns 304.
 // @Tags         layouts
 // @Accept       json
 // @Produce      json
-// @Param        category  query     string  false  "Filter by category (e.g., 'elegant', 'modern')"
-// @Param        featured  query     string  false  "Filter featured layouts (true/false)"
+// @Param        category       query     string  false  "Filter by category (e.g., 'elegant', 'modern')"
+// @Param        featured       query     string  false  "Filter featured layouts (true/false)"
+// @Param        q              query     string  false  "Search terms matched against name, description, category and tags"
+// @Param        sort           query     string  false  "popularity, createdAt or name; prefix with - for descending (e.g. -popularity)"
+// @Param        If-None-Match  header    string  false  "ETag of a cached response"
 // @Success      200       {object}  map[string]interface{}  "Layouts and categories"
+// @Success      304       "Not modified"
+// @Failure      400       {object}  ErrorResponse           "Invalid sort"
 // @Failure      500       {object}  ErrorResponse           "Internal server error"
 // @Router       /layouts [get]
 func (h *LayoutHandler) GetAll(c *gin.Context) {
@@ -55,9 +71,18 @@ func (h *LayoutHandler) GetAll(c *gin.Context) {
 		featuredPtr = &featured
 	}
 
+	sortField, sortDirection, err := repository.ParseSort(c.Query("sort"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort"})
+		return
+	}
+
 	output, err := h.getAllUC.Execute(c.Request.Context(), layout.GetAllLayoutsInput{
-		Category: categoryPtr,
-		Featured: featuredPtr,
+		Category:      categoryPtr,
+		Featured:      featuredPtr,
+		Query:         c.Query("q"),
+		SortField:     sortField,
+		SortDirection: sortDirection,
 	})
 
 	if err != nil {
@@ -74,7 +99,7 @@ func (h *LayoutHandler) GetAll(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	h.writeCacheable(c, gin.H{
 		"layouts":    output.Layouts,
 		"categories": output.Categories,
 	})
@@ -82,11 +107,13 @@ func (h *LayoutHandler) GetAll(c *gin.Context) {
 
 // GetManifests retrieves all layout manifests
 // @Summary      Get all manifests
-// @Description  Get manifests for all available layouts.
+// @Description  Get manifests for all available layouts. Responses carry a strong ETag; a matching If-None-Match returns 304.
 // @Tags         layouts
 // @Accept       json
 // @Produce      json
+// @Param        If-None-Match  header  string  false  "ETag of a cached response"
 // @Success      200  {object}  map[string]interface{}  "All layout manifests"
+// @Success      304  "Not modified"
 // @Failure      500  {object}  ErrorResponse           "Internal server error"
 // @Router       /layouts/manifests [get]
 func (h *LayoutHandler) GetManifests(c *gin.Context) {
@@ -101,23 +128,26 @@ func (h *LayoutHandler) GetManifests(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"manifests": output.Manifests})
+	h.writeCacheable(c, gin.H{"manifests": output.Manifests})
 }
 
 // GetManifest retrieves a specific layout manifest
 // @Summary      Get layout manifest
-// @Description  Get the manifest for a specific layout by ID.
+// @Description  Get the manifest for a specific layout by ID. Pass a version to get the manifest an invitation pinned to that version renders with.
 // @Tags         layouts
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Layout ID"
+// @Param        id       path      string  true   "Layout ID"
+// @Param        version        query     string  false  "Layout version (defaults to the current version)"
+// @Param        If-None-Match  header    string  false  "ETag of a cached response"
 // @Success      200  {object}  map[string]interface{}  "Layout manifest"
-// @Failure      404  {object}  ErrorResponse           "Layout not found"
+// @Success      304  "Not modified"
+// @Failure      404  {object}  ErrorResponse           "Layout or layout version not found"
 // @Failure      500  {object}  ErrorResponse           "Internal server error"
 // @Router       /layouts/{id}/manifest [get]
 func (h *LayoutHandler) GetManifest(c *gin.Context) {
 	id := c.Param("id")
-	output, err := h.getManifestUC.Execute(c.Request.Context(), id)
+	output, err := h.getManifestUC.Execute(c.Request.Context(), id, c.Query("version"))
 	if err != nil {
 		appErr, ok := err.(*errors.AppError)
 		if ok {
@@ -128,7 +158,7 @@ func (h *LayoutHandler) GetManifest(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"manifest": output.Manifest})
+	h.writeCacheable(c, gin.H{"manifest": output.Manifest})
 }
 
 // GetByID retrieves a layout by ID
@@ -137,8 +167,10 @@ func (h *LayoutHandler) GetManifest(c *gin.Context) {
 // @Tags         layouts
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Layout ID"
+// @Param        id             path      string  true   "Layout ID"
+// @Param        If-None-Match  header    string  false  "ETag of a cached response"
 // @Success      200  {object}  map[string]interface{}  "Layout details"
+// @Success      304  "Not modified"
 // @Failure      404  {object}  ErrorResponse           "Layout not found"
 // @Failure      500  {object}  ErrorResponse           "Internal server error"
 // @Router       /layouts/{id} [get]
@@ -155,5 +187,37 @@ func (h *LayoutHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"layout": output.Layout})
+	h.writeCacheable(c, gin.H{"layout": output.Layout})
+}
+
+// writeCacheable writes body as JSON with Cache-Control and a strong ETag over the exact bytes,
+// and answers 304 without a body when the request's If-None-Match already has that ETag
+func (h *LayoutHandler) writeCacheable(c *gin.Context, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encode response"})
+		return
+	}
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", h.cacheControl)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// etagMatches reports whether an If-None-Match header lists etag. If-None-Match uses the weak
+// comparison, so a W/ prefix is ignored.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveLayouts(engine *gin.Engine, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestLayoutHandler_ConditionalRequests(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	manifest := json.RawMessage(`{"id":"classic-scroll","name":"Classic Scroll","status":"ready"}`)
+	layoutRepo := &memoryLayoutRepository{layouts: map[string]*domain.Layout{
+		"classic-scroll": {ID: "classic-scroll", Name: "Classic Scroll", Version: "1.0.0", Manifest: &manifest, IsActive: true},
+	}}
+	catalog := layout.NewLayoutCatalog(layoutRepo, nil, time.Hour, clock.NewRealClock())
+	handler := NewLayoutHandler(
+		layout.NewGetAllLayoutsUseCase(catalog),
+		layout.NewGetLayoutByIDUseCase(layoutRepo),
+		layout.NewGetLayoutManifestUseCase(layoutRepo),
+		layout.NewGetManifestsUseCase(catalog),
+		time.Minute,
+	)
+	engine := gin.New()
+	engine.GET("/layouts", handler.GetAll)
+	engine.GET("/layouts/manifests", handler.GetManifests)
+	updateUC := layout.NewUpdateLayoutUseCase(layoutRepo, nil, catalog)
+
+	for _, path := range []string{"/layouts", "/layouts/manifests"} {
+		t.Run(path, func(t *testing.T) {
+			// Act
+			first := serveLayouts(engine, path, "")
+			etag := first.Header().Get("ETag")
+			notModified := serveLayouts(engine, path, `"other", `+etag)
+			weak := serveLayouts(engine, path, "W/"+etag)
+			stale := serveLayouts(engine, path, `"other"`)
+
+			// Assert
+			require.Equal(t, http.StatusOK, first.Code)
+			assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+			assert.Equal(t, "public, max-age=60", first.Header().Get("Cache-Control"))
+			assert.Contains(t, first.Body.String(), "classic-scroll")
+
+			assert.Equal(t, http.StatusNotModified, notModified.Code)
+			assert.Empty(t, notModified.Body.String())
+			assert.Equal(t, etag, notModified.Header().Get("ETag"))
+			assert.Equal(t, http.StatusNotModified, weak.Code)
+			assert.Equal(t, http.StatusOK, stale.Code)
+			assert.Equal(t, first.Body.String(), stale.Body.String())
+		})
+	}
+
+	t.Run("admin changes invalidate the catalog", func(t *testing.T) {
+		before := serveLayouts(engine, "/layouts", "")
+		description := "Now with a guestbook"
+
+		_, err := updateUC.Execute(context.Background(), layout.UpdateLayoutInput{ID: "classic-scroll", Description: &description, ActorID: "admin-1"})
+		require.NoError(t, err)
+		after := serveLayouts(engine, "/layouts", before.Header().Get("ETag"))
+
+		require.Equal(t, http.StatusOK, after.Code)
+		assert.NotEqual(t, before.Header().Get("ETag"), after.Header().Get("ETag"))
+		assert.Contains(t, after.Body.String(), description)
+	})
+}
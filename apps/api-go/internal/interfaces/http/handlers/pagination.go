@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+)
+
+// parseListOptions reads ?limit=&cursor=&sort= plus the given equality filter params.
+// sort is a field name, prefixed with "-" for descending (e.g. sort=-createdAt).
+func parseListOptions(c *gin.Context, filterParams ...string) (repository.ListOptions, error) {
+	opts := repository.ListOptions{Cursor: c.Query("cursor")}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > repository.MaxPageLimit {
+			return opts, fmt.Errorf("limit must be between 1 and %d", repository.MaxPageLimit)
+		}
+		opts.Limit = limit
+	}
+
+	field, direction, err := repository.ParseSort(c.Query("sort"))
+	if err != nil {
+		return opts, fmt.Errorf("invalid sort")
+	}
+	opts.SortField, opts.SortDirection = field, direction
+
+	for _, param := range filterParams {
+		if value := c.Query(param); value != "" {
+			if opts.Filters == nil {
+				opts.Filters = map[string]string{}
+			}
+			opts.Filters[param] = value
+		}
+	}
+	return opts, nil
+}
+
+// withNextCursor adds nextCursor to a list response when there is another page
+func withNextCursor(body gin.H, nextCursor string) gin.H {
+	if nextCursor != "" {
+		body["nextCursor"] = nextCursor
+	}
+	return body
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListOptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    repository.ListOptions
+		wantErr bool
+	}{
+		{"defaults", "", repository.ListOptions{}, false},
+		{"limit and cursor", "limit=20&cursor=abc", repository.ListOptions{Limit: 20, Cursor: "abc"}, false},
+		{"ascending sort", "sort=createdAt", repository.ListOptions{SortField: "createdAt", SortDirection: repository.SortAsc}, false},
+		{"descending sort", "sort=-createdAt", repository.ListOptions{SortField: "createdAt", SortDirection: repository.SortDesc}, false},
+		{"filter", "layoutId=classic-scroll&other=x", repository.ListOptions{Filters: map[string]string{"layoutId": "classic-scroll"}}, false},
+		{"limit too large", "limit=101", repository.ListOptions{}, true},
+		{"limit not a number", "limit=ten", repository.ListOptions{}, true},
+		{"empty sort field", "sort=-", repository.ListOptions{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/invitations?"+tt.query, nil)
+
+			opts, err := parseListOptions(c, "layoutId")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, opts)
+		})
+	}
+}
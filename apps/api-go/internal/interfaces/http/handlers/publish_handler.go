@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/domain"
 	"github.com/sacred-vows/api-go/internal/usecase/publish"
 	"github.com/sacred-vows/api-go/pkg/logger"
 	"go.uber.org/zap"
@@ -14,8 +18,10 @@ import (
 type PublishHandler struct {
 	validateUC      *publish.ValidateSubdomainUseCase
 	publishUC       *publish.PublishInvitationUseCase
+	unpublishUC     *publish.UnpublishInvitationUseCase
 	listVersionsUC  *publish.ListPublishedVersionsUseCase
 	rollbackUC      *publish.RollbackPublishedSiteUseCase
+	exportUC        *publish.ExportPublishedSiteUseCase
 	baseDomain      string
 	subdomainSuffix string // Optional suffix (e.g., "-dev") to append to subdomain in URL
 	serverPort      string
@@ -24,8 +30,10 @@ type PublishHandler struct {
 func NewPublishHandler(
 	validateUC *publish.ValidateSubdomainUseCase,
 	publishUC *publish.PublishInvitationUseCase,
+	unpublishUC *publish.UnpublishInvitationUseCase,
 	listVersionsUC *publish.ListPublishedVersionsUseCase,
 	rollbackUC *publish.RollbackPublishedSiteUseCase,
+	exportUC *publish.ExportPublishedSiteUseCase,
 	baseDomain string,
 	subdomainSuffix string,
 	serverPort string,
@@ -33,8 +41,10 @@ func NewPublishHandler(
 	return &PublishHandler{
 		validateUC:      validateUC,
 		publishUC:       publishUC,
+		unpublishUC:     unpublishUC,
 		listVersionsUC:  listVersionsUC,
 		rollbackUC:      rollbackUC,
+		exportUC:        exportUC,
 		baseDomain:      baseDomain,
 		subdomainSuffix: subdomainSuffix,
 		serverPort:      serverPort,
@@ -141,7 +151,11 @@ func (h *PublishHandler) Publish(c *gin.Context) {
 			zap.Error(err),
 		)
 		// Keep error mapping simple for now
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, ErrorResponse{Error: err.Error()})
 		return
 	}
 	logger.GetLogger().Info("publish succeeded",
@@ -208,6 +222,54 @@ func (h *PublishHandler) Publish(c *gin.Context) {
 	})
 }
 
+type unpublishRequest struct {
+	InvitationID string `json:"invitationId"`
+}
+
+// Unpublish takes an invitation's published site offline
+// @Summary      Unpublish invitation
+// @Description  Take a published invitation offline and mark it unpublished. Published versions are kept, so publishing again restores the site. Authentication is required.
+// @Tags         publish
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      unpublishRequest  true  "Unpublish request"
+// @Success      200      {object}  MessageResponse   "Invitation unpublished"
+// @Failure      400      {object}  ErrorResponse     "Invalid request"
+// @Failure      401      {object}  ErrorResponse     "Authentication required"
+// @Failure      409      {object}  ErrorResponse     "Invitation is not published"
+// @Router       /publish/unpublish [post]
+func (h *PublishHandler) Unpublish(c *gin.Context) {
+	var req unpublishRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.InvitationID == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request"})
+		return
+	}
+
+	userIDAny, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "authentication required"})
+		return
+	}
+	userID, _ := userIDAny.(string)
+
+	if err := h.unpublishUC.Execute(c.Request.Context(), req.InvitationID, userID); err != nil {
+		logger.GetLogger().Warn("unpublish failed",
+			zap.String("userId", userID),
+			zap.String("invitationId", req.InvitationID),
+			zap.Error(err),
+		)
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{Message: "Invitation unpublished"})
+}
+
 type listVersionsResponse struct {
 	Versions []publish.VersionInfo `json:"versions"`
 }
@@ -314,3 +376,68 @@ func (h *PublishHandler) Rollback(c *gin.Context) {
 		Message: "Rollback successful",
 	})
 }
+
+// Export downloads a published site version as a ZIP archive
+// @Summary      Export published site
+// @Description  Download every artifact of a published version as a ZIP archive that works when opened locally. Defaults to the current version. Authentication is required.
+// @Tags         publish
+// @Produce      application/zip
+// @Security     BearerAuth
+// @Param        subdomain  query     string  true   "Subdomain of the published site"
+// @Param        version    query     int     false  "Version to export (defaults to the current version)"
+// @Success      200        {file}    file           "ZIP archive"
+// @Failure      400        {object}  ErrorResponse  "Invalid request"
+// @Failure      401        {object}  ErrorResponse  "Authentication required"
+// @Failure      413        {object}  ErrorResponse  "Export too large"
+// @Router       /published/export [get]
+func (h *PublishHandler) Export(c *gin.Context) {
+	subdomain := c.Query("subdomain")
+	if subdomain == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "subdomain is required"})
+		return
+	}
+	version := 0
+	if raw := c.Query("version"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "version must be a positive integer"})
+			return
+		}
+		version = v
+	}
+
+	userIDAny, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "authentication required"})
+		return
+	}
+	userID, _ := userIDAny.(string)
+
+	export, err := h.exportUC.Execute(c.Request.Context(), subdomain, version, userID)
+	if err != nil {
+		logger.GetLogger().Warn("export failed",
+			zap.String("userId", userID),
+			zap.String("subdomain", subdomain),
+			zap.Int("version", version),
+			zap.Error(err),
+		)
+		if errors.Is(err, domain.ErrExportTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, export.Filename))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+	if err := export.WriteZip(c.Request.Context(), c.Writer); err != nil {
+		// Headers are already sent; the client sees a truncated archive.
+		logger.GetLogger().Error("failed to write export archive",
+			zap.String("subdomain", subdomain),
+			zap.Error(err),
+		)
+	}
+}
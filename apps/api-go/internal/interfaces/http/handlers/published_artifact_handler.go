@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/publish"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// PublishedArtifactHandler streams published artifacts from ArtifactStorage instead of redirecting
+// guests to the bucket. It sets strong ETags, honours conditional and Range requests, replays the
+// Cache-Control recorded at publish time and serves precompressed variants by Accept-Encoding.
+type PublishedArtifactHandler struct {
+	artifactStore publish.ArtifactStorage
+}
+
+func NewPublishedArtifactHandler(artifactStore publish.ArtifactStorage) *PublishedArtifactHandler {
+	return &PublishedArtifactHandler{artifactStore: artifactStore}
+}
+
+// ServeKey writes the artifact stored at key (e.g. sites/foo/v3/index.html).
+func (h *PublishedArtifactHandler) ServeKey(c *gin.Context, key string) {
+	ctx := c.Request.Context()
+	compressible := publish.IsCompressibleKey(key)
+
+	var artifact *publish.Artifact
+	contentEncoding := ""
+	if compressible {
+		for _, enc := range acceptedEncodings(c.GetHeader("Accept-Encoding")) {
+			suffix := publish.GzipVariantSuffix
+			if enc == "br" {
+				suffix = publish.BrotliVariantSuffix
+			}
+			variant, err := h.artifactStore.Get(ctx, key+suffix)
+			if err == nil {
+				artifact = variant
+				contentEncoding = enc
+				break
+			}
+			if !errors.Is(err, domain.ErrArtifactNotFound) {
+				logger.GetLogger().Warn("failed to read precompressed artifact",
+					zap.String("key", key+suffix),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	if artifact == nil {
+		var err error
+		artifact, err = h.artifactStore.Get(ctx, key)
+		if errors.Is(err, domain.ErrArtifactNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Not found"})
+			return
+		}
+		if err != nil {
+			logger.GetLogger().Error("failed to read published artifact",
+				zap.String("key", key),
+				zap.Error(err),
+			)
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to load published site"})
+			return
+		}
+	}
+
+	header := c.Writer.Header()
+	if artifact.ContentType != "" {
+		header.Set("Content-Type", artifact.ContentType)
+	}
+	if artifact.CacheControl != "" {
+		header.Set("Cache-Control", artifact.CacheControl)
+	}
+	if compressible {
+		header.Add("Vary", "Accept-Encoding")
+	}
+	if contentEncoding != "" {
+		header.Set("Content-Encoding", contentEncoding)
+	}
+	// Strong validator over the exact bytes served, so each encoding has its own ETag.
+	sum := sha256.Sum256(artifact.Body)
+	header.Set("ETag", fmt.Sprintf(`"%x"`, sum[:16]))
+
+	// ServeContent handles If-None-Match/If-Modified-Since (304), Range (206/416) and HEAD.
+	http.ServeContent(c.Writer, c.Request, "", artifact.ModTime, bytes.NewReader(artifact.Body))
+}
+
+// acceptedEncodings returns the precompressed encodings the client accepts, in server preference
+// order (brotli first). Encodings with q=0 are treated as refused.
+func acceptedEncodings(acceptEncoding string) []string {
+	accepted := map[string]bool{}
+	wildcard := false
+	refused := map[string]bool{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, p := range strings.Split(params, ";") {
+			k, v, ok := strings.Cut(strings.TrimSpace(p), "=")
+			if ok && strings.TrimSpace(k) == "q" {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q <= 0 {
+			refused[name] = true
+			continue
+		}
+		if name == "*" {
+			wildcard = true
+			continue
+		}
+		accepted[name] = true
+	}
+
+	var out []string
+	for _, enc := range []string{"br", "gzip"} {
+		if refused[enc] {
+			continue
+		}
+		if accepted[enc] || wildcard {
+			out = append(out, enc)
+		}
+	}
+	return out
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/publish"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryArtifactStorage is an in-memory ArtifactStorage for handler tests.
+type memoryArtifactStorage struct {
+	artifacts map[string]*publish.Artifact
+}
+
+func newMemoryArtifactStorage() *memoryArtifactStorage {
+	return &memoryArtifactStorage{artifacts: map[string]*publish.Artifact{}}
+}
+
+func (s *memoryArtifactStorage) Put(ctx context.Context, key string, contentType string, cacheControl string, body []byte) error {
+	s.artifacts[key] = &publish.Artifact{
+		Body:         body,
+		ContentType:  contentType,
+		CacheControl: cacheControl,
+		ModTime:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	return nil
+}
+
+func (s *memoryArtifactStorage) Get(ctx context.Context, key string) (*publish.Artifact, error) {
+	a, ok := s.artifacts[key]
+	if !ok {
+		return nil, domain.ErrArtifactNotFound
+	}
+	return a, nil
+}
+
+func (s *memoryArtifactStorage) PublicURL(key string) string { return "/published/" + key }
+
+func (s *memoryArtifactStorage) ListVersions(ctx context.Context, subdomain string) ([]int, error) {
+	return nil, nil
+}
+
+func (s *memoryArtifactStorage) ListObjects(ctx context.Context, subdomain string, version int) ([]publish.ArtifactObject, error) {
+	prefix := fmt.Sprintf("sites/%s/v%d/", subdomain, version)
+	objects := []publish.ArtifactObject{}
+	for key, a := range s.artifacts {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, publish.ArtifactObject{Key: key, Size: int64(len(a.Body))})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+func (s *memoryArtifactStorage) DeleteVersion(ctx context.Context, subdomain string, version int) error {
+	return nil
+}
+
+func serveArtifact(t *testing.T, h *PublishedArtifactHandler, key string, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/published/"+key, nil)
+	for k, v := range headers {
+		c.Request.Header.Set(k, v)
+	}
+	h.ServeKey(c, key)
+	// gin's engine flushes the status after the handler chain; do the same for bodiless 304s.
+	c.Writer.WriteHeaderNow()
+	return w
+}
+
+func TestPublishedArtifactHandler_ServesBodyWithStoredHeaders(t *testing.T) {
+	store := newMemoryArtifactStorage()
+	require.NoError(t, store.Put(context.Background(), "sites/a/v1/assets/photo.jpg", "image/jpeg", "public, max-age=31536000, immutable", []byte("jpegbytes")))
+	h := NewPublishedArtifactHandler(store)
+
+	w := serveArtifact(t, h, "sites/a/v1/assets/photo.jpg", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "jpegbytes", w.Body.String())
+	assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+	assert.Equal(t, "public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Vary"), "images have no precompressed variants")
+}
+
+func TestPublishedArtifactHandler_IfNoneMatch_Returns304(t *testing.T) {
+	store := newMemoryArtifactStorage()
+	require.NoError(t, store.Put(context.Background(), "sites/a/v1/index.html", "text/html; charset=utf-8", "public, max-age=60", []byte("<html></html>")))
+	h := NewPublishedArtifactHandler(store)
+
+	first := serveArtifact(t, h, "sites/a/v1/index.html", nil)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.NotContains(t, etag, "W/", "ETag must be strong")
+
+	second := serveArtifact(t, h, "sites/a/v1/index.html", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Empty(t, second.Body.String())
+}
+
+func TestPublishedArtifactHandler_Range_Returns206(t *testing.T) {
+	store := newMemoryArtifactStorage()
+	require.NoError(t, store.Put(context.Background(), "sites/a/v1/assets/video.mp4", "video/mp4", "public, max-age=31536000, immutable", []byte("0123456789")))
+	h := NewPublishedArtifactHandler(store)
+
+	w := serveArtifact(t, h, "sites/a/v1/assets/video.mp4", map[string]string{"Range": "bytes=2-5"})
+
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "2345", w.Body.String())
+	assert.Equal(t, "bytes 2-5/10", w.Header().Get("Content-Range"))
+}
+
+func TestPublishedArtifactHandler_PrefersPrecompressedVariants(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryArtifactStorage()
+	require.NoError(t, store.Put(ctx, "sites/a/v1/styles.css", "text/css; charset=utf-8", "public, max-age=31536000, immutable", []byte("plain")))
+	require.NoError(t, store.Put(ctx, "sites/a/v1/styles.css.br", "text/css; charset=utf-8", "public, max-age=31536000, immutable", []byte("brotli")))
+	require.NoError(t, store.Put(ctx, "sites/a/v1/styles.css.gz", "text/css; charset=utf-8", "public, max-age=31536000, immutable", []byte("gzip")))
+	h := NewPublishedArtifactHandler(store)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		wantBody       string
+		wantEncoding   string
+	}{
+		{"brotli_preferred", "gzip, deflate, br", "brotli", "br"},
+		{"gzip_only", "gzip", "gzip", "gzip"},
+		{"brotli_refused", "br;q=0, gzip", "gzip", "gzip"},
+		{"wildcard", "*", "brotli", "br"},
+		{"identity", "", "plain", ""},
+	}
+	etags := map[string]bool{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveArtifact(t, h, "sites/a/v1/styles.css", map[string]string{"Accept-Encoding": tt.acceptEncoding})
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+			assert.Equal(t, tt.wantEncoding, w.Header().Get("Content-Encoding"))
+			assert.Equal(t, "text/css; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+			etags[w.Header().Get("ETag")] = true
+		})
+	}
+	assert.Len(t, etags, 3, "each representation must have its own ETag")
+}
+
+func TestPublishedArtifactHandler_FallsBackWhenVariantMissing(t *testing.T) {
+	store := newMemoryArtifactStorage()
+	require.NoError(t, store.Put(context.Background(), "sites/a/v1/index.html", "text/html; charset=utf-8", "public, max-age=60", []byte("<html></html>")))
+	h := NewPublishedArtifactHandler(store)
+
+	w := serveArtifact(t, h, "sites/a/v1/index.html", map[string]string{"Accept-Encoding": "br, gzip"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "<html></html>", w.Body.String())
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+}
+
+func TestPublishedArtifactHandler_Missing_Returns404(t *testing.T) {
+	h := NewPublishedArtifactHandler(newMemoryArtifactStorage())
+
+	w := serveArtifact(t, h, "sites/a/v1/index.html", nil)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
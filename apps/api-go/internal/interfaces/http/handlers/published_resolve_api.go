@@ -21,6 +21,9 @@ type resolveResponse struct {
 	Subdomain      string `json:"subdomain"`
 	Published      bool   `json:"published"`
 	CurrentVersion int    `json:"currentVersion"`
+	// Locales and DefaultLocale are set for multi-language sites; each locale lives under v<N>/<locale>/.
+	Locales       []string `json:"locales,omitempty"`
+	DefaultLocale string   `json:"defaultLocale,omitempty"`
 }
 
 // Resolve resolves a host or subdomain to the current published version
@@ -68,5 +71,7 @@ func (h *PublishedResolveAPIHandler) Resolve(c *gin.Context) {
 		Subdomain:      site.Subdomain,
 		Published:      site.Published,
 		CurrentVersion: site.CurrentVersion,
+		Locales:        site.Locales,
+		DefaultLocale:  site.DefaultLocale,
 	})
 }
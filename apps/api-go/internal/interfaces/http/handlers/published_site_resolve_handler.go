@@ -1,27 +1,32 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/publish"
 )
 
 type PublishedSiteResolveHandler struct {
 	publishedRepo repository.PublishedSiteRepository
 	baseDomain    string
-	r2PublicBase  string // R2/MinIO public base URL (e.g., http://localhost:9000/sacred-vows-published-local)
-	artifactStore string // "filesystem" or "r2"
+	r2PublicBase  string // R2/MinIO/GCS public base URL (e.g., http://localhost:9000/sacred-vows-published-local)
+	artifactStore string // "filesystem", "r2" or "gcs"
+	// artifactHandler, when set, streams artifacts directly instead of redirecting.
+	artifactHandler *PublishedArtifactHandler
 }
 
-func NewPublishedSiteResolveHandler(publishedRepo repository.PublishedSiteRepository, baseDomain string, r2PublicBase string, artifactStore string) *PublishedSiteResolveHandler {
+func NewPublishedSiteResolveHandler(publishedRepo repository.PublishedSiteRepository, baseDomain string, r2PublicBase string, artifactStore string, artifactHandler *PublishedArtifactHandler) *PublishedSiteResolveHandler {
 	return &PublishedSiteResolveHandler{
-		publishedRepo: publishedRepo,
-		baseDomain:    baseDomain,
-		r2PublicBase:  r2PublicBase,
-		artifactStore: artifactStore,
+		publishedRepo:   publishedRepo,
+		baseDomain:      baseDomain,
+		r2PublicBase:    r2PublicBase,
+		artifactStore:   artifactStore,
+		artifactHandler: artifactHandler,
 	}
 }
 
@@ -61,12 +66,53 @@ func (h *PublishedSiteResolveHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	// Redirect to the published artifact index.html for current version.
-	key := "sites/" + subdomain + "/v" + itoa(site.CurrentVersion) + "/index.html"
+	prefix := "sites/" + subdomain + "/v" + itoa(site.CurrentVersion) + "/"
 
-	// For R2/MinIO storage, redirect to MinIO public URL; for filesystem, use /published/ path
+	// Multi-language sites: pick a locale unless the path already names one (e.g. /hi/).
+	rel := strings.TrimPrefix(path, "/")
+	locale := ""
+	pathHasLocale := false
+	if len(site.Locales) > 0 {
+		first, _, _ := strings.Cut(rel, "/")
+		if containsString(site.Locales, first) {
+			locale, pathHasLocale = first, true
+			if rel == first {
+				rel += "/"
+			}
+		} else {
+			locale = publish.NegotiateLocale(c.Query("lang"), c.GetHeader("Accept-Language"), site.Locales, site.DefaultLocale)
+		}
+		c.Header("Vary", "Accept-Language")
+		c.Header("Content-Language", locale)
+	}
+	localeDir := ""
+	if locale != "" {
+		localeDir = locale + "/"
+	}
+
+	// Serve the requested file of the current version directly, saving guests the redirect round trip.
+	if h.artifactHandler != nil {
+		if rel == "" || strings.HasSuffix(rel, "/") {
+			rel += "index.html"
+		}
+		if !isServableRelativePath(rel) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid path"})
+			return
+		}
+		if pathHasLocale {
+			h.artifactHandler.ServeKey(c, prefix+rel)
+		} else {
+			h.artifactHandler.ServeKey(c, prefix+localeDir+rel)
+		}
+		return
+	}
+
+	// Redirect to the published artifact index.html for current version (and locale).
+	key := prefix + localeDir + "index.html"
+
+	// For R2/MinIO/GCS storage, redirect to the bucket's public URL; for filesystem, use /published/ path
 	var redirectURL string
-	if h.artifactStore == "r2" && h.r2PublicBase != "" {
+	if (h.artifactStore == "r2" || h.artifactStore == "gcs") && h.r2PublicBase != "" {
 		// R2/MinIO: redirect to public base URL (MinIO is now public)
 		redirectURL = fmt.Sprintf("%s/%s", h.r2PublicBase, key)
 	} else {
@@ -76,6 +122,67 @@ func (h *PublishedSiteResolveHandler) Handle(c *gin.Context) {
 	c.Redirect(http.StatusFound, redirectURL)
 }
 
+// AllowsKey reports whether key (sites/<subdomain>/v<version>/<path>) may be served under
+// /published: it must belong to the current version of a published site, and must not be a
+// metadata sidecar or a precompressed variant, which are only served through content negotiation.
+func (h *PublishedSiteResolveHandler) AllowsKey(ctx context.Context, key string) bool {
+	rest, ok := strings.CutPrefix(key, "sites/")
+	if !ok {
+		return false
+	}
+	parts := strings.SplitN(rest, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || strings.ContainsAny(parts[0], ".\\") || !isServableRelativePath(parts[2]) {
+		return false
+	}
+
+	site, err := h.publishedRepo.FindBySubdomain(ctx, parts[0])
+	if err != nil || site == nil || !site.Published || site.CurrentVersion <= 0 {
+		return false
+	}
+	return parts[1] == "v"+itoa(site.CurrentVersion)
+}
+
+// isServableRelativePath reports whether p is a safe relative path of a site file guests may
+// request: no hidden segments (such as .meta sidecars) and no precompressed variants.
+func isServableRelativePath(p string) bool {
+	if !isSafeRelativePath(p) {
+		return false
+	}
+	for _, seg := range strings.Split(p, "/") {
+		if strings.HasPrefix(seg, ".") {
+			return false
+		}
+	}
+	for _, suffix := range []string{publish.BrotliVariantSuffix, publish.GzipVariantSuffix} {
+		if base, ok := strings.CutSuffix(p, suffix); ok && publish.IsCompressibleKey(base) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSafeRelativePath reports whether p is a clean relative path that cannot escape its prefix.
+func isSafeRelativePath(p string) bool {
+	if p == "" || strings.HasPrefix(p, "/") || strings.Contains(p, "\\") {
+		return false
+	}
+	for _, seg := range strings.Split(p, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
 func itoa(v int) string {
 	// tiny helper to avoid importing strconv in this small file
 	if v == 0 {
@@ -133,6 +133,7 @@ func TestPublishedSiteResolveHandler_R2StorageRedirect(t *testing.T) {
 				"localhost",
 				tt.r2PublicBase,
 				tt.artifactStore,
+				nil,
 			)
 
 			// Setup mock expectations
@@ -226,6 +227,7 @@ func TestPublishedSiteResolveHandler_EdgeCases(t *testing.T) {
 				"localhost",
 				tt.r2PublicBase,
 				tt.artifactStore,
+				nil,
 			)
 
 			// Setup mock expectations
@@ -252,3 +254,105 @@ func TestPublishedSiteResolveHandler_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishedSiteResolveHandler_ServesArtifactsDirectly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store := newMemoryArtifactStorage()
+	ctx := context.Background()
+	_ = store.Put(ctx, "sites/test/v2/index.html", "text/html; charset=utf-8", "public, max-age=60", []byte("<html>v2</html>"))
+	_ = store.Put(ctx, "sites/test/v2/styles.css", "text/css; charset=utf-8", "public, max-age=31536000, immutable", []byte("body{}"))
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{"root serves index", "/", http.StatusOK, "<html>v2</html>"},
+		{"relative asset", "/styles.css", http.StatusOK, "body{}"},
+		{"missing asset", "/missing.js", http.StatusNotFound, ""},
+		{"traversal rejected", "/../v1/index.html", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPublishedSiteRepository)
+			mockRepo.On("FindBySubdomain", mock.Anything, "test").Return(&domain.PublishedSite{
+				Subdomain:      "test",
+				Published:      true,
+				CurrentVersion: 2,
+			}, nil)
+			handler := NewPublishedSiteResolveHandler(mockRepo, "localhost", "", "r2", NewPublishedArtifactHandler(store))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.URL.Path = tt.path
+			req.Host = "test.localhost"
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.Handle(c)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Empty(t, w.Header().Get("Location"), "must not redirect")
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPublishedSiteResolveHandler_NegotiatesLocale(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store := newMemoryArtifactStorage()
+	ctx := context.Background()
+	_ = store.Put(ctx, "sites/test/v3/en/index.html", "text/html; charset=utf-8", "public, max-age=60", []byte("<html>en</html>"))
+	_ = store.Put(ctx, "sites/test/v3/hi/index.html", "text/html; charset=utf-8", "public, max-age=60", []byte("<html>hi</html>"))
+	_ = store.Put(ctx, "sites/test/v3/hi/styles.css", "text/css; charset=utf-8", "public, max-age=31536000, immutable", []byte("hi{}"))
+
+	tests := []struct {
+		name           string
+		url            string
+		acceptLanguage string
+		wantBody       string
+		wantLanguage   string
+	}{
+		{"default locale without preference", "/", "", "<html>en</html>", "en"},
+		{"accept-language picks locale", "/", "hi-IN,hi;q=0.9,en;q=0.5", "<html>hi</html>", "hi"},
+		{"lang query wins over header", "/?lang=en", "hi", "<html>en</html>", "en"},
+		{"locale in path is not prefixed again", "/hi/styles.css", "en", "hi{}", "hi"},
+		{"bare locale path serves its index", "/hi", "", "<html>hi</html>", "hi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPublishedSiteRepository)
+			mockRepo.On("FindBySubdomain", mock.Anything, "test").Return(&domain.PublishedSite{
+				Subdomain:      "test",
+				Published:      true,
+				CurrentVersion: 3,
+				Locales:        []string{"en", "hi"},
+				DefaultLocale:  "en",
+			}, nil)
+			handler := NewPublishedSiteResolveHandler(mockRepo, "localhost", "", "r2", NewPublishedArtifactHandler(store))
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req.Host = "test.localhost"
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.Handle(c)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+			assert.Equal(t, tt.wantLanguage, w.Header().Get("Content-Language"))
+			assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+		})
+	}
+}
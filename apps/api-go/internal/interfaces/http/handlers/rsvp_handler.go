@@ -47,8 +47,9 @@ type RSVPResponse struct {
 }
 
 type RSVPsResponse struct {
-	Responses []RSVPDTO `json:"responses"`
-	Count     int       `json:"count" example:"5"`
+	Responses  []RSVPDTO `json:"responses"`
+	Count      int       `json:"count" example:"42"`
+	NextCursor string    `json:"nextCursor,omitempty"`
 }
 
 // Submit submits an RSVP response
@@ -101,13 +102,24 @@ func (h *RSVPHandler) Submit(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        invitationId  path      string          true  "Invitation ID"
+// @Param        limit         query     int             false "Page size (1-100, default 50)"
+// @Param        cursor        query     string          false "nextCursor from the previous page"
+// @Param        sort          query     string          false "submittedAt or name, prefix - for descending (default -submittedAt)"
+// @Param        date          query     string          false "Only responses for this date"
 // @Success      200           {object}  RSVPsResponse   "List of RSVP responses"
+// @Failure      400           {object}  ErrorResponse   "Invalid pagination parameters"
 // @Failure      404           {object}  ErrorResponse   "Invitation not found"
 // @Failure      500           {object}  ErrorResponse   "Internal server error"
 // @Router       /rsvp/{invitationId} [get]
 func (h *RSVPHandler) GetByInvitation(c *gin.Context) {
 	invitationID := c.Param("invitationId")
-	output, err := h.getByInvitationUC.Execute(c.Request.Context(), invitationID)
+	opts, err := parseListOptions(c, "date")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := h.getByInvitationUC.Execute(c.Request.Context(), invitationID, opts)
 	if err != nil {
 		appErr, ok := err.(*errors.AppError)
 		if ok {
@@ -118,8 +130,8 @@ func (h *RSVPHandler) GetByInvitation(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusOK, withNextCursor(gin.H{
 		"responses": output.Responses,
 		"count":     output.Count,
-	})
+	}, output.NextCursor))
 }
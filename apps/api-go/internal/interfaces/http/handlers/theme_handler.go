@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/usecase/theme"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+type ThemeHandler struct {
+	createUC *theme.CreateThemeUseCase
+	listUC   *theme.ListThemesUseCase
+	getUC    *theme.GetThemeUseCase
+	updateUC *theme.UpdateThemeUseCase
+	deleteUC *theme.DeleteThemeUseCase
+	fontsUC  *theme.ListFontsUseCase
+}
+
+func NewThemeHandler(
+	createUC *theme.CreateThemeUseCase,
+	listUC *theme.ListThemesUseCase,
+	getUC *theme.GetThemeUseCase,
+	updateUC *theme.UpdateThemeUseCase,
+	deleteUC *theme.DeleteThemeUseCase,
+	fontsUC *theme.ListFontsUseCase,
+) *ThemeHandler {
+	return &ThemeHandler{
+		createUC: createUC,
+		listUC:   listUC,
+		getUC:    getUC,
+		updateUC: updateUC,
+		deleteUC: deleteUC,
+		fontsUC:  fontsUC,
+	}
+}
+
+type CreateThemeRequest struct {
+	Name string `json:"name" binding:"required" example:"Midnight gold"`
+	// Colors by role: primary, secondary, background and text are required, accent is optional
+	Colors map[string]string `json:"colors" binding:"required" swaggertype:"object,string" example:"primary:#1a2238,secondary:#d4af37,background:#fffaf0,text:#222222"`
+	// Fonts by role (heading, body, script); see GET /themes/fonts
+	Fonts map[string]string `json:"fonts" binding:"required" swaggertype:"object,string" example:"heading:Playfair Display,body:Lato,script:Great Vibes"`
+}
+
+// UpdateThemeRequest changes the fields that are set; colors and fonts replace the saved ones
+type UpdateThemeRequest struct {
+	Name   *string           `json:"name" example:"Midnight gold"`
+	Colors map[string]string `json:"colors" swaggertype:"object,string" example:"primary:#1a2238,secondary:#d4af37,background:#fffaf0,text:#222222"`
+	Fonts  map[string]string `json:"fonts" swaggertype:"object,string" example:"heading:Playfair Display,body:Lato,script:Great Vibes"`
+}
+
+type ThemeDTO struct {
+	ID        string            `json:"id" example:"2bZ9c1kV8dPqL3nR7tYw0xJ5mHs"`
+	UserID    string            `json:"userId" example:"user123"`
+	Name      string            `json:"name" example:"Midnight gold"`
+	Colors    map[string]string `json:"colors" swaggertype:"object,string" example:"primary:#1a2238,secondary:#d4af37,background:#fffaf0,text:#222222"`
+	Fonts     map[string]string `json:"fonts" swaggertype:"object,string" example:"heading:Playfair Display,body:Lato,script:Great Vibes"`
+	CreatedAt string            `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt string            `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type ThemeResponse struct {
+	Theme *ThemeDTO `json:"theme"`
+}
+
+type ThemesResponse struct {
+	Themes     []ThemeDTO `json:"themes"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
+
+type ThemeFontsResponse struct {
+	Fonts []string `json:"fonts" example:"Great Vibes,Lato,Playfair Display"`
+}
+
+func toHandlerThemeDTO(dto *theme.ThemeDTO) *ThemeDTO {
+	return &ThemeDTO{
+		ID:        dto.ID,
+		UserID:    dto.UserID,
+		Name:      dto.Name,
+		Colors:    dto.Colors,
+		Fonts:     dto.Fonts,
+		CreatedAt: dto.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: dto.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// Create saves a custom theme for the caller
+// @Summary      Create theme
+// @Description  Save a custom theme (colors and fonts) that the current user can apply to any of their invitations by setting layoutConfig.theme.customThemeId. Fonts must be ones used by a layout theme. Authentication is required.
+// @Tags         themes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      CreateThemeRequest  true  "Theme to save"
+// @Success      201      {object}  ThemeResponse       "Theme created"
+// @Failure      400      {object}  ErrorResponse       "Invalid name, color or font"
+// @Failure      401      {object}  ErrorResponse       "Authentication required"
+// @Router       /themes [post]
+func (h *ThemeHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req CreateThemeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	output, err := h.createUC.Execute(c.Request.Context(), theme.CreateThemeInput{
+		UserID: userID.(string),
+		Name:   req.Name,
+		Colors: req.Colors,
+		Fonts:  req.Fonts,
+	})
+	if err != nil {
+		writeThemeError(c, err, "Failed to create theme")
+		return
+	}
+
+	c.JSON(http.StatusCreated, ThemeResponse{Theme: toHandlerThemeDTO(output)})
+}
+
+// GetAll lists the caller's themes
+// @Summary      List themes
+// @Description  List the current user's custom themes. Authentication is required.
+// @Tags         themes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit   query     int     false  "Page size (1-100, default 50)"
+// @Param        cursor  query     string  false  "nextCursor from the previous page"
+// @Param        sort    query     string  false  "createdAt or name, prefix - for descending (default -createdAt)"
+// @Success      200  {object}  ThemesResponse  "List of themes"
+// @Failure      400  {object}  ErrorResponse   "Invalid pagination parameters"
+// @Failure      401  {object}  ErrorResponse   "Authentication required"
+// @Router       /themes [get]
+func (h *ThemeHandler) GetAll(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	opts, err := parseListOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := h.listUC.Execute(c.Request.Context(), userID.(string), opts)
+	if err != nil {
+		writeThemeError(c, err, "Failed to get themes")
+		return
+	}
+
+	themes := make([]ThemeDTO, len(output.Themes))
+	for i, t := range output.Themes {
+		themes[i] = *toHandlerThemeDTO(t)
+	}
+	c.JSON(http.StatusOK, ThemesResponse{Themes: themes, NextCursor: output.NextCursor})
+}
+
+// GetFonts lists the fonts custom themes may use
+// @Summary      List theme fonts
+// @Description  List the font names custom themes may use: those of the themes of the available layouts. Authentication is required.
+// @Tags         themes
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  ThemeFontsResponse  "Supported fonts"
+// @Failure      401  {object}  ErrorResponse       "Authentication required"
+// @Router       /themes/fonts [get]
+func (h *ThemeHandler) GetFonts(c *gin.Context) {
+	fonts, err := h.fontsUC.Execute(c.Request.Context())
+	if err != nil {
+		writeThemeError(c, err, "Failed to get fonts")
+		return
+	}
+
+	c.JSON(http.StatusOK, ThemeFontsResponse{Fonts: fonts})
+}
+
+// GetByID returns one of the caller's themes
+// @Summary      Get theme
+// @Description  Get one of the current user's custom themes. Authentication is required.
+// @Tags         themes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string         true  "Theme ID"
+// @Success      200  {object}  ThemeResponse  "Theme"
+// @Failure      401  {object}  ErrorResponse  "Authentication required"
+// @Failure      403  {object}  ErrorResponse  "Theme belongs to another user"
+// @Failure      404  {object}  ErrorResponse  "Theme not found"
+// @Router       /themes/{id} [get]
+func (h *ThemeHandler) GetByID(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	output, err := h.getUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		writeThemeError(c, err, "Failed to get theme")
+		return
+	}
+
+	c.JSON(http.StatusOK, ThemeResponse{Theme: toHandlerThemeDTO(output)})
+}
+
+// Update changes one of the caller's themes
+// @Summary      Update theme
+// @Description  Change the name, colors or fonts of one of the current user's custom themes. Invitations using the theme pick up the change the next time they are published. Authentication is required.
+// @Tags         themes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true  "Theme ID"
+// @Param        request  body      UpdateThemeRequest  true  "Fields to change"
+// @Success      200      {object}  ThemeResponse       "Theme updated"
+// @Failure      400      {object}  ErrorResponse       "Invalid name, color or font"
+// @Failure      401      {object}  ErrorResponse       "Authentication required"
+// @Failure      403      {object}  ErrorResponse       "Theme belongs to another user"
+// @Failure      404      {object}  ErrorResponse       "Theme not found"
+// @Router       /themes/{id} [put]
+func (h *ThemeHandler) Update(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req UpdateThemeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	output, err := h.updateUC.Execute(c.Request.Context(), theme.UpdateThemeInput{
+		ID:     c.Param("id"),
+		UserID: userID.(string),
+		Name:   req.Name,
+		Colors: req.Colors,
+		Fonts:  req.Fonts,
+	})
+	if err != nil {
+		writeThemeError(c, err, "Failed to update theme")
+		return
+	}
+
+	c.JSON(http.StatusOK, ThemeResponse{Theme: toHandlerThemeDTO(output)})
+}
+
+// Delete permanently deletes one of the caller's themes
+// @Summary      Delete theme
+// @Description  Permanently delete one of the current user's custom themes. Invitations that used it render with the colors and fonts saved in their own data. Authentication is required.
+// @Tags         themes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Theme ID"
+// @Success      200  {object}  MessageResponse  "Theme deleted"
+// @Failure      401  {object}  ErrorResponse    "Authentication required"
+// @Failure      403  {object}  ErrorResponse    "Theme belongs to another user"
+// @Failure      404  {object}  ErrorResponse    "Theme not found"
+// @Router       /themes/{id} [delete]
+func (h *ThemeHandler) Delete(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists || userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	if err := h.deleteUC.Execute(c.Request.Context(), c.Param("id"), userID.(string)); err != nil {
+		writeThemeError(c, err, "Failed to delete theme")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Theme deleted"})
+}
+
+func writeThemeError(c *gin.Context, err error, fallback string) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		c.JSON(appErr.Code, appErr.ToResponse())
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
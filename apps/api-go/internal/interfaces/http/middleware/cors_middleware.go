@@ -29,7 +29,11 @@ func CORS(frontendURL string) gin.HandlerFunc {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
 		}
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, traceparent, tracestate, X-Request-ID, x-request-id")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, traceparent, tracestate, X-Request-ID, x-request-id, If-Match, If-None-Match, X-Guest-Session")
+		// ETag carries the invitation version the builder sends back in If-Match, and the
+		// layout response revalidated with If-None-Match;
+		// X-Guest-Session carries a newly issued guest session token
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag, X-Guest-Session")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 
 		if c.Request.Method == "OPTIONS" {
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/infrastructure/auth"
+)
+
+const (
+	// GuestSessionCookie holds the signed guest session token
+	GuestSessionCookie = "guest_session"
+	// GuestSessionHeader carries the token for clients that can't use cookies; it is also
+	// returned on responses that issue or renew a token
+	GuestSessionHeader = "X-Guest-Session"
+)
+
+// GuestSession resolves the anonymous browser session from the guest session cookie or header
+// and sets guestSessionID. Requests without an Authorization header and without a valid session
+// get a new one, so each logged-out browser owns its own drafts.
+func GuestSession(guestSessions *auth.GuestSessionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(GuestSessionHeader)
+		if token == "" {
+			token, _ = c.Cookie(GuestSessionCookie)
+		}
+
+		if token != "" {
+			if claims, err := guestSessions.ValidateToken(token); err == nil {
+				c.Set("guestSessionID", claims.SessionID)
+				if guestSessions.ShouldRenew(claims) {
+					issueGuestSession(c, guestSessions, claims.SessionID)
+				}
+				c.Next()
+				return
+			}
+		}
+
+		// Authenticated callers only read an existing session (e.g. to claim its drafts)
+		if c.GetHeader("Authorization") == "" {
+			sessionID := guestSessions.NewSessionID()
+			if issueGuestSession(c, guestSessions, sessionID) {
+				c.Set("guestSessionID", sessionID)
+			}
+		}
+		c.Next()
+	}
+}
+
+func issueGuestSession(c *gin.Context, guestSessions *auth.GuestSessionService, sessionID string) bool {
+	token, err := guestSessions.IssueToken(sessionID)
+	if err != nil {
+		return false
+	}
+	c.Header(GuestSessionHeader, token)
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     GuestSessionCookie,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(guestSessions.TTL().Seconds()),
+		HttpOnly: true,
+		Secure:   isSecureRequest(c),
+		SameSite: http.SameSiteLaxMode,
+	})
+	return true
+}
+
+// ClearGuestSession expires the guest session cookie, e.g. once its drafts have been claimed
+func ClearGuestSession(c *gin.Context) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     GuestSessionCookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isSecureRequest(c),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// isSecureRequest reports whether the request arrived over HTTPS, directly or via a proxy
+func isSecureRequest(c *gin.Context) bool {
+	return c.GetHeader("X-Forwarded-Proto") == "https" || c.Request.TLS != nil
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	pathpkg "path"
 	"path/filepath"
 	"strings"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/sacred-vows/api-go/internal/infrastructure/config"
 	"github.com/sacred-vows/api-go/internal/interfaces/http/handlers"
 	"github.com/sacred-vows/api-go/internal/interfaces/http/middleware"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 	"github.com/sacred-vows/api-go/pkg/logger"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -20,53 +22,74 @@ import (
 )
 
 type Router struct {
-	authHandler       *handlers.AuthHandler
-	invitationHandler *handlers.InvitationHandler
-	layoutHandler     *handlers.LayoutHandler
-	assetHandler      *handlers.AssetHandler
-	rsvpHandler       *handlers.RSVPHandler
-	analyticsHandler  *handlers.AnalyticsHandler
-	publishHandler    *handlers.PublishHandler
-	resolveHandler    *handlers.PublishedSiteResolveHandler
-	resolveAPIHandler *handlers.PublishedResolveAPIHandler
-	jwtService        *auth.JWTService
-	frontendURL       string
-	observabilityCfg  config.ObservabilityConfig
-	r2PublicBase      string // R2/MinIO public base URL (e.g., http://localhost:9000/sacred-vows-published-local)
-	artifactStore     string // "filesystem" or "r2"
+	authHandler        *handlers.AuthHandler
+	invitationHandler  *handlers.InvitationHandler
+	revisionHandler    *handlers.InvitationRevisionHandler
+	templateHandler    *handlers.InvitationTemplateHandler
+	themeHandler       *handlers.ThemeHandler
+	layoutHandler      *handlers.LayoutHandler
+	adminLayoutHandler *handlers.AdminLayoutHandler
+	assetHandler       *handlers.AssetHandler
+	rsvpHandler        *handlers.RSVPHandler
+	analyticsHandler   *handlers.AnalyticsHandler
+	publishHandler     *handlers.PublishHandler
+	resolveHandler     *handlers.PublishedSiteResolveHandler
+	resolveAPIHandler  *handlers.PublishedResolveAPIHandler
+	artifactHandler    *handlers.PublishedArtifactHandler // nil unless the API streams published artifacts itself
+	jwtService         *auth.JWTService
+	guestSessions      *auth.GuestSessionService
+	userRepo           repository.UserRepository // looks up roles for admin routes
+	frontendURL        string
+	observabilityCfg   config.ObservabilityConfig
+	r2PublicBase       string // R2/MinIO/GCS public base URL (e.g., http://localhost:9000/sacred-vows-published-local)
+	artifactStore      string // "filesystem", "r2" or "gcs"
 }
 
 func NewRouter(
 	authHandler *handlers.AuthHandler,
 	invitationHandler *handlers.InvitationHandler,
+	revisionHandler *handlers.InvitationRevisionHandler,
+	templateHandler *handlers.InvitationTemplateHandler,
+	themeHandler *handlers.ThemeHandler,
 	layoutHandler *handlers.LayoutHandler,
+	adminLayoutHandler *handlers.AdminLayoutHandler,
 	assetHandler *handlers.AssetHandler,
 	rsvpHandler *handlers.RSVPHandler,
 	analyticsHandler *handlers.AnalyticsHandler,
 	publishHandler *handlers.PublishHandler,
 	resolveHandler *handlers.PublishedSiteResolveHandler,
 	resolveAPIHandler *handlers.PublishedResolveAPIHandler,
+	artifactHandler *handlers.PublishedArtifactHandler,
 	jwtService *auth.JWTService,
+	guestSessions *auth.GuestSessionService,
+	userRepo repository.UserRepository,
 	frontendURL string,
 	observabilityCfg config.ObservabilityConfig,
 	r2PublicBase string,
 	artifactStore string,
 ) *Router {
 	return &Router{
-		authHandler:       authHandler,
-		invitationHandler: invitationHandler,
-		layoutHandler:     layoutHandler,
-		assetHandler:      assetHandler,
-		rsvpHandler:       rsvpHandler,
-		analyticsHandler:  analyticsHandler,
-		publishHandler:    publishHandler,
-		resolveHandler:    resolveHandler,
-		resolveAPIHandler: resolveAPIHandler,
-		jwtService:        jwtService,
-		frontendURL:       frontendURL,
-		observabilityCfg:  observabilityCfg,
-		r2PublicBase:      r2PublicBase,
-		artifactStore:     artifactStore,
+		authHandler:        authHandler,
+		invitationHandler:  invitationHandler,
+		revisionHandler:    revisionHandler,
+		templateHandler:    templateHandler,
+		themeHandler:       themeHandler,
+		layoutHandler:      layoutHandler,
+		adminLayoutHandler: adminLayoutHandler,
+		assetHandler:       assetHandler,
+		rsvpHandler:        rsvpHandler,
+		analyticsHandler:   analyticsHandler,
+		publishHandler:     publishHandler,
+		resolveHandler:     resolveHandler,
+		resolveAPIHandler:  resolveAPIHandler,
+		artifactHandler:    artifactHandler,
+		jwtService:         jwtService,
+		guestSessions:      guestSessions,
+		userRepo:           userRepo,
+		frontendURL:        frontendURL,
+		observabilityCfg:   observabilityCfg,
+		r2PublicBase:       r2PublicBase,
+		artifactStore:      artifactStore,
 	}
 }
 
@@ -123,13 +146,45 @@ func (r *Router) Setup() *gin.Engine {
 		// Invitation routes
 		invitations := api.Group("/invitations")
 		{
-			invitations.GET("", middleware.OptionalAuth(r.jwtService), r.invitationHandler.GetAll)
+			// Logged-out callers own their drafts through a per-browser guest session
+			guestSession := middleware.GuestSession(r.guestSessions)
+			invitations.GET("", middleware.OptionalAuth(r.jwtService), guestSession, r.invitationHandler.GetAll)
 			invitations.GET("/:id/preview", r.invitationHandler.GetPreview)
 			invitations.GET("/:id", r.invitationHandler.GetByID)
-			invitations.POST("", middleware.OptionalAuth(r.jwtService), r.invitationHandler.Create)
-			invitations.PUT("/:id", middleware.OptionalAuth(r.jwtService), r.invitationHandler.Update)
-			invitations.DELETE("/:id", middleware.OptionalAuth(r.jwtService), r.invitationHandler.Delete)
-			invitations.POST("/migrate", middleware.AuthenticateToken(r.jwtService), r.invitationHandler.MigrateInvitations)
+			invitations.POST("", middleware.OptionalAuth(r.jwtService), guestSession, r.invitationHandler.Create)
+			invitations.PUT("/:id", middleware.OptionalAuth(r.jwtService), guestSession, r.invitationHandler.Update)
+			invitations.PATCH("/:id", middleware.OptionalAuth(r.jwtService), guestSession, r.invitationHandler.Patch)
+			invitations.DELETE("/:id", middleware.OptionalAuth(r.jwtService), guestSession, r.invitationHandler.Delete)
+			invitations.GET("/trash", middleware.OptionalAuth(r.jwtService), guestSession, r.invitationHandler.GetTrash)
+			invitations.POST("/:id/restore", middleware.OptionalAuth(r.jwtService), guestSession, r.invitationHandler.RestoreFromTrash)
+			invitations.POST("/:id/duplicate", middleware.OptionalAuth(r.jwtService), guestSession, r.invitationHandler.Duplicate)
+			invitations.POST("/:id/switch-layout", middleware.OptionalAuth(r.jwtService), guestSession, r.invitationHandler.SwitchLayout)
+			invitations.POST("/:id/layout/upgrade", middleware.OptionalAuth(r.jwtService), guestSession, r.invitationHandler.UpgradeLayout)
+			invitations.GET("/:id/revisions", middleware.OptionalAuth(r.jwtService), guestSession, r.revisionHandler.List)
+			invitations.GET("/:id/revisions/:rev", middleware.OptionalAuth(r.jwtService), guestSession, r.revisionHandler.Get)
+			invitations.POST("/:id/revisions/:rev/restore", middleware.OptionalAuth(r.jwtService), guestSession, r.revisionHandler.Restore)
+			invitations.POST("/migrate", middleware.AuthenticateToken(r.jwtService), guestSession, r.invitationHandler.MigrateInvitations)
+		}
+
+		// Personal invitation template routes
+		templates := api.Group("/templates")
+		{
+			templates.GET("", middleware.AuthenticateToken(r.jwtService), r.templateHandler.GetAll)
+			templates.POST("", middleware.AuthenticateToken(r.jwtService), r.templateHandler.Save)
+			templates.GET("/:id", middleware.AuthenticateToken(r.jwtService), r.templateHandler.GetByID)
+			templates.DELETE("/:id", middleware.AuthenticateToken(r.jwtService), r.templateHandler.Delete)
+			templates.POST("/:id/invitations", middleware.AuthenticateToken(r.jwtService), r.templateHandler.CreateInvitation)
+		}
+
+		// Custom theme routes
+		themes := api.Group("/themes")
+		{
+			themes.GET("", middleware.AuthenticateToken(r.jwtService), r.themeHandler.GetAll)
+			themes.POST("", middleware.AuthenticateToken(r.jwtService), r.themeHandler.Create)
+			themes.GET("/fonts", middleware.AuthenticateToken(r.jwtService), r.themeHandler.GetFonts)
+			themes.GET("/:id", middleware.AuthenticateToken(r.jwtService), r.themeHandler.GetByID)
+			themes.PUT("/:id", middleware.AuthenticateToken(r.jwtService), r.themeHandler.Update)
+			themes.DELETE("/:id", middleware.AuthenticateToken(r.jwtService), r.themeHandler.Delete)
 		}
 
 		// Layout routes
@@ -141,13 +196,29 @@ func (r *Router) Setup() *gin.Engine {
 			layouts.GET("/:id", r.layoutHandler.GetByID)
 		}
 
+		// Admin routes; RequireAdmin checks the caller's role on every request
+		admin := api.Group("/admin", middleware.AuthenticateToken(r.jwtService), middleware.RequireAdmin(r.userRepo))
+		{
+			adminLayouts := admin.Group("/layouts")
+			adminLayouts.GET("", r.adminLayoutHandler.GetAll)
+			adminLayouts.POST("", r.adminLayoutHandler.Create)
+			adminLayouts.PUT("/:id", r.adminLayoutHandler.Update)
+			adminLayouts.POST("/:id/activate", r.adminLayoutHandler.Activate)
+			adminLayouts.POST("/:id/deactivate", r.adminLayoutHandler.Deactivate)
+			adminLayouts.DELETE("/:id", r.adminLayoutHandler.Retire)
+			adminLayouts.GET("/:id/audit", r.adminLayoutHandler.GetAudit)
+		}
+
 		// Asset routes
 		assets := api.Group("/assets")
 		{
 			assets.POST("/upload", middleware.AuthenticateToken(r.jwtService), r.assetHandler.Upload)
 			assets.POST("/upload-url", middleware.AuthenticateToken(r.jwtService), r.assetHandler.GenerateSignedURL)
+			assets.POST("/finalize", middleware.AuthenticateToken(r.jwtService), r.assetHandler.FinalizeUpload)
 			assets.GET("", middleware.AuthenticateToken(r.jwtService), r.assetHandler.GetAll)
 			assets.DELETE("/delete", middleware.AuthenticateToken(r.jwtService), r.assetHandler.Delete)
+			assets.GET("/trash", middleware.AuthenticateToken(r.jwtService), r.assetHandler.GetTrash)
+			assets.POST("/:id/restore", middleware.AuthenticateToken(r.jwtService), r.assetHandler.RestoreFromTrash)
 			assets.POST("/count-by-urls", middleware.AuthenticateToken(r.jwtService), r.assetHandler.CountByURLs)
 		}
 
@@ -170,6 +241,7 @@ func (r *Router) Setup() *gin.Engine {
 		{
 			publish.POST("/validate", middleware.RateLimit(20, 2), r.publishHandler.ValidateSubdomain)
 			publish.POST("", middleware.RateLimit(10, 1), middleware.AuthenticateToken(r.jwtService), r.publishHandler.Publish)
+			publish.POST("/unpublish", middleware.AuthenticateToken(r.jwtService), r.publishHandler.Unpublish)
 		}
 
 		// Published resolve endpoint for edge (no auth)
@@ -178,6 +250,7 @@ func (r *Router) Setup() *gin.Engine {
 			published.GET("/resolve", r.resolveAPIHandler.Resolve)
 			published.GET("/versions", middleware.AuthenticateToken(r.jwtService), r.publishHandler.ListVersions)
 			published.POST("/rollback", middleware.AuthenticateToken(r.jwtService), r.publishHandler.Rollback)
+			published.GET("/export", middleware.AuthenticateToken(r.jwtService), r.publishHandler.Export)
 		}
 	}
 
@@ -185,21 +258,40 @@ func (r *Router) Setup() *gin.Engine {
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Serve published artifacts
+	// When artifact streaming is enabled: serve from ArtifactStorage with ETag/Range/precompression
 	// For filesystem storage: serve from local directory
-	// For R2/MinIO storage: proxy to MinIO public URL
+	// For R2/MinIO/GCS storage: redirect to the bucket's public URL
 	publishedGroup := router.Group("/published")
 	{
-		// Exclude /published/resolve, /published/versions, /published/rollback (handled by API routes above)
-		publishedGroup.GET("/*path", func(c *gin.Context) {
+		// Exclude /published/resolve, /published/versions, /published/rollback, /published/export (handled by API routes above)
+		servePublished := func(c *gin.Context) {
 			// Check if this is an API route (shouldn't happen due to route ordering, but safety check)
 			path := c.Param("path")
-			if path == "/resolve" || strings.HasPrefix(path, "/versions") || strings.HasPrefix(path, "/rollback") {
+			if path == "/resolve" || strings.HasPrefix(path, "/versions") || strings.HasPrefix(path, "/rollback") || strings.HasPrefix(path, "/export") {
 				c.Next()
 				return
 			}
 
-			// For R2/MinIO: redirect to MinIO public URL
-			if r.artifactStore == "r2" && r.r2PublicBase != "" {
+			key := strings.TrimPrefix(path, "/")
+			// Only accept already-normalized keys; anything else could escape the sites/ prefix.
+			if key == "" || pathpkg.Clean(key) != key || strings.HasPrefix(key, "..") {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid path"})
+				return
+			}
+			// Only the current version of published sites is public; sidecars and precompressed
+			// variants never are
+			if r.resolveHandler == nil || !r.resolveHandler.AllowsKey(c.Request.Context(), key) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+				return
+			}
+
+			if r.artifactHandler != nil {
+				r.artifactHandler.ServeKey(c, key)
+				return
+			}
+
+			// For R2/MinIO/GCS: redirect to the bucket's public URL
+			if (r.artifactStore == "r2" || r.artifactStore == "gcs") && r.r2PublicBase != "" {
 				// Validate r2PublicBase URL format
 				baseURL, err := url.Parse(r.r2PublicBase)
 				if err != nil || (baseURL.Scheme != "http" && baseURL.Scheme != "https") {
@@ -208,15 +300,6 @@ func (r *Router) Setup() *gin.Engine {
 				}
 
 				// Redirect to MinIO public URL
-				// Sanitize the path to prevent path traversal attacks
-				key := strings.TrimPrefix(path, "/")
-				// Remove any path traversal attempts
-				key = filepath.Clean(key)
-				// Ensure no directory traversal (should not start with ..)
-				if strings.HasPrefix(key, "..") || strings.Contains(key, "../") {
-					c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid path"})
-					return
-				}
 				c.Redirect(http.StatusFound, fmt.Sprintf("%s/%s", r.r2PublicBase, key))
 				return
 			}
@@ -241,7 +324,9 @@ func (r *Router) Setup() *gin.Engine {
 				return
 			}
 			c.File(fullPath)
-		})
+		}
+		publishedGroup.GET("/*path", servePublished)
+		publishedGroup.HEAD("/*path", servePublished)
 	}
 
 	// 404 handler
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -8,24 +9,52 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sacred-vows/api-go/internal/domain"
 	"github.com/sacred-vows/api-go/internal/infrastructure/config"
+	"github.com/sacred-vows/api-go/internal/interfaces/http/handlers"
 	"github.com/stretchr/testify/assert"
 )
 
+// publishedSites is a PublishedSiteRepository holding the sites the published artifact tests use
+type publishedSites map[string]*domain.PublishedSite
+
+func (s publishedSites) FindBySubdomain(ctx context.Context, subdomain string) (*domain.PublishedSite, error) {
+	return s[subdomain], nil
+}
+func (s publishedSites) FindByInvitationID(ctx context.Context, invitationID string) (*domain.PublishedSite, error) {
+	return nil, nil
+}
+func (s publishedSites) Create(ctx context.Context, site *domain.PublishedSite) error { return nil }
+func (s publishedSites) Update(ctx context.Context, site *domain.PublishedSite) error { return nil }
+
+// testSites has "test" published at version 1 and "draft" published once but taken down
+var testSites = publishedSites{
+	"test":  {Subdomain: "test", Published: true, CurrentVersion: 1},
+	"draft": {Subdomain: "draft", Published: false, CurrentVersion: 1},
+}
+
 func setupTestRouter(r2PublicBase, artifactStore string) *Router {
 	gin.SetMode(gin.TestMode)
+	resolveHandler := handlers.NewPublishedSiteResolveHandler(testSites, "", r2PublicBase, artifactStore, nil)
 	// Create minimal router with only the fields needed for published artifacts tests
 	return NewRouter(
 		nil,                     // authHandler
 		nil,                     // invitationHandler
+		nil,                     // revisionHandler
+		nil,                     // templateHandler
+		nil,                     // themeHandler
 		nil,                     // layoutHandler
+		nil,                     // adminLayoutHandler
 		nil,                     // assetHandler
 		nil,                     // rsvpHandler
 		nil,                     // analyticsHandler
 		nil,                     // publishHandler
-		nil,                     // resolveHandler
+		resolveHandler,          // resolveHandler
 		nil,                     // resolveAPIHandler
+		nil,                     // artifactHandler
 		nil,                     // jwtService
+		nil,                     // guestSessions
+		nil,                     // userRepo
 		"http://localhost:5173", // frontendURL
 		config.ObservabilityConfig{Enabled: false}, // observabilityCfg
 		r2PublicBase,
@@ -86,6 +115,37 @@ func TestRouter_PublishedArtifacts_PathTraversalProtection(t *testing.T) {
 	}
 }
 
+func TestRouter_PublishedArtifacts_OnlyCurrentPublishedFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "unpublished site", path: "/published/sites/draft/v1/index.html"},
+		{name: "unknown site", path: "/published/sites/unknown/v1/index.html"},
+		{name: "previous version", path: "/published/sites/test/v0/index.html"},
+		{name: "metadata sidecar", path: "/published/.meta/sites/test/v1/index.html.json"},
+		{name: "hidden file in the site", path: "/published/sites/test/v1/.meta/index.html.json"},
+		{name: "brotli variant", path: "/published/sites/test/v1/index.html.br"},
+		{name: "gzip variant", path: "/published/sites/test/v1/styles.css.gz"},
+		{name: "outside sites", path: "/published/other/test/v1/index.html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestRouter("http://localhost:9000/sacred-vows-published-local", "r2")
+			engine := router.Setup()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Empty(t, w.Header().Get("Location"), "Should not redirect to the bucket")
+		})
+	}
+}
+
 func TestRouter_PublishedArtifacts_R2Redirect(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -210,6 +270,11 @@ func TestRouter_PublishedArtifacts_RouteExclusion(t *testing.T) {
 			path:     "/published/rollback",
 			wantCode: http.StatusOK, // API route handles this (returns 200 with JSON or 401/404)
 		},
+		{
+			name:     "excludes /published/export",
+			path:     "/published/export",
+			wantCode: http.StatusOK, // API route handles this (returns 200 with JSON or 401/404)
+		},
 		{
 			name:     "handles regular published path",
 			path:     "/published/sites/test/v1/index.html",
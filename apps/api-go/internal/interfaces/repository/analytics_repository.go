@@ -9,6 +9,7 @@ import (
 // AnalyticsRepository defines the interface for analytics data operations
 type AnalyticsRepository interface {
 	Create(ctx context.Context, analytics *domain.Analytics) error
-	FindByInvitationID(ctx context.Context, invitationID string) ([]*domain.Analytics, error)
+	// FindByInvitationID lists an invitation's events. Sort: timestamp (default -timestamp). Filters: type.
+	FindByInvitationID(ctx context.Context, invitationID string, opts ListOptions) (*Page[*domain.Analytics], error)
 	CountByType(ctx context.Context, invitationID string, analyticsType domain.AnalyticsType) (int64, error)
 }
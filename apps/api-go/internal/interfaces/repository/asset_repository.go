@@ -2,20 +2,36 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
 )
 
-// AssetRepository defines the interface for asset data operations
+// AssetRepository defines the interface for asset data operations.
+// Lookups and listings skip assets in the trash unless the method name says otherwise.
 type AssetRepository interface {
 	Create(ctx context.Context, asset *domain.Asset) error
 	FindByID(ctx context.Context, id string) (*domain.Asset, error)
-	FindByUserID(ctx context.Context, userID string) ([]*domain.Asset, error)
+	// FindByUserID lists a user's assets. Sort: createdAt, size (default -createdAt). Filters: mimeType.
+	FindByUserID(ctx context.Context, userID string, opts ListOptions) (*Page[*domain.Asset], error)
 	FindByURL(ctx context.Context, url string) (*domain.Asset, error)
 	FindByURLs(ctx context.Context, urls []string) ([]*domain.Asset, error)
+	// Delete and DeleteByURL permanently remove the record; the stored file is left to the caller
 	Delete(ctx context.Context, id string) error
 	DeleteByURL(ctx context.Context, url string) error
 
+	// Trash methods
+	MoveToTrash(ctx context.Context, id string, deletedAt time.Time) error
+	RestoreFromTrash(ctx context.Context, id string) error
+	FindTrashedByID(ctx context.Context, id string) (*domain.Asset, error)
+	// FindTrashedByUserID lists a user's trashed assets. Sort: deletedAt (default -deletedAt).
+	FindTrashedByUserID(ctx context.Context, userID string, opts ListOptions) (*Page[*domain.Asset], error)
+	// FindTrashedBefore returns up to limit assets trashed before cutoff, oldest first
+	FindTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Asset, error)
+	// FindAllIncludingTrashed lists every asset of every user, trashed ones included, for
+	// maintenance jobs. Sort: createdAt (default createdAt).
+	FindAllIncludingTrashed(ctx context.Context, opts ListOptions) (*Page[*domain.Asset], error)
+
 	// Usage tracking methods
 	FindUsedInInvitations(ctx context.Context, assetID string) ([]string, error) // Returns invitation IDs
 	TrackUsage(ctx context.Context, assetID, invitationID string) error
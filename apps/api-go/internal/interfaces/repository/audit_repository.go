@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+)
+
+// AuditRepository stores the append-only log of administrative changes.
+type AuditRepository interface {
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+	// FindByResource lists the entries for one resource. Sort: createdAt (default -createdAt). Filters: action, actorId.
+	FindByResource(ctx context.Context, resourceType, resourceID string, opts ListOptions) (*Page[*domain.AuditEntry], error)
+}
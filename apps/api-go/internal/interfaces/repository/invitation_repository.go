@@ -2,16 +2,34 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
 )
 
-// InvitationRepository defines the interface for invitation data operations
+// InvitationRepository defines the interface for invitation data operations.
+// Lookups and listings skip invitations in the trash unless the method name says otherwise.
 type InvitationRepository interface {
 	Create(ctx context.Context, invitation *domain.Invitation) error
 	FindByID(ctx context.Context, id string) (*domain.Invitation, error)
-	FindByUserID(ctx context.Context, userID string) ([]*domain.Invitation, error)
+	// FindByUserID lists a user's invitations. Sort: createdAt, updatedAt (default -updatedAt). Filters: layoutId, status.
+	FindByUserID(ctx context.Context, userID string, opts ListOptions) (*Page[*domain.Invitation], error)
 	Update(ctx context.Context, invitation *domain.Invitation) error
+	// UpdateStatus sets the lifecycle status without changing the content version
+	UpdateStatus(ctx context.Context, id string, status domain.InvitationStatus) error
+	// Delete permanently removes the invitation
 	Delete(ctx context.Context, id string) error
+
+	// Trash methods
+	MoveToTrash(ctx context.Context, id string, deletedAt time.Time) error
+	RestoreFromTrash(ctx context.Context, id string) error
+	FindTrashedByID(ctx context.Context, id string) (*domain.Invitation, error)
+	// FindTrashedByUserID lists a user's trashed invitations. Sort: deletedAt (default -deletedAt).
+	FindTrashedByUserID(ctx context.Context, userID string, opts ListOptions) (*Page[*domain.Invitation], error)
+	// FindTrashedBefore returns up to limit invitations trashed before cutoff, oldest first
+	FindTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error)
+
 	MigrateUserInvitations(ctx context.Context, fromUserID, toUserID string) (int, error)
+	// FindGuestInvitationsUpdatedBefore returns up to limit guest session drafts last saved before cutoff, oldest first
+	FindGuestInvitationsUpdatedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error)
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+)
+
+// InvitationRevisionRepository stores the revision history of invitations.
+type InvitationRevisionRepository interface {
+	// Append stores revision with the next revision number for its invitation and sets revision.Revision.
+	Append(ctx context.Context, revision *domain.InvitationRevision) error
+	// ListByInvitationID returns revisions newest first, without their data.
+	ListByInvitationID(ctx context.Context, invitationID string) ([]*domain.InvitationRevision, error)
+	// FindByRevision returns nil, nil when the revision does not exist.
+	FindByRevision(ctx context.Context, invitationID string, revision int) (*domain.InvitationRevision, error)
+	// Prune deletes all but the newest keep revisions and returns how many were deleted.
+	Prune(ctx context.Context, invitationID string, keep int) (int, error)
+	DeleteByInvitationID(ctx context.Context, invitationID string) error
+}
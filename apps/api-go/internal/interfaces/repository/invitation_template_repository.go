@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+)
+
+// InvitationTemplateRepository stores the personal templates users start new invitations from.
+type InvitationTemplateRepository interface {
+	Create(ctx context.Context, template *domain.InvitationTemplate) error
+	// FindByID returns nil, nil when the template does not exist.
+	FindByID(ctx context.Context, id string) (*domain.InvitationTemplate, error)
+	// FindByUserID lists a user's templates. Sort: createdAt, name (default -createdAt). Filters: layoutId.
+	FindByUserID(ctx context.Context, userID string, opts ListOptions) (*Page[*domain.InvitationTemplate], error)
+	Delete(ctx context.Context, id string) error
+}
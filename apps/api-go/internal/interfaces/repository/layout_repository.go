@@ -7,10 +7,18 @@ import (
 )
 
 // LayoutRepository defines the interface for layout data operations
+// Create and Update also append audit to the audit log in the same atomic write when it is not
+// nil, so a change is never saved without its entry.
 type LayoutRepository interface {
-	Create(ctx context.Context, layout *domain.Layout) error
+	Create(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error
 	FindByID(ctx context.Context, id string) (*domain.Layout, error)
+	// FindAll returns the active layouts
 	FindAll(ctx context.Context) ([]*domain.Layout, error)
-	Update(ctx context.Context, layout *domain.Layout) error
+	// FindAllIncludingInactive returns every layout, including inactive and retired ones, by ID
+	FindAllIncludingInactive(ctx context.Context) ([]*domain.Layout, error)
+	// FindVersion returns the content (metadata, config, manifest) the layout had at version;
+	// nil when that version was never saved. Create and Update keep a copy of every version.
+	FindVersion(ctx context.Context, id, version string) (*domain.Layout, error)
+	Update(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error
 	Delete(ctx context.Context, id string) error
 }
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+)
+
+// LayoutStatsRepository keeps the popularity counters of layouts
+type LayoutStatsRepository interface {
+	// IncrementSelections atomically adds one to the layout's selections, creating its stats if needed
+	IncrementSelections(ctx context.Context, layoutID string) error
+	// IncrementPublishes atomically adds one to the layout's publishes, creating its stats if needed
+	IncrementPublishes(ctx context.Context, layoutID string) error
+	// FindAll returns the stats of every layout that has been selected or published
+	FindAll(ctx context.Context) ([]*domain.LayoutStats, error)
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidListOptions is returned (wrapped) for an unknown sort field or filter, or a cursor
+// that is malformed or was issued for a different sort order
+var ErrInvalidListOptions = errors.New("invalid list options")
+
+const (
+	// DefaultPageLimit is used when ListOptions.Limit is not set
+	DefaultPageLimit = 50
+	// MaxPageLimit caps ListOptions.Limit
+	MaxPageLimit = 100
+)
+
+// SortDirection orders a list ascending or descending
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// ListOptions selects one page of a list. Sort fields and filter keys are the API (camelCase)
+// field names; each repository documents which it supports.
+type ListOptions struct {
+	Limit int
+	// Cursor is the NextCursor of the previous page; empty starts from the beginning
+	Cursor        string
+	SortField     string
+	SortDirection SortDirection
+	// Filters are equality filters keyed by field name
+	Filters map[string]string
+}
+
+// PageLimit returns Limit clamped to [1, MaxPageLimit], defaulting to DefaultPageLimit
+func (o ListOptions) PageLimit() int {
+	switch {
+	case o.Limit <= 0:
+		return DefaultPageLimit
+	case o.Limit > MaxPageLimit:
+		return MaxPageLimit
+	default:
+		return o.Limit
+	}
+}
+
+// Page is one page of a list. NextCursor is empty on the last page.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+}
+
+// ParseSort parses "field" (ascending) or "-field" (descending). An empty value returns empty options.
+func ParseSort(sort string) (string, SortDirection, error) {
+	sort = strings.TrimSpace(sort)
+	if sort == "" {
+		return "", "", nil
+	}
+	direction := SortAsc
+	if strings.HasPrefix(sort, "-") {
+		direction = SortDesc
+		sort = sort[1:]
+	}
+	if sort == "" {
+		return "", "", fmt.Errorf("%w: empty sort field", ErrInvalidListOptions)
+	}
+	return sort, direction, nil
+}
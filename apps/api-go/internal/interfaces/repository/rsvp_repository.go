@@ -9,6 +9,9 @@ import (
 // RSVPRepository defines the interface for RSVP data operations
 type RSVPRepository interface {
 	Create(ctx context.Context, rsvp *domain.RSVPResponse) error
-	FindByInvitationID(ctx context.Context, invitationID string) ([]*domain.RSVPResponse, error)
+	// FindByInvitationID lists an invitation's responses. Sort: submittedAt, name (default -submittedAt). Filters: date.
+	FindByInvitationID(ctx context.Context, invitationID string, opts ListOptions) (*Page[*domain.RSVPResponse], error)
+	// CountByInvitationID counts all of an invitation's responses that match filters (the FindByInvitationID filters)
+	CountByInvitationID(ctx context.Context, invitationID string, filters map[string]string) (int, error)
 	FindByID(ctx context.Context, id string) (*domain.RSVPResponse, error)
 }
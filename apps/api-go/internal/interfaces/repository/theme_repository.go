@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+)
+
+// ThemeRepository stores the custom themes users apply to their invitations
+type ThemeRepository interface {
+	Create(ctx context.Context, theme *domain.Theme) error
+	// FindByID returns nil, nil when the theme does not exist.
+	FindByID(ctx context.Context, id string) (*domain.Theme, error)
+	// FindByUserID lists a user's themes. Sort: createdAt, name (default -createdAt).
+	FindByUserID(ctx context.Context, userID string, opts ListOptions) (*Page[*domain.Theme], error)
+	Update(ctx context.Context, theme *domain.Theme) error
+	Delete(ctx context.Context, id string) error
+}
@@ -11,6 +11,9 @@ type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	FindByID(ctx context.Context, id string) (*domain.User, error)
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	// Update saves the profile and password; it never changes the role
 	Update(ctx context.Context, user *domain.User) error
+	// UpdateRole changes only the user's role
+	UpdateRole(ctx context.Context, id string, role domain.UserRole) error
 	Delete(ctx context.Context, id string) error
 }
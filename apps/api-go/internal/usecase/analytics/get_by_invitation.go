@@ -2,6 +2,7 @@ package analytics
 
 import (
 	"context"
+	stderrors "errors"
 
 	"github.com/sacred-vows/api-go/internal/domain"
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
@@ -23,13 +24,20 @@ type GetAnalyticsByInvitationOutput struct {
 	Views        int
 	RSVPs        int
 	Analytics    []*AnalyticsDTO
+	// NextCursor fetches the next page of events; empty on the last page
+	NextCursor string
 }
 
-func (uc *GetAnalyticsByInvitationUseCase) Execute(ctx context.Context, invitationID string) (*GetAnalyticsByInvitationOutput, error) {
-	analytics, err := uc.analyticsRepo.FindByInvitationID(ctx, invitationID)
+// Execute returns one page of events; Views and RSVPs always count every event.
+func (uc *GetAnalyticsByInvitationUseCase) Execute(ctx context.Context, invitationID string, opts repository.ListOptions) (*GetAnalyticsByInvitationOutput, error) {
+	page, err := uc.analyticsRepo.FindByInvitationID(ctx, invitationID, opts)
 	if err != nil {
+		if stderrors.Is(err, repository.ErrInvalidListOptions) {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, err.Error(), err)
+		}
 		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get analytics", err)
 	}
+	analytics := page.Items
 
 	views, err := uc.analyticsRepo.CountByType(ctx, invitationID, domain.AnalyticsTypeView)
 	if err != nil {
@@ -51,5 +59,6 @@ func (uc *GetAnalyticsByInvitationUseCase) Execute(ctx context.Context, invitati
 		Views:        int(views),
 		RSVPs:        int(rsvps),
 		Analytics:    dtos,
+		NextCursor:   page.NextCursor,
 	}, nil
 }
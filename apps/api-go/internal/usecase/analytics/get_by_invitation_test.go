@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -29,9 +30,9 @@ func TestGetAnalyticsByInvitationUseCase_Execute_InvitationHasAnalytics_ReturnsA
 	}
 
 	mockRepo := &MockAnalyticsRepository{
-		FindByInvitationIDFn: func(ctx context.Context, id string) ([]*domain.Analytics, error) {
+		FindByInvitationIDFn: func(ctx context.Context, id string, opts repository.ListOptions) (*repository.Page[*domain.Analytics], error) {
 			if id == invitationID {
-				return analyticsList, nil
+				return &repository.Page[*domain.Analytics]{Items: analyticsList}, nil
 			}
 			return nil, nil
 		},
@@ -46,7 +47,7 @@ func TestGetAnalyticsByInvitationUseCase_Execute_InvitationHasAnalytics_ReturnsA
 	useCase := NewGetAnalyticsByInvitationUseCase(mockRepo)
 
 	// Act
-	output, err := useCase.Execute(context.Background(), invitationID)
+	output, err := useCase.Execute(context.Background(), invitationID, repository.ListOptions{})
 
 	// Assert
 	require.NoError(t, err, "Get analytics should not return error")
@@ -4,12 +4,13 @@ import (
 	"context"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 )
 
 // MockAnalyticsRepository is a hand-written mock implementation of AnalyticsRepository
 type MockAnalyticsRepository struct {
 	CreateFn             func(ctx context.Context, analytics *domain.Analytics) error
-	FindByInvitationIDFn func(ctx context.Context, invitationID string) ([]*domain.Analytics, error)
+	FindByInvitationIDFn func(ctx context.Context, invitationID string, opts repository.ListOptions) (*repository.Page[*domain.Analytics], error)
 	CountByTypeFn        func(ctx context.Context, invitationID string, analyticsType domain.AnalyticsType) (int64, error)
 }
 
@@ -20,9 +21,9 @@ func (m *MockAnalyticsRepository) Create(ctx context.Context, analytics *domain.
 	return nil
 }
 
-func (m *MockAnalyticsRepository) FindByInvitationID(ctx context.Context, invitationID string) ([]*domain.Analytics, error) {
+func (m *MockAnalyticsRepository) FindByInvitationID(ctx context.Context, invitationID string, opts repository.ListOptions) (*repository.Page[*domain.Analytics], error) {
 	if m.FindByInvitationIDFn != nil {
-		return m.FindByInvitationIDFn(ctx, invitationID)
+		return m.FindByInvitationIDFn(ctx, invitationID, opts)
 	}
 	return nil, nil
 }
@@ -2,75 +2,234 @@ package asset
 
 import (
 	"context"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
 
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
 )
 
+const (
+	// defaultCleanupLimit bounds how many orphans a single cleanup run deletes
+	defaultCleanupLimit = 500
+	// defaultCleanupMinAge leaves recent records and files alone: an upload saves its record
+	// before the file, and signed URL uploads store the file before the record
+	defaultCleanupMinAge = 24 * time.Hour
+)
+
+// ListableFileStorage is the part of the file store needed to find and delete orphaned files
+type ListableFileStorage interface {
+	FileStorage
+	ListFiles(ctx context.Context) ([]fs.FileInfo, error)
+}
+
+// CleanupOrphanedAssetsUseCase compares asset records with the stored files and removes what
+// is left over from failed uploads and deletions: records whose file is gone and files without
+// a record. It can also remove assets no invitation uses.
 type CleanupOrphanedAssetsUseCase struct {
 	assetRepo repository.AssetRepository
+	files     ListableFileStorage
+	clock     clock.Clock
 }
 
-func NewCleanupOrphanedAssetsUseCase(assetRepo repository.AssetRepository) *CleanupOrphanedAssetsUseCase {
+func NewCleanupOrphanedAssetsUseCase(assetRepo repository.AssetRepository, files ListableFileStorage, clk clock.Clock) *CleanupOrphanedAssetsUseCase {
 	return &CleanupOrphanedAssetsUseCase{
 		assetRepo: assetRepo,
+		files:     files,
+		clock:     clk,
 	}
 }
 
 type OrphanedAssetsResult struct {
-	OrphanedInDB      []*AssetDTO // Assets in DB but not in storage
-	OrphanedInStorage []string    // Filenames in storage but not in DB
+	OrphanedInDB      []*AssetDTO `json:"orphanedInDb"`      // Assets in DB but not in storage
+	OrphanedInStorage []string    `json:"orphanedInStorage"` // Filenames in storage but not in DB
+	Unused            []*AssetDTO `json:"unused"`            // Assets in both that no invitation uses
 }
 
 type CleanupOrphanedAssetsInput struct {
 	DryRun bool
+	// MinAge skips records and files younger than this (default: 24h)
+	MinAge time.Duration
+	// Limit is the maximum number of orphans deleted in this run (default: 500)
+	Limit int
+	// DeleteUnused also deletes the unused assets. They are only reported by default, since
+	// users may upload to their library before placing an image in an invitation.
+	DeleteUnused bool
 }
 
+// CleanupOrphanedAssetsOutput is also the JSON report of the cleanup command
 type CleanupOrphanedAssetsOutput struct {
-	OrphanedAssets     OrphanedAssetsResult
-	DeletedFromDB      []string
-	DeletedFromStorage []string
-	Errors             []string
+	DryRun bool `json:"dryRun"`
+	// Cutoff is the creation or modification time records and files must be older than
+	Cutoff             time.Time            `json:"cutoff"`
+	OrphanedAssets     OrphanedAssetsResult `json:"orphanedAssets"`
+	DeletedFromDB      []string             `json:"deletedFromDb"`      // Asset IDs
+	DeletedFromStorage []string             `json:"deletedFromStorage"` // Filenames
+	// LimitReached is set when orphans were left for the next run
+	LimitReached bool     `json:"limitReached"`
+	Errors       []string `json:"errors"`
 }
 
-// Execute finds and optionally deletes orphaned assets
-// Note: This is a simplified version - full implementation would need storage interface
-// to list files. For now, it only finds orphaned DB records (not used by any invitation)
+// Execute finds orphaned assets and, unless DryRun, deletes up to Limit of them. Trashed
+// assets are left to the trash purge.
 func (uc *CleanupOrphanedAssetsUseCase) Execute(ctx context.Context, input CleanupOrphanedAssetsInput) (*CleanupOrphanedAssetsOutput, error) {
+	minAge := input.MinAge
+	if minAge <= 0 {
+		minAge = defaultCleanupMinAge
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultCleanupLimit
+	}
+
 	output := &CleanupOrphanedAssetsOutput{
+		DryRun: input.DryRun,
+		Cutoff: uc.clock.Now().Add(-minAge),
 		OrphanedAssets: OrphanedAssetsResult{
 			OrphanedInDB:      make([]*AssetDTO, 0),
 			OrphanedInStorage: make([]string, 0),
+			Unused:            make([]*AssetDTO, 0),
 		},
 		DeletedFromDB:      make([]string, 0),
 		DeletedFromStorage: make([]string, 0),
 		Errors:             make([]string, 0),
 	}
 
-	// Find all assets
-	// Note: This requires getting all assets, which might be expensive
-	// In production, you'd want pagination or a more efficient approach
-	// For now, we'll find assets that are not used by any invitation
+	files, err := uc.files.ListFiles(ctx)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to list stored files", err)
+	}
+	stored := make(map[string]fs.FileInfo, len(files))
+	for _, file := range files {
+		stored[file.Name()] = file
+	}
+
+	// Every record's file counts as known, even for recent and trashed assets
+	known := make(map[string]bool)
+	var missing, unused []*domain.Asset
+	opts := repository.ListOptions{Limit: repository.MaxPageLimit}
+	for {
+		page, err := uc.assetRepo.FindAllIncludingTrashed(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to list assets", err)
+		}
+		for _, asset := range page.Items {
+			for _, filename := range asset.Filenames() {
+				known[filename] = true
+			}
+			if asset.IsTrashed() || !asset.CreatedAt.Before(output.Cutoff) {
+				continue
+			}
+			if _, ok := stored[asset.Filename]; !ok {
+				missing = append(missing, asset)
+				continue
+			}
+			invitationIDs, err := uc.assetRepo.FindUsedInInvitations(ctx, asset.ID)
+			if err != nil {
+				output.Errors = append(output.Errors, fmt.Sprintf("%s: find usage: %v", asset.ID, err))
+				continue
+			}
+			if len(invitationIDs) == 0 {
+				unused = append(unused, asset)
+			}
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+
+	var orphanedFiles []string
+	for name, file := range stored {
+		if !known[name] && file.ModTime().Before(output.Cutoff) {
+			orphanedFiles = append(orphanedFiles, name)
+		}
+	}
+	sort.Strings(orphanedFiles)
+
+	for _, asset := range missing {
+		output.OrphanedAssets.OrphanedInDB = append(output.OrphanedAssets.OrphanedInDB, toAssetDTO(asset))
+	}
+	output.OrphanedAssets.OrphanedInStorage = append(output.OrphanedAssets.OrphanedInStorage, orphanedFiles...)
+	for _, asset := range unused {
+		output.OrphanedAssets.Unused = append(output.OrphanedAssets.Unused, toAssetDTO(asset))
+	}
 
-	// This is a simplified implementation
-	// Full implementation would:
-	// 1. List all files in storage
-	// 2. List all assets in DB
-	// 3. Compare and find orphans
-	// 4. Check usage before deleting
+	if !input.DryRun {
+		uc.deleteOrphans(ctx, output, missing, orphanedFiles, unused, input.DeleteUnused, limit)
+	}
 
-	// For now, we return empty results
-	// The actual cleanup logic would need access to storage interface to list files
+	logger.GetLogger().Info("Cleaned up orphaned assets",
+		zap.Time("cutoff", output.Cutoff),
+		zap.Int("orphanedInDb", len(output.OrphanedAssets.OrphanedInDB)),
+		zap.Int("orphanedInStorage", len(output.OrphanedAssets.OrphanedInStorage)),
+		zap.Int("unused", len(output.OrphanedAssets.Unused)),
+		zap.Int("deletedFromDb", len(output.DeletedFromDB)),
+		zap.Int("deletedFromStorage", len(output.DeletedFromStorage)),
+		zap.Int("errors", len(output.Errors)),
+		zap.Bool("dryRun", input.DryRun),
+	)
 
 	return output, nil
 }
 
-// FindOrphanedAssetsInDB finds assets in DB that are not used by any invitation
-func (uc *CleanupOrphanedAssetsUseCase) FindOrphanedAssetsInDB(ctx context.Context) ([]*AssetDTO, error) {
-	// This would require:
-	// 1. Get all assets
-	// 2. For each asset, check if it's used by any invitation
-	// 3. Return assets not used by any invitation
+// deleteOrphans deletes up to limit orphans, recording them and the failures in output
+func (uc *CleanupOrphanedAssetsUseCase) deleteOrphans(ctx context.Context, output *CleanupOrphanedAssetsOutput, missing []*domain.Asset, orphanedFiles []string, unused []*domain.Asset, deleteUnused bool, limit int) {
+	deletions := 0
+	budget := func() bool {
+		if deletions >= limit {
+			output.LimitReached = true
+			return false
+		}
+		deletions++
+		return true
+	}
+
+	for _, asset := range missing {
+		if !budget() {
+			return
+		}
+		if err := uc.assetRepo.Delete(ctx, asset.ID); err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: delete record: %v", asset.ID, err))
+			continue
+		}
+		output.DeletedFromDB = append(output.DeletedFromDB, asset.ID)
+	}
+
+	for _, name := range orphanedFiles {
+		if !budget() {
+			return
+		}
+		if err := uc.files.DeleteFile(name); err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: delete file: %v", name, err))
+			continue
+		}
+		output.DeletedFromStorage = append(output.DeletedFromStorage, name)
+	}
 
-	// Simplified - would need full implementation with access to all assets
-	return []*AssetDTO{}, nil
+	if !deleteUnused {
+		return
+	}
+	for _, asset := range unused {
+		if !budget() {
+			return
+		}
+		// As in the trash purge, the record is only removed once the files are gone
+		if err := deleteAssetFiles(uc.files, asset); err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: delete file: %v", asset.ID, err))
+			continue
+		}
+		output.DeletedFromStorage = append(output.DeletedFromStorage, asset.Filenames()...)
+		if err := uc.assetRepo.Delete(ctx, asset.ID); err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: delete record: %v", asset.ID, err))
+			continue
+		}
+		output.DeletedFromDB = append(output.DeletedFromDB, asset.ID)
+	}
 }
@@ -0,0 +1,133 @@
+package asset
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanupOrphanedAssetsUseCase_Execute(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+	recent := now.Add(-time.Hour)
+	deletedAt := old
+
+	// Two pages of records: used, unused, missing file, recent without file, trashed
+	pages := map[string]*repository.Page[*domain.Asset]{
+		"": {
+			Items: []*domain.Asset{
+				{ID: "used", Filename: "used.jpg", CreatedAt: old,
+					Variants: []domain.AssetVariant{{Name: "thumbnail", Filename: "used_thumbnail.jpg"}}},
+				{ID: "unused", Filename: "unused.jpg", CreatedAt: old,
+					Variants: []domain.AssetVariant{{Name: "thumbnail", Filename: "unused_thumbnail.jpg"}}},
+				{ID: "missing", Filename: "missing.jpg", CreatedAt: old},
+			},
+			NextCursor: "page-2",
+		},
+		"page-2": {
+			Items: []*domain.Asset{
+				{ID: "uploading", Filename: "uploading.jpg", CreatedAt: recent},
+				{ID: "trashed", Filename: "trashed.jpg", CreatedAt: old, DeletedAt: &deletedAt},
+			},
+		},
+	}
+	storedFiles := []fs.FileInfo{
+		fakeFileInfo{name: "used.jpg", modTime: old},
+		fakeFileInfo{name: "used_thumbnail.jpg", modTime: old},
+		fakeFileInfo{name: "unused.jpg", modTime: old},
+		fakeFileInfo{name: "unused_thumbnail.jpg", modTime: old},
+		fakeFileInfo{name: "trashed.jpg", modTime: old},
+		fakeFileInfo{name: "stray-b.png", modTime: old},
+		fakeFileInfo{name: "stray-a.png", modTime: old},
+		fakeFileInfo{name: "signed-upload.png", modTime: recent},
+	}
+
+	tests := []struct {
+		name         string
+		input        CleanupOrphanedAssetsInput
+		wantRecords  []string
+		wantFiles    []string
+		limitReached bool
+	}{
+		{
+			name:  "dry run only reports",
+			input: CleanupOrphanedAssetsInput{DryRun: true, DeleteUnused: true},
+		},
+		{
+			name:        "deletes orphans but keeps unused assets",
+			input:       CleanupOrphanedAssetsInput{},
+			wantRecords: []string{"missing"},
+			wantFiles:   []string{"stray-a.png", "stray-b.png"},
+		},
+		{
+			name:        "deletes unused assets when asked",
+			input:       CleanupOrphanedAssetsInput{DeleteUnused: true},
+			wantRecords: []string{"missing", "unused"},
+			wantFiles:   []string{"stray-a.png", "stray-b.png", "unused.jpg", "unused_thumbnail.jpg"},
+		},
+		{
+			name:         "stops at the limit",
+			input:        CleanupOrphanedAssetsInput{Limit: 2, DeleteUnused: true},
+			wantRecords:  []string{"missing"},
+			wantFiles:    []string{"stray-a.png"},
+			limitReached: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var records, files []string
+			mockRepo := &MockAssetRepository{
+				FindAllIncludingTrashedFn: func(ctx context.Context, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+					return pages[opts.Cursor], nil
+				},
+				FindUsedInInvitationsFn: func(ctx context.Context, assetID string) ([]string, error) {
+					if assetID == "used" {
+						return []string{"invitation-1"}, nil
+					}
+					return []string{}, nil
+				},
+				DeleteFn: func(ctx context.Context, id string) error {
+					records = append(records, id)
+					return nil
+				},
+			}
+			mockFiles := &MockFileStorage{
+				ListFilesFn: func(ctx context.Context) ([]fs.FileInfo, error) {
+					return storedFiles, nil
+				},
+				DeleteFileFn: func(filename string) error {
+					files = append(files, filename)
+					return nil
+				},
+			}
+			uc := NewCleanupOrphanedAssetsUseCase(mockRepo, mockFiles, &MockClock{NowFn: func() time.Time { return now }})
+
+			// Act
+			output, err := uc.Execute(context.Background(), tt.input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, now.Add(-24*time.Hour), output.Cutoff)
+			require.Len(t, output.OrphanedAssets.OrphanedInDB, 1)
+			assert.Equal(t, "missing", output.OrphanedAssets.OrphanedInDB[0].ID)
+			assert.Equal(t, []string{"stray-a.png", "stray-b.png"}, output.OrphanedAssets.OrphanedInStorage)
+			require.Len(t, output.OrphanedAssets.Unused, 1)
+			assert.Equal(t, "unused", output.OrphanedAssets.Unused[0].ID)
+
+			assert.ElementsMatch(t, tt.wantRecords, records)
+			assert.ElementsMatch(t, tt.wantFiles, files)
+			assert.ElementsMatch(t, tt.wantRecords, output.DeletedFromDB)
+			assert.ElementsMatch(t, tt.wantFiles, output.DeletedFromStorage)
+			assert.Equal(t, tt.limitReached, output.LimitReached)
+			assert.Empty(t, output.Errors)
+		})
+	}
+}
@@ -8,17 +8,38 @@ import (
 
 // AssetDTO represents an asset data transfer object
 type AssetDTO struct {
-	ID           string    `json:"id"`
-	URL          string    `json:"url"`
-	Filename     string    `json:"filename"`
-	OriginalName string    `json:"originalName"`
-	Size         int64     `json:"size"`
-	MimeType     string    `json:"mimetype"`
-	UserID       string    `json:"userId"`
-	CreatedAt    time.Time `json:"createdAt"`
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	Filename     string `json:"filename"`
+	OriginalName string `json:"originalName"`
+	Size         int64  `json:"size"`
+	MimeType     string `json:"mimetype"`
+	UserID       string `json:"userId"`
+	// Width, Height and Variants are only set for processed images
+	Width     int               `json:"width,omitempty"`
+	Height    int               `json:"height,omitempty"`
+	Variants  []AssetVariantDTO `json:"variants,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	// DeletedAt and PurgeAt are only set for assets in the trash
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	PurgeAt   *time.Time `json:"purgeAt,omitempty"`
+}
+
+// AssetVariantDTO is a downscaled copy of an image asset, for srcset
+type AssetVariantDTO struct {
+	Name     string `json:"name"`
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	Size     int64  `json:"size"`
 }
 
 func toAssetDTO(asset *domain.Asset) *AssetDTO {
+	var variants []AssetVariantDTO
+	for _, variant := range asset.Variants {
+		variants = append(variants, AssetVariantDTO(variant))
+	}
 	return &AssetDTO{
 		ID:           asset.ID,
 		URL:          asset.URL,
@@ -27,6 +48,20 @@ func toAssetDTO(asset *domain.Asset) *AssetDTO {
 		Size:         asset.Size,
 		MimeType:     asset.MimeType,
 		UserID:       asset.UserID,
+		Width:        asset.Width,
+		Height:       asset.Height,
+		Variants:     variants,
 		CreatedAt:    asset.CreatedAt,
+		DeletedAt:    asset.DeletedAt,
+	}
+}
+
+// toTrashedAssetDTO also reports when the trashed asset will be purged
+func toTrashedAssetDTO(asset *domain.Asset, retention time.Duration) *AssetDTO {
+	dto := toAssetDTO(asset)
+	if asset.DeletedAt != nil {
+		purgeAt := asset.DeletedAt.Add(retention)
+		dto.PurgeAt = &purgeAt
 	}
+	return dto
 }
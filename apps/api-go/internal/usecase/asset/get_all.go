@@ -2,6 +2,7 @@ package asset
 
 import (
 	"context"
+	stderrors "errors"
 
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 	"github.com/sacred-vows/api-go/pkg/errors"
@@ -19,13 +20,19 @@ func NewGetAllAssetsUseCase(assetRepo repository.AssetRepository) *GetAllAssetsU
 
 type GetAllAssetsOutput struct {
 	Assets []*AssetDTO
+	// NextCursor fetches the next page; empty on the last page
+	NextCursor string
 }
 
-func (uc *GetAllAssetsUseCase) Execute(ctx context.Context, userID string) (*GetAllAssetsOutput, error) {
-	assets, err := uc.assetRepo.FindByUserID(ctx, userID)
+func (uc *GetAllAssetsUseCase) Execute(ctx context.Context, userID string, opts repository.ListOptions) (*GetAllAssetsOutput, error) {
+	page, err := uc.assetRepo.FindByUserID(ctx, userID, opts)
 	if err != nil {
+		if stderrors.Is(err, repository.ErrInvalidListOptions) {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, err.Error(), err)
+		}
 		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get assets", err)
 	}
+	assets := page.Items
 
 	dtos := make([]*AssetDTO, len(assets))
 	for i, asset := range assets {
@@ -33,6 +40,7 @@ func (uc *GetAllAssetsUseCase) Execute(ctx context.Context, userID string) (*Get
 	}
 
 	return &GetAllAssetsOutput{
-		Assets: dtos,
+		Assets:     dtos,
+		NextCursor: page.NextCursor,
 	}, nil
 }
@@ -2,10 +2,14 @@ package asset
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -37,9 +41,9 @@ func TestGetAllAssetsUseCase_Execute_UserHasAssets_ReturnsAssets(t *testing.T) {
 	}
 
 	mockRepo := &MockAssetRepository{
-		FindByUserIDFn: func(ctx context.Context, id string) ([]*domain.Asset, error) {
+		FindByUserIDFn: func(ctx context.Context, id string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
 			if id == userID {
-				return assets, nil
+				return &repository.Page[*domain.Asset]{Items: assets}, nil
 			}
 			return nil, nil
 		},
@@ -48,7 +52,7 @@ func TestGetAllAssetsUseCase_Execute_UserHasAssets_ReturnsAssets(t *testing.T) {
 	useCase := NewGetAllAssetsUseCase(mockRepo)
 
 	// Act
-	output, err := useCase.Execute(context.Background(), userID)
+	output, err := useCase.Execute(context.Background(), userID, repository.ListOptions{})
 
 	// Assert
 	require.NoError(t, err, "Get all assets should not return error")
@@ -57,3 +61,43 @@ func TestGetAllAssetsUseCase_Execute_UserHasAssets_ReturnsAssets(t *testing.T) {
 	assert.Equal(t, "asset-1", output.Assets[0].ID, "First asset ID should match")
 	assert.Equal(t, "asset-2", output.Assets[1].ID, "Second asset ID should match")
 }
+
+func TestGetAllAssetsUseCase_Execute_PassesOptionsAndReturnsNextCursor(t *testing.T) {
+	// Arrange
+	opts := repository.ListOptions{Limit: 10, Cursor: "c1", SortField: "size", SortDirection: repository.SortAsc}
+	var gotOpts repository.ListOptions
+	mockRepo := &MockAssetRepository{
+		FindByUserIDFn: func(ctx context.Context, userID string, o repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+			gotOpts = o
+			return &repository.Page[*domain.Asset]{Items: []*domain.Asset{{ID: "asset-1"}}, NextCursor: "c2"}, nil
+		},
+	}
+
+	// Act
+	output, err := NewGetAllAssetsUseCase(mockRepo).Execute(context.Background(), "user-123", opts)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, opts, gotOpts, "List options should be passed to the repository")
+	assert.Equal(t, "c2", output.NextCursor)
+	require.Len(t, output.Assets, 1)
+}
+
+func TestGetAllAssetsUseCase_Execute_InvalidListOptions_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockRepo := &MockAssetRepository{
+		FindByUserIDFn: func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+			return nil, fmt.Errorf("%w: cannot sort by %q", repository.ErrInvalidListOptions, "filename")
+		},
+	}
+
+	// Act
+	output, err := NewGetAllAssetsUseCase(mockRepo).Execute(context.Background(), "user-123", repository.ListOptions{SortField: "filename"})
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "Error should be an AppError")
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
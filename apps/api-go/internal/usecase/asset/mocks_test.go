@@ -2,23 +2,32 @@ package asset
 
 import (
 	"context"
+	"io/fs"
+	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 )
 
 // MockAssetRepository is a hand-written mock implementation of AssetRepository
 type MockAssetRepository struct {
-	CreateFn                func(ctx context.Context, asset *domain.Asset) error
-	FindByIDFn              func(ctx context.Context, id string) (*domain.Asset, error)
-	FindByUserIDFn          func(ctx context.Context, userID string) ([]*domain.Asset, error)
-	FindByURLFn             func(ctx context.Context, url string) (*domain.Asset, error)
-	FindByURLsFn            func(ctx context.Context, urls []string) ([]*domain.Asset, error)
-	DeleteFn                func(ctx context.Context, id string) error
-	DeleteByURLFn           func(ctx context.Context, url string) error
-	FindUsedInInvitationsFn func(ctx context.Context, assetID string) ([]string, error)
-	TrackUsageFn            func(ctx context.Context, assetID, invitationID string) error
-	UntrackUsageFn          func(ctx context.Context, assetID, invitationID string) error
-	UntrackAllUsageFn       func(ctx context.Context, invitationID string) error
+	CreateFn                  func(ctx context.Context, asset *domain.Asset) error
+	FindByIDFn                func(ctx context.Context, id string) (*domain.Asset, error)
+	FindByUserIDFn            func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error)
+	FindByURLFn               func(ctx context.Context, url string) (*domain.Asset, error)
+	FindByURLsFn              func(ctx context.Context, urls []string) ([]*domain.Asset, error)
+	DeleteFn                  func(ctx context.Context, id string) error
+	DeleteByURLFn             func(ctx context.Context, url string) error
+	FindUsedInInvitationsFn   func(ctx context.Context, assetID string) ([]string, error)
+	TrackUsageFn              func(ctx context.Context, assetID, invitationID string) error
+	UntrackUsageFn            func(ctx context.Context, assetID, invitationID string) error
+	UntrackAllUsageFn         func(ctx context.Context, invitationID string) error
+	MoveToTrashFn             func(ctx context.Context, id string, deletedAt time.Time) error
+	RestoreFromTrashFn        func(ctx context.Context, id string) error
+	FindTrashedByIDFn         func(ctx context.Context, id string) (*domain.Asset, error)
+	FindTrashedByUserIDFn     func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error)
+	FindTrashedBeforeFn       func(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Asset, error)
+	FindAllIncludingTrashedFn func(ctx context.Context, opts repository.ListOptions) (*repository.Page[*domain.Asset], error)
 }
 
 func (m *MockAssetRepository) Create(ctx context.Context, asset *domain.Asset) error {
@@ -35,9 +44,9 @@ func (m *MockAssetRepository) FindByID(ctx context.Context, id string) (*domain.
 	return nil, nil
 }
 
-func (m *MockAssetRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Asset, error) {
+func (m *MockAssetRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
 	if m.FindByUserIDFn != nil {
-		return m.FindByUserIDFn(ctx, userID)
+		return m.FindByUserIDFn(ctx, userID, opts)
 	}
 	return nil, nil
 }
@@ -97,3 +106,90 @@ func (m *MockAssetRepository) UntrackAllUsage(ctx context.Context, invitationID
 	}
 	return nil
 }
+
+func (m *MockAssetRepository) MoveToTrash(ctx context.Context, id string, deletedAt time.Time) error {
+	if m.MoveToTrashFn != nil {
+		return m.MoveToTrashFn(ctx, id, deletedAt)
+	}
+	return nil
+}
+
+func (m *MockAssetRepository) RestoreFromTrash(ctx context.Context, id string) error {
+	if m.RestoreFromTrashFn != nil {
+		return m.RestoreFromTrashFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockAssetRepository) FindTrashedByID(ctx context.Context, id string) (*domain.Asset, error) {
+	if m.FindTrashedByIDFn != nil {
+		return m.FindTrashedByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockAssetRepository) FindTrashedByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+	if m.FindTrashedByUserIDFn != nil {
+		return m.FindTrashedByUserIDFn(ctx, userID, opts)
+	}
+	return nil, nil
+}
+
+func (m *MockAssetRepository) FindTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Asset, error) {
+	if m.FindTrashedBeforeFn != nil {
+		return m.FindTrashedBeforeFn(ctx, cutoff, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockAssetRepository) FindAllIncludingTrashed(ctx context.Context, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+	if m.FindAllIncludingTrashedFn != nil {
+		return m.FindAllIncludingTrashedFn(ctx, opts)
+	}
+	return &repository.Page[*domain.Asset]{}, nil
+}
+
+// MockFileStorage is a hand-written mock implementation of ListableFileStorage
+type MockFileStorage struct {
+	DeleteFileFn func(filename string) error
+	ListFilesFn  func(ctx context.Context) ([]fs.FileInfo, error)
+}
+
+func (m *MockFileStorage) DeleteFile(filename string) error {
+	if m.DeleteFileFn != nil {
+		return m.DeleteFileFn(filename)
+	}
+	return nil
+}
+
+func (m *MockFileStorage) ListFiles(ctx context.Context) ([]fs.FileInfo, error) {
+	if m.ListFilesFn != nil {
+		return m.ListFilesFn(ctx)
+	}
+	return nil, nil
+}
+
+// fakeFileInfo is a stored file returned by MockFileStorage.ListFiles
+type fakeFileInfo struct {
+	name    string
+	modTime time.Time
+}
+
+func (f fakeFileInfo) Name() string       { return f.name }
+func (f fakeFileInfo) Size() int64        { return 0 }
+func (f fakeFileInfo) Mode() fs.FileMode  { return 0o644 }
+func (f fakeFileInfo) ModTime() time.Time { return f.modTime }
+func (f fakeFileInfo) IsDir() bool        { return false }
+func (f fakeFileInfo) Sys() any           { return nil }
+
+// MockClock is a hand-written mock implementation of clock.Clock
+type MockClock struct {
+	NowFn func() time.Time
+}
+
+func (m *MockClock) Now() time.Time {
+	if m.NowFn != nil {
+		return m.NowFn()
+	}
+	return time.Now()
+}
@@ -0,0 +1,108 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// defaultPurgeLimit bounds how many trashed items a single purge run deletes
+const defaultPurgeLimit = 500
+
+// FileStorage is the part of the file store needed to delete asset files
+type FileStorage interface {
+	DeleteFile(filename string) error
+}
+
+// deleteAssetFiles deletes the original and variant files of asset, stopping at the first failure
+func deleteAssetFiles(files FileStorage, asset *domain.Asset) error {
+	for _, filename := range asset.Filenames() {
+		if err := files.DeleteFile(filename); err != nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+	}
+	return nil
+}
+
+// PurgeTrashedAssetsUseCase permanently deletes assets that have been in the trash for longer
+// than the retention window, removing the stored file before the record.
+type PurgeTrashedAssetsUseCase struct {
+	assetRepo repository.AssetRepository
+	files     FileStorage
+	retention time.Duration
+	clock     clock.Clock
+}
+
+func NewPurgeTrashedAssetsUseCase(assetRepo repository.AssetRepository, files FileStorage, retention time.Duration, clk clock.Clock) *PurgeTrashedAssetsUseCase {
+	return &PurgeTrashedAssetsUseCase{
+		assetRepo: assetRepo,
+		files:     files,
+		retention: retention,
+		clock:     clk,
+	}
+}
+
+type PurgeTrashedAssetsInput struct {
+	DryRun bool
+	// Limit is the maximum number of assets handled in this run (default: 500)
+	Limit int
+}
+
+type PurgeTrashedAssetsOutput struct {
+	Purged []*AssetDTO // Assets purged (or that would be, with DryRun)
+	Errors []string
+}
+
+func (uc *PurgeTrashedAssetsUseCase) Execute(ctx context.Context, input PurgeTrashedAssetsInput) (*PurgeTrashedAssetsOutput, error) {
+	if uc.retention <= 0 {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Trash retention must be positive", nil)
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultPurgeLimit
+	}
+
+	cutoff := uc.clock.Now().Add(-uc.retention)
+	assets, err := uc.assetRepo.FindTrashedBefore(ctx, cutoff, limit)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find trashed assets", err)
+	}
+
+	output := &PurgeTrashedAssetsOutput{
+		Purged: make([]*AssetDTO, 0, len(assets)),
+		Errors: make([]string, 0),
+	}
+	for _, asset := range assets {
+		if input.DryRun {
+			output.Purged = append(output.Purged, toAssetDTO(asset))
+			continue
+		}
+
+		// The record is only removed once the files are gone, so a failed run is retried next time
+		if err := deleteAssetFiles(uc.files, asset); err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: delete file: %v", asset.ID, err))
+			continue
+		}
+		if err := uc.assetRepo.Delete(ctx, asset.ID); err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: delete record: %v", asset.ID, err))
+			continue
+		}
+		output.Purged = append(output.Purged, toAssetDTO(asset))
+	}
+
+	logger.GetLogger().Info("Purged trashed assets",
+		zap.Time("cutoff", cutoff),
+		zap.Int("count", len(output.Purged)),
+		zap.Int("errors", len(output.Errors)),
+		zap.Bool("dryRun", input.DryRun),
+	)
+
+	return output, nil
+}
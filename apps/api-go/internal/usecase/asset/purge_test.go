@@ -0,0 +1,93 @@
+package asset
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeTrashedAssetsUseCase_Execute(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name           string
+		dryRun         bool
+		fileErr        string
+		wantPurged     []string
+		wantFiles      []string
+		wantRecordsDel []string
+		wantErrors     int
+	}{
+		{
+			name:           "deletes file then record",
+			wantPurged:     []string{"asset-1", "asset-2"},
+			wantFiles:      []string{"a.jpg", "b.jpg"},
+			wantRecordsDel: []string{"asset-1", "asset-2"},
+		},
+		{
+			name:       "dry run deletes nothing",
+			dryRun:     true,
+			wantPurged: []string{"asset-1", "asset-2"},
+		},
+		{
+			name:           "record kept when file deletion fails",
+			fileErr:        "a.jpg",
+			wantPurged:     []string{"asset-2"},
+			wantFiles:      []string{"b.jpg"},
+			wantRecordsDel: []string{"asset-2"},
+			wantErrors:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var gotCutoff time.Time
+			var files, records []string
+			mockRepo := &MockAssetRepository{
+				FindTrashedBeforeFn: func(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Asset, error) {
+					gotCutoff = cutoff
+					return []*domain.Asset{
+						{ID: "asset-1", Filename: "a.jpg"},
+						{ID: "asset-2", Filename: "b.jpg"},
+					}, nil
+				},
+				DeleteFn: func(ctx context.Context, id string) error {
+					records = append(records, id)
+					return nil
+				},
+			}
+			storage := &MockFileStorage{
+				DeleteFileFn: func(filename string) error {
+					if filename == tt.fileErr {
+						return stderrors.New("bucket unavailable")
+					}
+					files = append(files, filename)
+					return nil
+				},
+			}
+			useCase := NewPurgeTrashedAssetsUseCase(mockRepo, storage, retention, &MockClock{NowFn: func() time.Time { return now }})
+
+			// Act
+			output, err := useCase.Execute(context.Background(), PurgeTrashedAssetsInput{DryRun: tt.dryRun})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, now.Add(-retention), gotCutoff)
+			purged := make([]string, len(output.Purged))
+			for i, a := range output.Purged {
+				purged[i] = a.ID
+			}
+			assert.Equal(t, tt.wantPurged, purged)
+			assert.Equal(t, tt.wantFiles, files)
+			assert.Equal(t, tt.wantRecordsDel, records)
+			assert.Len(t, output.Errors, tt.wantErrors)
+		})
+	}
+}
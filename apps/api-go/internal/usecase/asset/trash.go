@@ -0,0 +1,112 @@
+package asset
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// TrashAssetUseCase moves a user's asset to the trash. The record and file are kept until
+// PurgeTrashedAssetsUseCase removes them after the retention window.
+type TrashAssetUseCase struct {
+	assetRepo repository.AssetRepository
+	retention time.Duration
+	clock     clock.Clock
+}
+
+func NewTrashAssetUseCase(assetRepo repository.AssetRepository, retention time.Duration, clk clock.Clock) *TrashAssetUseCase {
+	return &TrashAssetUseCase{
+		assetRepo: assetRepo,
+		retention: retention,
+		clock:     clk,
+	}
+}
+
+func (uc *TrashAssetUseCase) Execute(ctx context.Context, url, userID string) (*AssetDTO, error) {
+	asset, err := uc.assetRepo.FindByURL(ctx, url)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find asset", err)
+	}
+	if asset == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Asset not found", nil)
+	}
+	if asset.UserID != userID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Cannot delete another user's asset", nil)
+	}
+
+	now := uc.clock.Now()
+	if err := uc.assetRepo.MoveToTrash(ctx, asset.ID, now); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to move asset to trash", err)
+	}
+	asset.DeletedAt = &now
+
+	return toTrashedAssetDTO(asset, uc.retention), nil
+}
+
+// ListTrashedAssetsUseCase lists a user's trashed assets, one page at a time
+type ListTrashedAssetsUseCase struct {
+	assetRepo repository.AssetRepository
+	retention time.Duration
+}
+
+func NewListTrashedAssetsUseCase(assetRepo repository.AssetRepository, retention time.Duration) *ListTrashedAssetsUseCase {
+	return &ListTrashedAssetsUseCase{
+		assetRepo: assetRepo,
+		retention: retention,
+	}
+}
+
+func (uc *ListTrashedAssetsUseCase) Execute(ctx context.Context, userID string, opts repository.ListOptions) (*GetAllAssetsOutput, error) {
+	page, err := uc.assetRepo.FindTrashedByUserID(ctx, userID, opts)
+	if err != nil {
+		if stderrors.Is(err, repository.ErrInvalidListOptions) {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, err.Error(), err)
+		}
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get trashed assets", err)
+	}
+
+	dtos := make([]*AssetDTO, len(page.Items))
+	for i, asset := range page.Items {
+		dtos[i] = toTrashedAssetDTO(asset, uc.retention)
+	}
+
+	return &GetAllAssetsOutput{
+		Assets:     dtos,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// RestoreAssetUseCase takes an asset back out of the trash
+type RestoreAssetUseCase struct {
+	assetRepo repository.AssetRepository
+}
+
+func NewRestoreAssetUseCase(assetRepo repository.AssetRepository) *RestoreAssetUseCase {
+	return &RestoreAssetUseCase{
+		assetRepo: assetRepo,
+	}
+}
+
+func (uc *RestoreAssetUseCase) Execute(ctx context.Context, id, userID string) (*AssetDTO, error) {
+	asset, err := uc.assetRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find asset", err)
+	}
+	if asset == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Asset not found in trash", nil)
+	}
+	if asset.UserID != userID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Cannot restore another user's asset", nil)
+	}
+
+	if err := uc.assetRepo.RestoreFromTrash(ctx, id); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to restore asset", err)
+	}
+	asset.DeletedAt = nil
+
+	return toAssetDTO(asset), nil
+}
@@ -0,0 +1,106 @@
+package asset
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashAssetUseCase_Execute(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name        string
+		asset       *domain.Asset
+		callerID    string
+		wantCode    int
+		wantTrashed bool
+	}{
+		{
+			name:        "owner moves asset to trash",
+			asset:       &domain.Asset{ID: "asset-1", URL: "/uploads/a.jpg", UserID: "user-1"},
+			callerID:    "user-1",
+			wantTrashed: true,
+		},
+		{
+			name:     "asset not found",
+			callerID: "user-1",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "other user's asset",
+			asset:    &domain.Asset{ID: "asset-1", URL: "/uploads/a.jpg", UserID: "user-1"},
+			callerID: "user-2",
+			wantCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			trashed := false
+			deleted := false
+			mockRepo := &MockAssetRepository{
+				FindByURLFn: func(ctx context.Context, url string) (*domain.Asset, error) {
+					return tt.asset, nil
+				},
+				MoveToTrashFn: func(ctx context.Context, id string, deletedAt time.Time) error {
+					trashed = true
+					assert.Equal(t, now, deletedAt)
+					return nil
+				},
+				DeleteByURLFn: func(ctx context.Context, url string) error {
+					deleted = true
+					return nil
+				},
+			}
+			useCase := NewTrashAssetUseCase(mockRepo, retention, &MockClock{NowFn: func() time.Time { return now }})
+
+			// Act
+			output, err := useCase.Execute(context.Background(), "/uploads/a.jpg", tt.callerID)
+
+			// Assert
+			assert.Equal(t, tt.wantTrashed, trashed)
+			assert.False(t, deleted, "Trashing must keep the record")
+			if tt.wantCode != 0 {
+				appErr, ok := err.(*errors.AppError)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, now.Add(retention), *output.PurgeAt)
+		})
+	}
+}
+
+func TestRestoreAssetUseCase_Execute_OwnerRestores(t *testing.T) {
+	// Arrange
+	deletedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	restored := ""
+	mockRepo := &MockAssetRepository{
+		FindTrashedByIDFn: func(ctx context.Context, id string) (*domain.Asset, error) {
+			return &domain.Asset{ID: id, UserID: "user-1", DeletedAt: &deletedAt}, nil
+		},
+		RestoreFromTrashFn: func(ctx context.Context, id string) error {
+			restored = id
+			return nil
+		},
+	}
+	useCase := NewRestoreAssetUseCase(mockRepo)
+
+	// Act
+	output, err := useCase.Execute(context.Background(), "asset-1", "user-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "asset-1", restored)
+	assert.Nil(t, output.DeletedAt)
+}
@@ -33,31 +33,76 @@ func NewUploadAssetUseCase(
 }
 
 type UploadAssetInput struct {
+	// Filename is the name of a direct upload (its object key without the pending prefix), which
+	// is kept; empty generates a new one
 	Filename     string
 	OriginalName string
 	Size         int64
 	MimeType     string
 	UserID       string
+	// Width, Height and Variants describe processed images; zero values for other files
+	Width    int
+	Height   int
+	Variants []UploadVariantInput
+}
+
+// UploadVariantInput is a downscaled copy of the uploaded image, stored next to it
+type UploadVariantInput struct {
+	Name   string
+	Width  int
+	Height int
+	Size   int64
 }
 
 type UploadAssetOutput struct {
 	URL      string
 	Filename string // Generated unique filename for storage
-	Asset    *AssetDTO
+	// Asset.Variants has the generated filenames of the variants, in the order of the input
+	Asset *AssetDTO
 }
 
 func (uc *UploadAssetUseCase) Execute(ctx context.Context, input UploadAssetInput) (*UploadAssetOutput, error) {
 	// Validation is done in handler, but we still check here as a safety measure
 	// Generate unique filename
 	ext := filepath.Ext(input.OriginalName)
-	uniqueFilename := ksuid.New().String() + ext
+	base := ksuid.New().String()
+	if input.Filename != "" {
+		ext = filepath.Ext(input.Filename)
+		base = strings.TrimSuffix(input.Filename, ext)
+	}
+	uniqueFilename := base + ext
 	url := "/uploads/" + uniqueFilename
 
+	if input.Filename != "" {
+		// A direct upload is finalized once
+		existing, err := uc.assetRepo.FindByURL(ctx, url)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to check asset", err)
+		}
+		if existing != nil {
+			return nil, errors.New(errors.ErrConflict.Code, "Upload already finalized")
+		}
+	}
+
 	// Create asset entity
 	asset, err := domain.NewAsset(url, uniqueFilename, input.OriginalName, input.MimeType, input.UserID, input.Size)
 	if err != nil {
 		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid asset data", err)
 	}
+	asset.Width = input.Width
+	asset.Height = input.Height
+	// Variants share the original's name with a suffix: 2bZ...k_medium.jpg
+	for _, variant := range input.Variants {
+		filename := base + "_" + variant.Name + ext
+		asset.Variants = append(asset.Variants, domain.AssetVariant{
+			Name:     variant.Name,
+			Filename: filename,
+			URL:      "/uploads/" + filename,
+			Width:    variant.Width,
+			Height:   variant.Height,
+			Size:     variant.Size,
+		})
+	}
 
 	asset.ID = ksuid.New().String()
 
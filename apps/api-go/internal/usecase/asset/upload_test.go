@@ -3,9 +3,12 @@ package asset
 import (
 	"context"
 	"errors"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	apperrors "github.com/sacred-vows/api-go/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -51,6 +54,115 @@ func TestUploadAssetUseCase_Execute_ValidAsset_ReturnsAsset(t *testing.T) {
 	// Note: Metrics tracking (RecordAssetUpload) is verified in integration tests
 }
 
+func TestUploadAssetUseCase_Execute_WithVariants_NamesVariantFiles(t *testing.T) {
+	// Arrange
+	var created *domain.Asset
+	mockRepo := &MockAssetRepository{
+		CreateFn: func(ctx context.Context, asset *domain.Asset) error {
+			created = asset
+			return nil
+		},
+	}
+
+	useCase := NewUploadAssetUseCase(mockRepo, 10*1024*1024, []string{"image/*"})
+	input := UploadAssetInput{
+		Filename:     "photo.jpg",
+		OriginalName: "photo.jpg",
+		Size:         4096,
+		MimeType:     "image/jpeg",
+		UserID:       "user-123",
+		Width:        1200,
+		Height:       800,
+		Variants: []UploadVariantInput{
+			{Name: "thumbnail", Width: 320, Height: 213, Size: 512},
+			{Name: "medium", Width: 800, Height: 533, Size: 2048},
+		},
+	}
+
+	// Act
+	output, err := useCase.Execute(context.Background(), input)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, 1200, output.Asset.Width)
+	assert.Equal(t, 800, output.Asset.Height)
+	require.Len(t, output.Asset.Variants, 2)
+
+	base := strings.TrimSuffix(output.Filename, ".jpg")
+	thumbnail := output.Asset.Variants[0]
+	assert.Equal(t, "thumbnail", thumbnail.Name)
+	assert.Equal(t, base+"_thumbnail.jpg", thumbnail.Filename)
+	assert.Equal(t, "/uploads/"+thumbnail.Filename, thumbnail.URL)
+	assert.Equal(t, 320, thumbnail.Width)
+	assert.Equal(t, int64(512), thumbnail.Size)
+	assert.Equal(t, []string{output.Filename, base + "_thumbnail.jpg", base + "_medium.jpg"}, created.Filenames())
+}
+
+func TestUploadAssetUseCase_Execute_DirectUpload_KeepsObjectKey(t *testing.T) {
+	// Arrange
+	mockRepo := &MockAssetRepository{
+		FindByURLFn: func(ctx context.Context, url string) (*domain.Asset, error) {
+			assert.Equal(t, "/uploads/2bZdirect.jpeg", url)
+			return nil, nil
+		},
+	}
+
+	useCase := NewUploadAssetUseCase(mockRepo, 10*1024*1024, []string{"image/*"})
+	input := UploadAssetInput{
+		Filename:     "2bZdirect.jpeg",
+		OriginalName: "photo.jpg",
+		Size:         1024,
+		MimeType:     "image/jpeg",
+		UserID:       "user-123",
+		Variants:     []UploadVariantInput{{Name: "thumbnail", Width: 320, Height: 240, Size: 256}},
+	}
+
+	// Act
+	output, err := useCase.Execute(context.Background(), input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "2bZdirect.jpeg", output.Filename)
+	assert.Equal(t, "/uploads/2bZdirect.jpeg", output.URL)
+	require.Len(t, output.Asset.Variants, 1)
+	assert.Equal(t, "2bZdirect_thumbnail.jpeg", output.Asset.Variants[0].Filename)
+}
+
+func TestUploadAssetUseCase_Execute_DirectUploadAlreadyFinalized_ReturnsConflict(t *testing.T) {
+	// Arrange
+	created := false
+	mockRepo := &MockAssetRepository{
+		FindByURLFn: func(ctx context.Context, url string) (*domain.Asset, error) {
+			return &domain.Asset{ID: "asset-1", URL: url}, nil
+		},
+		CreateFn: func(ctx context.Context, asset *domain.Asset) error {
+			created = true
+			return nil
+		},
+	}
+
+	useCase := NewUploadAssetUseCase(mockRepo, 10*1024*1024, []string{"image/*"})
+	input := UploadAssetInput{
+		Filename:     "2bZdirect.jpeg",
+		OriginalName: "photo.jpg",
+		Size:         1024,
+		MimeType:     "image/jpeg",
+		UserID:       "user-123",
+	}
+
+	// Act
+	output, err := useCase.Execute(context.Background(), input)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperrors.AppError)
+	require.True(t, ok, "Error should be an AppError")
+	assert.Equal(t, http.StatusConflict, appErr.Code)
+	assert.False(t, created, "No second record should be created")
+}
+
 func TestUploadAssetUseCase_Execute_RepositoryError_ReturnsError(t *testing.T) {
 	// Arrange
 	filename := "test.jpg"
@@ -9,12 +9,18 @@ type UserDTO struct {
 	ID    string  `json:"id"`
 	Email string  `json:"email"`
 	Name  *string `json:"name,omitempty"`
+	Role  string  `json:"role"`
 }
 
 func toUserDTO(user *domain.User) *UserDTO {
-	return &UserDTO{
+	dto := &UserDTO{
 		ID:    user.ID,
 		Email: user.Email,
 		Name:  user.Name,
+		Role:  string(user.Role),
 	}
+	if dto.Role == "" {
+		dto.Role = string(domain.UserRoleUser)
+	}
+	return dto
 }
@@ -14,6 +14,7 @@ type MockUserRepository struct {
 	FindByIDFn    func(ctx context.Context, id string) (*domain.User, error)
 	FindByEmailFn func(ctx context.Context, email string) (*domain.User, error)
 	UpdateFn      func(ctx context.Context, user *domain.User) error
+	UpdateRoleFn  func(ctx context.Context, id string, role domain.UserRole) error
 	DeleteFn      func(ctx context.Context, id string) error
 }
 
@@ -45,6 +46,13 @@ func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) erro
 	return nil
 }
 
+func (m *MockUserRepository) UpdateRole(ctx context.Context, id string, role domain.UserRole) error {
+	if m.UpdateRoleFn != nil {
+		return m.UpdateRoleFn(ctx, id, role)
+	}
+	return nil
+}
+
 func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
 	if m.DeleteFn != nil {
 		return m.DeleteFn(ctx, id)
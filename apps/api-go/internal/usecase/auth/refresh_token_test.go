@@ -44,7 +44,10 @@ func (f *fakeUserRepo) FindByID(ctx context.Context, id string) (*domain.User, e
 	return &domain.User{ID: id, Email: "u@example.com"}, nil
 }
 func (f *fakeUserRepo) Update(ctx context.Context, user *domain.User) error { return nil }
-func (f *fakeUserRepo) Delete(ctx context.Context, id string) error         { return nil }
+func (f *fakeUserRepo) UpdateRole(ctx context.Context, id string, role domain.UserRole) error {
+	return nil
+}
+func (f *fakeUserRepo) Delete(ctx context.Context, id string) error { return nil }
 
 func TestRefreshTokenUseCase_OrderedKeysActiveFirst(t *testing.T) {
 	uc := &RefreshTokenUseCase{
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// SetUserRoleUseCase grants or revokes a role. There is no HTTP route for it; admins are
+// bootstrapped with cmd/grant-admin.
+type SetUserRoleUseCase struct {
+	userRepo repository.UserRepository
+}
+
+func NewSetUserRoleUseCase(userRepo repository.UserRepository) *SetUserRoleUseCase {
+	return &SetUserRoleUseCase{
+		userRepo: userRepo,
+	}
+}
+
+type SetUserRoleOutput struct {
+	User *UserDTO
+	// Changed is false when the user already had the role
+	Changed bool
+}
+
+func (uc *SetUserRoleUseCase) Execute(ctx context.Context, email string, role domain.UserRole) (*SetUserRoleOutput, error) {
+	if !role.IsValid() {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid role", domain.ErrInvalidRole)
+	}
+
+	user, err := uc.userRepo.FindByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find user", err)
+	}
+	if user == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "User not found", nil)
+	}
+	if user.Role == role {
+		return &SetUserRoleOutput{User: toUserDTO(user)}, nil
+	}
+
+	if err := uc.userRepo.UpdateRole(ctx, user.ID, role); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to update user", err)
+	}
+	user.Role = role
+
+	return &SetUserRoleOutput{User: toUserDTO(user), Changed: true}, nil
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUserRoleUseCase_Execute_GrantsAdmin(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: "user-123", Email: "admin@example.com", Role: domain.UserRoleUser}
+	var savedID string
+	var savedRole domain.UserRole
+	mockRepo := &MockUserRepository{
+		FindByEmailFn: func(ctx context.Context, email string) (*domain.User, error) {
+			if email == user.Email {
+				return user, nil
+			}
+			return nil, nil
+		},
+		UpdateFn: func(ctx context.Context, u *domain.User) error {
+			t.Fatal("Update must not be used to change the role")
+			return nil
+		},
+		UpdateRoleFn: func(ctx context.Context, id string, role domain.UserRole) error {
+			savedID, savedRole = id, role
+			return nil
+		},
+	}
+	useCase := NewSetUserRoleUseCase(mockRepo)
+
+	// Act
+	output, err := useCase.Execute(context.Background(), " admin@example.com ", domain.UserRoleAdmin)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, output.Changed)
+	assert.Equal(t, "user-123", savedID)
+	assert.Equal(t, domain.UserRoleAdmin, savedRole)
+	assert.Equal(t, "admin", output.User.Role)
+}
+
+func TestSetUserRoleUseCase_Execute_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		role     domain.UserRole
+		wantCode int
+	}{
+		{name: "invalid role", email: "admin@example.com", role: "owner", wantCode: http.StatusBadRequest},
+		{name: "unknown user", email: "missing@example.com", role: domain.UserRoleAdmin, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockUserRepository{
+				FindByEmailFn: func(ctx context.Context, email string) (*domain.User, error) {
+					return nil, nil
+				},
+			}
+
+			_, err := NewSetUserRoleUseCase(mockRepo).Execute(context.Background(), tt.email, tt.role)
+
+			appErr, ok := err.(*errors.AppError)
+			require.True(t, ok, "expected AppError, got %v", err)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+		})
+	}
+}
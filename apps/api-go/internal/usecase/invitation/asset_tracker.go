@@ -1,11 +1,34 @@
 package invitation
 
 import (
+	"context"
 	"encoding/json"
 	"regexp"
 	"strings"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 )
 
+// retrackAssetUsage replaces the asset usage tracked for an invitation with the assets its data references now
+func retrackAssetUsage(ctx context.Context, assetRepo repository.AssetRepository, invitation *domain.Invitation) {
+	if assetRepo == nil {
+		return
+	}
+
+	// Remove all existing usage tracking for this invitation
+	assetRepo.UntrackAllUsage(ctx, invitation.ID)
+
+	// Track new asset usage
+	assetURLs := extractAssetURLs(invitation.Data)
+	for _, url := range assetURLs {
+		asset, err := assetRepo.FindByURL(ctx, url)
+		if err == nil && asset != nil {
+			assetRepo.TrackUsage(ctx, asset.ID, invitation.ID)
+		}
+	}
+}
+
 // extractAssetURLs extracts all asset URLs from invitation data
 // Looks for URLs that match asset patterns (e.g., /uploads/, signed URLs, etc.)
 func extractAssetURLs(data json.RawMessage) []string {
@@ -78,3 +101,39 @@ func isAssetURL(url string) bool {
 
 	return false
 }
+
+// diffAssetUsage updates asset usage tracking for only the asset URLs that were added or
+// removed between two versions of the invitation data
+func diffAssetUsage(ctx context.Context, assetRepo repository.AssetRepository, invitationID string, before, after json.RawMessage) {
+	if assetRepo == nil {
+		return
+	}
+
+	previous := make(map[string]bool)
+	for _, url := range extractAssetURLs(before) {
+		previous[url] = true
+	}
+	current := make(map[string]bool)
+	for _, url := range extractAssetURLs(after) {
+		current[url] = true
+	}
+
+	for url := range previous {
+		if current[url] {
+			continue
+		}
+		asset, err := assetRepo.FindByURL(ctx, url)
+		if err == nil && asset != nil {
+			assetRepo.UntrackUsage(ctx, asset.ID, invitationID)
+		}
+	}
+	for url := range current {
+		if previous[url] {
+			continue
+		}
+		asset, err := assetRepo.FindByURL(ctx, url)
+		if err == nil && asset != nil {
+			assetRepo.TrackUsage(ctx, asset.ID, invitationID)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/sacred-vows/api-go/internal/domain"
 	"github.com/sacred-vows/api-go/internal/infrastructure/observability"
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
 	"github.com/sacred-vows/api-go/pkg/errors"
 	"github.com/segmentio/ksuid"
 )
@@ -15,12 +16,18 @@ import (
 type CreateInvitationUseCase struct {
 	invitationRepo repository.InvitationRepository
 	assetRepo      repository.AssetRepository
+	revisions      *RevisionRecorder
+	dataValidator  *layout.ValidateInvitationDataUseCase // Optional; without it data is only checked to be an object
+	popularity     *layout.PopularityRecorder            // Optional
 }
 
-func NewCreateInvitationUseCase(invitationRepo repository.InvitationRepository, assetRepo repository.AssetRepository) *CreateInvitationUseCase {
+func NewCreateInvitationUseCase(invitationRepo repository.InvitationRepository, assetRepo repository.AssetRepository, revisions *RevisionRecorder, dataValidator *layout.ValidateInvitationDataUseCase, popularity *layout.PopularityRecorder) *CreateInvitationUseCase {
 	return &CreateInvitationUseCase{
 		invitationRepo: invitationRepo,
 		assetRepo:      assetRepo,
+		revisions:      revisions,
+		dataValidator:  dataValidator,
+		popularity:     popularity,
 	}
 }
 
@@ -29,6 +36,9 @@ type CreateInvitationInput struct {
 	Data     json.RawMessage
 	Title    *string
 	UserID   string
+	// Optional per-locale translations for multi-language published sites
+	Translations  map[string]json.RawMessage
+	DefaultLocale string
 }
 
 type CreateInvitationOutput struct {
@@ -42,11 +52,7 @@ func (uc *CreateInvitationUseCase) Execute(ctx context.Context, input CreateInvi
 		input.Data = json.RawMessage("{}")
 	}
 
-	// Default status to "draft" for new invitations
-	defaultStatus := "draft"
-
-	// Merge title and default status into data if provided
-	dataWithMeta, err := mergeMetadataIntoData(input.Data, input.Title, &defaultStatus)
+	data, title, err := splitLegacyMetadata(input.Data, input.Title)
 	if err != nil {
 		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid invitation data", err)
 	}
@@ -56,10 +62,24 @@ func (uc *CreateInvitationUseCase) Execute(ctx context.Context, input CreateInvi
 		layoutID = "classic-scroll"
 	}
 
-	invitation, err := domain.NewInvitation(layoutID, input.UserID, dataWithMeta)
+	// New invitations always start as drafts
+	invitation, err := domain.NewInvitation(layoutID, input.UserID, data)
 	if err != nil {
 		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid invitation data", err)
 	}
+	if title != nil {
+		invitation.Title = *title
+	}
+
+	invitation.LayoutVersion, err = validateNewLayoutData(ctx, uc.dataValidator, invitation.LayoutID, invitation.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.Translations, invitation.DefaultLocale, err = normalizeTranslations(input.Translations, input.DefaultLocale)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid translations", err)
+	}
 
 	invitation.ID = ksuid.New().String()
 
@@ -74,6 +94,7 @@ func (uc *CreateInvitationUseCase) Execute(ctx context.Context, input CreateInvi
 	// Track layout selection
 	if layoutID != "" {
 		observability.RecordLayoutSelection(layoutID)
+		uc.popularity.RecordSelection(ctx, layoutID)
 	}
 
 	// Track asset usage
@@ -87,7 +108,29 @@ func (uc *CreateInvitationUseCase) Execute(ctx context.Context, input CreateInvi
 		}
 	}
 
+	// The initial content is revision 1, so even the first edit can be undone
+	uc.revisions.Record(ctx, invitation, input.UserID, 0)
+
 	return &CreateInvitationOutput{
 		Invitation: toInvitationDTO(invitation),
 	}, nil
 }
+
+// validateInvitationData checks data against the schema of the layout version the invitation is
+// pinned to when a validator is configured
+func validateInvitationData(ctx context.Context, validator *layout.ValidateInvitationDataUseCase, layoutID, layoutVersion string, data json.RawMessage) error {
+	if validator == nil {
+		return nil
+	}
+	return validator.Execute(ctx, layoutID, layoutVersion, data)
+}
+
+// validateNewLayoutData is validateInvitationData for data moving onto a layout, which must also
+// be open to new invitations. It returns the current layout version for the invitation to pin,
+// or "" (the current version, whatever it is) without a validator.
+func validateNewLayoutData(ctx context.Context, validator *layout.ValidateInvitationDataUseCase, layoutID string, data json.RawMessage) (string, error) {
+	if validator == nil {
+		return "", nil
+	}
+	return validator.ExecuteForNewLayout(ctx, layoutID, data)
+}
@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateInvitationUseCase_Execute_DefaultStatusToDraft(t *testing.T) {
@@ -18,7 +21,7 @@ func TestCreateInvitationUseCase_Execute_DefaultStatusToDraft(t *testing.T) {
 		},
 	}
 
-	useCase := NewCreateInvitationUseCase(mockRepo, nil)
+	useCase := NewCreateInvitationUseCase(mockRepo, nil, nil, nil, nil)
 	input := CreateInvitationInput{
 		LayoutID: "classic-scroll",
 		Data:     json.RawMessage(`{}`),
@@ -46,28 +49,22 @@ func TestCreateInvitationUseCase_Execute_DefaultStatusToDraft(t *testing.T) {
 		t.Errorf("Expected status to be 'draft', got '%s'", *output.Invitation.Status)
 	}
 
-	// Verify status is stored in _meta.status in the data
+	// Verify status is stored on the invitation, not in the data
 	if savedInvitation == nil {
 		t.Fatal("Expected invitation to be saved")
 	}
 
+	if savedInvitation.Status != domain.InvitationStatusDraft {
+		t.Errorf("Expected saved status to be 'draft', got '%s'", savedInvitation.Status)
+	}
+
 	var dataMap map[string]interface{}
 	if err := json.Unmarshal(savedInvitation.Data, &dataMap); err != nil {
 		t.Fatalf("Failed to unmarshal saved data: %v", err)
 	}
 
-	meta, ok := dataMap["_meta"].(map[string]interface{})
-	if !ok {
-		t.Fatal("Expected _meta field to exist in saved data")
-	}
-
-	status, ok := meta["status"].(string)
-	if !ok {
-		t.Fatal("Expected status field to exist in _meta")
-	}
-
-	if status != "draft" {
-		t.Errorf("Expected status in _meta to be 'draft', got '%s'", status)
+	if _, ok := dataMap["_meta"]; ok {
+		t.Error("Expected no _meta field in saved data")
 	}
 }
 
@@ -79,7 +76,7 @@ func TestCreateInvitationUseCase_Execute_WithTitle(t *testing.T) {
 		},
 	}
 
-	useCase := NewCreateInvitationUseCase(mockRepo, nil)
+	useCase := NewCreateInvitationUseCase(mockRepo, nil, nil, nil, nil)
 	title := "My Wedding Invitation"
 	input := CreateInvitationInput{
 		LayoutID: "classic-scroll",
@@ -129,7 +126,7 @@ func TestCreateInvitationUseCase_Execute_DefaultLayoutID(t *testing.T) {
 		},
 	}
 
-	useCase := NewCreateInvitationUseCase(mockRepo, nil)
+	useCase := NewCreateInvitationUseCase(mockRepo, nil, nil, nil, nil)
 	input := CreateInvitationInput{
 		LayoutID: "", // Empty layout ID should default to "classic-scroll"
 		Data:     json.RawMessage(`{}`),
@@ -153,3 +150,33 @@ func TestCreateInvitationUseCase_Execute_DefaultLayoutID(t *testing.T) {
 	}
 	// Note: Metrics tracking (RecordInvitationCreated, RecordLayoutSelection) is verified in integration tests
 }
+
+func TestCreateInvitationUseCase_Execute_PinsLayoutVersion(t *testing.T) {
+	// Arrange
+	var savedInvitation *domain.Invitation
+	mockRepo := &MockInvitationRepository{
+		CreateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			savedInvitation = invitation
+			return nil
+		},
+	}
+	validator := layout.NewValidateInvitationDataUseCase(&MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Version: "1.2.0", IsActive: true}, nil
+		},
+	})
+	useCase := NewCreateInvitationUseCase(mockRepo, nil, nil, validator, nil)
+
+	// Act
+	output, err := useCase.Execute(context.Background(), CreateInvitationInput{
+		LayoutID: "classic-scroll",
+		Data:     json.RawMessage(`{}`),
+		UserID:   "user-123",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, savedInvitation)
+	assert.Equal(t, "1.2.0", savedInvitation.LayoutVersion)
+	assert.Equal(t, "1.2.0", output.Invitation.LayoutVersion)
+}
@@ -2,39 +2,36 @@ package invitation
 
 import (
 	"context"
+	"time"
 
-	"github.com/sacred-vows/api-go/internal/infrastructure/observability"
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
-	"github.com/sacred-vows/api-go/internal/usecase/asset"
 	"github.com/sacred-vows/api-go/pkg/errors"
 )
 
+// DeleteInvitationUseCase moves an invitation to the trash. Its data, revisions, asset usage
+// and published site are left untouched so RestoreInvitationUseCase can bring it back until
+// PurgeTrashedInvitationsUseCase deletes it permanently.
 type DeleteInvitationUseCase struct {
-	invitationRepo       repository.InvitationRepository
-	publishedRepo        repository.PublishedSiteRepository
-	assetRepo            repository.AssetRepository
-	deleteAssetsByURLsUC *asset.DeleteAssetsByURLsUseCase
+	invitationRepo repository.InvitationRepository
+	retention      time.Duration
+	clock          clock.Clock
 }
 
-func NewDeleteInvitationUseCase(
-	invitationRepo repository.InvitationRepository,
-	publishedRepo repository.PublishedSiteRepository,
-	assetRepo repository.AssetRepository,
-	deleteAssetsByURLsUC *asset.DeleteAssetsByURLsUseCase,
-) *DeleteInvitationUseCase {
+func NewDeleteInvitationUseCase(invitationRepo repository.InvitationRepository, retention time.Duration, clk clock.Clock) *DeleteInvitationUseCase {
 	return &DeleteInvitationUseCase{
-		invitationRepo:       invitationRepo,
-		publishedRepo:        publishedRepo,
-		assetRepo:            assetRepo,
-		deleteAssetsByURLsUC: deleteAssetsByURLsUC,
+		invitationRepo: invitationRepo,
+		retention:      retention,
+		clock:          clk,
 	}
 }
 
 type DeleteInvitationOutput struct {
-	DeletedAssets []*asset.AssetDTO // Assets that were deleted
+	PurgeAt time.Time // When the invitation is permanently deleted unless restored
 }
 
-func (uc *DeleteInvitationUseCase) Execute(ctx context.Context, id string) (*DeleteInvitationOutput, error) {
+// Execute trashes an invitation owned by userID (a user or guest session ID).
+func (uc *DeleteInvitationUseCase) Execute(ctx context.Context, id, userID string) (*DeleteInvitationOutput, error) {
 	invitation, err := uc.invitationRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find invitation", err)
@@ -42,45 +39,16 @@ func (uc *DeleteInvitationUseCase) Execute(ctx context.Context, id string) (*Del
 	if invitation == nil {
 		return nil, errors.Wrap(errors.ErrNotFound.Code, "Invitation not found", nil)
 	}
-
-	// Extract asset URLs before deleting invitation
-	var assetURLs []string
-	if uc.assetRepo != nil {
-		assetURLs = extractAssetURLs(invitation.Data)
-	}
-
-	// Check if invitation was published before deleting
-	if uc.publishedRepo != nil {
-		site, err := uc.publishedRepo.FindByInvitationID(ctx, id)
-		if err == nil && site != nil && site.Published {
-			// Record unpublish metric before deletion
-			observability.RecordInvitationUnpublished()
-		}
-	}
-
-	// Delete invitation first
-	if err := uc.invitationRepo.Delete(ctx, id); err != nil {
-		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to delete invitation", err)
-	}
-
-	// Untrack asset usage
-	if uc.assetRepo != nil {
-		uc.assetRepo.UntrackAllUsage(ctx, id)
+	if invitation.UserID != userID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Cannot delete another user's invitation", nil)
 	}
 
-	// Delete assets that are not used by other invitations
-	var deletedAssets []*asset.AssetDTO
-	if uc.deleteAssetsByURLsUC != nil && len(assetURLs) > 0 {
-		deleteOutput, err := uc.deleteAssetsByURLsUC.Execute(ctx, asset.DeleteAssetsByURLsInput{
-			URLs: assetURLs,
-		})
-		if err == nil {
-			deletedAssets = deleteOutput.Deleted
-		}
-		// Note: Failed deletions are logged but don't fail the invitation deletion
+	now := uc.clock.Now()
+	if err := uc.invitationRepo.MoveToTrash(ctx, id, now); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to move invitation to trash", err)
 	}
 
 	return &DeleteInvitationOutput{
-		DeletedAssets: deletedAssets,
+		PurgeAt: now.Add(uc.retention),
 	}, nil
 }
@@ -3,30 +3,34 @@ package invitation
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestDeleteInvitationUseCase_Execute_InvitationExists_DeletesInvitation(t *testing.T) {
+func TestDeleteInvitationUseCase_Execute_InvitationExists_MovesToTrash(t *testing.T) {
 	// Arrange
 	invitationID := "invitation-123"
-	userID := "user-123"
-	layoutID := "classic-scroll"
-	data := json.RawMessage(`{"title": "My Wedding"}`)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
 
 	invitation := &domain.Invitation{
 		ID:        invitationID,
-		UserID:    userID,
-		LayoutID:  layoutID,
-		Data:      data,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		UserID:    "user-123",
+		LayoutID:  "classic-scroll",
+		Data:      json.RawMessage(`{"title": "My Wedding"}`),
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 
+	var trashedID string
+	var trashedAt time.Time
+	deleted := false
 	mockInvitationRepo := &MockInvitationRepository{
 		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
 			if id == invitationID {
@@ -34,24 +38,28 @@ func TestDeleteInvitationUseCase_Execute_InvitationExists_DeletesInvitation(t *t
 			}
 			return nil, nil
 		},
-		DeleteFn: func(ctx context.Context, id string) error {
+		MoveToTrashFn: func(ctx context.Context, id string, deletedAt time.Time) error {
+			trashedID, trashedAt = id, deletedAt
 			return nil
 		},
-	}
-	mockAssetRepo := &MockAssetRepository{
-		UntrackAllUsageFn: func(ctx context.Context, invitationID string) error {
+		DeleteFn: func(ctx context.Context, id string) error {
+			deleted = true
 			return nil
 		},
 	}
 
-	useCase := NewDeleteInvitationUseCase(mockInvitationRepo, nil, mockAssetRepo, nil)
+	useCase := NewDeleteInvitationUseCase(mockInvitationRepo, retention, &MockClock{NowFn: func() time.Time { return now }})
 
 	// Act
-	output, err := useCase.Execute(context.Background(), invitationID)
+	output, err := useCase.Execute(context.Background(), invitationID, "user-123")
 
 	// Assert
 	require.NoError(t, err, "Successful deletion should not return error")
 	require.NotNil(t, output, "Output should not be nil")
+	assert.Equal(t, invitationID, trashedID)
+	assert.Equal(t, now, trashedAt)
+	assert.Equal(t, now.Add(retention), output.PurgeAt)
+	assert.False(t, deleted, "Deleting should only move the invitation to the trash")
 }
 
 func TestDeleteInvitationUseCase_Execute_InvitationNotFound_ReturnsError(t *testing.T) {
@@ -63,14 +71,41 @@ func TestDeleteInvitationUseCase_Execute_InvitationNotFound_ReturnsError(t *test
 			return nil, nil
 		},
 	}
-	mockAssetRepo := &MockAssetRepository{}
 
-	useCase := NewDeleteInvitationUseCase(mockInvitationRepo, nil, mockAssetRepo, nil)
+	useCase := NewDeleteInvitationUseCase(mockInvitationRepo, time.Hour, &MockClock{})
 
 	// Act
-	output, err := useCase.Execute(context.Background(), invitationID)
+	output, err := useCase.Execute(context.Background(), invitationID, "user-123")
 
 	// Assert
 	require.Error(t, err, "Invitation not found should return error")
 	assert.Nil(t, output, "Output should be nil on error")
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
+
+func TestDeleteInvitationUseCase_Execute_AnotherUsersInvitation_ReturnsForbidden(t *testing.T) {
+	// Arrange
+	trashed := false
+	mockInvitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "guest_abc"}, nil
+		},
+		MoveToTrashFn: func(ctx context.Context, id string, deletedAt time.Time) error {
+			trashed = true
+			return nil
+		},
+	}
+	useCase := NewDeleteInvitationUseCase(mockInvitationRepo, time.Hour, &MockClock{})
+
+	// Act
+	output, err := useCase.Execute(context.Background(), "invitation-123", "guest_xyz")
+
+	// Assert
+	assert.Nil(t, output)
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusForbidden, appErr.Code)
+	assert.False(t, trashed, "another user's invitation must not be trashed")
 }
@@ -9,14 +9,23 @@ import (
 
 // InvitationDTO represents an invitation data transfer object
 type InvitationDTO struct {
-	ID        string          `json:"id"`
-	LayoutID  string          `json:"layoutId"`
-	Data      json.RawMessage `json:"data"`
-	Title     *string         `json:"title,omitempty"`
-	Status    *string         `json:"status,omitempty"`
-	UserID    string          `json:"userId"`
-	CreatedAt time.Time       `json:"createdAt"`
-	UpdatedAt time.Time       `json:"updatedAt"`
+	ID       string `json:"id"`
+	LayoutID string `json:"layoutId"`
+	// LayoutVersion is the layout version the invitation renders with; empty means the current one
+	LayoutVersion string          `json:"layoutVersion,omitempty"`
+	Data          json.RawMessage `json:"data"`
+	Title         *string         `json:"title,omitempty"`
+	Status        *string         `json:"status,omitempty"`
+	UserID        string          `json:"userId"`
+	// Translations maps locale to renderer strings; DefaultLocale is served when no locale matches
+	Translations  map[string]json.RawMessage `json:"translations,omitempty"`
+	DefaultLocale string                     `json:"defaultLocale,omitempty"`
+	Version       int                        `json:"version"`
+	CreatedAt     time.Time                  `json:"createdAt"`
+	UpdatedAt     time.Time                  `json:"updatedAt"`
+	// DeletedAt and PurgeAt are only set for invitations in the trash
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	PurgeAt   *time.Time `json:"purgeAt,omitempty"`
 }
 
 // InvitationPreviewDTO represents a preview invitation DTO
@@ -28,89 +37,53 @@ type InvitationPreviewDTO struct {
 
 func toInvitationDTO(invitation *domain.Invitation) *InvitationDTO {
 	dto := &InvitationDTO{
-		ID:        invitation.ID,
-		LayoutID:  invitation.LayoutID,
-		Data:      invitation.Data,
-		UserID:    invitation.UserID,
-		CreatedAt: invitation.CreatedAt,
-		UpdatedAt: invitation.UpdatedAt,
+		ID:       invitation.ID,
+		LayoutID: invitation.LayoutID,
+		Data:     invitation.Data,
+
+		LayoutVersion: invitation.LayoutVersion,
+		UserID:        invitation.UserID,
+		CreatedAt:     invitation.CreatedAt,
+		UpdatedAt:     invitation.UpdatedAt,
+
+		Translations:  invitation.Translations,
+		DefaultLocale: invitation.DefaultLocale,
+		Version:       invitation.Version,
+		DeletedAt:     invitation.DeletedAt,
 	}
 
-	// Extract title and status from data if they exist
-	title, status := extractMetadataFromData(invitation.Data)
-	if title != nil {
-		dto.Title = title
+	if invitation.Title != "" {
+		title := invitation.Title
+		dto.Title = &title
 	}
-
-	// Default status to "draft" if not present
-	if status != nil {
-		dto.Status = status
-	} else {
-		defaultStatus := "draft"
-		dto.Status = &defaultStatus
+	status := string(invitation.Status)
+	if status == "" {
+		status = string(domain.InvitationStatusDraft)
 	}
+	dto.Status = &status
 
 	return dto
 }
 
-// mergeMetadataIntoData merges title and status into the JSON data field
-func mergeMetadataIntoData(data json.RawMessage, title *string, status *string) (json.RawMessage, error) {
-	var dataMap map[string]interface{}
-	if len(data) == 0 || string(data) == "{}" {
-		dataMap = make(map[string]interface{})
-	} else {
-		if err := json.Unmarshal(data, &dataMap); err != nil {
-			return nil, err
-		}
-	}
-
-	// Store title and status in _meta field to keep them separate from user data
-	if _, ok := dataMap["_meta"]; !ok {
-		dataMap["_meta"] = make(map[string]interface{})
-	}
-	meta, ok := dataMap["_meta"].(map[string]interface{})
-	if !ok {
-		meta = make(map[string]interface{})
-		dataMap["_meta"] = meta
-	}
-
-	if title != nil {
-		meta["title"] = *title
-	}
-	if status != nil {
-		meta["status"] = *status
-	}
-
-	mergedData, err := json.Marshal(dataMap)
-	if err != nil {
-		return nil, err
+// toTrashedInvitationDTO also reports when the trashed invitation will be purged
+func toTrashedInvitationDTO(invitation *domain.Invitation, retention time.Duration) *InvitationDTO {
+	dto := toInvitationDTO(invitation)
+	if invitation.DeletedAt != nil {
+		purgeAt := invitation.DeletedAt.Add(retention)
+		dto.PurgeAt = &purgeAt
 	}
-
-	return json.RawMessage(mergedData), nil
+	return dto
 }
 
-// extractMetadataFromData extracts title and status from the JSON data field
-func extractMetadataFromData(data json.RawMessage) (*string, *string) {
-	if len(data) == 0 {
-		return nil, nil
-	}
-
-	var dataMap map[string]interface{}
-	if err := json.Unmarshal(data, &dataMap); err != nil {
-		return nil, nil
+// splitLegacyMetadata strips the "_meta" object older clients still send inside data. Its title
+// is used when the request does not set one; its status is ignored since status has its own rules.
+func splitLegacyMetadata(data json.RawMessage, title *string) (json.RawMessage, *string, error) {
+	rest, legacyTitle, _, err := domain.SplitLegacyMetadata(data)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	var title *string
-	var status *string
-
-	if meta, ok := dataMap["_meta"].(map[string]interface{}); ok {
-		if t, ok := meta["title"].(string); ok {
-			title = &t
-		}
-		if s, ok := meta["status"].(string); ok {
-			status = &s
-		}
+	if title == nil && legacyTitle != "" {
+		title = &legacyTitle
 	}
-
-	return title, status
+	return rest, title, nil
 }
@@ -9,7 +9,7 @@ import (
 )
 
 func TestToInvitationDTO_DefaultStatusToDraft(t *testing.T) {
-	// Arrange: Create invitation without status in _meta
+	// Arrange: Create invitation without a status
 	invitation := &domain.Invitation{
 		ID:        "inv-123",
 		LayoutID:  "classic-scroll",
@@ -34,80 +34,21 @@ func TestToInvitationDTO_DefaultStatusToDraft(t *testing.T) {
 	if *dto.Status != "draft" {
 		t.Errorf("Expected status to default to 'draft', got '%s'", *dto.Status)
 	}
-}
-
-func TestToInvitationDTO_WithStatusInMeta(t *testing.T) {
-	// Arrange: Create invitation with status="published" in _meta
-	invitation := &domain.Invitation{
-		ID:       "inv-123",
-		LayoutID: "classic-scroll",
-		Data: json.RawMessage(`{
-			"couple": {"bride": "Alice", "groom": "Bob"},
-			"_meta": {"status": "published"}
-		}`),
-		UserID:    "user-123",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-
-	// Act
-	dto := toInvitationDTO(invitation)
-
-	// Assert
-	if dto == nil {
-		t.Fatal("Expected DTO to be created")
-	}
-
-	if dto.Status == nil {
-		t.Fatal("Expected status to be set")
-	}
-
-	if *dto.Status != "published" {
-		t.Errorf("Expected status to be 'published', got '%s'", *dto.Status)
-	}
-}
-
-func TestToInvitationDTO_WithDraftStatusInMeta(t *testing.T) {
-	// Arrange: Create invitation with status="draft" in _meta
-	invitation := &domain.Invitation{
-		ID:       "inv-123",
-		LayoutID: "classic-scroll",
-		Data: json.RawMessage(`{
-			"couple": {"bride": "Alice", "groom": "Bob"},
-			"_meta": {"status": "draft"}
-		}`),
-		UserID:    "user-123",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-
-	// Act
-	dto := toInvitationDTO(invitation)
-
-	// Assert
-	if dto == nil {
-		t.Fatal("Expected DTO to be created")
-	}
-
-	if dto.Status == nil {
-		t.Fatal("Expected status to be set")
-	}
 
-	if *dto.Status != "draft" {
-		t.Errorf("Expected status to be 'draft', got '%s'", *dto.Status)
+	if dto.Title != nil {
+		t.Errorf("Expected title to be omitted, got '%s'", *dto.Title)
 	}
 }
 
-func TestToInvitationDTO_WithTitleInMeta(t *testing.T) {
-	// Arrange: Create invitation with title in _meta
+func TestToInvitationDTO_WithStatusAndTitle(t *testing.T) {
+	// Arrange
 	invitation := &domain.Invitation{
-		ID:       "inv-123",
-		LayoutID: "classic-scroll",
-		Data: json.RawMessage(`{
-			"couple": {"bride": "Alice", "groom": "Bob"},
-			"_meta": {"title": "Alice & Bob's Wedding"}
-		}`),
+		ID:        "inv-123",
+		LayoutID:  "classic-scroll",
+		Data:      json.RawMessage(`{"couple": {"bride": "Alice", "groom": "Bob"}}`),
 		UserID:    "user-123",
+		Title:     "Alice & Bob's Wedding",
+		Status:    domain.InvitationStatusPublished,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -116,99 +57,78 @@ func TestToInvitationDTO_WithTitleInMeta(t *testing.T) {
 	dto := toInvitationDTO(invitation)
 
 	// Assert
-	if dto == nil {
-		t.Fatal("Expected DTO to be created")
-	}
-
-	if dto.Title == nil {
-		t.Fatal("Expected title to be set")
-	}
-
-	if *dto.Title != "Alice & Bob's Wedding" {
-		t.Errorf("Expected title to be 'Alice & Bob's Wedding', got '%s'", *dto.Title)
-	}
-
-	// Status should still default to "draft" when not present
-	if dto.Status == nil {
-		t.Fatal("Expected status to be set (defaulted to 'draft')")
-	}
-
-	if *dto.Status != "draft" {
-		t.Errorf("Expected status to default to 'draft', got '%s'", *dto.Status)
-	}
-}
-
-func TestExtractMetadataFromData_NoMeta(t *testing.T) {
-	// Arrange
-	data := json.RawMessage(`{"couple": {"bride": "Alice", "groom": "Bob"}}`)
-
-	// Act
-	title, status := extractMetadataFromData(data)
-
-	// Assert
-	if title != nil {
-		t.Errorf("Expected title to be nil, got '%s'", *title)
+	if dto.Status == nil || *dto.Status != "published" {
+		t.Errorf("Expected status to be 'published', got %v", dto.Status)
 	}
 
-	if status != nil {
-		t.Errorf("Expected status to be nil, got '%s'", *status)
+	if dto.Title == nil || *dto.Title != "Alice & Bob's Wedding" {
+		t.Errorf("Expected title to be 'Alice & Bob's Wedding', got %v", dto.Title)
 	}
 }
 
-func TestExtractMetadataFromData_WithStatus(t *testing.T) {
+func TestSplitLegacyMetadata_UsesMetaTitleWhenNoneGiven(t *testing.T) {
 	// Arrange
 	data := json.RawMessage(`{
 		"couple": {"bride": "Alice", "groom": "Bob"},
-		"_meta": {"status": "published"}
+		"_meta": {"title": "Alice & Bob's Wedding", "status": "published"}
 	}`)
 
 	// Act
-	title, status := extractMetadataFromData(data)
+	rest, title, err := splitLegacyMetadata(data, nil)
 
 	// Assert
-	if title != nil {
-		t.Errorf("Expected title to be nil, got '%s'", *title)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if status == nil {
-		t.Fatal("Expected status to be extracted")
+	if title == nil || *title != "Alice & Bob's Wedding" {
+		t.Errorf("Expected title from _meta, got %v", title)
 	}
 
-	if *status != "published" {
-		t.Errorf("Expected status to be 'published', got '%s'", *status)
+	var dataMap map[string]interface{}
+	if err := json.Unmarshal(rest, &dataMap); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if _, ok := dataMap["_meta"]; ok {
+		t.Error("Expected _meta to be removed from data")
 	}
 }
 
-func TestExtractMetadataFromData_EmptyData(t *testing.T) {
+func TestSplitLegacyMetadata_RequestTitleWins(t *testing.T) {
 	// Arrange
-	data := json.RawMessage(`{}`)
+	data := json.RawMessage(`{"_meta": {"title": "Old Title"}}`)
+	requested := "New Title"
 
 	// Act
-	title, status := extractMetadataFromData(data)
+	_, title, err := splitLegacyMetadata(data, &requested)
 
 	// Assert
-	if title != nil {
-		t.Errorf("Expected title to be nil, got '%s'", *title)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if status != nil {
-		t.Errorf("Expected status to be nil, got '%s'", *status)
+	if title == nil || *title != "New Title" {
+		t.Errorf("Expected requested title to win, got %v", title)
 	}
 }
 
-func TestExtractMetadataFromData_EmptyString(t *testing.T) {
+func TestSplitLegacyMetadata_NoMeta(t *testing.T) {
 	// Arrange
-	data := json.RawMessage(``)
+	data := json.RawMessage(`{"couple": {"bride": "Alice", "groom": "Bob"}}`)
 
 	// Act
-	title, status := extractMetadataFromData(data)
+	rest, title, err := splitLegacyMetadata(data, nil)
 
 	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
 	if title != nil {
 		t.Errorf("Expected title to be nil, got '%s'", *title)
 	}
 
-	if status != nil {
-		t.Errorf("Expected status to be nil, got '%s'", *status)
+	if string(rest) != string(data) {
+		t.Errorf("Expected data to be unchanged, got %s", rest)
 	}
 }
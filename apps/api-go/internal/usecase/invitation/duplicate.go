@@ -0,0 +1,92 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// DuplicateInvitationUseCase copies one of the caller's invitations into a new draft
+type DuplicateInvitationUseCase struct {
+	invitationRepo repository.InvitationRepository
+	createUC       *CreateInvitationUseCase
+	migrator       *layout.MigrateInvitationDataUseCase // Optional; without it a copy on another layout keeps the data as is
+}
+
+func NewDuplicateInvitationUseCase(invitationRepo repository.InvitationRepository, createUC *CreateInvitationUseCase, migrator *layout.MigrateInvitationDataUseCase) *DuplicateInvitationUseCase {
+	return &DuplicateInvitationUseCase{
+		invitationRepo: invitationRepo,
+		createUC:       createUC,
+		migrator:       migrator,
+	}
+}
+
+type DuplicateInvitationInput struct {
+	ID     string
+	UserID string
+	// LayoutID switches the copy to another layout, migrating its data like SwitchLayoutUseCase;
+	// the source layout is kept when empty
+	LayoutID string
+	// Title of the copy; defaults to the source title followed by " (copy)"
+	Title *string
+	// StripPersonalData drops the domain.PersonalDataKeys sections from the copy and its translations
+	StripPersonalData bool
+}
+
+// Execute creates the copy through CreateInvitationUseCase, so it starts as a draft at version 1
+// with its own revision history and registers usage of the assets its data references.
+// The published site, revisions and trash state of the source are not copied.
+func (uc *DuplicateInvitationUseCase) Execute(ctx context.Context, input DuplicateInvitationInput) (*CreateInvitationOutput, error) {
+	source, err := uc.invitationRepo.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find invitation", err)
+	}
+	if source == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Invitation not found", nil)
+	}
+	if source.UserID != input.UserID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Cannot duplicate another user's invitation", nil)
+	}
+
+	data := append(json.RawMessage(nil), source.Data...)
+	translations := domain.CopyTranslations(source.Translations)
+	if input.StripPersonalData {
+		if data, err = domain.StripPersonalData(data); err != nil {
+			return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to copy invitation data", err)
+		}
+		if translations, err = domain.StripPersonalTranslations(translations); err != nil {
+			return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to copy invitation translations", err)
+		}
+	}
+
+	layoutID := input.LayoutID
+	if layoutID == "" {
+		layoutID = source.LayoutID
+	}
+	if layoutID != source.LayoutID && uc.migrator != nil {
+		migrated, err := uc.migrator.Execute(ctx, source.LayoutID, layoutID, data)
+		if err != nil {
+			return nil, err
+		}
+		data = migrated.Data
+	}
+
+	title := input.Title
+	if title == nil && source.Title != "" {
+		copyTitle := source.Title + " (copy)"
+		title = &copyTitle
+	}
+
+	return uc.createUC.Execute(ctx, CreateInvitationInput{
+		LayoutID:      layoutID,
+		Data:          data,
+		Title:         title,
+		UserID:        input.UserID,
+		Translations:  translations,
+		DefaultLocale: source.DefaultLocale,
+	})
+}
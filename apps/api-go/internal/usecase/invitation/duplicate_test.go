@@ -0,0 +1,195 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateInvitationUseCase_Execute_CopiesIntoNewDraft(t *testing.T) {
+	// Arrange
+	source := &domain.Invitation{
+		ID:            "inv-1",
+		LayoutID:      "classic-scroll",
+		UserID:        "user-1",
+		Title:         "Our Wedding",
+		Status:        domain.InvitationStatusPublished,
+		Data:          json.RawMessage(`{"couple":{"bride":{"name":"Jane"}},"hero":{"mainImage":"/uploads/a.jpg"},"events":{"day1":{}}}`),
+		Translations:  map[string]json.RawMessage{"en": json.RawMessage(`{"title":"Hi"}`)},
+		DefaultLocale: "en",
+		Version:       7,
+	}
+	var created *domain.Invitation
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return source, nil
+		},
+		CreateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			created = invitation
+			return nil
+		},
+	}
+	var tracked []string
+	assetRepo := &MockAssetRepository{
+		FindByURLFn: func(ctx context.Context, url string) (*domain.Asset, error) {
+			return &domain.Asset{ID: "asset-" + url, URL: url}, nil
+		},
+		TrackUsageFn: func(ctx context.Context, assetID, invitationID string) error {
+			tracked = append(tracked, assetID+"@"+invitationID)
+			return nil
+		},
+	}
+	useCase := NewDuplicateInvitationUseCase(invitationRepo, NewCreateInvitationUseCase(invitationRepo, assetRepo, nil, nil, nil), nil)
+
+	// Act
+	output, err := useCase.Execute(context.Background(), DuplicateInvitationInput{
+		ID:       "inv-1",
+		UserID:   "user-1",
+		LayoutID: "editorial-elegance",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.NotEqual(t, "inv-1", created.ID)
+	assert.Equal(t, "editorial-elegance", created.LayoutID)
+	assert.Equal(t, "Our Wedding (copy)", created.Title)
+	assert.Equal(t, domain.InvitationStatusDraft, created.Status)
+	assert.Equal(t, 1, created.Version)
+	assert.JSONEq(t, string(source.Data), string(created.Data))
+	assert.Equal(t, "en", created.DefaultLocale)
+	assert.Equal(t, []string{"asset-/uploads/a.jpg@" + created.ID}, tracked)
+	assert.Equal(t, created.ID, output.Invitation.ID)
+
+	// The copy must not share memory with the source
+	created.Translations["en"][2] = 'X'
+	assert.JSONEq(t, `{"title":"Hi"}`, string(source.Translations["en"]))
+}
+
+func TestDuplicateInvitationUseCase_Execute_StripsPersonalData(t *testing.T) {
+	// Arrange
+	var created *domain.Invitation
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{
+				ID:       id,
+				LayoutID: "classic-scroll",
+				UserID:   "user-1",
+				Data:     json.RawMessage(`{"couple":{"bride":{"name":"Jane"}},"rsvp":{"whatsappNumber":"91"},"events":{"day1":{}}}`),
+				Translations: map[string]json.RawMessage{
+					"hi": json.RawMessage(`{"couple":{"bride":{"name":"जेन"}},"events":{"day1":{"title":"मेहंदी"}}}`),
+				},
+				DefaultLocale: "hi",
+			}, nil
+		},
+		CreateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			created = invitation
+			return nil
+		},
+	}
+	title := "Client template"
+	useCase := NewDuplicateInvitationUseCase(invitationRepo, NewCreateInvitationUseCase(invitationRepo, nil, nil, nil, nil), nil)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), DuplicateInvitationInput{
+		ID:                "inv-1",
+		UserID:            "user-1",
+		Title:             &title,
+		StripPersonalData: true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"events":{"day1":{}}}`, string(created.Data))
+	assert.JSONEq(t, `{"events":{"day1":{"title":"मेहंदी"}}}`, string(created.Translations["hi"]))
+	assert.Equal(t, "Client template", created.Title)
+}
+
+func TestDuplicateInvitationUseCase_Execute_StripsEditorialElegancePersonalData(t *testing.T) {
+	// Arrange
+	var created *domain.Invitation
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{
+				ID:       id,
+				LayoutID: "editorial-elegance",
+				UserID:   "user-1",
+				Data: json.RawMessage(`{
+					"couple":{"bride":{"name":"Pooja Singh","parents":{"mother":"Mrs. Geeta Singh"}}},
+					"wedding":{"dates":["2026-05-13"],"venue":{"name":"Halcyon Hotel Residences"}},
+					"hero":{"mainImage":"/uploads/couple/1.jpeg","alignment":"center"},
+					"editorialIntro":{"text":"Two paths, one story.","image":"/uploads/couple/2.jpeg"},
+					"weddingParty":{"bride":{"name":"Pooja Singh","bio":"Loves trains","image":"/uploads/bride.jpeg"},"members":[],"filter":"bw"},
+					"video":{"url":"/uploads/film.mp4"},
+					"events":{"events":[
+						{"label":"Haldi","date":"2021-05-13","time":"9:00 AM","venue":"Halcyon Hotel Residences","image":"/uploads/haldi.jpeg"},
+						{"label":"Marriage","date":"2021-05-15","time":"12:00 AM","venue":"Halcyon Hotel Residences"}
+					]},
+					"galleryConfig":{"layout":"masonry","maxImages":12},
+					"music":{"volume":0.5}
+				}`),
+			}, nil
+		},
+		CreateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			created = invitation
+			return nil
+		},
+	}
+	useCase := NewDuplicateInvitationUseCase(invitationRepo, NewCreateInvitationUseCase(invitationRepo, nil, nil, nil, nil), nil)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), DuplicateInvitationInput{
+		ID:                "inv-1",
+		UserID:            "user-1",
+		StripPersonalData: true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"events":{"events":[
+			{"label":"Haldi","date":"","time":"","venue":"Halcyon Hotel Residences","image":""},
+			{"label":"Marriage","date":"","time":"","venue":"Halcyon Hotel Residences"}
+		]},
+		"galleryConfig":{"layout":"masonry","maxImages":12},
+		"music":{"volume":0.5}
+	}`, string(created.Data))
+}
+
+func TestDuplicateInvitationUseCase_Execute_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   *domain.Invitation
+		wantCode int
+	}{
+		{name: "not found", source: nil, wantCode: http.StatusNotFound},
+		{name: "another user's invitation", source: &domain.Invitation{ID: "inv-1", LayoutID: "classic-scroll", UserID: "user-2"}, wantCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitationRepo := &MockInvitationRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+					return tt.source, nil
+				},
+				CreateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+					t.Fatal("Create should not be called")
+					return nil
+				},
+			}
+			useCase := NewDuplicateInvitationUseCase(invitationRepo, NewCreateInvitationUseCase(invitationRepo, nil, nil, nil, nil), nil)
+
+			_, err := useCase.Execute(context.Background(), DuplicateInvitationInput{ID: "inv-1", UserID: "user-1"})
+
+			var appErr *errors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package invitation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/asset"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// defaultExpireGuestLimit bounds how many drafts a single run deletes
+const defaultExpireGuestLimit = 500
+
+// ExpireGuestInvitationsUseCase deletes guest session drafts that were never claimed by
+// signing up and have not been saved for longer than the TTL.
+type ExpireGuestInvitationsUseCase struct {
+	invitationRepo repository.InvitationRepository
+	purgeUC        *PurgeInvitationUseCase
+	ttl            time.Duration
+	clock          clock.Clock
+}
+
+func NewExpireGuestInvitationsUseCase(invitationRepo repository.InvitationRepository, purgeUC *PurgeInvitationUseCase, ttl time.Duration, clk clock.Clock) *ExpireGuestInvitationsUseCase {
+	return &ExpireGuestInvitationsUseCase{
+		invitationRepo: invitationRepo,
+		purgeUC:        purgeUC,
+		ttl:            ttl,
+		clock:          clk,
+	}
+}
+
+type ExpireGuestInvitationsInput struct {
+	DryRun bool
+	// Limit is the maximum number of drafts handled in this run (default: 500)
+	Limit int
+}
+
+type ExpireGuestInvitationsOutput struct {
+	Expired       []string          // IDs of expired drafts (deleted unless DryRun)
+	DeletedAssets []*asset.AssetDTO // Assets removed with the drafts
+	Errors        []string
+}
+
+func (uc *ExpireGuestInvitationsUseCase) Execute(ctx context.Context, input ExpireGuestInvitationsInput) (*ExpireGuestInvitationsOutput, error) {
+	if uc.ttl <= 0 {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Guest draft TTL must be positive", nil)
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultExpireGuestLimit
+	}
+
+	cutoff := uc.clock.Now().Add(-uc.ttl)
+	invitations, err := uc.invitationRepo.FindGuestInvitationsUpdatedBefore(ctx, cutoff, limit)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find expired guest drafts", err)
+	}
+
+	output := &ExpireGuestInvitationsOutput{
+		Expired:       make([]string, 0, len(invitations)),
+		DeletedAssets: make([]*asset.AssetDTO, 0),
+		Errors:        make([]string, 0),
+	}
+	for _, inv := range invitations {
+		if input.DryRun {
+			output.Expired = append(output.Expired, inv.ID)
+			continue
+		}
+
+		deleted, err := uc.purgeUC.Execute(ctx, inv)
+		if err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: %v", inv.ID, err))
+			continue
+		}
+		output.Expired = append(output.Expired, inv.ID)
+		output.DeletedAssets = append(output.DeletedAssets, deleted.DeletedAssets...)
+	}
+
+	logger.GetLogger().Info("Expired guest drafts",
+		zap.Time("cutoff", cutoff),
+		zap.Int("count", len(output.Expired)),
+		zap.Int("errors", len(output.Errors)),
+		zap.Bool("dryRun", input.DryRun),
+	)
+
+	return output, nil
+}
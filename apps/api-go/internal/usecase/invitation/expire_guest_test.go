@@ -0,0 +1,92 @@
+package invitation
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpireGuestInvitationsUseCase_Execute(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	ttl := 30 * 24 * time.Hour
+	clk := &MockClock{NowFn: func() time.Time { return now }}
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		deleteErrID string
+		wantExpired []string
+		wantDeleted []string
+		wantErrors  int
+	}{
+		{
+			name:        "deletes expired drafts",
+			wantExpired: []string{"inv-1", "inv-2"},
+			wantDeleted: []string{"inv-1", "inv-2"},
+		},
+		{
+			name:        "dry run deletes nothing",
+			dryRun:      true,
+			wantExpired: []string{"inv-1", "inv-2"},
+		},
+		{
+			name:        "failed deletion is reported and skipped",
+			deleteErrID: "inv-1",
+			wantExpired: []string{"inv-2"},
+			wantDeleted: []string{"inv-2"},
+			wantErrors:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			drafts := map[string]*domain.Invitation{
+				"inv-1": {ID: "inv-1", UserID: "guest_a"},
+				"inv-2": {ID: "inv-2", UserID: "guest_b"},
+			}
+			var gotCutoff time.Time
+			var gotLimit int
+			var deleted []string
+			mockInvitationRepo := &MockInvitationRepository{
+				FindGuestInvitationsUpdatedBeforeFn: func(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error) {
+					gotCutoff, gotLimit = cutoff, limit
+					return []*domain.Invitation{drafts["inv-1"], drafts["inv-2"]}, nil
+				},
+				DeleteFn: func(ctx context.Context, id string) error {
+					if id == tt.deleteErrID {
+						return stderrors.New("firestore unavailable")
+					}
+					deleted = append(deleted, id)
+					return nil
+				},
+			}
+			purgeUC := NewPurgeInvitationUseCase(mockInvitationRepo, nil, nil, nil, nil, nil)
+			useCase := NewExpireGuestInvitationsUseCase(mockInvitationRepo, purgeUC, ttl, clk)
+
+			// Act
+			output, err := useCase.Execute(context.Background(), ExpireGuestInvitationsInput{DryRun: tt.dryRun})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, now.Add(-ttl), gotCutoff, "Drafts older than the TTL should be selected")
+			assert.Equal(t, defaultExpireGuestLimit, gotLimit)
+			assert.Equal(t, tt.wantExpired, output.Expired)
+			assert.Equal(t, tt.wantDeleted, deleted)
+			assert.Len(t, output.Errors, tt.wantErrors)
+		})
+	}
+}
+
+func TestExpireGuestInvitationsUseCase_Execute_RequiresTTL(t *testing.T) {
+	useCase := NewExpireGuestInvitationsUseCase(&MockInvitationRepository{}, nil, 0, &MockClock{})
+
+	_, err := useCase.Execute(context.Background(), ExpireGuestInvitationsInput{})
+
+	require.Error(t, err)
+}
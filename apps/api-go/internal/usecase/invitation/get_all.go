@@ -2,6 +2,7 @@ package invitation
 
 import (
 	"context"
+	stderrors "errors"
 
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 	"github.com/sacred-vows/api-go/pkg/errors"
@@ -19,13 +20,19 @@ func NewGetAllInvitationsUseCase(invitationRepo repository.InvitationRepository)
 
 type GetAllInvitationsOutput struct {
 	Invitations []*InvitationDTO
+	// NextCursor fetches the next page; empty on the last page
+	NextCursor string
 }
 
-func (uc *GetAllInvitationsUseCase) Execute(ctx context.Context, userID string) (*GetAllInvitationsOutput, error) {
-	invitations, err := uc.invitationRepo.FindByUserID(ctx, userID)
+func (uc *GetAllInvitationsUseCase) Execute(ctx context.Context, userID string, opts repository.ListOptions) (*GetAllInvitationsOutput, error) {
+	page, err := uc.invitationRepo.FindByUserID(ctx, userID, opts)
 	if err != nil {
+		if stderrors.Is(err, repository.ErrInvalidListOptions) {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, err.Error(), err)
+		}
 		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get invitations", err)
 	}
+	invitations := page.Items
 
 	dtos := make([]*InvitationDTO, len(invitations))
 	for i, inv := range invitations {
@@ -34,5 +41,6 @@ func (uc *GetAllInvitationsUseCase) Execute(ctx context.Context, userID string)
 
 	return &GetAllInvitationsOutput{
 		Invitations: dtos,
+		NextCursor:  page.NextCursor,
 	}, nil
 }
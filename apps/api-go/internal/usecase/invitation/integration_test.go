@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 )
 
 // TestCreateInvitationUseCase_Integration_StatusInResponse tests that
@@ -18,7 +19,7 @@ func TestCreateInvitationUseCase_Integration_StatusInResponse(t *testing.T) {
 		},
 	}
 
-	useCase := NewCreateInvitationUseCase(mockRepo, nil)
+	useCase := NewCreateInvitationUseCase(mockRepo, nil, nil, nil, nil)
 	input := CreateInvitationInput{
 		LayoutID: "classic-scroll",
 		Data:     json.RawMessage(`{}`),
@@ -68,9 +69,9 @@ func TestGetAllInvitationsUseCase_Integration_DefaultStatus(t *testing.T) {
 	}
 
 	mockRepo := &MockInvitationRepository{
-		FindByUserIDFn: func(ctx context.Context, userID string) ([]*domain.Invitation, error) {
+		FindByUserIDFn: func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
 			if userID == "user-123" {
-				return invitationsWithoutStatus, nil
+				return &repository.Page[*domain.Invitation]{Items: invitationsWithoutStatus}, nil
 			}
 			return nil, nil
 		},
@@ -79,7 +80,7 @@ func TestGetAllInvitationsUseCase_Integration_DefaultStatus(t *testing.T) {
 	useCase := NewGetAllInvitationsUseCase(mockRepo)
 
 	// Act
-	output, err := useCase.Execute(context.Background(), "user-123")
+	output, err := useCase.Execute(context.Background(), "user-123", repository.ListOptions{})
 
 	// Assert
 	if err != nil {
@@ -115,14 +116,16 @@ func TestGetAllInvitationsUseCase_Integration_PreserveExistingStatus(t *testing.
 		{
 			ID:       "inv-1",
 			LayoutID: "classic-scroll",
-			Data:     json.RawMessage(`{"_meta": {"status": "published"}}`),
+			Data:     json.RawMessage(`{}`),
 			UserID:   "user-123",
+			Status:   domain.InvitationStatusPublished,
 		},
 		{
 			ID:       "inv-2",
 			LayoutID: "classic-scroll",
-			Data:     json.RawMessage(`{"_meta": {"status": "draft"}}`),
+			Data:     json.RawMessage(`{}`),
 			UserID:   "user-123",
+			Status:   domain.InvitationStatusDraft,
 		},
 		{
 			ID:       "inv-3",
@@ -133,9 +136,9 @@ func TestGetAllInvitationsUseCase_Integration_PreserveExistingStatus(t *testing.
 	}
 
 	mockRepo := &MockInvitationRepository{
-		FindByUserIDFn: func(ctx context.Context, userID string) ([]*domain.Invitation, error) {
+		FindByUserIDFn: func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
 			if userID == "user-123" {
-				return invitationsWithStatus, nil
+				return &repository.Page[*domain.Invitation]{Items: invitationsWithStatus}, nil
 			}
 			return nil, nil
 		},
@@ -144,7 +147,7 @@ func TestGetAllInvitationsUseCase_Integration_PreserveExistingStatus(t *testing.
 	useCase := NewGetAllInvitationsUseCase(mockRepo)
 
 	// Act
-	output, err := useCase.Execute(context.Background(), "user-123")
+	output, err := useCase.Execute(context.Background(), "user-123", repository.ListOptions{})
 
 	// Assert
 	if err != nil {
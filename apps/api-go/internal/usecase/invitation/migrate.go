@@ -3,6 +3,7 @@ package invitation
 import (
 	"context"
 
+	"github.com/sacred-vows/api-go/internal/domain"
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 	"github.com/sacred-vows/api-go/pkg/errors"
 	"github.com/sacred-vows/api-go/pkg/logger"
@@ -38,6 +39,10 @@ func (uc *MigrateInvitationsUseCase) Execute(ctx context.Context, input MigrateI
 	if input.FromUserID == input.ToUserID {
 		return nil, errors.Wrap(errors.ErrBadRequest.Code, "FromUserID and ToUserID must be different", nil)
 	}
+	// Only guest session drafts can be claimed; registered users' invitations never move
+	if !domain.IsGuestSessionID(input.FromUserID) {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "FromUserID must be a guest session", nil)
+	}
 
 	count, err := uc.invitationRepo.MigrateUserInvitations(ctx, input.FromUserID, input.ToUserID)
 	if err != nil {
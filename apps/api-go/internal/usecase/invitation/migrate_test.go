@@ -0,0 +1,60 @@
+package invitation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateInvitationsUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name       string
+		fromUserID string
+		toUserID   string
+		wantCode   int
+		wantCount  int
+	}{
+		{"claims guest session drafts", "guest_2bZ8yQm6a8K3k1bTQeYxk2m0TfA", "user-123", 0, 2},
+		{"rejects registered user source", "user-456", "user-123", http.StatusBadRequest, 0},
+		{"rejects legacy shared anonymous user", "anonymous", "user-123", http.StatusBadRequest, 0},
+		{"rejects bare guest prefix", "guest_", "user-123", http.StatusBadRequest, 0},
+		{"requires source", "", "user-123", http.StatusBadRequest, 0},
+		{"requires target", "guest_abc", "", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			migrated := false
+			mockInvitationRepo := &MockInvitationRepository{
+				MigrateUserInvitationsFn: func(ctx context.Context, fromUserID, toUserID string) (int, error) {
+					migrated = true
+					return 2, nil
+				},
+			}
+			useCase := NewMigrateInvitationsUseCase(mockInvitationRepo)
+
+			// Act
+			output, err := useCase.Execute(context.Background(), MigrateInvitationsInput{
+				FromUserID: tt.fromUserID,
+				ToUserID:   tt.toUserID,
+			})
+
+			// Assert
+			if tt.wantCode != 0 {
+				require.Error(t, err)
+				appErr, ok := err.(*errors.AppError)
+				require.True(t, ok, "Should return AppError")
+				assert.Equal(t, tt.wantCode, appErr.Code)
+				assert.False(t, migrated, "Nothing should be migrated")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCount, output.MigratedCount)
+		})
+	}
+}
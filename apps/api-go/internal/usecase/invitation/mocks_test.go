@@ -2,18 +2,28 @@ package invitation
 
 import (
 	"context"
+	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 )
 
 // MockInvitationRepository is a hand-written mock implementation of InvitationRepository
 type MockInvitationRepository struct {
 	CreateFn                 func(ctx context.Context, invitation *domain.Invitation) error
 	FindByIDFn               func(ctx context.Context, id string) (*domain.Invitation, error)
-	FindByUserIDFn           func(ctx context.Context, userID string) ([]*domain.Invitation, error)
+	FindByUserIDFn           func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error)
 	UpdateFn                 func(ctx context.Context, invitation *domain.Invitation) error
+	UpdateStatusFn           func(ctx context.Context, id string, status domain.InvitationStatus) error
 	DeleteFn                 func(ctx context.Context, id string) error
 	MigrateUserInvitationsFn func(ctx context.Context, fromUserID, toUserID string) (int, error)
+
+	FindGuestInvitationsUpdatedBeforeFn func(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error)
+	MoveToTrashFn                       func(ctx context.Context, id string, deletedAt time.Time) error
+	RestoreFromTrashFn                  func(ctx context.Context, id string) error
+	FindTrashedByIDFn                   func(ctx context.Context, id string) (*domain.Invitation, error)
+	FindTrashedByUserIDFn               func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error)
+	FindTrashedBeforeFn                 func(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error)
 }
 
 func (m *MockInvitationRepository) Create(ctx context.Context, invitation *domain.Invitation) error {
@@ -30,9 +40,9 @@ func (m *MockInvitationRepository) FindByID(ctx context.Context, id string) (*do
 	return nil, nil
 }
 
-func (m *MockInvitationRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Invitation, error) {
+func (m *MockInvitationRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
 	if m.FindByUserIDFn != nil {
-		return m.FindByUserIDFn(ctx, userID)
+		return m.FindByUserIDFn(ctx, userID, opts)
 	}
 	return nil, nil
 }
@@ -44,6 +54,13 @@ func (m *MockInvitationRepository) Update(ctx context.Context, invitation *domai
 	return nil
 }
 
+func (m *MockInvitationRepository) UpdateStatus(ctx context.Context, id string, status domain.InvitationStatus) error {
+	if m.UpdateStatusFn != nil {
+		return m.UpdateStatusFn(ctx, id, status)
+	}
+	return nil
+}
+
 func (m *MockInvitationRepository) Delete(ctx context.Context, id string) error {
 	if m.DeleteFn != nil {
 		return m.DeleteFn(ctx, id)
@@ -58,19 +75,67 @@ func (m *MockInvitationRepository) MigrateUserInvitations(ctx context.Context, f
 	return 0, nil
 }
 
+func (m *MockInvitationRepository) FindGuestInvitationsUpdatedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error) {
+	if m.FindGuestInvitationsUpdatedBeforeFn != nil {
+		return m.FindGuestInvitationsUpdatedBeforeFn(ctx, cutoff, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockInvitationRepository) MoveToTrash(ctx context.Context, id string, deletedAt time.Time) error {
+	if m.MoveToTrashFn != nil {
+		return m.MoveToTrashFn(ctx, id, deletedAt)
+	}
+	return nil
+}
+
+func (m *MockInvitationRepository) RestoreFromTrash(ctx context.Context, id string) error {
+	if m.RestoreFromTrashFn != nil {
+		return m.RestoreFromTrashFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockInvitationRepository) FindTrashedByID(ctx context.Context, id string) (*domain.Invitation, error) {
+	if m.FindTrashedByIDFn != nil {
+		return m.FindTrashedByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockInvitationRepository) FindTrashedByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
+	if m.FindTrashedByUserIDFn != nil {
+		return m.FindTrashedByUserIDFn(ctx, userID, opts)
+	}
+	return nil, nil
+}
+
+func (m *MockInvitationRepository) FindTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error) {
+	if m.FindTrashedBeforeFn != nil {
+		return m.FindTrashedBeforeFn(ctx, cutoff, limit)
+	}
+	return nil, nil
+}
+
 // MockAssetRepository is a hand-written mock implementation of AssetRepository
 type MockAssetRepository struct {
-	CreateFn                func(ctx context.Context, asset *domain.Asset) error
-	FindByIDFn              func(ctx context.Context, id string) (*domain.Asset, error)
-	FindByUserIDFn          func(ctx context.Context, userID string) ([]*domain.Asset, error)
-	FindByURLFn             func(ctx context.Context, url string) (*domain.Asset, error)
-	FindByURLsFn            func(ctx context.Context, urls []string) ([]*domain.Asset, error)
-	DeleteFn                func(ctx context.Context, id string) error
-	DeleteByURLFn           func(ctx context.Context, url string) error
-	FindUsedInInvitationsFn func(ctx context.Context, assetID string) ([]string, error)
-	TrackUsageFn            func(ctx context.Context, assetID, invitationID string) error
-	UntrackUsageFn          func(ctx context.Context, assetID, invitationID string) error
-	UntrackAllUsageFn       func(ctx context.Context, invitationID string) error
+	CreateFn                  func(ctx context.Context, asset *domain.Asset) error
+	FindByIDFn                func(ctx context.Context, id string) (*domain.Asset, error)
+	FindByUserIDFn            func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error)
+	FindByURLFn               func(ctx context.Context, url string) (*domain.Asset, error)
+	FindByURLsFn              func(ctx context.Context, urls []string) ([]*domain.Asset, error)
+	DeleteFn                  func(ctx context.Context, id string) error
+	DeleteByURLFn             func(ctx context.Context, url string) error
+	FindUsedInInvitationsFn   func(ctx context.Context, assetID string) ([]string, error)
+	TrackUsageFn              func(ctx context.Context, assetID, invitationID string) error
+	UntrackUsageFn            func(ctx context.Context, assetID, invitationID string) error
+	UntrackAllUsageFn         func(ctx context.Context, invitationID string) error
+	MoveToTrashFn             func(ctx context.Context, id string, deletedAt time.Time) error
+	RestoreFromTrashFn        func(ctx context.Context, id string) error
+	FindTrashedByIDFn         func(ctx context.Context, id string) (*domain.Asset, error)
+	FindTrashedByUserIDFn     func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error)
+	FindTrashedBeforeFn       func(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Asset, error)
+	FindAllIncludingTrashedFn func(ctx context.Context, opts repository.ListOptions) (*repository.Page[*domain.Asset], error)
 }
 
 func (m *MockAssetRepository) Create(ctx context.Context, asset *domain.Asset) error {
@@ -87,9 +152,9 @@ func (m *MockAssetRepository) FindByID(ctx context.Context, id string) (*domain.
 	return nil, nil
 }
 
-func (m *MockAssetRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Asset, error) {
+func (m *MockAssetRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
 	if m.FindByUserIDFn != nil {
-		return m.FindByUserIDFn(ctx, userID)
+		return m.FindByUserIDFn(ctx, userID, opts)
 	}
 	return nil, nil
 }
@@ -149,3 +214,189 @@ func (m *MockAssetRepository) UntrackAllUsage(ctx context.Context, invitationID
 	}
 	return nil
 }
+
+func (m *MockAssetRepository) MoveToTrash(ctx context.Context, id string, deletedAt time.Time) error {
+	if m.MoveToTrashFn != nil {
+		return m.MoveToTrashFn(ctx, id, deletedAt)
+	}
+	return nil
+}
+
+func (m *MockAssetRepository) RestoreFromTrash(ctx context.Context, id string) error {
+	if m.RestoreFromTrashFn != nil {
+		return m.RestoreFromTrashFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockAssetRepository) FindTrashedByID(ctx context.Context, id string) (*domain.Asset, error) {
+	if m.FindTrashedByIDFn != nil {
+		return m.FindTrashedByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockAssetRepository) FindTrashedByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+	if m.FindTrashedByUserIDFn != nil {
+		return m.FindTrashedByUserIDFn(ctx, userID, opts)
+	}
+	return nil, nil
+}
+
+func (m *MockAssetRepository) FindTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Asset, error) {
+	if m.FindTrashedBeforeFn != nil {
+		return m.FindTrashedBeforeFn(ctx, cutoff, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockAssetRepository) FindAllIncludingTrashed(ctx context.Context, opts repository.ListOptions) (*repository.Page[*domain.Asset], error) {
+	if m.FindAllIncludingTrashedFn != nil {
+		return m.FindAllIncludingTrashedFn(ctx, opts)
+	}
+	return &repository.Page[*domain.Asset]{}, nil
+}
+
+// MockInvitationRevisionRepository is a hand-written mock implementation of InvitationRevisionRepository
+type MockInvitationRevisionRepository struct {
+	AppendFn               func(ctx context.Context, revision *domain.InvitationRevision) error
+	ListByInvitationIDFn   func(ctx context.Context, invitationID string) ([]*domain.InvitationRevision, error)
+	FindByRevisionFn       func(ctx context.Context, invitationID string, revision int) (*domain.InvitationRevision, error)
+	PruneFn                func(ctx context.Context, invitationID string, keep int) (int, error)
+	DeleteByInvitationIDFn func(ctx context.Context, invitationID string) error
+}
+
+func (m *MockInvitationRevisionRepository) Append(ctx context.Context, revision *domain.InvitationRevision) error {
+	if m.AppendFn != nil {
+		return m.AppendFn(ctx, revision)
+	}
+	return nil
+}
+
+func (m *MockInvitationRevisionRepository) ListByInvitationID(ctx context.Context, invitationID string) ([]*domain.InvitationRevision, error) {
+	if m.ListByInvitationIDFn != nil {
+		return m.ListByInvitationIDFn(ctx, invitationID)
+	}
+	return nil, nil
+}
+
+func (m *MockInvitationRevisionRepository) FindByRevision(ctx context.Context, invitationID string, revision int) (*domain.InvitationRevision, error) {
+	if m.FindByRevisionFn != nil {
+		return m.FindByRevisionFn(ctx, invitationID, revision)
+	}
+	return nil, nil
+}
+
+func (m *MockInvitationRevisionRepository) Prune(ctx context.Context, invitationID string, keep int) (int, error) {
+	if m.PruneFn != nil {
+		return m.PruneFn(ctx, invitationID, keep)
+	}
+	return 0, nil
+}
+
+func (m *MockInvitationRevisionRepository) DeleteByInvitationID(ctx context.Context, invitationID string) error {
+	if m.DeleteByInvitationIDFn != nil {
+		return m.DeleteByInvitationIDFn(ctx, invitationID)
+	}
+	return nil
+}
+
+// MockClock is a hand-written mock implementation of Clock
+type MockClock struct {
+	NowFn func() time.Time
+}
+
+func (m *MockClock) Now() time.Time {
+	if m.NowFn != nil {
+		return m.NowFn()
+	}
+	return time.Now()
+}
+
+// MockFileStorage is a hand-written mock implementation of asset.FileStorage
+type MockFileStorage struct {
+	DeleteFileFn func(filename string) error
+}
+
+func (m *MockFileStorage) DeleteFile(filename string) error {
+	if m.DeleteFileFn != nil {
+		return m.DeleteFileFn(filename)
+	}
+	return nil
+}
+
+// MockInvitationTemplateRepository is a hand-written mock implementation of InvitationTemplateRepository
+type MockInvitationTemplateRepository struct {
+	CreateFn       func(ctx context.Context, template *domain.InvitationTemplate) error
+	FindByIDFn     func(ctx context.Context, id string) (*domain.InvitationTemplate, error)
+	FindByUserIDFn func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.InvitationTemplate], error)
+	DeleteFn       func(ctx context.Context, id string) error
+}
+
+func (m *MockInvitationTemplateRepository) Create(ctx context.Context, template *domain.InvitationTemplate) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, template)
+	}
+	return nil
+}
+
+func (m *MockInvitationTemplateRepository) FindByID(ctx context.Context, id string) (*domain.InvitationTemplate, error) {
+	if m.FindByIDFn != nil {
+		return m.FindByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockInvitationTemplateRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.InvitationTemplate], error) {
+	if m.FindByUserIDFn != nil {
+		return m.FindByUserIDFn(ctx, userID, opts)
+	}
+	return &repository.Page[*domain.InvitationTemplate]{}, nil
+}
+
+func (m *MockInvitationTemplateRepository) Delete(ctx context.Context, id string) error {
+	if m.DeleteFn != nil {
+		return m.DeleteFn(ctx, id)
+	}
+	return nil
+}
+
+// MockLayoutRepository is a hand-written mock implementation of LayoutRepository
+type MockLayoutRepository struct {
+	FindByIDFn    func(ctx context.Context, id string) (*domain.Layout, error)
+	FindVersionFn func(ctx context.Context, id, version string) (*domain.Layout, error)
+}
+
+func (m *MockLayoutRepository) Create(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+	return nil
+}
+
+func (m *MockLayoutRepository) FindByID(ctx context.Context, id string) (*domain.Layout, error) {
+	if m.FindByIDFn != nil {
+		return m.FindByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockLayoutRepository) FindAll(ctx context.Context) ([]*domain.Layout, error) {
+	return nil, nil
+}
+
+func (m *MockLayoutRepository) FindAllIncludingInactive(ctx context.Context) ([]*domain.Layout, error) {
+	return nil, nil
+}
+
+func (m *MockLayoutRepository) FindVersion(ctx context.Context, id, version string) (*domain.Layout, error) {
+	if m.FindVersionFn != nil {
+		return m.FindVersionFn(ctx, id, version)
+	}
+	return nil, nil
+}
+
+func (m *MockLayoutRepository) Update(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+	return nil
+}
+
+func (m *MockLayoutRepository) Delete(ctx context.Context, id string) error {
+	return nil
+}
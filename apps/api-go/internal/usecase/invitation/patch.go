@@ -0,0 +1,116 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/sacred-vows/api-go/pkg/jsonpatch"
+)
+
+// PatchFormat identifies how a patch document is interpreted
+type PatchFormat string
+
+const (
+	// PatchFormatJSONPatch is an RFC 6902 JSON Patch (application/json-patch+json)
+	PatchFormatJSONPatch PatchFormat = "json-patch"
+	// PatchFormatMergePatch is an RFC 7396 JSON Merge Patch (application/merge-patch+json)
+	PatchFormatMergePatch PatchFormat = "merge-patch"
+)
+
+type PatchInvitationUseCase struct {
+	invitationRepo repository.InvitationRepository
+	assetRepo      repository.AssetRepository
+	revisions      *RevisionRecorder
+	dataValidator  *layout.ValidateInvitationDataUseCase // Optional; without it data is only checked to be an object
+}
+
+func NewPatchInvitationUseCase(invitationRepo repository.InvitationRepository, assetRepo repository.AssetRepository, revisions *RevisionRecorder, dataValidator *layout.ValidateInvitationDataUseCase) *PatchInvitationUseCase {
+	return &PatchInvitationUseCase{
+		invitationRepo: invitationRepo,
+		assetRepo:      assetRepo,
+		revisions:      revisions,
+		dataValidator:  dataValidator,
+	}
+}
+
+type PatchInvitationInput struct {
+	ID     string
+	Format PatchFormat
+	// Patch is applied to the invitation data document
+	Patch json.RawMessage
+	// AuthorID is the caller, who must own the invitation; it is recorded on the revision created by this patch
+	AuthorID string
+	// ExpectedVersion is the version the patch was computed against; a mismatch is a conflict
+	ExpectedVersion *int
+}
+
+type PatchInvitationOutput struct {
+	Invitation *InvitationDTO
+}
+
+// Execute applies a patch to the invitation data. Asset usage is only updated for
+// asset URLs the patch added or removed.
+func (uc *PatchInvitationUseCase) Execute(ctx context.Context, input PatchInvitationInput) (*PatchInvitationOutput, error) {
+	invitation, err := uc.invitationRepo.FindByID(ctx, input.ID)
+	if err != nil || invitation == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Invitation not found", err)
+	}
+	if invitation.UserID != input.AuthorID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Cannot update another user's invitation", nil)
+	}
+
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != invitation.Version {
+		return nil, versionConflict(&domain.VersionConflictError{CurrentVersion: invitation.Version})
+	}
+
+	before := invitation.Data
+	if len(before) == 0 {
+		before = json.RawMessage("{}")
+	}
+
+	var patched []byte
+	switch input.Format {
+	case PatchFormatJSONPatch:
+		patched, err = jsonpatch.Apply(before, input.Patch)
+	case PatchFormatMergePatch:
+		patched, err = jsonpatch.MergePatch(before, input.Patch)
+	default:
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Unsupported patch format", nil)
+	}
+	if err != nil {
+		if stderrors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, errors.Wrap(errors.ErrConflict.Code, "Patch test operation failed", err)
+		}
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid patch", err)
+	}
+
+	// Invitation data must remain a JSON object
+	var dataMap map[string]interface{}
+	if err := json.Unmarshal(patched, &dataMap); err != nil || dataMap == nil {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Patched invitation data must be a JSON object", err)
+	}
+
+	if err := validateInvitationData(ctx, uc.dataValidator, invitation.LayoutID, invitation.LayoutVersion, patched); err != nil {
+		return nil, err
+	}
+
+	invitation.Data = json.RawMessage(patched)
+	if err := uc.invitationRepo.Update(ctx, invitation); err != nil {
+		if stderrors.Is(err, domain.ErrVersionConflict) {
+			return nil, versionConflict(err)
+		}
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to update invitation", err)
+	}
+
+	diffAssetUsage(ctx, uc.assetRepo, invitation.ID, before, invitation.Data)
+	uc.revisions.Record(ctx, invitation, input.AuthorID, 0)
+
+	return &PatchInvitationOutput{
+		Invitation: toInvitationDTO(invitation),
+	}, nil
+}
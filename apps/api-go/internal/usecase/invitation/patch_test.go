@@ -0,0 +1,202 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchInvitationUseCase_Execute(t *testing.T) {
+	newExisting := func() *domain.Invitation {
+		return &domain.Invitation{
+			ID:       "invitation-123",
+			UserID:   "user-123",
+			LayoutID: "classic-scroll",
+			Data:     json.RawMessage(`{"bride":"Jane","photos":["/uploads/a.jpg"]}`),
+			Version:  3,
+		}
+	}
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name            string
+		format          PatchFormat
+		patch           string
+		expectedVersion *int
+		wantData        string
+		wantCode        int
+	}{
+		{
+			name:     "json patch",
+			format:   PatchFormatJSONPatch,
+			patch:    `[{"op":"replace","path":"/bride","value":"Janet"}]`,
+			wantData: `{"bride":"Janet","photos":["/uploads/a.jpg"]}`,
+		},
+		{
+			name:            "merge patch",
+			format:          PatchFormatMergePatch,
+			patch:           `{"bride":null,"groom":"John"}`,
+			expectedVersion: intPtr(3),
+			wantData:        `{"groom":"John","photos":["/uploads/a.jpg"]}`,
+		},
+		{
+			name:            "stale version",
+			format:          PatchFormatMergePatch,
+			patch:           `{"groom":"John"}`,
+			expectedVersion: intPtr(2),
+			wantCode:        http.StatusConflict,
+		},
+		{
+			name:     "failed test operation",
+			format:   PatchFormatJSONPatch,
+			patch:    `[{"op":"test","path":"/bride","value":"Janet"}]`,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "invalid patch",
+			format:   PatchFormatJSONPatch,
+			patch:    `[{"op":"remove","path":"/groom"}]`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "result is not an object",
+			format:   PatchFormatMergePatch,
+			patch:    `["bride"]`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown format",
+			format:   PatchFormat("xml-patch"),
+			patch:    `{}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			existing := newExisting()
+			var saved *domain.Invitation
+			mockInvitationRepo := &MockInvitationRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+					return existing, nil
+				},
+				UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+					saved = invitation
+					return nil
+				},
+			}
+			useCase := NewPatchInvitationUseCase(mockInvitationRepo, nil, nil, nil)
+
+			// Act
+			output, err := useCase.Execute(context.Background(), PatchInvitationInput{
+				ID:              existing.ID,
+				Format:          tt.format,
+				Patch:           json.RawMessage(tt.patch),
+				AuthorID:        existing.UserID,
+				ExpectedVersion: tt.expectedVersion,
+			})
+
+			// Assert
+			if tt.wantCode != 0 {
+				require.Error(t, err)
+				appErr, ok := err.(*errors.AppError)
+				require.True(t, ok, "Should return AppError")
+				assert.Equal(t, tt.wantCode, appErr.Code)
+				assert.Nil(t, saved, "Invitation should not be saved")
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, saved)
+			assert.JSONEq(t, tt.wantData, string(saved.Data))
+			assert.JSONEq(t, tt.wantData, string(output.Invitation.Data))
+		})
+	}
+}
+
+func TestPatchInvitationUseCase_Execute_TracksOnlyChangedAssets(t *testing.T) {
+	// Arrange
+	existing := &domain.Invitation{
+		ID:     "invitation-123",
+		UserID: "user-123",
+		Data:   json.RawMessage(`{"photos":["/uploads/a.jpg","/uploads/b.jpg"]}`),
+	}
+	mockInvitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return existing, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			return nil
+		},
+	}
+	var tracked, untracked []string
+	mockAssetRepo := &MockAssetRepository{
+		FindByURLFn: func(ctx context.Context, url string) (*domain.Asset, error) {
+			return &domain.Asset{ID: url}, nil
+		},
+		TrackUsageFn: func(ctx context.Context, assetID, invitationID string) error {
+			tracked = append(tracked, assetID)
+			return nil
+		},
+		UntrackUsageFn: func(ctx context.Context, assetID, invitationID string) error {
+			untracked = append(untracked, assetID)
+			return nil
+		},
+		UntrackAllUsageFn: func(ctx context.Context, invitationID string) error {
+			t.Fatal("Patch should not retrack all asset usage")
+			return nil
+		},
+	}
+	useCase := NewPatchInvitationUseCase(mockInvitationRepo, mockAssetRepo, nil, nil)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), PatchInvitationInput{
+		ID:       existing.ID,
+		Format:   PatchFormatJSONPatch,
+		AuthorID: existing.UserID,
+		Patch:    json.RawMessage(`[{"op":"replace","path":"/photos/1","value":"/uploads/c.jpg"},{"op":"add","path":"/cover","value":"/uploads/d.jpg"}]`),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	sort.Strings(tracked)
+	assert.Equal(t, []string{"/uploads/c.jpg", "/uploads/d.jpg"}, tracked)
+	assert.Equal(t, []string{"/uploads/b.jpg"}, untracked)
+}
+
+func TestPatchInvitationUseCase_Execute_AnotherUsersInvitation_ReturnsForbidden(t *testing.T) {
+	// Arrange
+	updated := false
+	mockInvitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "user-123", Data: json.RawMessage(`{}`)}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			updated = true
+			return nil
+		},
+	}
+	useCase := NewPatchInvitationUseCase(mockInvitationRepo, nil, nil, nil)
+
+	// Act
+	output, err := useCase.Execute(context.Background(), PatchInvitationInput{
+		ID:       "invitation-123",
+		Format:   PatchFormatMergePatch,
+		Patch:    json.RawMessage(`{"bride":"Mallory"}`),
+		AuthorID: "user-456",
+	})
+
+	// Assert
+	assert.Nil(t, output)
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "Should return AppError")
+	assert.Equal(t, http.StatusForbidden, appErr.Code)
+	assert.False(t, updated, "another user's invitation must not be written")
+}
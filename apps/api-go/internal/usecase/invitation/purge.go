@@ -0,0 +1,194 @@
+package invitation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/infrastructure/observability"
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/asset"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// defaultPurgeLimit bounds how many trashed invitations a single purge run deletes
+const defaultPurgeLimit = 500
+
+// PurgeInvitationUseCase permanently deletes an invitation together with its revisions, its
+// asset usage and the assets (records and files) no other invitation uses.
+type PurgeInvitationUseCase struct {
+	invitationRepo       repository.InvitationRepository
+	publishedRepo        repository.PublishedSiteRepository
+	assetRepo            repository.AssetRepository
+	revisionRepo         repository.InvitationRevisionRepository
+	deleteAssetsByURLsUC *asset.DeleteAssetsByURLsUseCase
+	files                asset.FileStorage // Optional; without it asset files are left for the orphaned asset cleanup
+}
+
+func NewPurgeInvitationUseCase(
+	invitationRepo repository.InvitationRepository,
+	publishedRepo repository.PublishedSiteRepository,
+	assetRepo repository.AssetRepository,
+	revisionRepo repository.InvitationRevisionRepository,
+	deleteAssetsByURLsUC *asset.DeleteAssetsByURLsUseCase,
+	files asset.FileStorage,
+) *PurgeInvitationUseCase {
+	return &PurgeInvitationUseCase{
+		invitationRepo:       invitationRepo,
+		publishedRepo:        publishedRepo,
+		assetRepo:            assetRepo,
+		revisionRepo:         revisionRepo,
+		deleteAssetsByURLsUC: deleteAssetsByURLsUC,
+		files:                files,
+	}
+}
+
+type PurgeInvitationOutput struct {
+	DeletedAssets []*asset.AssetDTO // Assets that were deleted
+}
+
+func (uc *PurgeInvitationUseCase) Execute(ctx context.Context, invitation *domain.Invitation) (*PurgeInvitationOutput, error) {
+	id := invitation.ID
+
+	// Extract asset URLs before deleting invitation
+	var assetURLs []string
+	if uc.assetRepo != nil {
+		assetURLs = extractAssetURLs(invitation.Data)
+	}
+
+	// Check if invitation was published before deleting
+	if uc.publishedRepo != nil {
+		site, err := uc.publishedRepo.FindByInvitationID(ctx, id)
+		if err == nil && site != nil && site.Published {
+			// Record unpublish metric before deletion
+			observability.RecordInvitationUnpublished()
+		}
+	}
+
+	// Delete invitation first
+	if err := uc.invitationRepo.Delete(ctx, id); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to delete invitation", err)
+	}
+
+	// Untrack asset usage
+	if uc.assetRepo != nil {
+		uc.assetRepo.UntrackAllUsage(ctx, id)
+	}
+
+	// Firestore does not delete subcollections with their parent
+	if uc.revisionRepo != nil {
+		_ = uc.revisionRepo.DeleteByInvitationID(ctx, id)
+	}
+
+	// Delete assets that are not used by other invitations
+	var deletedAssets []*asset.AssetDTO
+	if uc.deleteAssetsByURLsUC != nil && len(assetURLs) > 0 {
+		deleteOutput, err := uc.deleteAssetsByURLsUC.Execute(ctx, asset.DeleteAssetsByURLsInput{
+			URLs: assetURLs,
+		})
+		if err == nil {
+			deletedAssets = deleteOutput.Deleted
+		}
+		// Note: Failed deletions are logged but don't fail the invitation deletion
+	}
+
+	if uc.files != nil {
+		for _, deletedAsset := range deletedAssets {
+			filenames := []string{deletedAsset.Filename}
+			for _, variant := range deletedAsset.Variants {
+				filenames = append(filenames, variant.Filename)
+			}
+			for _, filename := range filenames {
+				if err := uc.files.DeleteFile(filename); err != nil {
+					// The record is already gone; the orphaned asset cleanup picks up the file
+					logger.GetLogger().Warn("Failed to delete asset from storage",
+						zap.String("filename", filename),
+						zap.Error(err))
+				}
+			}
+		}
+	}
+
+	return &PurgeInvitationOutput{
+		DeletedAssets: deletedAssets,
+	}, nil
+}
+
+// PurgeTrashedInvitationsUseCase permanently deletes invitations that have been in the trash
+// for longer than the retention window.
+type PurgeTrashedInvitationsUseCase struct {
+	invitationRepo repository.InvitationRepository
+	purgeUC        *PurgeInvitationUseCase
+	retention      time.Duration
+	clock          clock.Clock
+}
+
+func NewPurgeTrashedInvitationsUseCase(invitationRepo repository.InvitationRepository, purgeUC *PurgeInvitationUseCase, retention time.Duration, clk clock.Clock) *PurgeTrashedInvitationsUseCase {
+	return &PurgeTrashedInvitationsUseCase{
+		invitationRepo: invitationRepo,
+		purgeUC:        purgeUC,
+		retention:      retention,
+		clock:          clk,
+	}
+}
+
+type PurgeTrashedInvitationsInput struct {
+	DryRun bool
+	// Limit is the maximum number of invitations handled in this run (default: 500)
+	Limit int
+}
+
+type PurgeTrashedInvitationsOutput struct {
+	Purged        []string          // IDs of purged invitations (or that would be, with DryRun)
+	DeletedAssets []*asset.AssetDTO // Assets deleted with the invitations
+	Errors        []string
+}
+
+func (uc *PurgeTrashedInvitationsUseCase) Execute(ctx context.Context, input PurgeTrashedInvitationsInput) (*PurgeTrashedInvitationsOutput, error) {
+	if uc.retention <= 0 {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Trash retention must be positive", nil)
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultPurgeLimit
+	}
+
+	cutoff := uc.clock.Now().Add(-uc.retention)
+	invitations, err := uc.invitationRepo.FindTrashedBefore(ctx, cutoff, limit)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find trashed invitations", err)
+	}
+
+	output := &PurgeTrashedInvitationsOutput{
+		Purged:        make([]string, 0, len(invitations)),
+		DeletedAssets: make([]*asset.AssetDTO, 0),
+		Errors:        make([]string, 0),
+	}
+	for _, inv := range invitations {
+		if input.DryRun {
+			output.Purged = append(output.Purged, inv.ID)
+			continue
+		}
+
+		purged, err := uc.purgeUC.Execute(ctx, inv)
+		if err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: %v", inv.ID, err))
+			continue
+		}
+		output.Purged = append(output.Purged, inv.ID)
+		output.DeletedAssets = append(output.DeletedAssets, purged.DeletedAssets...)
+	}
+
+	logger.GetLogger().Info("Purged trashed invitations",
+		zap.Time("cutoff", cutoff),
+		zap.Int("count", len(output.Purged)),
+		zap.Int("errors", len(output.Errors)),
+		zap.Bool("dryRun", input.DryRun),
+	)
+
+	return output, nil
+}
@@ -0,0 +1,155 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/asset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeInvitationUseCase_Execute_DeletesInvitationAndUnusedAssets(t *testing.T) {
+	// Arrange
+	invitation := &domain.Invitation{
+		ID:     "invitation-123",
+		UserID: "user-123",
+		Data:   json.RawMessage(`{"hero": "/uploads/unused.jpg", "gallery": ["/uploads/shared.jpg"]}`),
+	}
+	assets := map[string]*domain.Asset{
+		"/uploads/unused.jpg": {ID: "asset-1", URL: "/uploads/unused.jpg", Filename: "unused.jpg"},
+		"/uploads/shared.jpg": {ID: "asset-2", URL: "/uploads/shared.jpg", Filename: "shared.jpg"},
+	}
+
+	var deletedInvitation string
+	mockInvitationRepo := &MockInvitationRepository{
+		DeleteFn: func(ctx context.Context, id string) error {
+			deletedInvitation = id
+			return nil
+		},
+	}
+	var untracked string
+	var deletedRecords []string
+	mockAssetRepo := &MockAssetRepository{
+		UntrackAllUsageFn: func(ctx context.Context, invitationID string) error {
+			untracked = invitationID
+			return nil
+		},
+		FindByURLsFn: func(ctx context.Context, urls []string) ([]*domain.Asset, error) {
+			found := make([]*domain.Asset, 0, len(urls))
+			for _, url := range urls {
+				found = append(found, assets[url])
+			}
+			return found, nil
+		},
+		FindUsedInInvitationsFn: func(ctx context.Context, assetID string) ([]string, error) {
+			if assetID == "asset-2" {
+				return []string{"other-invitation"}, nil
+			}
+			return nil, nil
+		},
+		DeleteByURLFn: func(ctx context.Context, url string) error {
+			deletedRecords = append(deletedRecords, url)
+			return nil
+		},
+	}
+	var revisionsDeleted string
+	mockRevisionRepo := &MockInvitationRevisionRepository{
+		DeleteByInvitationIDFn: func(ctx context.Context, invitationID string) error {
+			revisionsDeleted = invitationID
+			return nil
+		},
+	}
+	var deletedFiles []string
+	files := &MockFileStorage{
+		DeleteFileFn: func(filename string) error {
+			deletedFiles = append(deletedFiles, filename)
+			return nil
+		},
+	}
+
+	useCase := NewPurgeInvitationUseCase(mockInvitationRepo, nil, mockAssetRepo, mockRevisionRepo, asset.NewDeleteAssetsByURLsUseCase(mockAssetRepo), files)
+
+	// Act
+	output, err := useCase.Execute(context.Background(), invitation)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "invitation-123", deletedInvitation)
+	assert.Equal(t, "invitation-123", untracked)
+	assert.Equal(t, "invitation-123", revisionsDeleted)
+	assert.Equal(t, []string{"/uploads/unused.jpg"}, deletedRecords, "Assets used elsewhere must be kept")
+	assert.Equal(t, []string{"unused.jpg"}, deletedFiles)
+	require.Len(t, output.DeletedAssets, 1)
+	assert.Equal(t, "asset-1", output.DeletedAssets[0].ID)
+}
+
+func TestPurgeTrashedInvitationsUseCase_Execute(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+	clk := &MockClock{NowFn: func() time.Time { return now }}
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		deleteErrID string
+		wantPurged  []string
+		wantDeleted []string
+		wantErrors  int
+	}{
+		{
+			name:        "purges invitations past the retention window",
+			wantPurged:  []string{"inv-1", "inv-2"},
+			wantDeleted: []string{"inv-1", "inv-2"},
+		},
+		{
+			name:       "dry run deletes nothing",
+			dryRun:     true,
+			wantPurged: []string{"inv-1", "inv-2"},
+		},
+		{
+			name:        "failed purge is reported and skipped",
+			deleteErrID: "inv-1",
+			wantPurged:  []string{"inv-2"},
+			wantDeleted: []string{"inv-2"},
+			wantErrors:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var gotCutoff time.Time
+			var deleted []string
+			mockInvitationRepo := &MockInvitationRepository{
+				FindTrashedBeforeFn: func(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error) {
+					gotCutoff = cutoff
+					return []*domain.Invitation{{ID: "inv-1"}, {ID: "inv-2"}}, nil
+				},
+				DeleteFn: func(ctx context.Context, id string) error {
+					if id == tt.deleteErrID {
+						return stderrors.New("firestore unavailable")
+					}
+					deleted = append(deleted, id)
+					return nil
+				},
+			}
+			purgeUC := NewPurgeInvitationUseCase(mockInvitationRepo, nil, nil, nil, nil, nil)
+			useCase := NewPurgeTrashedInvitationsUseCase(mockInvitationRepo, purgeUC, retention, clk)
+
+			// Act
+			output, err := useCase.Execute(context.Background(), PurgeTrashedInvitationsInput{DryRun: tt.dryRun})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, now.Add(-retention), gotCutoff, "Only invitations trashed before the retention window should be selected")
+			assert.Equal(t, tt.wantPurged, output.Purged)
+			assert.Equal(t, tt.wantDeleted, deleted)
+			assert.Len(t, output.Errors, tt.wantErrors)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// RevisionRecorder appends invitation snapshots to the revision history and keeps it bounded.
+// A nil recorder records nothing.
+type RevisionRecorder struct {
+	revisionRepo repository.InvitationRevisionRepository
+	keep         int // revisions kept per invitation; 0 keeps all
+}
+
+func NewRevisionRecorder(revisionRepo repository.InvitationRevisionRepository, keep int) *RevisionRecorder {
+	return &RevisionRecorder{
+		revisionRepo: revisionRepo,
+		keep:         keep,
+	}
+}
+
+// Record snapshots the invitation's current content. Failures are logged, not returned:
+// the invitation itself has already been saved and history is best effort.
+func (r *RevisionRecorder) Record(ctx context.Context, invitation *domain.Invitation, authorID string, restoredFrom int) {
+	if r == nil || r.revisionRepo == nil {
+		return
+	}
+
+	revision := domain.NewInvitationRevision(invitation, authorID)
+	revision.RestoredFrom = restoredFrom
+	if err := r.revisionRepo.Append(ctx, revision); err != nil {
+		logger.GetLogger().Warn("Failed to record invitation revision",
+			zap.String("invitationID", invitation.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if r.keep > 0 && revision.Revision > r.keep {
+		if _, err := r.revisionRepo.Prune(ctx, invitation.ID, r.keep); err != nil {
+			logger.GetLogger().Warn("Failed to prune invitation revisions",
+				zap.String("invitationID", invitation.ID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// InvitationRevisionDTO represents a revision; Data and Translations are only set for single-revision reads.
+type InvitationRevisionDTO struct {
+	Revision      int                        `json:"revision"`
+	LayoutID      string                     `json:"layoutId"`
+	LayoutVersion string                     `json:"layoutVersion,omitempty"`
+	Data          json.RawMessage            `json:"data,omitempty"`
+	Translations  map[string]json.RawMessage `json:"translations,omitempty"`
+	DefaultLocale string                     `json:"defaultLocale,omitempty"`
+	AuthorID      string                     `json:"authorId"`
+	SizeBytes     int                        `json:"sizeBytes"`
+	RestoredFrom  int                        `json:"restoredFrom,omitempty"`
+	CreatedAt     time.Time                  `json:"createdAt"`
+}
+
+func toInvitationRevisionDTO(revision *domain.InvitationRevision) *InvitationRevisionDTO {
+	return &InvitationRevisionDTO{
+		Revision:      revision.Revision,
+		LayoutID:      revision.LayoutID,
+		LayoutVersion: revision.LayoutVersion,
+		Data:          revision.Data,
+		Translations:  revision.Translations,
+		DefaultLocale: revision.DefaultLocale,
+		AuthorID:      revision.AuthorID,
+		SizeBytes:     revision.SizeBytes,
+		RestoredFrom:  revision.RestoredFrom,
+		CreatedAt:     revision.CreatedAt,
+	}
+}
+
+type ListInvitationRevisionsUseCase struct {
+	invitationRepo repository.InvitationRepository
+	revisionRepo   repository.InvitationRevisionRepository
+}
+
+func NewListInvitationRevisionsUseCase(invitationRepo repository.InvitationRepository, revisionRepo repository.InvitationRevisionRepository) *ListInvitationRevisionsUseCase {
+	return &ListInvitationRevisionsUseCase{
+		invitationRepo: invitationRepo,
+		revisionRepo:   revisionRepo,
+	}
+}
+
+type ListInvitationRevisionsOutput struct {
+	Revisions []*InvitationRevisionDTO
+}
+
+// Execute lists the revisions of an invitation owned by userID (a user or guest session ID).
+func (uc *ListInvitationRevisionsUseCase) Execute(ctx context.Context, invitationID, userID string) (*ListInvitationRevisionsOutput, error) {
+	if _, err := findRevisionedInvitation(ctx, uc.invitationRepo, invitationID, userID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := uc.revisionRepo.ListByInvitationID(ctx, invitationID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to list revisions", err)
+	}
+
+	dtos := make([]*InvitationRevisionDTO, len(revisions))
+	for i, revision := range revisions {
+		dto := toInvitationRevisionDTO(revision)
+		dto.Data = nil
+		dto.Translations = nil
+		dtos[i] = dto
+	}
+
+	return &ListInvitationRevisionsOutput{
+		Revisions: dtos,
+	}, nil
+}
+
+type GetInvitationRevisionUseCase struct {
+	invitationRepo repository.InvitationRepository
+	revisionRepo   repository.InvitationRevisionRepository
+}
+
+func NewGetInvitationRevisionUseCase(invitationRepo repository.InvitationRepository, revisionRepo repository.InvitationRevisionRepository) *GetInvitationRevisionUseCase {
+	return &GetInvitationRevisionUseCase{
+		invitationRepo: invitationRepo,
+		revisionRepo:   revisionRepo,
+	}
+}
+
+type GetInvitationRevisionOutput struct {
+	Revision *InvitationRevisionDTO
+}
+
+// Execute returns a revision, content included, of an invitation owned by userID.
+func (uc *GetInvitationRevisionUseCase) Execute(ctx context.Context, invitationID, userID string, revision int) (*GetInvitationRevisionOutput, error) {
+	if _, err := findRevisionedInvitation(ctx, uc.invitationRepo, invitationID, userID); err != nil {
+		return nil, err
+	}
+
+	rev, err := uc.revisionRepo.FindByRevision(ctx, invitationID, revision)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get revision", err)
+	}
+	if rev == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Revision not found", nil)
+	}
+
+	return &GetInvitationRevisionOutput{
+		Revision: toInvitationRevisionDTO(rev),
+	}, nil
+}
+
+type RestoreInvitationRevisionUseCase struct {
+	invitationRepo repository.InvitationRepository
+	revisionRepo   repository.InvitationRevisionRepository
+	assetRepo      repository.AssetRepository
+	revisions      *RevisionRecorder
+}
+
+func NewRestoreInvitationRevisionUseCase(
+	invitationRepo repository.InvitationRepository,
+	revisionRepo repository.InvitationRevisionRepository,
+	assetRepo repository.AssetRepository,
+	revisions *RevisionRecorder,
+) *RestoreInvitationRevisionUseCase {
+	return &RestoreInvitationRevisionUseCase{
+		invitationRepo: invitationRepo,
+		revisionRepo:   revisionRepo,
+		assetRepo:      assetRepo,
+		revisions:      revisions,
+	}
+}
+
+type RestoreInvitationRevisionInput struct {
+	InvitationID string
+	Revision     int
+	AuthorID     string // the caller, who must own the invitation
+	// ExpectedVersion is the version the restore is based on; a mismatch is a conflict
+	ExpectedVersion *int
+}
+
+type RestoreInvitationRevisionOutput struct {
+	Invitation *InvitationDTO
+}
+
+// Execute copies the content of an earlier revision back onto the invitation.
+// The restore is itself recorded as a new revision, so it can be undone too.
+func (uc *RestoreInvitationRevisionUseCase) Execute(ctx context.Context, input RestoreInvitationRevisionInput) (*RestoreInvitationRevisionOutput, error) {
+	invitation, err := findRevisionedInvitation(ctx, uc.invitationRepo, input.InvitationID, input.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != invitation.Version {
+		return nil, versionConflict(&domain.VersionConflictError{CurrentVersion: invitation.Version})
+	}
+
+	revision, err := uc.revisionRepo.FindByRevision(ctx, input.InvitationID, input.Revision)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get revision", err)
+	}
+	if revision == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Revision not found", nil)
+	}
+
+	// Revisions from before versions were pinned keep the invitation's version on the same layout
+	if revision.LayoutVersion != "" || revision.LayoutID != invitation.LayoutID {
+		invitation.LayoutVersion = revision.LayoutVersion
+	}
+	invitation.LayoutID = revision.LayoutID
+	invitation.Data = revision.Data
+	invitation.Translations = revision.Translations
+	invitation.DefaultLocale = revision.DefaultLocale
+
+	if err := uc.invitationRepo.Update(ctx, invitation); err != nil {
+		if stderrors.Is(err, domain.ErrVersionConflict) {
+			return nil, versionConflict(err)
+		}
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to restore invitation", err)
+	}
+
+	retrackAssetUsage(ctx, uc.assetRepo, invitation)
+	uc.revisions.Record(ctx, invitation, input.AuthorID, revision.Revision)
+
+	return &RestoreInvitationRevisionOutput{
+		Invitation: toInvitationDTO(invitation),
+	}, nil
+}
+
+// findRevisionedInvitation loads an invitation whose history userID may see: only the owner
+// reads or restores revisions, as they hold the full content of every earlier save.
+func findRevisionedInvitation(ctx context.Context, invitationRepo repository.InvitationRepository, invitationID, userID string) (*domain.Invitation, error) {
+	invitation, err := invitationRepo.FindByID(ctx, invitationID)
+	if err != nil || invitation == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Invitation not found", err)
+	}
+	if invitation.UserID != userID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Cannot access the revisions of another user's invitation", nil)
+	}
+	return invitation, nil
+}
@@ -0,0 +1,265 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	apperrors "github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateInvitationUseCase_Execute_RecordsRevision(t *testing.T) {
+	// Arrange
+	existing := &domain.Invitation{ID: "inv-1", UserID: "user-1", LayoutID: "classic-scroll", Data: json.RawMessage(`{"bride":"Jane"}`)}
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return existing, nil
+		},
+	}
+	var appended []*domain.InvitationRevision
+	var prunedKeep int
+	revisionRepo := &MockInvitationRevisionRepository{
+		AppendFn: func(ctx context.Context, revision *domain.InvitationRevision) error {
+			revision.Revision = 4
+			appended = append(appended, revision)
+			return nil
+		},
+		PruneFn: func(ctx context.Context, invitationID string, keep int) (int, error) {
+			prunedKeep = keep
+			return 1, nil
+		},
+	}
+	useCase := NewUpdateInvitationUseCase(invitationRepo, nil, NewRevisionRecorder(revisionRepo, 3), nil)
+	newData := json.RawMessage(`{"bride":"Janet"}`)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), UpdateInvitationInput{ID: "inv-1", Data: &newData, AuthorID: "user-1"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, appended, 1)
+	assert.Equal(t, "inv-1", appended[0].InvitationID)
+	assert.Equal(t, "user-1", appended[0].AuthorID)
+	assert.JSONEq(t, `{"bride":"Janet"}`, string(appended[0].Data))
+	assert.Equal(t, len(appended[0].Data), appended[0].SizeBytes)
+	assert.Equal(t, 3, prunedKeep, "history beyond the retention is pruned")
+}
+
+func TestUpdateInvitationUseCase_Execute_RevisionFailureDoesNotFailUpdate(t *testing.T) {
+	// Arrange
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "user-1", LayoutID: "classic-scroll", Data: json.RawMessage(`{}`)}, nil
+		},
+	}
+	revisionRepo := &MockInvitationRevisionRepository{
+		AppendFn: func(ctx context.Context, revision *domain.InvitationRevision) error {
+			return errors.New("firestore unavailable")
+		},
+	}
+	useCase := NewUpdateInvitationUseCase(invitationRepo, nil, NewRevisionRecorder(revisionRepo, 50), nil)
+	title := "Our Wedding"
+
+	// Act
+	output, err := useCase.Execute(context.Background(), UpdateInvitationInput{ID: "inv-1", Title: &title, AuthorID: "user-1"})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, output.Invitation.Title)
+	assert.Equal(t, "Our Wedding", *output.Invitation.Title)
+}
+
+func TestRestoreInvitationRevisionUseCase_Execute(t *testing.T) {
+	snapshot := &domain.InvitationRevision{
+		InvitationID:  "inv-1",
+		Revision:      2,
+		LayoutID:      "classic-scroll",
+		Data:          json.RawMessage(`{"bride":"Jane"}`),
+		Translations:  map[string]json.RawMessage{"en": json.RawMessage(`{}`)},
+		DefaultLocale: "en",
+	}
+
+	tests := []struct {
+		name     string
+		revision int
+		callerID string
+		wantCode int
+	}{
+		{"restores revision content", 2, "user-1", 0},
+		{"missing revision", 9, "user-1", http.StatusNotFound},
+		{"another user's invitation", 2, "user-2", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			current := &domain.Invitation{ID: "inv-1", UserID: "user-1", LayoutID: "editorial-elegance", Data: json.RawMessage(`{"bride":"broken"}`)}
+			var saved *domain.Invitation
+			invitationRepo := &MockInvitationRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+					return current, nil
+				},
+				UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+					saved = invitation
+					return nil
+				},
+			}
+			var appended *domain.InvitationRevision
+			revisionRepo := &MockInvitationRevisionRepository{
+				FindByRevisionFn: func(ctx context.Context, invitationID string, revision int) (*domain.InvitationRevision, error) {
+					if revision == snapshot.Revision {
+						return snapshot, nil
+					}
+					return nil, nil
+				},
+				AppendFn: func(ctx context.Context, revision *domain.InvitationRevision) error {
+					appended = revision
+					return nil
+				},
+			}
+			useCase := NewRestoreInvitationRevisionUseCase(invitationRepo, revisionRepo, nil, NewRevisionRecorder(revisionRepo, 50))
+
+			// Act
+			output, err := useCase.Execute(context.Background(), RestoreInvitationRevisionInput{InvitationID: "inv-1", Revision: tt.revision, AuthorID: tt.callerID})
+
+			// Assert
+			if tt.wantCode != 0 {
+				var appErr *apperrors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+				assert.Nil(t, saved, "nothing is written")
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, saved)
+			assert.Equal(t, "classic-scroll", saved.LayoutID)
+			assert.JSONEq(t, `{"bride":"Jane"}`, string(saved.Data))
+			assert.Equal(t, "en", saved.DefaultLocale)
+			assert.Equal(t, "classic-scroll", output.Invitation.LayoutID)
+			require.NotNil(t, appended, "the restore is recorded as a new revision")
+			assert.Equal(t, 2, appended.RestoredFrom)
+			assert.Equal(t, "user-1", appended.AuthorID)
+		})
+	}
+}
+
+func TestRestoreInvitationRevisionUseCase_Execute_StaleVersion_ReturnsConflict(t *testing.T) {
+	// Arrange
+	updated := false
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "user-1", LayoutID: "classic-scroll", Data: json.RawMessage(`{}`), Version: 3}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			updated = true
+			return nil
+		},
+	}
+	revisionRepo := &MockInvitationRevisionRepository{
+		FindByRevisionFn: func(ctx context.Context, invitationID string, revision int) (*domain.InvitationRevision, error) {
+			return &domain.InvitationRevision{InvitationID: invitationID, Revision: revision, LayoutID: "classic-scroll", Data: json.RawMessage(`{"bride":"Jane"}`)}, nil
+		},
+	}
+	useCase := NewRestoreInvitationRevisionUseCase(invitationRepo, revisionRepo, nil, NewRevisionRecorder(revisionRepo, 50))
+	staleVersion := 2
+
+	// Act
+	_, err := useCase.Execute(context.Background(), RestoreInvitationRevisionInput{InvitationID: "inv-1", Revision: 1, AuthorID: "user-1", ExpectedVersion: &staleVersion})
+
+	// Assert
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusConflict, appErr.Code)
+	var conflict *domain.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 3, conflict.CurrentVersion)
+	assert.False(t, updated, "stale restores are rejected before writing")
+}
+
+func TestListInvitationRevisionsUseCase_Execute_OmitsContent(t *testing.T) {
+	// Arrange
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "user-1"}, nil
+		},
+	}
+	revisionRepo := &MockInvitationRevisionRepository{
+		ListByInvitationIDFn: func(ctx context.Context, invitationID string) ([]*domain.InvitationRevision, error) {
+			return []*domain.InvitationRevision{
+				{InvitationID: invitationID, Revision: 2, Data: json.RawMessage(`{"a":1}`), SizeBytes: 7},
+				{InvitationID: invitationID, Revision: 1, SizeBytes: 2},
+			}, nil
+		},
+	}
+	useCase := NewListInvitationRevisionsUseCase(invitationRepo, revisionRepo)
+
+	// Act
+	output, err := useCase.Execute(context.Background(), "inv-1", "user-1")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output.Revisions, 2)
+	assert.Equal(t, 2, output.Revisions[0].Revision)
+	assert.Equal(t, 7, output.Revisions[0].SizeBytes)
+	assert.Nil(t, output.Revisions[0].Data)
+}
+
+func TestInvitationRevisionUseCases_OnlyOwnerReadsHistory(t *testing.T) {
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			if id != "inv-1" {
+				return nil, nil
+			}
+			return &domain.Invitation{ID: id, UserID: "guest_abc"}, nil
+		},
+	}
+	revisionRepo := &MockInvitationRevisionRepository{
+		ListByInvitationIDFn: func(ctx context.Context, invitationID string) ([]*domain.InvitationRevision, error) {
+			return []*domain.InvitationRevision{{InvitationID: invitationID, Revision: 1}}, nil
+		},
+		FindByRevisionFn: func(ctx context.Context, invitationID string, revision int) (*domain.InvitationRevision, error) {
+			return &domain.InvitationRevision{InvitationID: invitationID, Revision: revision, Data: json.RawMessage(`{"bride":"Jane"}`)}, nil
+		},
+	}
+	listUC := NewListInvitationRevisionsUseCase(invitationRepo, revisionRepo)
+	getUC := NewGetInvitationRevisionUseCase(invitationRepo, revisionRepo)
+
+	tests := []struct {
+		name         string
+		invitationID string
+		callerID     string
+		wantCode     int
+	}{
+		{"owner by guest session", "inv-1", "guest_abc", 0},
+		{"another guest session", "inv-1", "guest_xyz", http.StatusForbidden},
+		{"another user", "inv-1", "user-1", http.StatusForbidden},
+		{"missing invitation", "inv-2", "guest_abc", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			listOutput, listErr := listUC.Execute(context.Background(), tt.invitationID, tt.callerID)
+			getOutput, getErr := getUC.Execute(context.Background(), tt.invitationID, tt.callerID, 1)
+
+			// Assert
+			if tt.wantCode == 0 {
+				require.NoError(t, listErr)
+				require.NoError(t, getErr)
+				assert.Len(t, listOutput.Revisions, 1)
+				assert.JSONEq(t, `{"bride":"Jane"}`, string(getOutput.Revision.Data))
+				return
+			}
+			for _, err := range []error{listErr, getErr} {
+				var appErr *apperrors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			}
+		})
+	}
+}
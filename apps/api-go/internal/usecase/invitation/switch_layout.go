@@ -0,0 +1,115 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// SwitchLayoutUseCase moves an invitation to another layout, carrying its data over with the
+// migration the new layout's manifest declares
+type SwitchLayoutUseCase struct {
+	invitationRepo repository.InvitationRepository
+	assetRepo      repository.AssetRepository
+	revisions      *RevisionRecorder
+	migrator       *layout.MigrateInvitationDataUseCase
+	dataValidator  *layout.ValidateInvitationDataUseCase // Optional
+	popularity     *layout.PopularityRecorder            // Optional
+}
+
+func NewSwitchLayoutUseCase(
+	invitationRepo repository.InvitationRepository,
+	assetRepo repository.AssetRepository,
+	revisions *RevisionRecorder,
+	migrator *layout.MigrateInvitationDataUseCase,
+	dataValidator *layout.ValidateInvitationDataUseCase,
+	popularity *layout.PopularityRecorder,
+) *SwitchLayoutUseCase {
+	return &SwitchLayoutUseCase{
+		invitationRepo: invitationRepo,
+		assetRepo:      assetRepo,
+		revisions:      revisions,
+		migrator:       migrator,
+		dataValidator:  dataValidator,
+		popularity:     popularity,
+	}
+}
+
+type SwitchLayoutInput struct {
+	ID       string
+	UserID   string
+	LayoutID string
+	// DryRun returns the migrated data and report without saving
+	DryRun bool
+	// ExpectedVersion is the version the switch is based on; a mismatch is a conflict
+	ExpectedVersion *int
+}
+
+type SwitchLayoutOutput struct {
+	// Invitation is the saved invitation; nil on a dry run
+	Invitation *InvitationDTO
+	// Data is the invitation data as it is (or would be) after the switch
+	Data   json.RawMessage
+	Report *layout.LayoutMigrationReport
+}
+
+func (uc *SwitchLayoutUseCase) Execute(ctx context.Context, input SwitchLayoutInput) (*SwitchLayoutOutput, error) {
+	if input.LayoutID == "" {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "layoutId is required", nil)
+	}
+
+	invitation, err := uc.invitationRepo.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find invitation", err)
+	}
+	if invitation == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Invitation not found", nil)
+	}
+	if invitation.UserID != input.UserID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Cannot change the layout of another user's invitation", nil)
+	}
+	if invitation.LayoutID == input.LayoutID {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invitation already uses this layout", nil)
+	}
+	if !input.DryRun && input.ExpectedVersion != nil && *input.ExpectedVersion != invitation.Version {
+		return nil, versionConflict(&domain.VersionConflictError{CurrentVersion: invitation.Version})
+	}
+
+	migrated, err := uc.migrator.Execute(ctx, invitation.LayoutID, input.LayoutID, invitation.Data)
+	if err != nil {
+		return nil, err
+	}
+	layoutVersion, err := validateNewLayoutData(ctx, uc.dataValidator, input.LayoutID, migrated.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &SwitchLayoutOutput{Data: migrated.Data, Report: migrated.Report}
+	if input.DryRun {
+		return output, nil
+	}
+
+	before := invitation.Data
+	invitation.LayoutID = input.LayoutID
+	invitation.LayoutVersion = layoutVersion
+	invitation.Data = migrated.Data
+	if err := uc.invitationRepo.Update(ctx, invitation); err != nil {
+		if stderrors.Is(err, domain.ErrVersionConflict) {
+			return nil, versionConflict(err)
+		}
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to update invitation", err)
+	}
+
+	// Field mappings may drop asset URLs; the previous layout stays reachable through revisions
+	diffAssetUsage(ctx, uc.assetRepo, invitation.ID, before, invitation.Data)
+	uc.revisions.Record(ctx, invitation, input.UserID, 0)
+	uc.popularity.RecordSelection(ctx, invitation.LayoutID)
+
+	output.Invitation = toInvitationDTO(invitation)
+	return output, nil
+}
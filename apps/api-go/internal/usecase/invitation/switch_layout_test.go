@@ -0,0 +1,164 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwitchLayoutUseCase_Execute_DryRun_DoesNotSave(t *testing.T) {
+	// Arrange
+	// editorial-elegance maps classic-scroll's venue section to location and has no header
+	manifest := json.RawMessage(`{
+		"sections": [{"id": "hero", "enabled": true}, {"id": "location", "enabled": true}],
+		"themes": [{"id": "editorial-classic", "isDefault": true}],
+		"migrations": {"classic-scroll": {"sections": {"venue": "location"}}}
+	}`)
+	layoutRepo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Manifest: &manifest, IsActive: true}, nil
+		},
+	}
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{
+				ID:       id,
+				UserID:   "user-1",
+				LayoutID: "classic-scroll",
+				Version:  3,
+				Data:     json.RawMessage(`{"layoutConfig":{"sections":[{"id":"hero","enabled":true,"order":0},{"id":"venue","enabled":true,"order":1},{"id":"header","enabled":true,"order":2}]}}`),
+			}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			t.Fatal("dry run must not save")
+			return nil
+		},
+	}
+	useCase := NewSwitchLayoutUseCase(invitationRepo, nil, nil, layout.NewMigrateInvitationDataUseCase(layoutRepo), nil, nil)
+
+	// Act
+	output, err := useCase.Execute(context.Background(), SwitchLayoutInput{
+		ID:       "inv-1",
+		UserID:   "user-1",
+		LayoutID: "editorial-elegance",
+		DryRun:   true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, output.Invitation)
+	assert.JSONEq(t, `{"layoutConfig":{"sections":[{"id":"hero","enabled":true,"order":0},{"id":"location","enabled":true,"order":1}]}}`, string(output.Data))
+	require.Len(t, output.Report.NotCarriedOver, 1)
+	assert.Equal(t, "/layoutConfig/sections/2", output.Report.NotCarriedOver[0].Path)
+}
+
+func TestSwitchLayoutUseCase_Execute_Apply_SavesMigratedData(t *testing.T) {
+	// Arrange
+	// editorial-elegance maps classic-scroll's venue section to location
+	manifest := json.RawMessage(`{
+		"sections": [{"id": "hero", "enabled": true}, {"id": "location", "enabled": true}],
+		"themes": [{"id": "editorial-classic", "isDefault": true}],
+		"migrations": {"classic-scroll": {"sections": {"venue": "location"}}}
+	}`)
+	layoutRepo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Manifest: &manifest, IsActive: true}, nil
+		},
+	}
+	var saved *domain.Invitation
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{
+				ID:       id,
+				UserID:   "user-1",
+				LayoutID: "classic-scroll",
+				Version:  3,
+				Data:     json.RawMessage(`{"layoutConfig":{"sections":[{"id":"hero","enabled":true,"order":0},{"id":"venue","enabled":true,"order":1}]}}`),
+			}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			saved = invitation
+			invitation.Version++
+			return nil
+		},
+	}
+	useCase := NewSwitchLayoutUseCase(invitationRepo, nil, nil, layout.NewMigrateInvitationDataUseCase(layoutRepo), nil, nil)
+	version := 3
+
+	// Act
+	output, err := useCase.Execute(context.Background(), SwitchLayoutInput{
+		ID:              "inv-1",
+		UserID:          "user-1",
+		LayoutID:        "editorial-elegance",
+		ExpectedVersion: &version,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, "editorial-elegance", saved.LayoutID)
+	assert.JSONEq(t, `{"layoutConfig":{"sections":[{"id":"hero","enabled":true,"order":0},{"id":"location","enabled":true,"order":1}]}}`, string(saved.Data))
+	assert.JSONEq(t, string(output.Data), string(saved.Data))
+	require.NotNil(t, output.Invitation)
+	assert.Equal(t, 4, output.Invitation.Version)
+}
+
+func TestSwitchLayoutUseCase_Execute_Errors(t *testing.T) {
+	stale := 1
+	tests := []struct {
+		name     string
+		input    SwitchLayoutInput
+		wantCode int
+	}{
+		{name: "missing_layout_id", input: SwitchLayoutInput{ID: "inv-1", UserID: "user-1"}, wantCode: http.StatusBadRequest},
+		{name: "not_found", input: SwitchLayoutInput{ID: "missing", UserID: "user-1", LayoutID: "editorial-elegance"}, wantCode: http.StatusNotFound},
+		{name: "other_user", input: SwitchLayoutInput{ID: "inv-1", UserID: "user-2", LayoutID: "editorial-elegance"}, wantCode: http.StatusForbidden},
+		{name: "same_layout", input: SwitchLayoutInput{ID: "inv-1", UserID: "user-1", LayoutID: "classic-scroll"}, wantCode: http.StatusBadRequest},
+		{name: "unknown_layout", input: SwitchLayoutInput{ID: "inv-1", UserID: "user-1", LayoutID: "missing"}, wantCode: http.StatusBadRequest},
+		{name: "stale_version", input: SwitchLayoutInput{ID: "inv-1", UserID: "user-1", LayoutID: "editorial-elegance", ExpectedVersion: &stale}, wantCode: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			// Only inv-1 (user-1, classic-scroll, version 3) and the editorial-elegance layout exist
+			manifest := json.RawMessage(`{"sections": [{"id": "hero", "enabled": true}]}`)
+			layoutRepo := &MockLayoutRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+					if id == "editorial-elegance" {
+						return &domain.Layout{ID: id, Manifest: &manifest, IsActive: true}, nil
+					}
+					return nil, nil
+				},
+			}
+			invitationRepo := &MockInvitationRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+					if id != "inv-1" {
+						return nil, nil
+					}
+					return &domain.Invitation{ID: id, UserID: "user-1", LayoutID: "classic-scroll", Version: 3, Data: json.RawMessage(`{}`)}, nil
+				},
+				UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+					t.Fatal("Update should not be called")
+					return nil
+				},
+			}
+			useCase := NewSwitchLayoutUseCase(invitationRepo, nil, nil, layout.NewMigrateInvitationDataUseCase(layoutRepo), nil, nil)
+
+			// Act
+			_, err := useCase.Execute(context.Background(), tt.input)
+
+			// Assert
+			appErr, ok := err.(*errors.AppError)
+			require.True(t, ok, "expected AppError, got %v", err)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// InvitationTemplateDTO represents a personal invitation template
+type InvitationTemplateDTO struct {
+	ID                 string                     `json:"id"`
+	UserID             string                     `json:"userId"`
+	Name               string                     `json:"name"`
+	Description        string                     `json:"description,omitempty"`
+	LayoutID           string                     `json:"layoutId"`
+	Data               json.RawMessage            `json:"data"`
+	Translations       map[string]json.RawMessage `json:"translations,omitempty"`
+	DefaultLocale      string                     `json:"defaultLocale,omitempty"`
+	SourceInvitationID string                     `json:"sourceInvitationId,omitempty"`
+	CreatedAt          time.Time                  `json:"createdAt"`
+	UpdatedAt          time.Time                  `json:"updatedAt"`
+}
+
+func toInvitationTemplateDTO(template *domain.InvitationTemplate) *InvitationTemplateDTO {
+	return &InvitationTemplateDTO{
+		ID:                 template.ID,
+		UserID:             template.UserID,
+		Name:               template.Name,
+		Description:        template.Description,
+		LayoutID:           template.LayoutID,
+		Data:               template.Data,
+		Translations:       template.Translations,
+		DefaultLocale:      template.DefaultLocale,
+		SourceInvitationID: template.SourceInvitationID,
+		CreatedAt:          template.CreatedAt,
+		UpdatedAt:          template.UpdatedAt,
+	}
+}
+
+// findOwnedTemplate returns the template when it exists and belongs to userID
+func findOwnedTemplate(ctx context.Context, templateRepo repository.InvitationTemplateRepository, id, userID string) (*domain.InvitationTemplate, error) {
+	template, err := templateRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find template", err)
+	}
+	if template == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Template not found", nil)
+	}
+	if template.UserID != userID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Template belongs to another user", nil)
+	}
+	return template, nil
+}
+
+// SaveAsTemplateUseCase saves a copy of one of the caller's invitations as a personal template
+type SaveAsTemplateUseCase struct {
+	invitationRepo repository.InvitationRepository
+	templateRepo   repository.InvitationTemplateRepository
+	assetRepo      repository.AssetRepository
+}
+
+func NewSaveAsTemplateUseCase(invitationRepo repository.InvitationRepository, templateRepo repository.InvitationTemplateRepository, assetRepo repository.AssetRepository) *SaveAsTemplateUseCase {
+	return &SaveAsTemplateUseCase{
+		invitationRepo: invitationRepo,
+		templateRepo:   templateRepo,
+		assetRepo:      assetRepo,
+	}
+}
+
+type SaveAsTemplateInput struct {
+	InvitationID string
+	UserID       string
+	// Name defaults to the invitation title
+	Name        string
+	Description string
+	// StripPersonalData drops the domain.PersonalDataKeys sections from the template and its translations
+	StripPersonalData bool
+}
+
+func (uc *SaveAsTemplateUseCase) Execute(ctx context.Context, input SaveAsTemplateInput) (*InvitationTemplateDTO, error) {
+	invitation, err := uc.invitationRepo.FindByID(ctx, input.InvitationID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find invitation", err)
+	}
+	if invitation == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Invitation not found", nil)
+	}
+	if invitation.UserID != input.UserID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Cannot save another user's invitation as a template", nil)
+	}
+
+	name := input.Name
+	if name == "" {
+		name = invitation.Title
+	}
+	template, err := domain.NewInvitationTemplate(invitation, input.UserID, name, input.Description)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Template name is required (at most 120 characters)", err)
+	}
+	if input.StripPersonalData {
+		if template.Data, err = domain.StripPersonalData(template.Data); err != nil {
+			return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to copy invitation data", err)
+		}
+		if template.Translations, err = domain.StripPersonalTranslations(template.Translations); err != nil {
+			return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to copy invitation translations", err)
+		}
+	}
+
+	template.ID = ksuid.New().String()
+	if err := uc.templateRepo.Create(ctx, template); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to save template", err)
+	}
+
+	// Usage is tracked under the template ID so purging the source invitation keeps the assets
+	// the template still shows
+	if uc.assetRepo != nil {
+		for _, url := range extractAssetURLs(template.Data) {
+			asset, err := uc.assetRepo.FindByURL(ctx, url)
+			if err == nil && asset != nil {
+				uc.assetRepo.TrackUsage(ctx, asset.ID, template.ID)
+			}
+		}
+	}
+
+	return toInvitationTemplateDTO(template), nil
+}
+
+// ListTemplatesUseCase lists a user's templates, one page at a time
+type ListTemplatesUseCase struct {
+	templateRepo repository.InvitationTemplateRepository
+}
+
+func NewListTemplatesUseCase(templateRepo repository.InvitationTemplateRepository) *ListTemplatesUseCase {
+	return &ListTemplatesUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+type ListTemplatesOutput struct {
+	Templates []*InvitationTemplateDTO
+	// NextCursor fetches the next page; empty on the last page
+	NextCursor string
+}
+
+func (uc *ListTemplatesUseCase) Execute(ctx context.Context, userID string, opts repository.ListOptions) (*ListTemplatesOutput, error) {
+	page, err := uc.templateRepo.FindByUserID(ctx, userID, opts)
+	if err != nil {
+		if stderrors.Is(err, repository.ErrInvalidListOptions) {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, err.Error(), err)
+		}
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get templates", err)
+	}
+
+	dtos := make([]*InvitationTemplateDTO, len(page.Items))
+	for i, template := range page.Items {
+		dtos[i] = toInvitationTemplateDTO(template)
+	}
+
+	return &ListTemplatesOutput{
+		Templates:  dtos,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// GetTemplateUseCase returns one of the caller's templates
+type GetTemplateUseCase struct {
+	templateRepo repository.InvitationTemplateRepository
+}
+
+func NewGetTemplateUseCase(templateRepo repository.InvitationTemplateRepository) *GetTemplateUseCase {
+	return &GetTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+func (uc *GetTemplateUseCase) Execute(ctx context.Context, id, userID string) (*InvitationTemplateDTO, error) {
+	template, err := findOwnedTemplate(ctx, uc.templateRepo, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toInvitationTemplateDTO(template), nil
+}
+
+// DeleteTemplateUseCase permanently deletes one of the caller's templates. Invitations created
+// from it are not affected.
+type DeleteTemplateUseCase struct {
+	templateRepo repository.InvitationTemplateRepository
+	assetRepo    repository.AssetRepository
+}
+
+func NewDeleteTemplateUseCase(templateRepo repository.InvitationTemplateRepository, assetRepo repository.AssetRepository) *DeleteTemplateUseCase {
+	return &DeleteTemplateUseCase{
+		templateRepo: templateRepo,
+		assetRepo:    assetRepo,
+	}
+}
+
+func (uc *DeleteTemplateUseCase) Execute(ctx context.Context, id, userID string) error {
+	if _, err := findOwnedTemplate(ctx, uc.templateRepo, id, userID); err != nil {
+		return err
+	}
+
+	if err := uc.templateRepo.Delete(ctx, id); err != nil {
+		return errors.Wrap(errors.ErrInternalServerError.Code, "Failed to delete template", err)
+	}
+
+	// Assets no longer used anywhere are left to the orphaned asset cleanup
+	if uc.assetRepo != nil {
+		uc.assetRepo.UntrackAllUsage(ctx, id)
+	}
+	return nil
+}
+
+// CreateFromTemplateUseCase starts a new invitation from one of the caller's templates
+type CreateFromTemplateUseCase struct {
+	templateRepo repository.InvitationTemplateRepository
+	createUC     *CreateInvitationUseCase
+}
+
+func NewCreateFromTemplateUseCase(templateRepo repository.InvitationTemplateRepository, createUC *CreateInvitationUseCase) *CreateFromTemplateUseCase {
+	return &CreateFromTemplateUseCase{
+		templateRepo: templateRepo,
+		createUC:     createUC,
+	}
+}
+
+type CreateFromTemplateInput struct {
+	TemplateID string
+	UserID     string
+	Title      *string
+}
+
+func (uc *CreateFromTemplateUseCase) Execute(ctx context.Context, input CreateFromTemplateInput) (*CreateInvitationOutput, error) {
+	template, err := findOwnedTemplate(ctx, uc.templateRepo, input.TemplateID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.createUC.Execute(ctx, CreateInvitationInput{
+		LayoutID:      template.LayoutID,
+		Data:          append(json.RawMessage(nil), template.Data...),
+		Title:         input.Title,
+		UserID:        input.UserID,
+		Translations:  domain.CopyTranslations(template.Translations),
+		DefaultLocale: template.DefaultLocale,
+	})
+}
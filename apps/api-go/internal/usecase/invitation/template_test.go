@@ -0,0 +1,231 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAsTemplateUseCase_Execute_SavesCopyAndTracksAssets(t *testing.T) {
+	// Arrange
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{
+				ID:       id,
+				LayoutID: "classic-scroll",
+				UserID:   "user-1",
+				Title:    "Our Wedding",
+				Data:     json.RawMessage(`{"couple":{"bride":{"image":"/uploads/bride.jpg"}},"music":{"file":"/uploads/song.mp3"}}`),
+				Translations: map[string]json.RawMessage{
+					"hi": json.RawMessage(`{"couple":{"bride":{"name":"जेन"}},"music":{"title":"गीत"}}`),
+				},
+				DefaultLocale: "hi",
+			}, nil
+		},
+	}
+	var saved *domain.InvitationTemplate
+	templateRepo := &MockInvitationTemplateRepository{
+		CreateFn: func(ctx context.Context, template *domain.InvitationTemplate) error {
+			saved = template
+			return nil
+		},
+	}
+	var tracked []string
+	assetRepo := &MockAssetRepository{
+		FindByURLFn: func(ctx context.Context, url string) (*domain.Asset, error) {
+			return &domain.Asset{ID: url}, nil
+		},
+		TrackUsageFn: func(ctx context.Context, assetID, invitationID string) error {
+			tracked = append(tracked, assetID+"@"+invitationID)
+			return nil
+		},
+	}
+	useCase := NewSaveAsTemplateUseCase(invitationRepo, templateRepo, assetRepo)
+
+	// Act
+	output, err := useCase.Execute(context.Background(), SaveAsTemplateInput{
+		InvitationID:      "inv-1",
+		UserID:            "user-1",
+		StripPersonalData: true,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.NotEmpty(t, saved.ID)
+	assert.Equal(t, "Our Wedding", saved.Name)
+	assert.Equal(t, "inv-1", saved.SourceInvitationID)
+	assert.JSONEq(t, `{"music":{"file":"/uploads/song.mp3"}}`, string(saved.Data))
+	assert.JSONEq(t, `{"music":{"title":"गीत"}}`, string(saved.Translations["hi"]))
+	// Stripped sections no longer reference their assets
+	assert.Equal(t, []string{"/uploads/song.mp3@" + saved.ID}, tracked)
+	assert.Equal(t, saved.ID, output.ID)
+}
+
+func TestSaveAsTemplateUseCase_Execute_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		invitation *domain.Invitation
+		input      SaveAsTemplateInput
+		wantCode   int
+	}{
+		{
+			name:     "invitation not found",
+			input:    SaveAsTemplateInput{InvitationID: "inv-1", UserID: "user-1", Name: "Base"},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:       "another user's invitation",
+			invitation: &domain.Invitation{ID: "inv-1", LayoutID: "classic-scroll", UserID: "user-2"},
+			input:      SaveAsTemplateInput{InvitationID: "inv-1", UserID: "user-1", Name: "Base"},
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:       "no name and untitled invitation",
+			invitation: &domain.Invitation{ID: "inv-1", LayoutID: "classic-scroll", UserID: "user-1"},
+			input:      SaveAsTemplateInput{InvitationID: "inv-1", UserID: "user-1"},
+			wantCode:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitationRepo := &MockInvitationRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+					return tt.invitation, nil
+				},
+			}
+			templateRepo := &MockInvitationTemplateRepository{
+				CreateFn: func(ctx context.Context, template *domain.InvitationTemplate) error {
+					t.Fatal("Create should not be called")
+					return nil
+				},
+			}
+			useCase := NewSaveAsTemplateUseCase(invitationRepo, templateRepo, nil)
+
+			_, err := useCase.Execute(context.Background(), tt.input)
+
+			var appErr *errors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+		})
+	}
+}
+
+func TestListTemplatesUseCase_Execute_InvalidListOptions_ReturnsBadRequest(t *testing.T) {
+	templateRepo := &MockInvitationTemplateRepository{
+		FindByUserIDFn: func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.InvitationTemplate], error) {
+			return nil, repository.ErrInvalidListOptions
+		},
+	}
+
+	_, err := NewListTemplatesUseCase(templateRepo).Execute(context.Background(), "user-1", repository.ListOptions{})
+
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestDeleteTemplateUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    *domain.InvitationTemplate
+		wantCode    int
+		wantDeleted bool
+	}{
+		{name: "own template", template: &domain.InvitationTemplate{ID: "tpl-1", UserID: "user-1"}, wantDeleted: true},
+		{name: "not found", wantCode: http.StatusNotFound},
+		{name: "another user's template", template: &domain.InvitationTemplate{ID: "tpl-1", UserID: "user-2"}, wantCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted, untracked := false, ""
+			templateRepo := &MockInvitationTemplateRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.InvitationTemplate, error) {
+					return tt.template, nil
+				},
+				DeleteFn: func(ctx context.Context, id string) error {
+					deleted = true
+					return nil
+				},
+			}
+			assetRepo := &MockAssetRepository{
+				UntrackAllUsageFn: func(ctx context.Context, invitationID string) error {
+					untracked = invitationID
+					return nil
+				},
+			}
+
+			err := NewDeleteTemplateUseCase(templateRepo, assetRepo).Execute(context.Background(), "tpl-1", "user-1")
+
+			if tt.wantCode != 0 {
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "tpl-1", untracked)
+			}
+			assert.Equal(t, tt.wantDeleted, deleted)
+		})
+	}
+}
+
+func TestCreateFromTemplateUseCase_Execute_CreatesDraftFromTemplate(t *testing.T) {
+	// Arrange
+	templateRepo := &MockInvitationTemplateRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.InvitationTemplate, error) {
+			return &domain.InvitationTemplate{
+				ID:       id,
+				UserID:   "user-1",
+				Name:     "Base",
+				LayoutID: "editorial-elegance",
+				Data:     json.RawMessage(`{"events":{"day1":{}}}`),
+			}, nil
+		},
+	}
+	var created *domain.Invitation
+	invitationRepo := &MockInvitationRepository{
+		CreateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			created = invitation
+			return nil
+		},
+	}
+	title := "Smith wedding"
+	useCase := NewCreateFromTemplateUseCase(templateRepo, NewCreateInvitationUseCase(invitationRepo, nil, nil, nil, nil))
+
+	// Act
+	output, err := useCase.Execute(context.Background(), CreateFromTemplateInput{TemplateID: "tpl-1", UserID: "user-1", Title: &title})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, "editorial-elegance", created.LayoutID)
+	assert.Equal(t, "Smith wedding", created.Title)
+	assert.Equal(t, domain.InvitationStatusDraft, created.Status)
+	assert.JSONEq(t, `{"events":{"day1":{}}}`, string(created.Data))
+	assert.Equal(t, created.ID, output.Invitation.ID)
+}
+
+func TestCreateFromTemplateUseCase_Execute_AnotherUsersTemplate_ReturnsForbidden(t *testing.T) {
+	templateRepo := &MockInvitationTemplateRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.InvitationTemplate, error) {
+			return &domain.InvitationTemplate{ID: id, UserID: "user-2", LayoutID: "classic-scroll"}, nil
+		},
+	}
+	useCase := NewCreateFromTemplateUseCase(templateRepo, NewCreateInvitationUseCase(&MockInvitationRepository{}, nil, nil, nil, nil))
+
+	_, err := useCase.Execute(context.Background(), CreateFromTemplateInput{TemplateID: "tpl-1", UserID: "user-1"})
+
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusForbidden, appErr.Code)
+}
@@ -0,0 +1,46 @@
+package invitation
+
+import (
+	"encoding/json"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+)
+
+// normalizeTranslations normalizes locale keys and the default locale. When translations are given
+// without a default, the first locale in sorted order becomes the default.
+func normalizeTranslations(translations map[string]json.RawMessage, defaultLocale string) (map[string]json.RawMessage, string, error) {
+	if len(translations) == 0 {
+		if defaultLocale != "" {
+			return nil, "", domain.ErrInvalidLocale
+		}
+		return nil, "", nil
+	}
+
+	normalized := make(map[string]json.RawMessage, len(translations))
+	for locale, strings := range translations {
+		key, err := domain.NormalizeLocale(locale)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, dup := normalized[key]; dup {
+			return nil, "", domain.ErrInvalidLocale
+		}
+		if !json.Valid(strings) {
+			return nil, "", domain.ErrInvalidLocale
+		}
+		normalized[key] = strings
+	}
+
+	if defaultLocale == "" {
+		inv := &domain.Invitation{Translations: normalized}
+		return normalized, inv.Locales()[0], nil
+	}
+	def, err := domain.NormalizeLocale(defaultLocale)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, ok := normalized[def]; !ok {
+		return nil, "", domain.ErrInvalidLocale
+	}
+	return normalized, def, nil
+}
@@ -0,0 +1,74 @@
+package invitation
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// ListTrashedInvitationsUseCase lists a user's trashed invitations, one page at a time
+type ListTrashedInvitationsUseCase struct {
+	invitationRepo repository.InvitationRepository
+	retention      time.Duration
+}
+
+func NewListTrashedInvitationsUseCase(invitationRepo repository.InvitationRepository, retention time.Duration) *ListTrashedInvitationsUseCase {
+	return &ListTrashedInvitationsUseCase{
+		invitationRepo: invitationRepo,
+		retention:      retention,
+	}
+}
+
+func (uc *ListTrashedInvitationsUseCase) Execute(ctx context.Context, userID string, opts repository.ListOptions) (*GetAllInvitationsOutput, error) {
+	page, err := uc.invitationRepo.FindTrashedByUserID(ctx, userID, opts)
+	if err != nil {
+		if stderrors.Is(err, repository.ErrInvalidListOptions) {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, err.Error(), err)
+		}
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get trashed invitations", err)
+	}
+
+	dtos := make([]*InvitationDTO, len(page.Items))
+	for i, inv := range page.Items {
+		dtos[i] = toTrashedInvitationDTO(inv, uc.retention)
+	}
+
+	return &GetAllInvitationsOutput{
+		Invitations: dtos,
+		NextCursor:  page.NextCursor,
+	}, nil
+}
+
+// RestoreInvitationUseCase takes an invitation back out of the trash
+type RestoreInvitationUseCase struct {
+	invitationRepo repository.InvitationRepository
+}
+
+func NewRestoreInvitationUseCase(invitationRepo repository.InvitationRepository) *RestoreInvitationUseCase {
+	return &RestoreInvitationUseCase{
+		invitationRepo: invitationRepo,
+	}
+}
+
+func (uc *RestoreInvitationUseCase) Execute(ctx context.Context, id, userID string) (*InvitationDTO, error) {
+	invitation, err := uc.invitationRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find invitation", err)
+	}
+	if invitation == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Invitation not found in trash", nil)
+	}
+	if invitation.UserID != userID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Cannot restore another user's invitation", nil)
+	}
+
+	if err := uc.invitationRepo.RestoreFromTrash(ctx, id); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to restore invitation", err)
+	}
+	invitation.DeletedAt = nil
+
+	return toInvitationDTO(invitation), nil
+}
@@ -0,0 +1,100 @@
+package invitation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTrashedInvitationsUseCase_Execute_ReportsPurgeTime(t *testing.T) {
+	// Arrange
+	deletedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+	mockRepo := &MockInvitationRepository{
+		FindTrashedByUserIDFn: func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
+			return &repository.Page[*domain.Invitation]{
+				Items:      []*domain.Invitation{{ID: "inv-1", UserID: userID, DeletedAt: &deletedAt}},
+				NextCursor: "next",
+			}, nil
+		},
+	}
+	useCase := NewListTrashedInvitationsUseCase(mockRepo, retention)
+
+	// Act
+	output, err := useCase.Execute(context.Background(), "user-1", repository.ListOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output.Invitations, 1)
+	assert.Equal(t, deletedAt, *output.Invitations[0].DeletedAt)
+	assert.Equal(t, deletedAt.Add(retention), *output.Invitations[0].PurgeAt)
+	assert.Equal(t, "next", output.NextCursor)
+}
+
+func TestRestoreInvitationUseCase_Execute(t *testing.T) {
+	deletedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		trashed      *domain.Invitation
+		callerID     string
+		wantCode     int
+		wantRestored bool
+	}{
+		{
+			name:         "owner restores",
+			trashed:      &domain.Invitation{ID: "inv-1", UserID: "user-1", DeletedAt: &deletedAt},
+			callerID:     "user-1",
+			wantRestored: true,
+		},
+		{
+			name:     "not in trash",
+			callerID: "user-1",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "other user",
+			trashed:  &domain.Invitation{ID: "inv-1", UserID: "user-1", DeletedAt: &deletedAt},
+			callerID: "user-2",
+			wantCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			restored := false
+			mockRepo := &MockInvitationRepository{
+				FindTrashedByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+					return tt.trashed, nil
+				},
+				RestoreFromTrashFn: func(ctx context.Context, id string) error {
+					restored = true
+					return nil
+				},
+			}
+			useCase := NewRestoreInvitationUseCase(mockRepo)
+
+			// Act
+			output, err := useCase.Execute(context.Background(), "inv-1", tt.callerID)
+
+			// Assert
+			assert.Equal(t, tt.wantRestored, restored)
+			if tt.wantCode != 0 {
+				appErr, ok := err.(*errors.AppError)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, output.DeletedAt)
+		})
+	}
+}
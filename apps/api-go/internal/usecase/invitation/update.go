@@ -3,30 +3,47 @@ package invitation
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 
+	"github.com/sacred-vows/api-go/internal/domain"
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
 	"github.com/sacred-vows/api-go/pkg/errors"
 )
 
 type UpdateInvitationUseCase struct {
 	invitationRepo repository.InvitationRepository
 	assetRepo      repository.AssetRepository
+	revisions      *RevisionRecorder
+	dataValidator  *layout.ValidateInvitationDataUseCase // Optional; without it data is only checked to be an object
 }
 
-func NewUpdateInvitationUseCase(invitationRepo repository.InvitationRepository, assetRepo repository.AssetRepository) *UpdateInvitationUseCase {
+func NewUpdateInvitationUseCase(invitationRepo repository.InvitationRepository, assetRepo repository.AssetRepository, revisions *RevisionRecorder, dataValidator *layout.ValidateInvitationDataUseCase) *UpdateInvitationUseCase {
 	return &UpdateInvitationUseCase{
 		invitationRepo: invitationRepo,
 		assetRepo:      assetRepo,
+		revisions:      revisions,
+		dataValidator:  dataValidator,
 	}
 }
 
 type UpdateInvitationInput struct {
-	ID           string
+	ID string
+	// LayoutID may only repeat the current layout; moving to another layout migrates the data and
+	// goes through SwitchLayoutUseCase
 	LayoutID     *string
 	Data         *json.RawMessage
 	LayoutConfig *json.RawMessage
 	Title        *string
-	Status       *string
+	// Status may only archive or unarchive; publish and unpublish set the published states
+	Status *string
+	// Translations replaces all translations when set; an empty map makes the site single-language
+	Translations  *map[string]json.RawMessage
+	DefaultLocale *string
+	// AuthorID is the caller, who must own the invitation; it is recorded on the revision created by this update
+	AuthorID string
+	// ExpectedVersion is the version the client's edit is based on; a mismatch is a conflict
+	ExpectedVersion *int
 }
 
 type UpdateInvitationOutput struct {
@@ -38,13 +55,37 @@ func (uc *UpdateInvitationUseCase) Execute(ctx context.Context, input UpdateInvi
 	if err != nil || invitation == nil {
 		return nil, errors.Wrap(errors.ErrNotFound.Code, "Invitation not found", err)
 	}
+	if invitation.UserID != input.AuthorID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Cannot update another user's invitation", nil)
+	}
+
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != invitation.Version {
+		return nil, versionConflict(&domain.VersionConflictError{CurrentVersion: invitation.Version})
+	}
+
+	statusChanged, err := applyStatusChange(invitation, input.Status)
+	if err != nil {
+		return nil, err
+	}
 
-	if input.LayoutID != nil {
-		invitation.LayoutID = *input.LayoutID
+	if input.LayoutID != nil && *input.LayoutID != invitation.LayoutID {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Use POST /api/invitations/:id/switch-layout to change the layout", nil)
+	}
+
+	title := input.Title
+	if input.Data != nil {
+		data, legacyTitle, err := splitLegacyMetadata(*input.Data, title)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid invitation data", err)
+		}
+		input.Data, title = &data, legacyTitle
+	}
+	if title != nil {
+		invitation.Title = *title
 	}
 
-	// Handle data update - merge with title/status/layoutConfig if provided
-	if input.Data != nil || input.LayoutConfig != nil || input.Title != nil || input.Status != nil {
+	// Handle data update - merge layoutConfig if provided
+	if input.Data != nil || input.LayoutConfig != nil {
 		dataToUpdate := invitation.Data
 		if input.Data != nil {
 			dataToUpdate = *input.Data
@@ -70,35 +111,87 @@ func (uc *UpdateInvitationUseCase) Execute(ctx context.Context, input UpdateInvi
 		if err != nil {
 			return nil, errors.Wrap(errors.ErrBadRequest.Code, "Failed to marshal invitation data", err)
 		}
+		invitation.Data = json.RawMessage(mergedDataBytes)
+	}
 
-		// Merge title and status into data
-		mergedData, err := mergeMetadataIntoData(json.RawMessage(mergedDataBytes), input.Title, input.Status)
+	// Title, status and translation changes leave the data alone, so existing data is not
+	// re-checked against a schema it was never validated with
+	if input.Data != nil || input.LayoutConfig != nil {
+		if err := validateInvitationData(ctx, uc.dataValidator, invitation.LayoutID, invitation.LayoutVersion, invitation.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Translations != nil || input.DefaultLocale != nil {
+		translations := invitation.Translations
+		if input.Translations != nil {
+			translations = *input.Translations
+		}
+		defaultLocale := ""
+		if input.DefaultLocale != nil {
+			defaultLocale = *input.DefaultLocale
+		}
+		normalized, def, err := normalizeTranslations(translations, defaultLocale)
 		if err != nil {
-			return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid invitation data", err)
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid translations", err)
 		}
-		invitation.Data = mergedData
+		// Keep the previous default when it was not changed and still has translations
+		if input.DefaultLocale == nil {
+			if _, ok := normalized[invitation.DefaultLocale]; ok {
+				def = invitation.DefaultLocale
+			}
+		}
+		invitation.Translations = normalized
+		invitation.DefaultLocale = def
 	}
 
 	if err := uc.invitationRepo.Update(ctx, invitation); err != nil {
+		if stderrors.Is(err, domain.ErrVersionConflict) {
+			return nil, versionConflict(err)
+		}
 		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to update invitation", err)
 	}
 
-	// Update asset usage tracking
-	if uc.assetRepo != nil {
-		// Remove all existing usage tracking for this invitation
-		uc.assetRepo.UntrackAllUsage(ctx, invitation.ID)
-
-		// Track new asset usage
-		assetURLs := extractAssetURLs(invitation.Data)
-		for _, url := range assetURLs {
-			asset, err := uc.assetRepo.FindByURL(ctx, url)
-			if err == nil && asset != nil {
-				uc.assetRepo.TrackUsage(ctx, asset.ID, invitation.ID)
-			}
+	// Update does not write status, so a concurrent publish is never overwritten by a stale copy
+	if statusChanged {
+		if err := uc.invitationRepo.UpdateStatus(ctx, invitation.ID, invitation.Status); err != nil {
+			return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to update invitation status", err)
 		}
 	}
 
+	// Update asset usage tracking
+	retrackAssetUsage(ctx, uc.assetRepo, invitation)
+
+	uc.revisions.Record(ctx, invitation, input.AuthorID, 0)
+
 	return &UpdateInvitationOutput{
 		Invitation: toInvitationDTO(invitation),
 	}, nil
 }
+
+// applyStatusChange validates a requested status against the invitation's lifecycle and applies it.
+// It reports whether the status changed.
+func applyStatusChange(invitation *domain.Invitation, requested *string) (bool, error) {
+	if requested == nil {
+		return false, nil
+	}
+	status, err := domain.ParseInvitationStatus(*requested)
+	if err != nil {
+		return false, errors.Wrap(errors.ErrBadRequest.Code, "Invalid status", err)
+	}
+	if status == invitation.Status {
+		return false, nil
+	}
+	if status == domain.InvitationStatusPublished || status == domain.InvitationStatusUnpublished {
+		return false, errors.Wrap(errors.ErrBadRequest.Code, "Use publish or unpublish to change the published state", domain.ErrInvalidTransition)
+	}
+	if err := invitation.TransitionTo(status); err != nil {
+		return false, errors.Wrap(errors.ErrConflict.Code, "Invalid status transition", err)
+	}
+	return true, nil
+}
+
+// versionConflict wraps a *domain.VersionConflictError so handlers can report the current version
+func versionConflict(err error) error {
+	return errors.Wrap(errors.ErrConflict.Code, "Invitation was modified by another session", err)
+}
@@ -3,10 +3,13 @@ package invitation
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -16,7 +19,6 @@ func TestUpdateInvitationUseCase_Execute_ValidUpdate_ReturnsUpdatedInvitation(t
 	invitationID := "invitation-123"
 	userID := "user-123"
 	layoutID := "classic-scroll"
-	newLayoutID := "editorial-elegance"
 	newData := json.RawMessage(`{"title": "Updated Wedding"}`)
 
 	existingInvitation := &domain.Invitation{
@@ -45,11 +47,12 @@ func TestUpdateInvitationUseCase_Execute_ValidUpdate_ReturnsUpdatedInvitation(t
 		},
 	}
 
-	useCase := NewUpdateInvitationUseCase(mockInvitationRepo, mockAssetRepo)
+	useCase := NewUpdateInvitationUseCase(mockInvitationRepo, mockAssetRepo, nil, nil)
 	input := UpdateInvitationInput{
 		ID:       invitationID,
-		LayoutID: &newLayoutID,
+		LayoutID: &layoutID,
 		Data:     &newData,
+		AuthorID: userID,
 	}
 
 	// Act
@@ -61,3 +64,325 @@ func TestUpdateInvitationUseCase_Execute_ValidUpdate_ReturnsUpdatedInvitation(t
 	require.NotNil(t, output.Invitation, "Invitation should not be nil")
 	assert.Equal(t, invitationID, output.Invitation.ID, "Invitation ID should match")
 }
+
+func TestUpdateInvitationUseCase_Execute_Translations(t *testing.T) {
+	newExisting := func() *domain.Invitation {
+		return &domain.Invitation{
+			ID:            "invitation-123",
+			UserID:        "user-123",
+			LayoutID:      "classic-scroll",
+			Data:          json.RawMessage(`{}`),
+			Translations:  map[string]json.RawMessage{"en": json.RawMessage(`{"rsvp":"RSVP"}`), "hi": json.RawMessage(`{"rsvp":"जवाब दें"}`)},
+			DefaultLocale: "hi",
+		}
+	}
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name          string
+		translations  *map[string]json.RawMessage
+		defaultLocale *string
+		wantLocales   []string
+		wantDefault   string
+		wantErr       bool
+	}{
+		{
+			name:         "replace_keeps_existing_default",
+			translations: &map[string]json.RawMessage{"EN": json.RawMessage(`{}`), "hi": json.RawMessage(`{}`), "pt_BR": json.RawMessage(`{}`)},
+			wantLocales:  []string{"en", "hi", "pt-br"},
+			wantDefault:  "hi",
+		},
+		{
+			name:         "removed_default_falls_back_to_first_locale",
+			translations: &map[string]json.RawMessage{"fr": json.RawMessage(`{}`), "en": json.RawMessage(`{}`)},
+			wantLocales:  []string{"en", "fr"},
+			wantDefault:  "en",
+		},
+		{
+			name:          "change_default_only",
+			defaultLocale: strPtr("EN"),
+			wantLocales:   []string{"en", "hi"},
+			wantDefault:   "en",
+		},
+		{
+			name:         "clear_translations",
+			translations: &map[string]json.RawMessage{},
+			wantLocales:  []string{},
+			wantDefault:  "",
+		},
+		{
+			name:          "unknown_default_rejected",
+			defaultLocale: strPtr("fr"),
+			wantErr:       true,
+		},
+		{
+			name:         "invalid_locale_rejected",
+			translations: &map[string]json.RawMessage{"not a locale": json.RawMessage(`{}`)},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			existing := newExisting()
+			var saved *domain.Invitation
+			repo := &MockInvitationRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) { return existing, nil },
+				UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+					saved = invitation
+					return nil
+				},
+			}
+			useCase := NewUpdateInvitationUseCase(repo, nil, nil, nil)
+
+			// Act
+			output, err := useCase.Execute(context.Background(), UpdateInvitationInput{
+				ID:            "invitation-123",
+				Translations:  tt.translations,
+				DefaultLocale: tt.defaultLocale,
+				AuthorID:      "user-123",
+			})
+
+			// Assert
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, saved, "invalid translations must not be saved")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLocales, saved.Locales())
+			assert.Equal(t, tt.wantDefault, saved.DefaultLocale)
+			assert.Equal(t, tt.wantDefault, output.Invitation.DefaultLocale)
+		})
+	}
+}
+
+func TestUpdateInvitationUseCase_Execute_VersionConflict(t *testing.T) {
+	staleVersion := 2
+	currentVersion := 3
+
+	tests := []struct {
+		name            string
+		expectedVersion *int
+		repoErr         error
+	}{
+		{"stale expected version", &staleVersion, nil},
+		{"concurrent write detected by repository", &currentVersion, &domain.VersionConflictError{CurrentVersion: 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			updated := false
+			repo := &MockInvitationRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+					return &domain.Invitation{ID: id, UserID: "user-1", LayoutID: "classic-scroll", Data: json.RawMessage(`{}`), Version: currentVersion}, nil
+				},
+				UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+					updated = true
+					return tt.repoErr
+				},
+			}
+			useCase := NewUpdateInvitationUseCase(repo, nil, nil, nil)
+			title := "Our Wedding"
+
+			// Act
+			_, err := useCase.Execute(context.Background(), UpdateInvitationInput{ID: "inv-1", Title: &title, AuthorID: "user-1", ExpectedVersion: tt.expectedVersion})
+
+			// Assert
+			var appErr *errors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, http.StatusConflict, appErr.Code)
+			var conflict *domain.VersionConflictError
+			require.ErrorAs(t, err, &conflict)
+			if tt.repoErr == nil {
+				assert.Equal(t, currentVersion, conflict.CurrentVersion)
+				assert.False(t, updated, "stale edits are rejected before writing")
+			} else {
+				assert.Equal(t, 4, conflict.CurrentVersion)
+			}
+		})
+	}
+}
+
+func TestUpdateInvitationUseCase_Execute_LayoutChange_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	updated := false
+	repo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "user-1", LayoutID: "classic-scroll", Data: json.RawMessage(`{}`)}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			updated = true
+			return nil
+		},
+	}
+	useCase := NewUpdateInvitationUseCase(repo, nil, nil, nil)
+	layoutID := "editorial-elegance"
+
+	// Act
+	_, err := useCase.Execute(context.Background(), UpdateInvitationInput{ID: "inv-1", LayoutID: &layoutID, AuthorID: "user-1"})
+
+	// Assert
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	assert.Contains(t, appErr.Message, "switch-layout")
+	assert.False(t, updated, "the layout is only changed through switch-layout")
+}
+
+func TestUpdateInvitationUseCase_Execute_StatusChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    domain.InvitationStatus
+		requested  string
+		wantCode   int
+		wantStatus domain.InvitationStatus
+	}{
+		{"archive a draft", domain.InvitationStatusDraft, "archived", 0, domain.InvitationStatusArchived},
+		{"unarchive", domain.InvitationStatusArchived, "draft", 0, domain.InvitationStatusDraft},
+		{"same status is a no-op", domain.InvitationStatusPublished, "published", 0, ""},
+		{"publish via update", domain.InvitationStatusDraft, "published", http.StatusBadRequest, ""},
+		{"unknown status", domain.InvitationStatusDraft, "deleted", http.StatusBadRequest, ""},
+		{"invalid transition", domain.InvitationStatusPublished, "draft", http.StatusConflict, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var statusWritten domain.InvitationStatus
+			repo := &MockInvitationRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+					return &domain.Invitation{ID: id, UserID: "user-1", LayoutID: "classic-scroll", Data: json.RawMessage(`{}`), Status: tt.current}, nil
+				},
+				UpdateStatusFn: func(ctx context.Context, id string, status domain.InvitationStatus) error {
+					statusWritten = status
+					return nil
+				},
+			}
+			useCase := NewUpdateInvitationUseCase(repo, nil, nil, nil)
+			requested := tt.requested
+
+			// Act
+			output, err := useCase.Execute(context.Background(), UpdateInvitationInput{ID: "inv-1", Status: &requested, AuthorID: "user-1"})
+
+			// Assert
+			if tt.wantCode != 0 {
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+				assert.Empty(t, statusWritten, "Rejected changes must not be written")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, statusWritten)
+			assert.Equal(t, tt.requested, *output.Invitation.Status)
+		})
+	}
+}
+
+func TestUpdateInvitationUseCase_Execute_ValidatesDataAgainstLayoutSchema(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	rawPtr := func(s string) *json.RawMessage { raw := json.RawMessage(s); return &raw }
+	manifest := json.RawMessage(`{"dataSchema":{"type":"object","properties":{"layoutConfig":{"type":"object","properties":{"sections":{"type":"array","items":{"type":"object","properties":{"id":{"enum":["hero","couple"]}}}}}}}}}`)
+	validator := layout.NewValidateInvitationDataUseCase(&MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			switch id {
+			case "classic-scroll":
+				return &domain.Layout{ID: id, Manifest: &manifest, IsActive: true}, nil
+			case "retired-layout":
+				retiredAt := time.Now()
+				return &domain.Layout{ID: id, RetiredAt: &retiredAt}, nil
+			}
+			return nil, nil
+		},
+	})
+
+	tests := []struct {
+		name        string
+		input       UpdateInvitationInput
+		wantDetails []errors.FieldError
+	}{
+		{
+			name:  "valid_layout_config",
+			input: UpdateInvitationInput{LayoutConfig: rawPtr(`{"sections":[{"id":"hero"}]}`)},
+		},
+		{
+			name:  "unknown_section_rejected",
+			input: UpdateInvitationInput{LayoutConfig: rawPtr(`{"sections":[{"id":"hero"},{"id":"venue"}]}`)},
+			wantDetails: []errors.FieldError{
+				{Field: "/data/layoutConfig/sections/1/id", Message: `must be one of "hero", "couple"`},
+			},
+		},
+		{
+			// Existing data is not re-checked when only metadata changes
+			name:  "title_only_skips_validation",
+			input: UpdateInvitationInput{Title: strPtr("New title")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			existing := &domain.Invitation{
+				ID:       "invitation-123",
+				UserID:   "user-123",
+				LayoutID: "classic-scroll",
+				Data:     json.RawMessage(`{"layoutConfig":{"sections":[{"id":"legacy"}]}}`),
+			}
+			updated := false
+			repo := &MockInvitationRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) { return existing, nil },
+				UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+					updated = true
+					return nil
+				},
+			}
+			useCase := NewUpdateInvitationUseCase(repo, nil, nil, validator)
+			tt.input.ID = existing.ID
+			tt.input.AuthorID = existing.UserID
+
+			// Act
+			_, err := useCase.Execute(context.Background(), tt.input)
+
+			// Assert
+			if tt.wantDetails == nil {
+				require.NoError(t, err)
+				assert.True(t, updated)
+				return
+			}
+			appErr, ok := err.(*errors.AppError)
+			require.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, appErr.Code)
+			assert.Equal(t, tt.wantDetails, appErr.Details)
+			assert.False(t, updated, "Invalid data should not be saved")
+		})
+	}
+}
+
+func TestUpdateInvitationUseCase_Execute_AnotherUsersInvitation_ReturnsForbidden(t *testing.T) {
+	// Arrange
+	updated := false
+	repo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "guest_abc", LayoutID: "classic-scroll", Data: json.RawMessage(`{}`)}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			updated = true
+			return nil
+		},
+	}
+	useCase := NewUpdateInvitationUseCase(repo, nil, nil, nil)
+	title := "Taken over"
+
+	// Act
+	output, err := useCase.Execute(context.Background(), UpdateInvitationInput{ID: "inv-1", Title: &title, AuthorID: "guest_xyz"})
+
+	// Assert
+	assert.Nil(t, output)
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "Should return AppError")
+	assert.Equal(t, http.StatusForbidden, appErr.Code)
+	assert.False(t, updated, "another user's invitation must not be written")
+}
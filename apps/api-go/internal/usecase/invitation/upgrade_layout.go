@@ -0,0 +1,102 @@
+package invitation
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// UpgradeLayoutVersionUseCase moves an invitation to the latest version of its layout.
+// Invitations keep rendering with the version they were created or switched on until their
+// owner opts in, so a layout release cannot change a published invitation unannounced.
+type UpgradeLayoutVersionUseCase struct {
+	invitationRepo repository.InvitationRepository
+	layoutRepo     repository.LayoutRepository
+	revisions      *RevisionRecorder
+	dataValidator  *layout.ValidateInvitationDataUseCase // Optional
+}
+
+func NewUpgradeLayoutVersionUseCase(
+	invitationRepo repository.InvitationRepository,
+	layoutRepo repository.LayoutRepository,
+	revisions *RevisionRecorder,
+	dataValidator *layout.ValidateInvitationDataUseCase,
+) *UpgradeLayoutVersionUseCase {
+	return &UpgradeLayoutVersionUseCase{
+		invitationRepo: invitationRepo,
+		layoutRepo:     layoutRepo,
+		revisions:      revisions,
+		dataValidator:  dataValidator,
+	}
+}
+
+type UpgradeLayoutVersionInput struct {
+	ID     string
+	UserID string
+	// DryRun checks the data against the latest version without saving
+	DryRun bool
+	// ExpectedVersion is the version the upgrade is based on; a mismatch is a conflict
+	ExpectedVersion *int
+}
+
+type UpgradeLayoutVersionOutput struct {
+	// Invitation is the saved invitation; nil on a dry run
+	Invitation  *InvitationDTO
+	FromVersion string
+	ToVersion   string
+}
+
+// Execute returns a 400 when the invitation is already on the latest version, and the
+// validator's 400 when its data does not fit the latest version's schema; the data has to be
+// fixed first, as an upgrade does not change it.
+func (uc *UpgradeLayoutVersionUseCase) Execute(ctx context.Context, input UpgradeLayoutVersionInput) (*UpgradeLayoutVersionOutput, error) {
+	invitation, err := uc.invitationRepo.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find invitation", err)
+	}
+	if invitation == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Invitation not found", nil)
+	}
+	if invitation.UserID != input.UserID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Cannot upgrade the layout of another user's invitation", nil)
+	}
+	if !input.DryRun && input.ExpectedVersion != nil && *input.ExpectedVersion != invitation.Version {
+		return nil, versionConflict(&domain.VersionConflictError{CurrentVersion: invitation.Version})
+	}
+
+	current, err := uc.layoutRepo.FindByID(ctx, invitation.LayoutID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get layout", err)
+	}
+	if current == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Layout not found", nil)
+	}
+	// Unpinned invitations already follow the current version
+	if invitation.LayoutVersion == "" || invitation.LayoutVersion == current.Version {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invitation already uses the latest layout version", nil)
+	}
+	if err := validateInvitationData(ctx, uc.dataValidator, invitation.LayoutID, current.Version, invitation.Data); err != nil {
+		return nil, err
+	}
+
+	output := &UpgradeLayoutVersionOutput{FromVersion: invitation.LayoutVersion, ToVersion: current.Version}
+	if input.DryRun {
+		return output, nil
+	}
+
+	invitation.LayoutVersion = current.Version
+	if err := uc.invitationRepo.Update(ctx, invitation); err != nil {
+		if stderrors.Is(err, domain.ErrVersionConflict) {
+			return nil, versionConflict(err)
+		}
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to update invitation", err)
+	}
+	uc.revisions.Record(ctx, invitation, input.UserID, 0)
+
+	output.Invitation = toInvitationDTO(invitation)
+	return output, nil
+}
@@ -0,0 +1,316 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeLayoutVersionUseCase_Execute_SavesLatestVersion(t *testing.T) {
+	// Arrange
+	// Version 2.0.0 of classic-scroll requires a "story" object, which the invitation has
+	manifest := json.RawMessage(`{"id": "classic-scroll", "dataSchema": {"type": "object", "required": ["story"]}}`)
+	layoutRepo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Version: "2.0.0", Manifest: &manifest, IsActive: true}, nil
+		},
+	}
+	var saved *domain.Invitation
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{
+				ID:            id,
+				UserID:        "user-1",
+				LayoutID:      "classic-scroll",
+				LayoutVersion: "1.0.0",
+				Version:       3,
+				Data:          json.RawMessage(`{"story":{}}`),
+			}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			saved = invitation
+			invitation.Version++
+			return nil
+		},
+	}
+	useCase := NewUpgradeLayoutVersionUseCase(invitationRepo, layoutRepo, nil, layout.NewValidateInvitationDataUseCase(layoutRepo))
+	version := 3
+
+	// Act
+	output, err := useCase.Execute(context.Background(), UpgradeLayoutVersionInput{
+		ID:              "inv-1",
+		UserID:          "user-1",
+		ExpectedVersion: &version,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, "2.0.0", saved.LayoutVersion)
+	assert.Equal(t, "1.0.0", output.FromVersion)
+	assert.Equal(t, "2.0.0", output.ToVersion)
+	require.NotNil(t, output.Invitation)
+	assert.Equal(t, "2.0.0", output.Invitation.LayoutVersion)
+	assert.Equal(t, 4, output.Invitation.Version)
+}
+
+func TestUpgradeLayoutVersionUseCase_Execute_DryRun_DoesNotSave(t *testing.T) {
+	// Arrange
+	manifest := json.RawMessage(`{"id": "classic-scroll"}`)
+	layoutRepo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Version: "2.0.0", Manifest: &manifest, IsActive: true}, nil
+		},
+	}
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "user-1", LayoutID: "classic-scroll", LayoutVersion: "1.0.0", Version: 3}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			t.Fatal("dry run must not save")
+			return nil
+		},
+	}
+	useCase := NewUpgradeLayoutVersionUseCase(invitationRepo, layoutRepo, nil, nil)
+
+	// Act
+	output, err := useCase.Execute(context.Background(), UpgradeLayoutVersionInput{ID: "inv-1", UserID: "user-1", DryRun: true})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, output.Invitation)
+	assert.Equal(t, "1.0.0", output.FromVersion)
+	assert.Equal(t, "2.0.0", output.ToVersion)
+}
+
+func TestUpgradeLayoutVersionUseCase_Execute_NotFound_ReturnsNotFound(t *testing.T) {
+	// Arrange
+	// Only inv-1 exists
+	manifest := json.RawMessage(`{"id": "classic-scroll", "dataSchema": {"type": "object", "required": ["story"]}}`)
+	layoutRepo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Version: "2.0.0", Manifest: &manifest, IsActive: true}, nil
+		},
+	}
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			if id != "inv-1" {
+				return nil, nil
+			}
+			return &domain.Invitation{
+				ID:            "inv-1",
+				UserID:        "user-1",
+				LayoutID:      "classic-scroll",
+				LayoutVersion: "1.0.0",
+				Version:       3,
+				Data:          json.RawMessage(`{"story":{}}`),
+			}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			t.Fatal("Update should not be called")
+			return nil
+		},
+	}
+	useCase := NewUpgradeLayoutVersionUseCase(invitationRepo, layoutRepo, nil, layout.NewValidateInvitationDataUseCase(layoutRepo))
+
+	// Act
+	_, err := useCase.Execute(context.Background(), UpgradeLayoutVersionInput{ID: "missing", UserID: "user-1"})
+
+	// Assert
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
+
+func TestUpgradeLayoutVersionUseCase_Execute_OtherUser_ReturnsForbidden(t *testing.T) {
+	// Arrange
+	// inv-1 belongs to user-1
+	manifest := json.RawMessage(`{"id": "classic-scroll", "dataSchema": {"type": "object", "required": ["story"]}}`)
+	layoutRepo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Version: "2.0.0", Manifest: &manifest, IsActive: true}, nil
+		},
+	}
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			if id != "inv-1" {
+				return nil, nil
+			}
+			return &domain.Invitation{
+				ID:            "inv-1",
+				UserID:        "user-1",
+				LayoutID:      "classic-scroll",
+				LayoutVersion: "1.0.0",
+				Version:       3,
+				Data:          json.RawMessage(`{"story":{}}`),
+			}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			t.Fatal("Update should not be called")
+			return nil
+		},
+	}
+	useCase := NewUpgradeLayoutVersionUseCase(invitationRepo, layoutRepo, nil, layout.NewValidateInvitationDataUseCase(layoutRepo))
+
+	// Act
+	_, err := useCase.Execute(context.Background(), UpgradeLayoutVersionInput{ID: "inv-1", UserID: "user-2"})
+
+	// Assert
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusForbidden, appErr.Code)
+}
+
+func TestUpgradeLayoutVersionUseCase_Execute_StaleVersion_ReturnsConflict(t *testing.T) {
+	// Arrange
+	// The invitation is at version 3; the caller last saw version 1
+	manifest := json.RawMessage(`{"id": "classic-scroll"}`)
+	layoutRepo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Version: "2.0.0", Manifest: &manifest, IsActive: true}, nil
+		},
+	}
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "user-1", LayoutID: "classic-scroll", LayoutVersion: "1.0.0", Version: 3}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			t.Fatal("Update should not be called")
+			return nil
+		},
+	}
+	useCase := NewUpgradeLayoutVersionUseCase(invitationRepo, layoutRepo, nil, nil)
+	stale := 1
+
+	// Act
+	_, err := useCase.Execute(context.Background(), UpgradeLayoutVersionInput{ID: "inv-1", UserID: "user-1", ExpectedVersion: &stale})
+
+	// Assert
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusConflict, appErr.Code)
+}
+
+func TestUpgradeLayoutVersionUseCase_Execute_AlreadyLatest_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	// The invitation is already pinned to the current 2.0.0
+	manifest := json.RawMessage(`{"id": "classic-scroll", "dataSchema": {"type": "object", "required": ["story"]}}`)
+	layoutRepo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Version: "2.0.0", Manifest: &manifest, IsActive: true}, nil
+		},
+	}
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			if id != "inv-1" {
+				return nil, nil
+			}
+			return &domain.Invitation{
+				ID:            "inv-1",
+				UserID:        "user-1",
+				LayoutID:      "classic-scroll",
+				LayoutVersion: "2.0.0",
+				Version:       3,
+				Data:          json.RawMessage(`{"story":{}}`),
+			}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			t.Fatal("Update should not be called")
+			return nil
+		},
+	}
+	useCase := NewUpgradeLayoutVersionUseCase(invitationRepo, layoutRepo, nil, layout.NewValidateInvitationDataUseCase(layoutRepo))
+
+	// Act
+	_, err := useCase.Execute(context.Background(), UpgradeLayoutVersionInput{ID: "inv-1", UserID: "user-1"})
+
+	// Assert
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestUpgradeLayoutVersionUseCase_Execute_Unpinned_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	// The invitation is not pinned to any version
+	manifest := json.RawMessage(`{"id": "classic-scroll", "dataSchema": {"type": "object", "required": ["story"]}}`)
+	layoutRepo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Version: "2.0.0", Manifest: &manifest, IsActive: true}, nil
+		},
+	}
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			if id != "inv-1" {
+				return nil, nil
+			}
+			return &domain.Invitation{
+				ID:            "inv-1",
+				UserID:        "user-1",
+				LayoutID:      "classic-scroll",
+				LayoutVersion: "",
+				Version:       3,
+				Data:          json.RawMessage(`{"story":{}}`),
+			}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			t.Fatal("Update should not be called")
+			return nil
+		},
+	}
+	useCase := NewUpgradeLayoutVersionUseCase(invitationRepo, layoutRepo, nil, layout.NewValidateInvitationDataUseCase(layoutRepo))
+
+	// Act
+	_, err := useCase.Execute(context.Background(), UpgradeLayoutVersionInput{ID: "inv-1", UserID: "user-1"})
+
+	// Assert
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestUpgradeLayoutVersionUseCase_Execute_DataDoesNotFit_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	// Version 2.0.0 requires a "story" object, which the invitation lacks
+	manifest := json.RawMessage(`{"id": "classic-scroll", "dataSchema": {"type": "object", "required": ["story"]}}`)
+	layoutRepo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Version: "2.0.0", Manifest: &manifest, IsActive: true}, nil
+		},
+	}
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			if id != "inv-1" {
+				return nil, nil
+			}
+			return &domain.Invitation{
+				ID:            "inv-1",
+				UserID:        "user-1",
+				LayoutID:      "classic-scroll",
+				LayoutVersion: "1.0.0",
+				Version:       3,
+				Data:          json.RawMessage(`{}`),
+			}, nil
+		},
+		UpdateFn: func(ctx context.Context, invitation *domain.Invitation) error {
+			t.Fatal("Update should not be called")
+			return nil
+		},
+	}
+	useCase := NewUpgradeLayoutVersionUseCase(invitationRepo, layoutRepo, nil, layout.NewValidateInvitationDataUseCase(layoutRepo))
+
+	// Act
+	_, err := useCase.Execute(context.Background(), UpgradeLayoutVersionInput{ID: "inv-1", UserID: "user-1"})
+
+	// Assert
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
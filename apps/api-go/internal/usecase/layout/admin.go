@@ -0,0 +1,174 @@
+package layout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/sacred-vows/api-go/pkg/jsonschema"
+	"github.com/segmentio/ksuid"
+)
+
+// layoutIDPattern keeps layout IDs URL- and path-safe; they appear in routes and asset paths
+var layoutIDPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// LayoutAdminDTO is the full stored form of a layout, including inactive and retired ones
+type LayoutAdminDTO struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Description  *string          `json:"description,omitempty"`
+	PreviewImage *string          `json:"previewImage,omitempty"`
+	Tags         []string         `json:"tags"`
+	Version      string           `json:"version"`
+	Config       *json.RawMessage `json:"config,omitempty"`
+	Manifest     *json.RawMessage `json:"manifest,omitempty"`
+	IsActive     bool             `json:"isActive"`
+	RetiredAt    *time.Time       `json:"retiredAt,omitempty"`
+	CreatedAt    time.Time        `json:"createdAt"`
+	UpdatedAt    time.Time        `json:"updatedAt"`
+}
+
+func toLayoutAdminDTO(layout *domain.Layout) *LayoutAdminDTO {
+	tags := layout.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return &LayoutAdminDTO{
+		ID:           layout.ID,
+		Name:         layout.Name,
+		Description:  layout.Description,
+		PreviewImage: layout.PreviewImage,
+		Tags:         tags,
+		Version:      layout.Version,
+		Config:       layout.Config,
+		Manifest:     layout.Manifest,
+		IsActive:     layout.IsActive,
+		RetiredAt:    layout.RetiredAt,
+		CreatedAt:    layout.CreatedAt,
+		UpdatedAt:    layout.UpdatedAt,
+	}
+}
+
+// AuditEntryDTO represents one entry of the audit log
+type AuditEntryDTO struct {
+	ID           string    `json:"id"`
+	ActorID      string    `json:"actorId"`
+	Action       string    `json:"action"`
+	ResourceType string    `json:"resourceType"`
+	ResourceID   string    `json:"resourceId"`
+	Changes      []string  `json:"changes"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func toAuditEntryDTO(entry *domain.AuditEntry) *AuditEntryDTO {
+	changes := entry.Changes
+	if changes == nil {
+		changes = []string{}
+	}
+	return &AuditEntryDTO{
+		ID:           entry.ID,
+		ActorID:      entry.ActorID,
+		Action:       string(entry.Action),
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceID,
+		Changes:      changes,
+		CreatedAt:    entry.CreatedAt,
+	}
+}
+
+// AuditRecorder builds the audit entries that layout writes save in the same atomic write as the
+// change, so a change is never saved without its entry. A nil recorder records nothing.
+type AuditRecorder struct{}
+
+func NewAuditRecorder() *AuditRecorder {
+	return &AuditRecorder{}
+}
+
+// layoutEntry returns the entry for a change to a layout, or nil when r is nil
+func (r *AuditRecorder) layoutEntry(actorID string, action domain.AuditAction, layoutID string, changes []string) (*domain.AuditEntry, error) {
+	if r == nil {
+		return nil, nil
+	}
+	entry, err := domain.NewAuditEntry(actorID, action, domain.AuditResourceLayout, layoutID, changes)
+	if err != nil {
+		return nil, err
+	}
+	entry.ID = ksuid.New().String()
+	return entry, nil
+}
+
+// findLayoutForAdmin returns the layout or a 404 AppError
+func findLayoutForAdmin(ctx context.Context, layoutRepo repository.LayoutRepository, id string) (*domain.Layout, error) {
+	layout, err := layoutRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get layout", err)
+	}
+	if layout == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Layout not found", nil)
+	}
+	return layout, nil
+}
+
+// validateLayoutManifest checks the parts of a manifest the API relies on: it is an object, its
+// "id" (when set) names the layout, its dataSchema compiles and its migrations parse. Problems
+// are returned as field errors relative to the request body.
+func validateLayoutManifest(layoutID string, manifest json.RawMessage) []errors.FieldError {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(manifest, &fields); err != nil || fields == nil {
+		return []errors.FieldError{{Field: "/manifest", Message: "must be a JSON object"}}
+	}
+
+	var details []errors.FieldError
+	if raw, ok := fields["id"]; ok {
+		var id string
+		if err := json.Unmarshal(raw, &id); err != nil || id != layoutID {
+			details = append(details, errors.FieldError{Field: "/manifest/id", Message: fmt.Sprintf("must be %q", layoutID)})
+		}
+	}
+	if raw, ok := fields["dataSchema"]; ok && !isJSONNull(raw) {
+		if _, err := jsonschema.Compile(raw); err != nil {
+			details = append(details, errors.FieldError{Field: "/manifest/dataSchema", Message: err.Error()})
+		}
+	}
+	if raw, ok := fields["migrations"]; ok && !isJSONNull(raw) {
+		var migrations map[string]LayoutMigration
+		if err := json.Unmarshal(raw, &migrations); err != nil {
+			details = append(details, errors.FieldError{Field: "/manifest/migrations", Message: "must map layout IDs to migrations"})
+		}
+	}
+	// Sections, presets and themes are read by the migration and summary code
+	if len(details) == 0 {
+		if err := json.Unmarshal(manifest, &migrationManifest{}); err != nil {
+			details = append(details, errors.FieldError{Field: "/manifest", Message: err.Error()})
+		}
+	}
+	return details
+}
+
+// validateLayoutConfig checks that a layout config is a JSON object
+func validateLayoutConfig(config json.RawMessage) []errors.FieldError {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(config, &fields); err != nil || fields == nil {
+		return []errors.FieldError{{Field: "/config", Message: "must be a JSON object"}}
+	}
+	return nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
+
+// compactJSON normalizes raw JSON so equal documents compare equal regardless of formatting
+func compactJSON(raw json.RawMessage) json.RawMessage {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return raw
+	}
+	return buf.Bytes()
+}
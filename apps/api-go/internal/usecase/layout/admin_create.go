@@ -0,0 +1,106 @@
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// CreateLayoutUseCase adds a layout to the catalog
+type CreateLayoutUseCase struct {
+	layoutRepo repository.LayoutRepository
+	audit      *AuditRecorder
+	catalog    *LayoutCatalog // Optional; invalidated on every write
+}
+
+func NewCreateLayoutUseCase(layoutRepo repository.LayoutRepository, audit *AuditRecorder, catalog *LayoutCatalog) *CreateLayoutUseCase {
+	return &CreateLayoutUseCase{
+		layoutRepo: layoutRepo,
+		audit:      audit,
+		catalog:    catalog,
+	}
+}
+
+type CreateLayoutInput struct {
+	ID           string
+	Name         string
+	Description  *string
+	PreviewImage *string
+	Tags         []string
+	Version      string
+	Config       *json.RawMessage
+	Manifest     *json.RawMessage
+	// IsActive publishes the layout right away; otherwise it is created inactive
+	IsActive bool
+	ActorID  string
+}
+
+type CreateLayoutOutput struct {
+	Layout *LayoutAdminDTO
+}
+
+func (uc *CreateLayoutUseCase) Execute(ctx context.Context, input CreateLayoutInput) (*CreateLayoutOutput, error) {
+	layout := &domain.Layout{
+		ID:           strings.TrimSpace(input.ID),
+		Name:         strings.TrimSpace(input.Name),
+		Description:  input.Description,
+		PreviewImage: input.PreviewImage,
+		Tags:         input.Tags,
+		Version:      strings.TrimSpace(input.Version),
+		IsActive:     input.IsActive,
+	}
+
+	var details []errors.FieldError
+	if !layoutIDPattern.MatchString(layout.ID) {
+		details = append(details, errors.FieldError{Field: "/id", Message: "must be lowercase letters, digits and dashes"})
+	}
+	if layout.Name == "" {
+		details = append(details, errors.FieldError{Field: "/name", Message: "is required"})
+	}
+	if layout.Version != "" {
+		if _, err := parseLayoutVersion(layout.Version); err != nil {
+			details = append(details, errors.FieldError{Field: "/version", Message: err.Error()})
+		}
+	}
+	if input.Config != nil {
+		config := compactJSON(*input.Config)
+		details = append(details, validateLayoutConfig(config)...)
+		layout.Config = &config
+	}
+	if input.Manifest != nil {
+		manifest := compactJSON(*input.Manifest)
+		details = append(details, validateLayoutManifest(layout.ID, manifest)...)
+		layout.Manifest = &manifest
+	}
+	if len(details) > 0 {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid layout", nil).WithDetails(details)
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid layout", err)
+	}
+
+	existing, err := uc.layoutRepo.FindByID(ctx, layout.ID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get layout", err)
+	}
+	if existing != nil {
+		return nil, errors.Wrap(errors.ErrConflict.Code, "Layout already exists", nil)
+	}
+
+	entry, err := uc.audit.layoutEntry(input.ActorID, domain.AuditActionLayoutCreate, layout.ID, nil)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to create layout", err)
+	}
+	if err := uc.layoutRepo.Create(ctx, layout, entry); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to create layout", err)
+	}
+	uc.catalog.Invalidate()
+
+	return &CreateLayoutOutput{
+		Layout: toLayoutAdminDTO(layout),
+	}, nil
+}
@@ -0,0 +1,80 @@
+package layout
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// ListLayoutsForAdminUseCase lists every layout, including inactive and retired ones
+type ListLayoutsForAdminUseCase struct {
+	layoutRepo repository.LayoutRepository
+}
+
+func NewListLayoutsForAdminUseCase(layoutRepo repository.LayoutRepository) *ListLayoutsForAdminUseCase {
+	return &ListLayoutsForAdminUseCase{
+		layoutRepo: layoutRepo,
+	}
+}
+
+type ListLayoutsForAdminOutput struct {
+	Layouts []*LayoutAdminDTO
+}
+
+func (uc *ListLayoutsForAdminUseCase) Execute(ctx context.Context) (*ListLayoutsForAdminOutput, error) {
+	layouts, err := uc.layoutRepo.FindAllIncludingInactive(ctx)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get layouts", err)
+	}
+
+	dtos := make([]*LayoutAdminDTO, len(layouts))
+	for i, layout := range layouts {
+		dtos[i] = toLayoutAdminDTO(layout)
+	}
+
+	return &ListLayoutsForAdminOutput{
+		Layouts: dtos,
+	}, nil
+}
+
+// ListLayoutAuditUseCase lists the audit log of one layout, one page at a time
+type ListLayoutAuditUseCase struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewListLayoutAuditUseCase(auditRepo repository.AuditRepository) *ListLayoutAuditUseCase {
+	return &ListLayoutAuditUseCase{
+		auditRepo: auditRepo,
+	}
+}
+
+type ListLayoutAuditOutput struct {
+	Entries []*AuditEntryDTO
+	// NextCursor fetches the next page; empty on the last page
+	NextCursor string
+}
+
+// Execute does not require the layout to exist, so the history of a layout created and
+// removed by hand stays readable
+func (uc *ListLayoutAuditUseCase) Execute(ctx context.Context, layoutID string, opts repository.ListOptions) (*ListLayoutAuditOutput, error) {
+	page, err := uc.auditRepo.FindByResource(ctx, domain.AuditResourceLayout, layoutID, opts)
+	if err != nil {
+		if stderrors.Is(err, repository.ErrInvalidListOptions) {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, err.Error(), err)
+		}
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get audit log", err)
+	}
+
+	dtos := make([]*AuditEntryDTO, len(page.Items))
+	for i, entry := range page.Items {
+		dtos[i] = toAuditEntryDTO(entry)
+	}
+
+	return &ListLayoutAuditOutput{
+		Entries:    dtos,
+		NextCursor: page.NextCursor,
+	}, nil
+}
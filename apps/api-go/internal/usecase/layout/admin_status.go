@@ -0,0 +1,120 @@
+package layout
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// SetLayoutActiveUseCase shows or hides a layout in the catalog. Inactive layouts keep
+// rendering for the invitations that already use them.
+type SetLayoutActiveUseCase struct {
+	layoutRepo repository.LayoutRepository
+	audit      *AuditRecorder
+	catalog    *LayoutCatalog // Optional; invalidated on every write
+}
+
+func NewSetLayoutActiveUseCase(layoutRepo repository.LayoutRepository, audit *AuditRecorder, catalog *LayoutCatalog) *SetLayoutActiveUseCase {
+	return &SetLayoutActiveUseCase{
+		layoutRepo: layoutRepo,
+		audit:      audit,
+		catalog:    catalog,
+	}
+}
+
+type SetLayoutActiveInput struct {
+	ID      string
+	Active  bool
+	ActorID string
+}
+
+type SetLayoutActiveOutput struct {
+	Layout *LayoutAdminDTO
+}
+
+func (uc *SetLayoutActiveUseCase) Execute(ctx context.Context, input SetLayoutActiveInput) (*SetLayoutActiveOutput, error) {
+	layout, err := findLayoutForAdmin(ctx, uc.layoutRepo, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if layout.IsActive == input.Active {
+		return &SetLayoutActiveOutput{Layout: toLayoutAdminDTO(layout)}, nil
+	}
+
+	if err := layout.SetActive(input.Active); err != nil {
+		if stderrors.Is(err, domain.ErrLayoutRetired) {
+			return nil, errors.Wrap(errors.ErrConflict.Code, "Retired layouts cannot be activated", err)
+		}
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid layout", err)
+	}
+
+	action := domain.AuditActionLayoutDeactivate
+	if input.Active {
+		action = domain.AuditActionLayoutActivate
+	}
+	entry, err := uc.audit.layoutEntry(input.ActorID, action, layout.ID, []string{"isActive"})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to update layout", err)
+	}
+	if err := uc.layoutRepo.Update(ctx, layout, entry); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to update layout", err)
+	}
+	uc.catalog.Invalidate()
+
+	return &SetLayoutActiveOutput{
+		Layout: toLayoutAdminDTO(layout),
+	}, nil
+}
+
+// RetireLayoutUseCase withdraws a layout for good. The layout is kept rather than deleted so
+// invitations built on it keep rendering; it just cannot be chosen or reactivated.
+type RetireLayoutUseCase struct {
+	layoutRepo repository.LayoutRepository
+	audit      *AuditRecorder
+	catalog    *LayoutCatalog // Optional; invalidated on every write
+}
+
+func NewRetireLayoutUseCase(layoutRepo repository.LayoutRepository, audit *AuditRecorder, catalog *LayoutCatalog) *RetireLayoutUseCase {
+	return &RetireLayoutUseCase{
+		layoutRepo: layoutRepo,
+		audit:      audit,
+		catalog:    catalog,
+	}
+}
+
+type RetireLayoutOutput struct {
+	Layout *LayoutAdminDTO
+}
+
+// Execute is idempotent: retiring a retired layout changes nothing
+func (uc *RetireLayoutUseCase) Execute(ctx context.Context, id, actorID string) (*RetireLayoutOutput, error) {
+	layout, err := findLayoutForAdmin(ctx, uc.layoutRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if layout.IsRetired() {
+		return &RetireLayoutOutput{Layout: toLayoutAdminDTO(layout)}, nil
+	}
+
+	changes := []string{"retiredAt"}
+	if layout.IsActive {
+		changes = append(changes, "isActive")
+	}
+	layout.Retire(time.Now())
+	entry, err := uc.audit.layoutEntry(actorID, domain.AuditActionLayoutRetire, layout.ID, changes)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to retire layout", err)
+	}
+	if err := uc.layoutRepo.Update(ctx, layout, entry); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to retire layout", err)
+	}
+	uc.catalog.Invalidate()
+
+	return &RetireLayoutOutput{
+		Layout: toLayoutAdminDTO(layout),
+	}, nil
+}
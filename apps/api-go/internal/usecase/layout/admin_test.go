@@ -0,0 +1,334 @@
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAuditSpy returns a recorder and the entries repo saves with its writes
+func newAuditSpy(repo *MockLayoutRepository) (*AuditRecorder, *[]*domain.AuditEntry) {
+	return NewAuditRecorder(), &repo.Audited
+}
+
+func TestCreateLayoutUseCase_Execute_CreatesInactiveLayoutAndAudits(t *testing.T) {
+	// Arrange
+	var created *domain.Layout
+	repo := &MockLayoutRepository{
+		CreateFn: func(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+			created = layout
+			return nil
+		},
+	}
+	audit, entries := newAuditSpy(repo)
+	manifest := json.RawMessage(`{
+		"id": "garden-party",
+		"dataSchema": {"type": "object"},
+		"migrations": {"classic-scroll": {"sections": {"venue": "location"}}}
+	}`)
+
+	// Act
+	output, err := NewCreateLayoutUseCase(repo, audit, nil).Execute(context.Background(), CreateLayoutInput{
+		ID:       "garden-party",
+		Name:     " Garden Party ",
+		Version:  "1.0.0",
+		Manifest: &manifest,
+		ActorID:  "admin-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, "Garden Party", created.Name)
+	assert.False(t, created.IsActive)
+	assert.JSONEq(t, string(manifest), string(*created.Manifest))
+	assert.Equal(t, "garden-party", output.Layout.ID)
+	require.Len(t, *entries, 1)
+	assert.Equal(t, domain.AuditActionLayoutCreate, (*entries)[0].Action)
+	assert.Equal(t, "admin-1", (*entries)[0].ActorID)
+	assert.Equal(t, "garden-party", (*entries)[0].ResourceID)
+	assert.NotEmpty(t, (*entries)[0].ID)
+}
+
+func TestCreateLayoutUseCase_Execute_Errors(t *testing.T) {
+	rawPtr := func(s string) *json.RawMessage { raw := json.RawMessage(s); return &raw }
+	tests := []struct {
+		name        string
+		input       CreateLayoutInput
+		wantCode    int
+		wantDetails []errors.FieldError
+	}{
+		{
+			name:        "invalid id",
+			input:       CreateLayoutInput{ID: "Garden Party", Name: "Garden Party"},
+			wantCode:    http.StatusBadRequest,
+			wantDetails: []errors.FieldError{{Field: "/id", Message: "must be lowercase letters, digits and dashes"}},
+		},
+		{
+			name:        "missing name",
+			input:       CreateLayoutInput{ID: "garden-party"},
+			wantCode:    http.StatusBadRequest,
+			wantDetails: []errors.FieldError{{Field: "/name", Message: "is required"}},
+		},
+		{
+			name:        "manifest not an object",
+			input:       CreateLayoutInput{ID: "garden-party", Name: "Garden Party", Manifest: rawPtr(`[]`)},
+			wantCode:    http.StatusBadRequest,
+			wantDetails: []errors.FieldError{{Field: "/manifest", Message: "must be a JSON object"}},
+		},
+		{
+			name:     "manifest problems",
+			input:    CreateLayoutInput{ID: "garden-party", Name: "Garden Party", Manifest: rawPtr(`{"id": "other", "dataSchema": {"type": "date"}, "migrations": []}`)},
+			wantCode: http.StatusBadRequest,
+			wantDetails: []errors.FieldError{
+				{Field: "/manifest/id", Message: `must be "garden-party"`},
+				{Field: "/manifest/dataSchema", Message: `invalid schema: /type has unknown type "date"`},
+				{Field: "/manifest/migrations", Message: "must map layout IDs to migrations"},
+			},
+		},
+		{
+			name:     "already exists",
+			input:    CreateLayoutInput{ID: "classic-scroll", Name: "Classic Scroll"},
+			wantCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockLayoutRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+					if id == "classic-scroll" {
+						return &domain.Layout{ID: id, Name: "Classic Scroll"}, nil
+					}
+					return nil, nil
+				},
+				CreateFn: func(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+					t.Fatal("Create should not be called")
+					return nil
+				},
+			}
+			audit, entries := newAuditSpy(repo)
+
+			_, err := NewCreateLayoutUseCase(repo, audit, nil).Execute(context.Background(), tt.input)
+
+			appErr, ok := err.(*errors.AppError)
+			require.True(t, ok, "expected AppError, got %v", err)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+			assert.Equal(t, tt.wantDetails, appErr.Details)
+			assert.Empty(t, *entries)
+		})
+	}
+}
+
+func TestUpdateLayoutUseCase_Execute_RecordsChangedFields(t *testing.T) {
+	// Arrange
+	manifest := json.RawMessage(`{"id":"classic-scroll","sections":[]}`)
+	existing := &domain.Layout{ID: "classic-scroll", Name: "Classic Scroll", Version: "1.0.0", Manifest: &manifest, IsActive: true}
+	updated := false
+	repo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) { return existing, nil },
+		UpdateFn: func(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+			updated = true
+			return nil
+		},
+	}
+	audit, entries := newAuditSpy(repo)
+	name := "Classic Scroll"
+	version := "1.1.0"
+	sameManifest := json.RawMessage(`{ "id": "classic-scroll", "sections": [] }`)
+
+	// Act
+	output, err := NewUpdateLayoutUseCase(repo, audit, nil).Execute(context.Background(), UpdateLayoutInput{
+		ID:       "classic-scroll",
+		Name:     &name,
+		Version:  &version,
+		Manifest: &sameManifest,
+		ActorID:  "admin-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, []string{"version"}, output.Changes)
+	assert.Equal(t, "1.1.0", output.Layout.Version)
+	require.Len(t, *entries, 1)
+	assert.Equal(t, domain.AuditActionLayoutUpdate, (*entries)[0].Action)
+	assert.Equal(t, []string{"version"}, (*entries)[0].Changes)
+}
+
+func TestUpdateLayoutUseCase_Execute_ManifestChangeRequiresVersionBump(t *testing.T) {
+	manifest := json.RawMessage(`{"id":"classic-scroll","sections":[]}`)
+	newManifest := json.RawMessage(`{"id":"classic-scroll","sections":[{"id":"hero"}]}`)
+	stringPtr := func(s string) *string { return &s }
+	tests := []struct {
+		name        string
+		version     *string
+		wantDetails []errors.FieldError
+	}{
+		{
+			name:        "version unchanged",
+			wantDetails: []errors.FieldError{{Field: "/version", Message: `must be raised above "1.2.0" when the config or manifest changes`}},
+		},
+		{
+			name:        "version lowered",
+			version:     stringPtr("1.1.0"),
+			wantDetails: []errors.FieldError{{Field: "/version", Message: `must be raised above "1.2.0" when the config or manifest changes`}},
+		},
+		{
+			name:        "version not parseable",
+			version:     stringPtr("next"),
+			wantDetails: []errors.FieldError{{Field: "/version", Message: `must be a version like 1.2.0, got "next"`}},
+		},
+		{
+			name:    "version raised",
+			version: stringPtr("1.3.0"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := &domain.Layout{ID: "classic-scroll", Name: "Classic Scroll", Version: "1.2.0", Manifest: &manifest, IsActive: true}
+			repo := &MockLayoutRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) { return existing, nil },
+			}
+
+			_, err := NewUpdateLayoutUseCase(repo, nil, nil).Execute(context.Background(), UpdateLayoutInput{
+				ID:       "classic-scroll",
+				Version:  tt.version,
+				Manifest: &newManifest,
+				ActorID:  "admin-1",
+			})
+
+			if tt.wantDetails == nil {
+				require.NoError(t, err)
+				return
+			}
+			appErr, ok := err.(*errors.AppError)
+			require.True(t, ok, "expected AppError, got %v", err)
+			assert.Equal(t, http.StatusBadRequest, appErr.Code)
+			assert.Equal(t, tt.wantDetails, appErr.Details)
+		})
+	}
+}
+
+func TestUpdateLayoutUseCase_Execute_NoChanges_DoesNotSave(t *testing.T) {
+	existing := &domain.Layout{ID: "classic-scroll", Name: "Classic Scroll"}
+	repo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) { return existing, nil },
+		UpdateFn: func(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+			t.Fatal("Update should not be called")
+			return nil
+		},
+	}
+	audit, entries := newAuditSpy(repo)
+	name := "Classic Scroll"
+
+	output, err := NewUpdateLayoutUseCase(repo, audit, nil).Execute(context.Background(), UpdateLayoutInput{ID: "classic-scroll", Name: &name, ActorID: "admin-1"})
+
+	require.NoError(t, err)
+	assert.Empty(t, output.Changes)
+	assert.Empty(t, *entries)
+}
+
+func TestSetLayoutActiveUseCase_Execute(t *testing.T) {
+	retiredAt := time.Now()
+	tests := []struct {
+		name       string
+		layout     *domain.Layout
+		active     bool
+		wantCode   int
+		wantAction domain.AuditAction
+	}{
+		{name: "activate", layout: &domain.Layout{ID: "l", Name: "L"}, active: true, wantAction: domain.AuditActionLayoutActivate},
+		{name: "deactivate", layout: &domain.Layout{ID: "l", Name: "L", IsActive: true}, active: false, wantAction: domain.AuditActionLayoutDeactivate},
+		{name: "unchanged", layout: &domain.Layout{ID: "l", Name: "L", IsActive: true}, active: true},
+		{name: "retired", layout: &domain.Layout{ID: "l", Name: "L", RetiredAt: &retiredAt}, active: true, wantCode: http.StatusConflict},
+		{name: "not found", active: true, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockLayoutRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) { return tt.layout, nil },
+			}
+			audit, entries := newAuditSpy(repo)
+
+			output, err := NewSetLayoutActiveUseCase(repo, audit, nil).Execute(context.Background(), SetLayoutActiveInput{ID: "l", Active: tt.active, ActorID: "admin-1"})
+
+			if tt.wantCode != 0 {
+				appErr, ok := err.(*errors.AppError)
+				require.True(t, ok, "expected AppError, got %v", err)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+				assert.Empty(t, *entries)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.active, output.Layout.IsActive)
+			if tt.wantAction == "" {
+				assert.Empty(t, *entries)
+				return
+			}
+			require.Len(t, *entries, 1)
+			assert.Equal(t, tt.wantAction, (*entries)[0].Action)
+		})
+	}
+}
+
+func TestRetireLayoutUseCase_Execute_IsIdempotent(t *testing.T) {
+	// Arrange
+	layout := &domain.Layout{ID: "classic-scroll", Name: "Classic Scroll", IsActive: true}
+	updates := 0
+	repo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) { return layout, nil },
+		UpdateFn: func(ctx context.Context, l *domain.Layout, audit *domain.AuditEntry) error {
+			updates++
+			return nil
+		},
+	}
+	audit, entries := newAuditSpy(repo)
+	useCase := NewRetireLayoutUseCase(repo, audit, nil)
+
+	// Act
+	first, err := useCase.Execute(context.Background(), "classic-scroll", "admin-1")
+	require.NoError(t, err)
+	_, err = useCase.Execute(context.Background(), "classic-scroll", "admin-1")
+	require.NoError(t, err)
+
+	// Assert
+	assert.False(t, first.Layout.IsActive)
+	assert.NotNil(t, first.Layout.RetiredAt)
+	assert.Equal(t, 1, updates)
+	require.Len(t, *entries, 1)
+	assert.Equal(t, domain.AuditActionLayoutRetire, (*entries)[0].Action)
+	assert.Equal(t, []string{"retiredAt", "isActive"}, (*entries)[0].Changes)
+}
+
+func TestRetireLayoutUseCase_Execute_WriteFails_RecordsNothing(t *testing.T) {
+	// Arrange
+	repo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return &domain.Layout{ID: id, Name: "Classic Scroll", IsActive: true}, nil
+		},
+		UpdateFn: func(ctx context.Context, l *domain.Layout, audit *domain.AuditEntry) error {
+			require.NotNil(t, audit, "The audit entry is saved with the change")
+			return assert.AnError
+		},
+	}
+	audit, entries := newAuditSpy(repo)
+
+	// Act
+	_, err := NewRetireLayoutUseCase(repo, audit, nil).Execute(context.Background(), "classic-scroll", "admin-1")
+
+	// Assert
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusInternalServerError, appErr.Code)
+	assert.Empty(t, *entries)
+}
@@ -0,0 +1,148 @@
+package layout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// UpdateLayoutUseCase changes a layout's metadata, config or manifest. Activation and retirement
+// have their own use cases so each shows up as its own audit action.
+type UpdateLayoutUseCase struct {
+	layoutRepo repository.LayoutRepository
+	audit      *AuditRecorder
+	catalog    *LayoutCatalog // Optional; invalidated on every write
+}
+
+func NewUpdateLayoutUseCase(layoutRepo repository.LayoutRepository, audit *AuditRecorder, catalog *LayoutCatalog) *UpdateLayoutUseCase {
+	return &UpdateLayoutUseCase{
+		layoutRepo: layoutRepo,
+		audit:      audit,
+		catalog:    catalog,
+	}
+}
+
+// UpdateLayoutInput changes only the fields that are set
+type UpdateLayoutInput struct {
+	ID           string
+	Name         *string
+	Description  *string
+	PreviewImage *string
+	Tags         *[]string
+	Version      *string
+	Config       *json.RawMessage
+	Manifest     *json.RawMessage
+	ActorID      string
+}
+
+type UpdateLayoutOutput struct {
+	Layout *LayoutAdminDTO
+	// Changes lists the fields that changed; an update that changes nothing is not saved
+	Changes []string
+}
+
+func (uc *UpdateLayoutUseCase) Execute(ctx context.Context, input UpdateLayoutInput) (*UpdateLayoutOutput, error) {
+	layout, err := findLayoutForAdmin(ctx, uc.layoutRepo, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousVersion := layout.Version
+	var details []errors.FieldError
+	changes := []string{}
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			details = append(details, errors.FieldError{Field: "/name", Message: "is required"})
+		} else if name != layout.Name {
+			layout.Name = name
+			changes = append(changes, "name")
+		}
+	}
+	if input.Description != nil && !equalStringPtr(layout.Description, input.Description) {
+		layout.Description = input.Description
+		changes = append(changes, "description")
+	}
+	if input.PreviewImage != nil && !equalStringPtr(layout.PreviewImage, input.PreviewImage) {
+		layout.PreviewImage = input.PreviewImage
+		changes = append(changes, "previewImage")
+	}
+	if input.Tags != nil && !slices.Equal(layout.Tags, *input.Tags) {
+		layout.Tags = *input.Tags
+		changes = append(changes, "tags")
+	}
+	if input.Version != nil {
+		version := strings.TrimSpace(*input.Version)
+		if _, err := parseLayoutVersion(version); err != nil {
+			details = append(details, errors.FieldError{Field: "/version", Message: err.Error()})
+		} else if version != layout.Version {
+			layout.Version = version
+			changes = append(changes, "version")
+		}
+	}
+	if input.Config != nil {
+		config := compactJSON(*input.Config)
+		if problems := validateLayoutConfig(config); len(problems) > 0 {
+			details = append(details, problems...)
+		} else if !equalJSON(layout.Config, config) {
+			layout.Config = &config
+			changes = append(changes, "config")
+		}
+	}
+	if input.Manifest != nil {
+		manifest := compactJSON(*input.Manifest)
+		if problems := validateLayoutManifest(layout.ID, manifest); len(problems) > 0 {
+			details = append(details, problems...)
+		} else if !equalJSON(layout.Manifest, manifest) {
+			layout.Manifest = &manifest
+			changes = append(changes, "manifest")
+		}
+	}
+	// Invitations pin a version, so what a version renders must not change under them
+	if len(details) == 0 && (slices.Contains(changes, "config") || slices.Contains(changes, "manifest")) &&
+		compareLayoutVersions(layout.Version, previousVersion) <= 0 {
+		details = append(details, errors.FieldError{
+			Field:   "/version",
+			Message: fmt.Sprintf("must be raised above %q when the config or manifest changes", previousVersion),
+		})
+	}
+	if len(details) > 0 {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid layout", nil).WithDetails(details)
+	}
+
+	if len(changes) == 0 {
+		return &UpdateLayoutOutput{Layout: toLayoutAdminDTO(layout), Changes: changes}, nil
+	}
+
+	entry, err := uc.audit.layoutEntry(input.ActorID, domain.AuditActionLayoutUpdate, layout.ID, changes)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to update layout", err)
+	}
+	if err := uc.layoutRepo.Update(ctx, layout, entry); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to update layout", err)
+	}
+	uc.catalog.Invalidate()
+
+	return &UpdateLayoutOutput{
+		Layout:  toLayoutAdminDTO(layout),
+		Changes: changes,
+	}, nil
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalJSON(current *json.RawMessage, next json.RawMessage) bool {
+	return current != nil && bytes.Equal(compactJSON(*current), next)
+}
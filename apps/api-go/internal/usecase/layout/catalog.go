@@ -0,0 +1,192 @@
+package layout
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// LayoutCatalog keeps the active layouts in memory with their manifests already parsed. The
+// catalog changes only on deploy or through the admin API, so the public layout endpoints do not
+// need to read Firestore on every request. Writes in this process invalidate it right away; the
+// TTL bounds how long a change made elsewhere (cmd/sync-layouts) takes to show, and how stale
+// the popularity ranking may get.
+type LayoutCatalog struct {
+	layoutRepo repository.LayoutRepository
+	statsRepo  repository.LayoutStatsRepository // Optional; without it every layout has popularity 0
+	ttl        time.Duration
+	clock      clock.Clock
+
+	mu       sync.Mutex
+	snapshot *CatalogSnapshot
+	loadedAt time.Time
+}
+
+// NewLayoutCatalog creates a catalog cache. A TTL of zero or less disables caching.
+func NewLayoutCatalog(layoutRepo repository.LayoutRepository, statsRepo repository.LayoutStatsRepository, ttl time.Duration, clk clock.Clock) *LayoutCatalog {
+	return &LayoutCatalog{
+		layoutRepo: layoutRepo,
+		statsRepo:  statsRepo,
+		ttl:        ttl,
+		clock:      clk,
+	}
+}
+
+// CatalogSnapshot is the catalog as loaded at one point in time. It is shared between requests
+// and must not be modified.
+type CatalogSnapshot struct {
+	Entries []*CatalogEntry
+}
+
+// Fonts returns the fonts the themes of the catalog's layouts use, sorted. They are the fonts the
+// renderer loads, so custom themes are limited to them.
+func (s *CatalogSnapshot) Fonts() []string {
+	seen := map[string]bool{}
+	for _, entry := range s.Entries {
+		themes, _ := entry.Manifest["themes"].([]interface{})
+		for _, t := range themes {
+			theme, _ := t.(map[string]interface{})
+			fonts, _ := theme["fonts"].(map[string]interface{})
+			for _, font := range fonts {
+				if name, ok := font.(string); ok && name != "" {
+					seen[name] = true
+				}
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// CatalogEntry is an active layout whose manifest parses, in repository order
+type CatalogEntry struct {
+	Summary *LayoutSummaryDTO
+	// Manifest is nil when the layout has no manifest
+	Manifest   map[string]interface{}
+	Popularity int
+	CreatedAt  time.Time
+	// searchText is the lowercased name, description, category and tags that searches match
+	searchText string
+}
+
+// IsReady reports whether the layout is offered to users rather than shown as coming soon
+func (e *CatalogEntry) IsReady() bool {
+	if e.Summary.Status != nil && *e.Summary.Status == "ready" {
+		return true
+	}
+	return e.Summary.IsAvailable != nil && *e.Summary.IsAvailable
+}
+
+// Get returns the cached snapshot, loading it from the repository when it is missing or expired
+func (c *LayoutCatalog) Get(ctx context.Context) (*CatalogSnapshot, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Loading under the lock lets concurrent requests after an expiry share one load
+	if c.snapshot != nil && c.clock.Now().Sub(c.loadedAt) < c.ttl {
+		return c.snapshot, nil
+	}
+
+	snapshot, err := c.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.ttl > 0 {
+		c.snapshot = snapshot
+		c.loadedAt = c.clock.Now()
+	}
+	return snapshot, nil
+}
+
+// Invalidate drops the cached snapshot so the next Get reloads it. Safe to call on a nil catalog.
+func (c *LayoutCatalog) Invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshot = nil
+}
+
+func (c *LayoutCatalog) load(ctx context.Context) (*CatalogSnapshot, error) {
+	layouts, err := c.layoutRepo.FindAll(ctx)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get layouts", err)
+	}
+
+	popularities := c.loadPopularities(ctx)
+	snapshot := &CatalogSnapshot{Entries: make([]*CatalogEntry, 0, len(layouts))}
+	for _, layout := range layouts {
+		summary, err := ToLayoutSummaryDTO(layout)
+		if err != nil {
+			// Skip layouts with invalid manifest
+			continue
+		}
+		manifest, err := ToManifestMap(layout)
+		if err != nil {
+			continue
+		}
+		popularity := popularities[layout.ID]
+		summary.Popularity = &popularity
+		snapshot.Entries = append(snapshot.Entries, &CatalogEntry{
+			Summary:    summary,
+			Manifest:   manifest,
+			Popularity: popularity,
+			CreatedAt:  layout.CreatedAt,
+			searchText: catalogSearchText(layout, summary),
+		})
+	}
+	return snapshot, nil
+}
+
+// loadPopularities returns the popularity of every layout with stats. The ranking is not worth
+// failing the catalog for, so errors are logged and every layout scores 0.
+func (c *LayoutCatalog) loadPopularities(ctx context.Context) map[string]int {
+	popularities := map[string]int{}
+	if c.statsRepo == nil {
+		return popularities
+	}
+	stats, err := c.statsRepo.FindAll(ctx)
+	if err != nil {
+		logger.GetLogger().Warn("Failed to load layout stats", zap.Error(err))
+		return popularities
+	}
+	for _, s := range stats {
+		popularities[s.LayoutID] = s.Popularity()
+	}
+	return popularities
+}
+
+func catalogSearchText(layout *domain.Layout, summary *LayoutSummaryDTO) string {
+	// The stored name and tags too: the manifest may override the name and the summary keeps
+	// only three tags
+	parts := []string{layout.Name, summary.Name}
+	if summary.Description != nil {
+		parts = append(parts, *summary.Description)
+	}
+	if summary.Category != nil {
+		parts = append(parts, *summary.Category)
+	}
+	parts = append(parts, layout.Tags...)
+	return strings.ToLower(strings.Join(parts, "\n"))
+}
+
+// Matches reports whether every whitespace-separated term of query occurs in the layout's name,
+// description, category or tags, ignoring case. An empty query matches every layout.
+func (e *CatalogEntry) Matches(query string) bool {
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(e.searchText, term) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayoutCatalog_Get_CachesUntilTTLOrInvalidate(t *testing.T) {
+	// Arrange
+	manifest := json.RawMessage(`{"name": "Classic Scroll", "status": "ready"}`)
+	loads := 0
+	repo := &MockLayoutRepository{
+		FindAllFn: func(ctx context.Context) ([]*domain.Layout, error) {
+			loads++
+			return []*domain.Layout{{ID: "classic-scroll", Name: "Classic Scroll", Manifest: &manifest, IsActive: true}}, nil
+		},
+	}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	catalog := NewLayoutCatalog(repo, nil, time.Minute, &MockClock{NowFn: func() time.Time { return now }})
+	ctx := context.Background()
+
+	// Act & Assert
+	first, err := catalog.Get(ctx)
+	require.NoError(t, err)
+	require.Len(t, first.Entries, 1)
+	assert.True(t, first.Entries[0].IsReady())
+	assert.Equal(t, "Classic Scroll", first.Entries[0].Manifest["name"])
+
+	now = now.Add(59 * time.Second)
+	second, err := catalog.Get(ctx)
+	require.NoError(t, err)
+	assert.Same(t, first, second, "served from the cache within the TTL")
+	assert.Equal(t, 1, loads)
+
+	now = now.Add(time.Second)
+	_, err = catalog.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads, "reloaded after the TTL")
+
+	catalog.Invalidate()
+	_, err = catalog.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, loads, "reloaded after invalidation")
+}
+
+func TestLayoutCatalog_Get_ZeroTTLDoesNotCache(t *testing.T) {
+	loads := 0
+	repo := &MockLayoutRepository{
+		FindAllFn: func(ctx context.Context) ([]*domain.Layout, error) {
+			loads++
+			return nil, nil
+		},
+	}
+	catalog := NewLayoutCatalog(repo, nil, 0, &MockClock{})
+
+	for i := 0; i < 2; i++ {
+		_, err := catalog.Get(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, loads)
+}
+
+func TestLayoutCatalog_Invalidate_NilCatalog(t *testing.T) {
+	var catalog *LayoutCatalog
+	assert.NotPanics(t, catalog.Invalidate)
+}
+
+func TestLayoutCatalog_Get_StatsFailureRanksEverythingZero(t *testing.T) {
+	manifest := json.RawMessage(`{"status": "ready"}`)
+	repo := &MockLayoutRepository{
+		FindAllFn: func(ctx context.Context) ([]*domain.Layout, error) {
+			return []*domain.Layout{{ID: "classic-scroll", Name: "Classic Scroll", Manifest: &manifest, IsActive: true}}, nil
+		},
+	}
+	stats := &MockLayoutStatsRepository{
+		FindAllFn: func(ctx context.Context) ([]*domain.LayoutStats, error) {
+			return nil, stderrors.New("firestore unavailable")
+		},
+	}
+
+	snapshot, err := NewLayoutCatalog(repo, stats, 0, &MockClock{}).Get(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, snapshot.Entries, 1)
+	assert.Equal(t, 0, snapshot.Entries[0].Popularity)
+}
+
+func TestCatalogSnapshot_Fonts(t *testing.T) {
+	classic := json.RawMessage(`{"themes": [
+		{"id": "royal-gold", "fonts": {"heading": "Playfair Display", "body": "Poppins", "script": "Great Vibes"}},
+		{"id": "rose-blush", "fonts": {"heading": "Cormorant Garamond", "body": "Lato", "script": ""}}
+	]}`)
+	editorial := json.RawMessage(`{"themes": [{"id": "editorial-classic", "fonts": {"heading": "Playfair Display", "body": "Inter"}}]}`)
+	repo := &MockLayoutRepository{
+		FindAllFn: func(ctx context.Context) ([]*domain.Layout, error) {
+			return []*domain.Layout{
+				{ID: "classic-scroll", Name: "Classic Scroll", Manifest: &classic, IsActive: true},
+				{ID: "editorial-elegance", Name: "Editorial Elegance", Manifest: &editorial, IsActive: true},
+				{ID: "blank", Name: "Blank", IsActive: true},
+			}, nil
+		},
+	}
+
+	snapshot, err := NewLayoutCatalog(repo, nil, 0, &MockClock{}).Get(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Cormorant Garamond", "Great Vibes", "Inter", "Lato", "Playfair Display", "Poppins"}, snapshot.Fonts())
+}
@@ -14,4 +14,6 @@ type LayoutSummaryDTO struct {
 	IsComingSoon *bool         `json:"isComingSoon,omitempty"`
 	IsFeatured   *bool         `json:"isFeatured,omitempty"`
 	Themes       []interface{} `json:"themes,omitempty"`
+	// Popularity ranks the layout by selections and publishes; only set in catalog listings
+	Popularity *int `json:"popularity,omitempty"`
 }
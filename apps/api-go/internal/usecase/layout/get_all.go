@@ -1,24 +1,44 @@
 package layout
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
 )
 
 type GetAllLayoutsUseCase struct {
-	layoutRepo repository.LayoutRepository
+	catalog *LayoutCatalog
 }
 
-func NewGetAllLayoutsUseCase(layoutRepo repository.LayoutRepository) *GetAllLayoutsUseCase {
+func NewGetAllLayoutsUseCase(catalog *LayoutCatalog) *GetAllLayoutsUseCase {
 	return &GetAllLayoutsUseCase{
-		layoutRepo: layoutRepo,
+		catalog: catalog,
 	}
 }
 
 type GetAllLayoutsInput struct {
 	Category *string
 	Featured *bool
+	// Query keeps the layouts whose name, description, category or tags contain every term
+	Query string
+	// SortField is "popularity", "createdAt" or "name"; empty keeps the catalog order
+	SortField     string
+	SortDirection repository.SortDirection
+}
+
+// layoutSorts compares catalog entries by each supported sort field, ascending
+var layoutSorts = map[string]func(a, b *CatalogEntry) int{
+	"popularity": func(a, b *CatalogEntry) int { return cmp.Compare(a.Popularity, b.Popularity) },
+	"createdAt":  func(a, b *CatalogEntry) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"name": func(a, b *CatalogEntry) int {
+		return strings.Compare(strings.ToLower(a.Summary.Name), strings.ToLower(b.Summary.Name))
+	},
 }
 
 type GetAllLayoutsOutput struct {
@@ -26,27 +46,42 @@ type GetAllLayoutsOutput struct {
 	Categories []string
 }
 
+// Execute lists the ready layouts of the cached catalog. Sorting is stable, so ties keep the
+// catalog order. Categories are those of the listed layouts, sorted with "all" first, so the same
+// catalog always gives the same response.
 func (uc *GetAllLayoutsUseCase) Execute(ctx context.Context, input GetAllLayoutsInput) (*GetAllLayoutsOutput, error) {
-	catalog, err := uc.getLayoutCatalog()
+	var compare func(a, b *CatalogEntry) int
+	if input.SortField != "" {
+		var ok bool
+		if compare, ok = layoutSorts[input.SortField]; !ok {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, fmt.Sprintf("Cannot sort layouts by %q", input.SortField), nil)
+		}
+	}
+
+	catalog, err := uc.catalog.Get(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	entries := slices.Clone(catalog.Entries)
+	if compare != nil {
+		slices.SortStableFunc(entries, func(a, b *CatalogEntry) int {
+			if input.SortDirection == repository.SortDesc {
+				return compare(b, a)
+			}
+			return compare(a, b)
+		})
+	}
+
 	var filteredLayouts []*LayoutSummaryDTO
 	categories := make(map[string]bool)
-	categories["all"] = true
 
-	for _, manifest := range catalog {
+	for _, entry := range entries {
 		// Filter out coming-soon layouts - only show ready layouts
-		isReady := false
-		if manifest.Status != nil && *manifest.Status == "ready" {
-			isReady = true
-		} else if manifest.IsAvailable != nil && *manifest.IsAvailable {
-			isReady = true
-		}
-		if !isReady {
+		if !entry.IsReady() {
 			continue
 		}
+		manifest := entry.Summary
 
 		// Filter by category
 		if input.Category != nil && *input.Category != "all" {
@@ -62,38 +97,25 @@ func (uc *GetAllLayoutsUseCase) Execute(ctx context.Context, input GetAllLayouts
 			}
 		}
 
+		if !entry.Matches(input.Query) {
+			continue
+		}
+
 		filteredLayouts = append(filteredLayouts, manifest)
-		if manifest.Category != nil {
+		if manifest.Category != nil && *manifest.Category != "all" {
 			categories[*manifest.Category] = true
 		}
 	}
 
-	categoryList := make([]string, 0, len(categories))
+	categoryList := make([]string, 0, len(categories)+1)
 	for cat := range categories {
 		categoryList = append(categoryList, cat)
 	}
+	sort.Strings(categoryList)
+	categoryList = append([]string{"all"}, categoryList...)
 
 	return &GetAllLayoutsOutput{
 		Layouts:    filteredLayouts,
 		Categories: categoryList,
 	}, nil
 }
-
-func (uc *GetAllLayoutsUseCase) getLayoutCatalog() ([]*LayoutSummaryDTO, error) {
-	layouts, err := uc.layoutRepo.FindAll(context.Background())
-	if err != nil {
-		return nil, err
-	}
-
-	var catalog []*LayoutSummaryDTO
-	for _, layout := range layouts {
-		dto, err := ToLayoutSummaryDTO(layout)
-		if err != nil {
-			// Skip layouts with invalid manifest
-			continue
-		}
-		catalog = append(catalog, dto)
-	}
-
-	return catalog, nil
-}
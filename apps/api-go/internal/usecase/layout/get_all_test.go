@@ -3,10 +3,13 @@ package layout
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,7 +34,7 @@ func TestGetAllLayoutsUseCase_Execute_NoFilters_ReturnsAllLayouts(t *testing.T)
 		},
 	}
 
-	useCase := NewGetAllLayoutsUseCase(mockRepo)
+	useCase := NewGetAllLayoutsUseCase(NewLayoutCatalog(mockRepo, nil, 0, &MockClock{}))
 	input := GetAllLayoutsInput{}
 
 	// Act
@@ -42,3 +45,93 @@ func TestGetAllLayoutsUseCase_Execute_NoFilters_ReturnsAllLayouts(t *testing.T)
 	require.NotNil(t, output, "Output should not be nil")
 	assert.GreaterOrEqual(t, len(output.Layouts), 0, "Should return layouts")
 }
+
+func searchableLayouts() *MockLayoutRepository {
+	layout := func(id, name string, tags []string, createdAt time.Time) *domain.Layout {
+		manifest := json.RawMessage(`{"status": "ready", "description": "A ` + name + ` invitation"}`)
+		return &domain.Layout{ID: id, Name: name, Tags: tags, Manifest: &manifest, IsActive: true, CreatedAt: createdAt}
+	}
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &MockLayoutRepository{
+		FindAllFn: func(ctx context.Context) ([]*domain.Layout, error) {
+			return []*domain.Layout{
+				layout("classic-scroll", "Classic Scroll", []string{"traditional", "gold", "scroll", "floral"}, day),
+				layout("editorial-elegance", "Editorial Elegance", []string{"modern", "magazine"}, day.AddDate(0, 2, 0)),
+				layout("garden-party", "Garden Party", []string{"floral", "outdoor"}, day.AddDate(0, 1, 0)),
+			}, nil
+		},
+	}
+}
+
+func TestGetAllLayoutsUseCase_Execute_SearchAndSort(t *testing.T) {
+	stats := &MockLayoutStatsRepository{
+		FindAllFn: func(ctx context.Context) ([]*domain.LayoutStats, error) {
+			return []*domain.LayoutStats{
+				{LayoutID: "garden-party", Selections: 2, Publishes: 3},
+				{LayoutID: "editorial-elegance", Selections: 5},
+			}, nil
+		},
+	}
+	useCase := NewGetAllLayoutsUseCase(NewLayoutCatalog(searchableLayouts(), stats, 0, &MockClock{}))
+
+	tests := []struct {
+		name    string
+		input   GetAllLayoutsInput
+		wantIDs []string
+	}{
+		{name: "no query keeps the catalog order", wantIDs: []string{"classic-scroll", "editorial-elegance", "garden-party"}},
+		{name: "query matches tags beyond the first three", input: GetAllLayoutsInput{Query: "FLORAL"}, wantIDs: []string{"classic-scroll", "garden-party"}},
+		{name: "every term must match", input: GetAllLayoutsInput{Query: "floral party"}, wantIDs: []string{"garden-party"}},
+		{name: "query matches the description", input: GetAllLayoutsInput{Query: "elegance invitation"}, wantIDs: []string{"editorial-elegance"}},
+		{name: "no match", input: GetAllLayoutsInput{Query: "beach"}, wantIDs: nil},
+		{
+			name:    "most popular first",
+			input:   GetAllLayoutsInput{SortField: "popularity", SortDirection: repository.SortDesc},
+			wantIDs: []string{"garden-party", "editorial-elegance", "classic-scroll"},
+		},
+		{
+			name:    "newest first",
+			input:   GetAllLayoutsInput{SortField: "createdAt", SortDirection: repository.SortDesc},
+			wantIDs: []string{"editorial-elegance", "garden-party", "classic-scroll"},
+		},
+		{
+			name:    "search then sort by name",
+			input:   GetAllLayoutsInput{Query: "floral", SortField: "name", SortDirection: repository.SortDesc},
+			wantIDs: []string{"garden-party", "classic-scroll"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := useCase.Execute(context.Background(), tt.input)
+
+			require.NoError(t, err)
+			var ids []string
+			for _, layout := range output.Layouts {
+				ids = append(ids, layout.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+
+	t.Run("popularity is listed", func(t *testing.T) {
+		output, err := useCase.Execute(context.Background(), GetAllLayoutsInput{SortField: "popularity", SortDirection: repository.SortDesc})
+
+		require.NoError(t, err)
+		require.NotNil(t, output.Layouts[0].Popularity)
+		assert.Equal(t, 11, *output.Layouts[0].Popularity)
+		require.NotNil(t, output.Layouts[2].Popularity)
+		assert.Equal(t, 0, *output.Layouts[2].Popularity)
+	})
+}
+
+func TestGetAllLayoutsUseCase_Execute_UnknownSort_ReturnsBadRequest(t *testing.T) {
+	useCase := NewGetAllLayoutsUseCase(NewLayoutCatalog(searchableLayouts(), nil, 0, &MockClock{}))
+
+	_, err := useCase.Execute(context.Background(), GetAllLayoutsInput{SortField: "rating"})
+
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "expected AppError, got %v", err)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	assert.Equal(t, `Cannot sort layouts by "rating"`, appErr.Message)
+}
@@ -3,6 +3,7 @@ package layout
 import (
 	"context"
 
+	"github.com/sacred-vows/api-go/internal/domain"
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 	"github.com/sacred-vows/api-go/pkg/errors"
 )
@@ -21,7 +22,9 @@ type GetLayoutManifestOutput struct {
 	Manifest map[string]interface{}
 }
 
-func (uc *GetLayoutManifestUseCase) Execute(ctx context.Context, id string) (*GetLayoutManifestOutput, error) {
+// Execute returns the manifest of the given layout version, which invitations pinned to an
+// older version render with. An empty version means the current one.
+func (uc *GetLayoutManifestUseCase) Execute(ctx context.Context, id, version string) (*GetLayoutManifestOutput, error) {
 	layout, err := uc.layoutRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get layout", err)
@@ -29,6 +32,13 @@ func (uc *GetLayoutManifestUseCase) Execute(ctx context.Context, id string) (*Ge
 	if layout == nil {
 		return nil, errors.Wrap(errors.ErrNotFound.Code, "Layout not found", nil)
 	}
+	layout, err = findLayoutVersion(ctx, uc.layoutRepo, layout, version)
+	if err != nil {
+		return nil, err
+	}
+	if layout == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Layout version not found", nil)
+	}
 
 	manifest, err := ToManifestMap(layout)
 	if err != nil {
@@ -42,3 +52,16 @@ func (uc *GetLayoutManifestUseCase) Execute(ctx context.Context, id string) (*Ge
 		Manifest: manifest,
 	}, nil
 }
+
+// findLayoutVersion returns current as it was at version, or current itself when version is
+// empty or the current one. Nil when that version was never saved.
+func findLayoutVersion(ctx context.Context, layoutRepo repository.LayoutRepository, current *domain.Layout, version string) (*domain.Layout, error) {
+	if version == "" || version == current.Version {
+		return current, nil
+	}
+	layout, err := layoutRepo.FindVersion(ctx, current.ID, version)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get layout version", err)
+	}
+	return layout, nil
+}
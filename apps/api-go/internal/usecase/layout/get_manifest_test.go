@@ -0,0 +1,64 @@
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLayoutManifestUseCase_Execute_Versions(t *testing.T) {
+	currentManifest := json.RawMessage(`{"id":"classic-scroll","sections":["hero","story"]}`)
+	oldManifest := json.RawMessage(`{"id":"classic-scroll","sections":["hero"]}`)
+	repo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			if id != "classic-scroll" {
+				return nil, nil
+			}
+			return &domain.Layout{ID: id, Name: "Classic Scroll", Version: "1.1.0", Manifest: &currentManifest}, nil
+		},
+		FindVersionFn: func(ctx context.Context, id, version string) (*domain.Layout, error) {
+			if version == "1.0.0" {
+				return &domain.Layout{ID: id, Name: "Classic Scroll", Version: version, Manifest: &oldManifest}, nil
+			}
+			return nil, nil
+		},
+	}
+	useCase := NewGetLayoutManifestUseCase(repo)
+
+	tests := []struct {
+		name         string
+		id           string
+		version      string
+		wantSections []interface{}
+		wantCode     int
+		wantMessage  string
+	}{
+		{name: "current by default", id: "classic-scroll", wantSections: []interface{}{"hero", "story"}},
+		{name: "current version", id: "classic-scroll", version: "1.1.0", wantSections: []interface{}{"hero", "story"}},
+		{name: "prior version", id: "classic-scroll", version: "1.0.0", wantSections: []interface{}{"hero"}},
+		{name: "unknown version", id: "classic-scroll", version: "0.9.0", wantCode: http.StatusNotFound, wantMessage: "Layout version not found"},
+		{name: "unknown layout", id: "garden-party", wantCode: http.StatusNotFound, wantMessage: "Layout not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := useCase.Execute(context.Background(), tt.id, tt.version)
+
+			if tt.wantCode != 0 {
+				appErr, ok := err.(*errors.AppError)
+				require.True(t, ok, "expected AppError, got %v", err)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+				assert.Equal(t, tt.wantMessage, appErr.Message)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSections, output.Manifest["sections"])
+		})
+	}
+}
@@ -2,17 +2,15 @@ package layout
 
 import (
 	"context"
-
-	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 )
 
 type GetManifestsUseCase struct {
-	layoutRepo repository.LayoutRepository
+	catalog *LayoutCatalog
 }
 
-func NewGetManifestsUseCase(layoutRepo repository.LayoutRepository) *GetManifestsUseCase {
+func NewGetManifestsUseCase(catalog *LayoutCatalog) *GetManifestsUseCase {
 	return &GetManifestsUseCase{
-		layoutRepo: layoutRepo,
+		catalog: catalog,
 	}
 }
 
@@ -20,40 +18,20 @@ type GetManifestsOutput struct {
 	Manifests []map[string]interface{}
 }
 
+// Execute returns the manifests of the ready layouts of the cached catalog
 func (uc *GetManifestsUseCase) Execute(ctx context.Context) (*GetManifestsOutput, error) {
-	layouts, err := uc.layoutRepo.FindAll(ctx)
+	catalog, err := uc.catalog.Get(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var manifests []map[string]interface{}
-	for _, layout := range layouts {
-		// Convert to DTO to check status
-		dto, err := ToLayoutSummaryDTO(layout)
-		if err != nil {
-			// Skip layouts with invalid manifest
-			continue
-		}
-
+	for _, entry := range catalog.Entries {
 		// Filter out coming-soon layouts - only show ready layouts
-		isReady := false
-		if dto.Status != nil && *dto.Status == "ready" {
-			isReady = true
-		} else if dto.IsAvailable != nil && *dto.IsAvailable {
-			isReady = true
-		}
-
-		if !isReady {
+		if !entry.IsReady() || entry.Manifest == nil {
 			continue
 		}
-
-		// Convert to map using ToManifestMap
-		manifestMap, err := ToManifestMap(layout)
-		if err != nil || manifestMap == nil {
-			continue
-		}
-
-		manifests = append(manifests, manifestMap)
+		manifests = append(manifests, entry.Manifest)
 	}
 
 	return &GetManifestsOutput{
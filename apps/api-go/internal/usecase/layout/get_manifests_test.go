@@ -66,7 +66,7 @@ func TestGetManifestsUseCase_Execute_IncludesPresets(t *testing.T) {
 		},
 	}
 
-	useCase := NewGetManifestsUseCase(mockRepo)
+	useCase := NewGetManifestsUseCase(NewLayoutCatalog(mockRepo, nil, 0, &MockClock{}))
 
 	// Act
 	output, err := useCase.Execute(context.Background())
@@ -127,7 +127,7 @@ func TestGetManifestsUseCase_Execute_FiltersNonReadyLayouts(t *testing.T) {
 		},
 	}
 
-	useCase := NewGetManifestsUseCase(mockRepo)
+	useCase := NewGetManifestsUseCase(NewLayoutCatalog(mockRepo, nil, 0, &MockClock{}))
 
 	// Act
 	output, err := useCase.Execute(context.Background())
@@ -0,0 +1,374 @@
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// LayoutMigration is how a layout manifest says to carry data over from another layout. Manifests
+// declare them under "migrations", keyed by the source layout ID:
+//
+//	"migrations": {
+//	  "classic-scroll": {
+//	    "sections": {"venue": "location", "header": ""},
+//	    "themes": {"royal-gold": "editorial-classic"},
+//	    "fields": [{"from": "/customContent/fathersLetter", "to": ""}]
+//	  }
+//	}
+//
+// An empty target drops the section or field. Sections and themes without a mapping keep their
+// ID when the target layout has it.
+type LayoutMigration struct {
+	Sections map[string]string `json:"sections"`
+	Themes   map[string]string `json:"themes"`
+	Fields   []FieldMapping    `json:"fields"`
+}
+
+// FieldMapping moves the value at the JSON Pointer From to To, or drops it when To is empty.
+// Only object members can be addressed.
+type FieldMapping struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// LayoutMigrationReport describes what a layout switch changes
+type LayoutMigrationReport struct {
+	FromLayoutID string `json:"fromLayoutId"`
+	ToLayoutID   string `json:"toLayoutId"`
+	// AddedSections are sections of the new layout the invitation did not have
+	AddedSections []string `json:"addedSections"`
+	// NotCarriedOver lists content the new layout cannot show
+	NotCarriedOver []ContentNotCarriedOver `json:"notCarriedOver"`
+}
+
+// ContentNotCarriedOver is one piece of content lost in a layout switch. Path is a JSON Pointer
+// into the invitation data before the switch.
+type ContentNotCarriedOver struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// MigrateInvitationDataUseCase transforms invitation data from one layout to another using the
+// migration the target manifest declares for the source layout
+type MigrateInvitationDataUseCase struct {
+	layoutRepo repository.LayoutRepository
+}
+
+func NewMigrateInvitationDataUseCase(layoutRepo repository.LayoutRepository) *MigrateInvitationDataUseCase {
+	return &MigrateInvitationDataUseCase{
+		layoutRepo: layoutRepo,
+	}
+}
+
+type MigrateInvitationDataOutput struct {
+	Data   json.RawMessage
+	Report *LayoutMigrationReport
+}
+
+// Execute returns the migrated data without saving anything. The target layout must be active
+// and not retired. Universal content (couple, wedding,
+// events, ...) is kept as is; sections, theme and the mapped fields are rewritten.
+func (uc *MigrateInvitationDataUseCase) Execute(ctx context.Context, fromLayoutID, toLayoutID string, data json.RawMessage) (*MigrateInvitationDataOutput, error) {
+	target, err := uc.layoutRepo.FindByID(ctx, toLayoutID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get layout", err)
+	}
+	if target == nil {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Unknown layout", nil).WithDetails([]errors.FieldError{
+			{Field: "/layoutId", Message: fmt.Sprintf("layout %q does not exist", toLayoutID)},
+		})
+	}
+	if err := checkSelectable(target); err != nil {
+		return nil, err
+	}
+
+	manifest, err := parseMigrationManifest(target)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to parse manifest", err)
+	}
+
+	var dataMap map[string]interface{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &dataMap); err != nil {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid invitation data", err)
+		}
+	}
+	if dataMap == nil {
+		dataMap = map[string]interface{}{}
+	}
+
+	report := migrateData(dataMap, fromLayoutID, toLayoutID, manifest)
+
+	migrated, err := json.Marshal(dataMap)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to marshal invitation data", err)
+	}
+	return &MigrateInvitationDataOutput{Data: migrated, Report: report}, nil
+}
+
+// migrationManifest is the part of a layout manifest a migration needs
+type migrationManifest struct {
+	Sections []struct {
+		ID             string `json:"id"`
+		Required       bool   `json:"required"`
+		Enabled        *bool  `json:"enabled"`
+		DefaultEnabled *bool  `json:"defaultEnabled"`
+	} `json:"sections"`
+	Presets []struct {
+		SectionIDs []string `json:"sectionIds"`
+	} `json:"presets"`
+	Themes     []map[string]interface{}   `json:"themes"`
+	Migrations map[string]LayoutMigration `json:"migrations"`
+}
+
+func parseMigrationManifest(layout *domain.Layout) (*migrationManifest, error) {
+	manifest := &migrationManifest{}
+	if layout.Manifest == nil {
+		return manifest, nil
+	}
+	if err := json.Unmarshal(*layout.Manifest, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// migrateData rewrites data in place for the target layout and reports what was lost
+func migrateData(data map[string]interface{}, fromLayoutID, toLayoutID string, manifest *migrationManifest) *LayoutMigrationReport {
+	report := &LayoutMigrationReport{
+		FromLayoutID:   fromLayoutID,
+		ToLayoutID:     toLayoutID,
+		AddedSections:  []string{},
+		NotCarriedOver: []ContentNotCarriedOver{},
+	}
+	migration := manifest.Migrations[fromLayoutID]
+
+	for _, field := range migration.Fields {
+		value, ok := removePointer(data, field.From)
+		if !ok {
+			continue
+		}
+		if field.To == "" {
+			report.NotCarriedOver = append(report.NotCarriedOver, ContentNotCarriedOver{
+				Path:   field.From,
+				Reason: fmt.Sprintf("not used by layout %q", toLayoutID),
+			})
+			continue
+		}
+		if !setPointer(data, field.To, value) {
+			report.NotCarriedOver = append(report.NotCarriedOver, ContentNotCarriedOver{
+				Path:   field.From,
+				Reason: fmt.Sprintf("%s already has content", field.To),
+			})
+		}
+	}
+
+	layoutConfig, ok := data["layoutConfig"].(map[string]interface{})
+	if !ok {
+		return report
+	}
+	migrateSections(layoutConfig, migration, manifest, toLayoutID, report)
+	migrateTheme(layoutConfig, migration, manifest, toLayoutID, report)
+	return report
+}
+
+func migrateSections(layoutConfig map[string]interface{}, migration LayoutMigration, manifest *migrationManifest, toLayoutID string, report *LayoutMigrationReport) {
+	available := map[string]bool{}
+	for _, section := range manifest.Sections {
+		available[section.ID] = true
+	}
+	for _, preset := range manifest.Presets {
+		for _, id := range preset.SectionIDs {
+			available[id] = true
+		}
+	}
+
+	sections, _ := layoutConfig["sections"].([]interface{})
+	migrated := []interface{}{}
+	present := map[string]bool{}
+	maxOrder := -1.0
+	for i, raw := range sections {
+		section, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := section["id"].(string)
+		target, mapped := migration.Sections[id]
+		if !mapped {
+			target = id
+		}
+		enabled, _ := section["enabled"].(bool)
+
+		if target == "" || !available[target] || present[target] {
+			// Disabled sections were not shown, so dropping them loses nothing
+			if enabled {
+				reason := fmt.Sprintf("section %q is not available in layout %q", id, toLayoutID)
+				if present[target] {
+					reason = fmt.Sprintf("section %q is already shown as %q", id, target)
+				}
+				report.NotCarriedOver = append(report.NotCarriedOver, ContentNotCarriedOver{
+					Path:   fmt.Sprintf("/layoutConfig/sections/%d", i),
+					Reason: reason,
+				})
+			}
+			continue
+		}
+
+		section["id"] = target
+		present[target] = true
+		if order, ok := section["order"].(float64); ok && order > maxOrder {
+			maxOrder = order
+		}
+		migrated = append(migrated, section)
+	}
+
+	// Sections new to the invitation start as the manifest enables them, after the existing ones
+	for _, section := range manifest.Sections {
+		if present[section.ID] {
+			continue
+		}
+		enabled := section.Required
+		if section.Enabled != nil {
+			enabled = enabled || *section.Enabled
+		} else if section.DefaultEnabled != nil {
+			enabled = enabled || *section.DefaultEnabled
+		}
+		maxOrder++
+		migrated = append(migrated, map[string]interface{}{"id": section.ID, "enabled": enabled, "order": maxOrder})
+		present[section.ID] = true
+		report.AddedSections = append(report.AddedSections, section.ID)
+	}
+
+	layoutConfig["sections"] = migrated
+}
+
+func migrateTheme(layoutConfig map[string]interface{}, migration LayoutMigration, manifest *migrationManifest, toLayoutID string, report *LayoutMigrationReport) {
+	if _, ok := layoutConfig["themes"]; ok {
+		// The builder keeps a copy of the manifest themes here
+		if len(manifest.Themes) > 0 {
+			themes := make([]interface{}, len(manifest.Themes))
+			for i, theme := range manifest.Themes {
+				themes[i] = theme
+			}
+			layoutConfig["themes"] = themes
+		} else {
+			delete(layoutConfig, "themes")
+		}
+	}
+
+	theme, ok := layoutConfig["theme"].(map[string]interface{})
+	if !ok || len(manifest.Themes) == 0 {
+		return
+	}
+	preset, _ := theme["preset"].(string)
+	// Custom colors and fonts belong to the couple, not to the layout
+	if preset == "custom" {
+		return
+	}
+
+	themesByID := map[string]map[string]interface{}{}
+	for _, t := range manifest.Themes {
+		if id, _ := t["id"].(string); id != "" {
+			themesByID[id] = t
+		}
+	}
+
+	target, mapped := migration.Themes[preset]
+	if !mapped {
+		target = preset
+	}
+	next, ok := themesByID[target]
+	if !ok {
+		next = defaultTheme(manifest.Themes)
+		if preset != "" && preset != "default" {
+			report.NotCarriedOver = append(report.NotCarriedOver, ContentNotCarriedOver{
+				Path:   "/layoutConfig/theme/preset",
+				Reason: fmt.Sprintf("theme %q is not available in layout %q; using %q", preset, toLayoutID, next["id"]),
+			})
+		}
+	}
+
+	theme["preset"] = next["id"]
+	if colors, ok := next["colors"]; ok {
+		theme["colors"] = colors
+	}
+	if fonts, ok := next["fonts"]; ok {
+		theme["fonts"] = fonts
+	}
+}
+
+func defaultTheme(themes []map[string]interface{}) map[string]interface{} {
+	for _, theme := range themes {
+		if isDefault, _ := theme["isDefault"].(bool); isDefault {
+			return theme
+		}
+	}
+	return themes[0]
+}
+
+// pointerTokens splits a JSON Pointer into unescaped reference tokens
+func pointerTokens(pointer string) []string {
+	if pointer == "" || !strings.HasPrefix(pointer, "/") {
+		return nil
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
+
+// removePointer removes and returns the object member at pointer
+func removePointer(data map[string]interface{}, pointer string) (interface{}, bool) {
+	tokens := pointerTokens(pointer)
+	if len(tokens) == 0 {
+		return nil, false
+	}
+	parent := data
+	for _, token := range tokens[:len(tokens)-1] {
+		next, ok := parent[token].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		parent = next
+	}
+	last := tokens[len(tokens)-1]
+	value, ok := parent[last]
+	if ok {
+		delete(parent, last)
+	}
+	return value, ok
+}
+
+// setPointer sets the object member at pointer, creating missing parent objects. Existing
+// content at the target is kept, so mappings never overwrite what the couple wrote; it reports
+// whether the value was set.
+func setPointer(data map[string]interface{}, pointer string, value interface{}) bool {
+	tokens := pointerTokens(pointer)
+	if len(tokens) == 0 {
+		return false
+	}
+	parent := data
+	for _, token := range tokens[:len(tokens)-1] {
+		next, ok := parent[token].(map[string]interface{})
+		if !ok {
+			if _, exists := parent[token]; exists {
+				return false
+			}
+			next = map[string]interface{}{}
+			parent[token] = next
+		}
+		parent = next
+	}
+	last := tokens[len(tokens)-1]
+	if _, exists := parent[last]; exists {
+		return false
+	}
+	parent[last] = value
+	return true
+}
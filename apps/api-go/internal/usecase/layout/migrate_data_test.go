@@ -0,0 +1,159 @@
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const editorialManifest = `{
+	"id": "editorial-elegance",
+	"sections": [
+		{"id": "hero", "required": true, "enabled": true},
+		{"id": "couple", "enabled": false},
+		{"id": "location", "enabled": true},
+		{"id": "footer", "required": true, "enabled": true}
+	],
+	"presets": [{"id": "short", "sectionIds": ["hero", "countdown", "footer"]}],
+	"themes": [
+		{"id": "editorial-classic", "isDefault": true, "colors": {"primary": "#C6A15B"}, "fonts": {"heading": "Playfair Display"}},
+		{"id": "warm-editorial", "colors": {"primary": "#B8956A"}, "fonts": {"heading": "Cormorant"}}
+	],
+	"migrations": {
+		"classic-scroll": {
+			"sections": {"venue": "location", "header": ""},
+			"themes": {"rose-blush": "warm-editorial"},
+			"fields": [
+				{"from": "/customContent/fathersLetter", "to": ""},
+				{"from": "/hero/mainImage", "to": "/hero/coverImage"}
+			]
+		}
+	}
+}`
+
+func newMigrateUseCase() *MigrateInvitationDataUseCase {
+	manifest := json.RawMessage(editorialManifest)
+	return NewMigrateInvitationDataUseCase(&MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			if id == "editorial-elegance" {
+				return &domain.Layout{ID: id, Manifest: &manifest, IsActive: true}, nil
+			}
+			return nil, nil
+		},
+	})
+}
+
+func TestMigrateInvitationDataUseCase_Execute_MapsSectionsThemeAndFields(t *testing.T) {
+	// Arrange
+	data := json.RawMessage(`{
+		"couple": {"bride": {"name": "Priya"}},
+		"hero": {"mainImage": "/photos/1.jpg"},
+		"customContent": {"fathersLetter": {"author": "Sanjay"}},
+		"layoutConfig": {
+			"sections": [
+				{"id": "header", "enabled": true, "order": 0},
+				{"id": "hero", "enabled": true, "order": 1},
+				{"id": "fathers-letter", "enabled": true, "order": 2},
+				{"id": "venue", "enabled": false, "order": 3},
+				{"id": "countdown", "enabled": true, "order": 4},
+				{"id": "gallery", "enabled": false, "order": 5}
+			],
+			"theme": {"preset": "rose-blush", "colors": {"primary": "#c77d8a"}, "fonts": {"heading": "Great Vibes"}},
+			"themes": [{"id": "rose-blush"}]
+		}
+	}`)
+
+	// Act
+	output, err := newMigrateUseCase().Execute(context.Background(), "classic-scroll", "editorial-elegance", data)
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"couple": {"bride": {"name": "Priya"}},
+		"hero": {"coverImage": "/photos/1.jpg"},
+		"customContent": {},
+		"layoutConfig": {
+			"sections": [
+				{"id": "hero", "enabled": true, "order": 1},
+				{"id": "location", "enabled": false, "order": 3},
+				{"id": "countdown", "enabled": true, "order": 4},
+				{"id": "couple", "enabled": false, "order": 5},
+				{"id": "footer", "enabled": true, "order": 6}
+			],
+			"theme": {"preset": "warm-editorial", "colors": {"primary": "#B8956A"}, "fonts": {"heading": "Cormorant"}},
+			"themes": [
+				{"id": "editorial-classic", "isDefault": true, "colors": {"primary": "#C6A15B"}, "fonts": {"heading": "Playfair Display"}},
+				{"id": "warm-editorial", "colors": {"primary": "#B8956A"}, "fonts": {"heading": "Cormorant"}}
+			]
+		}
+	}`, string(output.Data))
+
+	assert.Equal(t, "classic-scroll", output.Report.FromLayoutID)
+	assert.Equal(t, "editorial-elegance", output.Report.ToLayoutID)
+	assert.Equal(t, []string{"couple", "footer"}, output.Report.AddedSections)
+	assert.Equal(t, []ContentNotCarriedOver{
+		{Path: "/customContent/fathersLetter", Reason: `not used by layout "editorial-elegance"`},
+		{Path: "/layoutConfig/sections/0", Reason: `section "header" is not available in layout "editorial-elegance"`},
+		{Path: "/layoutConfig/sections/2", Reason: `section "fathers-letter" is not available in layout "editorial-elegance"`},
+	}, output.Report.NotCarriedOver)
+}
+
+func TestMigrateInvitationDataUseCase_Execute_Themes(t *testing.T) {
+	tests := []struct {
+		name       string
+		theme      string
+		wantPreset string
+		wantLost   bool
+	}{
+		{name: "shared theme kept", theme: `{"preset": "warm-editorial"}`, wantPreset: "warm-editorial"},
+		{name: "unknown theme falls back to default", theme: `{"preset": "midnight-navy"}`, wantPreset: "editorial-classic", wantLost: true},
+		{name: "custom theme kept", theme: `{"preset": "custom", "colors": {"primary": "#000000"}}`, wantPreset: "custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := json.RawMessage(`{"layoutConfig": {"theme": ` + tt.theme + `}}`)
+
+			output, err := newMigrateUseCase().Execute(context.Background(), "other-layout", "editorial-elegance", data)
+
+			require.NoError(t, err)
+			var migrated struct {
+				LayoutConfig struct {
+					Theme struct {
+						Preset string `json:"preset"`
+					} `json:"theme"`
+				} `json:"layoutConfig"`
+			}
+			require.NoError(t, json.Unmarshal(output.Data, &migrated))
+			assert.Equal(t, tt.wantPreset, migrated.LayoutConfig.Theme.Preset)
+			assert.Equal(t, tt.wantLost, len(output.Report.NotCarriedOver) == 1)
+		})
+	}
+}
+
+func TestMigrateInvitationDataUseCase_Execute_FieldTargetTaken_ReportsLoss(t *testing.T) {
+	data := json.RawMessage(`{"hero": {"mainImage": "/old.jpg", "coverImage": "/cover.jpg"}}`)
+
+	output, err := newMigrateUseCase().Execute(context.Background(), "classic-scroll", "editorial-elegance", data)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"hero": {"coverImage": "/cover.jpg"}}`, string(output.Data))
+	assert.Equal(t, []ContentNotCarriedOver{
+		{Path: "/hero/mainImage", Reason: "/hero/coverImage already has content"},
+	}, output.Report.NotCarriedOver)
+}
+
+func TestMigrateInvitationDataUseCase_Execute_UnknownLayout_ReturnsBadRequest(t *testing.T) {
+	_, err := newMigrateUseCase().Execute(context.Background(), "classic-scroll", "missing", json.RawMessage(`{}`))
+
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	assert.Equal(t, "/layoutId", appErr.Details[0].Field)
+}
@@ -2,23 +2,34 @@ package layout
 
 import (
 	"context"
+	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 )
 
 // MockLayoutRepository is a hand-written mock implementation of LayoutRepository
 type MockLayoutRepository struct {
-	CreateFn   func(ctx context.Context, layout *domain.Layout) error
+	CreateFn   func(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error
 	FindByIDFn func(ctx context.Context, id string) (*domain.Layout, error)
 	FindAllFn  func(ctx context.Context) ([]*domain.Layout, error)
-	UpdateFn   func(ctx context.Context, layout *domain.Layout) error
-	DeleteFn   func(ctx context.Context, id string) error
+	// FindAllIncludingInactiveFn falls back to FindAllFn when unset
+	FindAllIncludingInactiveFn func(ctx context.Context) ([]*domain.Layout, error)
+	FindVersionFn              func(ctx context.Context, id, version string) (*domain.Layout, error)
+	UpdateFn                   func(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error
+	DeleteFn                   func(ctx context.Context, id string) error
+
+	// Audited collects the audit entries of successful writes
+	Audited []*domain.AuditEntry
 }
 
-func (m *MockLayoutRepository) Create(ctx context.Context, layout *domain.Layout) error {
+func (m *MockLayoutRepository) Create(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
 	if m.CreateFn != nil {
-		return m.CreateFn(ctx, layout)
+		if err := m.CreateFn(ctx, layout, audit); err != nil {
+			return err
+		}
 	}
+	m.audited(audit)
 	return nil
 }
 
@@ -36,16 +47,99 @@ func (m *MockLayoutRepository) FindAll(ctx context.Context) ([]*domain.Layout, e
 	return nil, nil
 }
 
-func (m *MockLayoutRepository) Update(ctx context.Context, layout *domain.Layout) error {
+func (m *MockLayoutRepository) FindAllIncludingInactive(ctx context.Context) ([]*domain.Layout, error) {
+	if m.FindAllIncludingInactiveFn != nil {
+		return m.FindAllIncludingInactiveFn(ctx)
+	}
+	return m.FindAll(ctx)
+}
+
+func (m *MockLayoutRepository) FindVersion(ctx context.Context, id, version string) (*domain.Layout, error) {
+	if m.FindVersionFn != nil {
+		return m.FindVersionFn(ctx, id, version)
+	}
+	return nil, nil
+}
+
+func (m *MockLayoutRepository) Update(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
 	if m.UpdateFn != nil {
-		return m.UpdateFn(ctx, layout)
+		if err := m.UpdateFn(ctx, layout, audit); err != nil {
+			return err
+		}
 	}
+	m.audited(audit)
 	return nil
 }
 
+func (m *MockLayoutRepository) audited(audit *domain.AuditEntry) {
+	if audit != nil {
+		m.Audited = append(m.Audited, audit)
+	}
+}
+
 func (m *MockLayoutRepository) Delete(ctx context.Context, id string) error {
 	if m.DeleteFn != nil {
 		return m.DeleteFn(ctx, id)
 	}
 	return nil
 }
+
+// MockAuditRepository is a hand-written mock implementation of AuditRepository
+type MockAuditRepository struct {
+	CreateFn         func(ctx context.Context, entry *domain.AuditEntry) error
+	FindByResourceFn func(ctx context.Context, resourceType, resourceID string, opts repository.ListOptions) (*repository.Page[*domain.AuditEntry], error)
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, entry)
+	}
+	return nil
+}
+
+func (m *MockAuditRepository) FindByResource(ctx context.Context, resourceType, resourceID string, opts repository.ListOptions) (*repository.Page[*domain.AuditEntry], error) {
+	if m.FindByResourceFn != nil {
+		return m.FindByResourceFn(ctx, resourceType, resourceID, opts)
+	}
+	return &repository.Page[*domain.AuditEntry]{}, nil
+}
+
+// MockClock is a hand-written mock implementation of clock.Clock
+type MockClock struct {
+	NowFn func() time.Time
+}
+
+func (m *MockClock) Now() time.Time {
+	if m.NowFn != nil {
+		return m.NowFn()
+	}
+	return time.Now()
+}
+
+// MockLayoutStatsRepository is a hand-written mock implementation of LayoutStatsRepository
+type MockLayoutStatsRepository struct {
+	IncrementSelectionsFn func(ctx context.Context, layoutID string) error
+	IncrementPublishesFn  func(ctx context.Context, layoutID string) error
+	FindAllFn             func(ctx context.Context) ([]*domain.LayoutStats, error)
+}
+
+func (m *MockLayoutStatsRepository) IncrementSelections(ctx context.Context, layoutID string) error {
+	if m.IncrementSelectionsFn != nil {
+		return m.IncrementSelectionsFn(ctx, layoutID)
+	}
+	return nil
+}
+
+func (m *MockLayoutStatsRepository) IncrementPublishes(ctx context.Context, layoutID string) error {
+	if m.IncrementPublishesFn != nil {
+		return m.IncrementPublishesFn(ctx, layoutID)
+	}
+	return nil
+}
+
+func (m *MockLayoutStatsRepository) FindAll(ctx context.Context) ([]*domain.LayoutStats, error) {
+	if m.FindAllFn != nil {
+		return m.FindAllFn(ctx)
+	}
+	return nil, nil
+}
@@ -0,0 +1,43 @@
+package layout
+
+import (
+	"context"
+
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// PopularityRecorder counts layout selections and publishes for ranking the catalog. A nil
+// recorder records nothing. The catalog picks up new counts when its TTL expires.
+type PopularityRecorder struct {
+	statsRepo repository.LayoutStatsRepository
+}
+
+func NewPopularityRecorder(statsRepo repository.LayoutStatsRepository) *PopularityRecorder {
+	return &PopularityRecorder{
+		statsRepo: statsRepo,
+	}
+}
+
+// RecordSelection counts an invitation created on layoutID or switched to it. Failures are
+// logged, not returned: the invitation has already been saved.
+func (r *PopularityRecorder) RecordSelection(ctx context.Context, layoutID string) {
+	if r == nil || r.statsRepo == nil || layoutID == "" {
+		return
+	}
+	if err := r.statsRepo.IncrementSelections(ctx, layoutID); err != nil {
+		logger.GetLogger().Warn("Failed to record layout selection", zap.String("layoutID", layoutID), zap.Error(err))
+	}
+}
+
+// RecordPublish counts an invitation published on layoutID for the first time. Failures are
+// logged, not returned: the site is already live.
+func (r *PopularityRecorder) RecordPublish(ctx context.Context, layoutID string) {
+	if r == nil || r.statsRepo == nil || layoutID == "" {
+		return
+	}
+	if err := r.statsRepo.IncrementPublishes(ctx, layoutID); err != nil {
+		logger.GetLogger().Warn("Failed to record layout publish", zap.String("layoutID", layoutID), zap.Error(err))
+	}
+}
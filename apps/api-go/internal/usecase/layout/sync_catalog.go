@@ -0,0 +1,258 @@
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// CatalogSyncActorID is the audit actor of changes made by syncing the layout catalog
+const CatalogSyncActorID = "system:layout-sync"
+
+// SyncResult says what a catalog sync did with one layout
+type SyncResult string
+
+const (
+	SyncResultCreated   SyncResult = "created"
+	SyncResultUpdated   SyncResult = "updated"
+	SyncResultUnchanged SyncResult = "unchanged"
+	// SyncResultStoredNewer means the stored layout has a higher version, e.g. after an edit
+	// through the admin API. It is left alone.
+	SyncResultStoredNewer SyncResult = "stored-newer"
+	// SyncResultVersionNotBumped means the catalog changed the layout without raising its
+	// version. It is left alone until the version is bumped.
+	SyncResultVersionNotBumped SyncResult = "version-not-bumped"
+)
+
+// SyncLayoutsUseCase upserts the layout catalog into the repository. A layout is only
+// overwritten when the catalog has a higher version, which makes syncing idempotent.
+type SyncLayoutsUseCase struct {
+	layoutRepo repository.LayoutRepository
+	audit      *AuditRecorder
+	catalog    *LayoutCatalog // Optional; invalidated on every write
+}
+
+func NewSyncLayoutsUseCase(layoutRepo repository.LayoutRepository, audit *AuditRecorder, catalog *LayoutCatalog) *SyncLayoutsUseCase {
+	return &SyncLayoutsUseCase{
+		layoutRepo: layoutRepo,
+		audit:      audit,
+		catalog:    catalog,
+	}
+}
+
+type SyncLayoutsInput struct {
+	// Layouts is the catalog, as read by layoutcatalog.Load
+	Layouts []*domain.Layout
+	// DryRun reports what would change without writing anything
+	DryRun bool
+}
+
+type LayoutSyncResult struct {
+	ID             string
+	Result         SyncResult
+	StoredVersion  string
+	CatalogVersion string
+	// Changes lists the fields that differ from the stored layout
+	Changes []string
+}
+
+type SyncLayoutsOutput struct {
+	Results []*LayoutSyncResult
+}
+
+// Execute validates the whole catalog before writing anything; problems come back as a 400
+// whose details point into the catalog ("/<layout id>/manifest/..."). Created layouts are
+// active. Updates keep the stored activation and retirement, which are managed by admins.
+func (uc *SyncLayoutsUseCase) Execute(ctx context.Context, input SyncLayoutsInput) (*SyncLayoutsOutput, error) {
+	if details := validateCatalog(input.Layouts); len(details) > 0 {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid layout catalog", nil).WithDetails(details)
+	}
+
+	results := make([]*LayoutSyncResult, 0, len(input.Layouts))
+	for _, layout := range input.Layouts {
+		result, err := uc.sync(ctx, layout, input.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return &SyncLayoutsOutput{
+		Results: results,
+	}, nil
+}
+
+func (uc *SyncLayoutsUseCase) sync(ctx context.Context, layout *domain.Layout, dryRun bool) (*LayoutSyncResult, error) {
+	result := &LayoutSyncResult{
+		ID:             layout.ID,
+		CatalogVersion: layout.Version,
+	}
+
+	stored, err := uc.layoutRepo.FindByID(ctx, layout.ID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get layout", err)
+	}
+	if stored == nil {
+		result.Result = SyncResultCreated
+		if dryRun {
+			return result, nil
+		}
+		entry, err := uc.audit.layoutEntry(CatalogSyncActorID, domain.AuditActionLayoutCreate, layout.ID, nil)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrInternalServerError.Code, fmt.Sprintf("Failed to create layout %s", layout.ID), err)
+		}
+		if err := uc.layoutRepo.Create(ctx, layout, entry); err != nil {
+			return nil, errors.Wrap(errors.ErrInternalServerError.Code, fmt.Sprintf("Failed to create layout %s", layout.ID), err)
+		}
+		uc.catalog.Invalidate()
+		return result, nil
+	}
+
+	result.StoredVersion = stored.Version
+	result.Changes = catalogChanges(stored, layout)
+	switch compareLayoutVersions(layout.Version, stored.Version) {
+	case -1:
+		result.Result = SyncResultStoredNewer
+		return result, nil
+	case 0:
+		// "1.0" and "1.0.0" are the same version, so only other changes count
+		result.Result = SyncResultUnchanged
+		if slices.ContainsFunc(result.Changes, func(field string) bool { return field != "version" }) {
+			result.Result = SyncResultVersionNotBumped
+		}
+		return result, nil
+	}
+
+	result.Result = SyncResultUpdated
+	if dryRun {
+		return result, nil
+	}
+	stored.Name = layout.Name
+	stored.Description = layout.Description
+	stored.PreviewImage = layout.PreviewImage
+	stored.Tags = layout.Tags
+	stored.Version = layout.Version
+	stored.Config = layout.Config
+	stored.Manifest = layout.Manifest
+	entry, err := uc.audit.layoutEntry(CatalogSyncActorID, domain.AuditActionLayoutUpdate, layout.ID, result.Changes)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, fmt.Sprintf("Failed to update layout %s", layout.ID), err)
+	}
+	if err := uc.layoutRepo.Update(ctx, stored, entry); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, fmt.Sprintf("Failed to update layout %s", layout.ID), err)
+	}
+	uc.catalog.Invalidate()
+	return result, nil
+}
+
+// validateCatalog applies the admin API's checks to every catalog layout
+func validateCatalog(layouts []*domain.Layout) []errors.FieldError {
+	var details []errors.FieldError
+	seen := map[string]bool{}
+	for _, layout := range layouts {
+		prefix := "/" + layout.ID
+		var layoutDetails []errors.FieldError
+		if !layoutIDPattern.MatchString(layout.ID) {
+			layoutDetails = append(layoutDetails, errors.FieldError{Field: "/id", Message: "must be lowercase letters, digits and dashes"})
+		}
+		if seen[layout.ID] {
+			layoutDetails = append(layoutDetails, errors.FieldError{Field: "/id", Message: "is used by more than one layout"})
+		}
+		seen[layout.ID] = true
+		if layout.Name == "" {
+			layoutDetails = append(layoutDetails, errors.FieldError{Field: "/name", Message: "is required"})
+		}
+		if _, err := parseLayoutVersion(layout.Version); err != nil {
+			layoutDetails = append(layoutDetails, errors.FieldError{Field: "/version", Message: err.Error()})
+		}
+		if layout.Config != nil {
+			layoutDetails = append(layoutDetails, validateLayoutConfig(*layout.Config)...)
+		}
+		if layout.Manifest == nil {
+			layoutDetails = append(layoutDetails, errors.FieldError{Field: "/manifest", Message: "is required"})
+		} else {
+			layoutDetails = append(layoutDetails, validateLayoutManifest(layout.ID, *layout.Manifest)...)
+		}
+
+		for _, detail := range layoutDetails {
+			details = append(details, errors.FieldError{Field: prefix + detail.Field, Message: detail.Message})
+		}
+	}
+	return details
+}
+
+// catalogChanges lists the fields of stored that the catalog layout would change
+func catalogChanges(stored, layout *domain.Layout) []string {
+	changes := []string{}
+	if stored.Name != layout.Name {
+		changes = append(changes, "name")
+	}
+	if !equalStringPtr(stored.Description, layout.Description) {
+		changes = append(changes, "description")
+	}
+	if !equalStringPtr(stored.PreviewImage, layout.PreviewImage) {
+		changes = append(changes, "previewImage")
+	}
+	if !slices.Equal(stored.Tags, layout.Tags) {
+		changes = append(changes, "tags")
+	}
+	if stored.Version != layout.Version {
+		changes = append(changes, "version")
+	}
+	if !equalOptionalJSON(stored.Config, layout.Config) {
+		changes = append(changes, "config")
+	}
+	if !equalOptionalJSON(stored.Manifest, layout.Manifest) {
+		changes = append(changes, "manifest")
+	}
+	return changes
+}
+
+func equalOptionalJSON(current, next *json.RawMessage) bool {
+	if current == nil || next == nil {
+		return current == next
+	}
+	return equalJSON(current, compactJSON(*next))
+}
+
+// parseLayoutVersion parses a "MAJOR.MINOR.PATCH" version. A leading "v" and missing minor or
+// patch numbers are accepted ("v2", "1.1").
+func parseLayoutVersion(version string) ([3]int, error) {
+	var parsed [3]int
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if version == "" || len(parts) > 3 {
+		return parsed, fmt.Errorf("must be a version like 1.2.0, got %q", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return parsed, fmt.Errorf("must be a version like 1.2.0, got %q", version)
+		}
+		parsed[i] = n
+	}
+	return parsed, nil
+}
+
+// compareLayoutVersions returns -1, 0 or 1 as a is lower than, equal to or higher than b.
+// Unparseable versions sort lowest, so a catalog layout replaces a stored layout whose version
+// was set by hand to something else.
+func compareLayoutVersions(a, b string) int {
+	parsedA, errA := parseLayoutVersion(a)
+	parsedB, errB := parseLayoutVersion(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	return slices.Compare(parsedA[:], parsedB[:])
+}
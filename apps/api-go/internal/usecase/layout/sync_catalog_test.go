@@ -0,0 +1,192 @@
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func catalogLayout(id, version, manifest string) *domain.Layout {
+	raw := json.RawMessage(manifest)
+	return &domain.Layout{ID: id, Name: "Classic Scroll", Version: version, Manifest: &raw, IsActive: true}
+}
+
+func TestSyncLayoutsUseCase_Execute_ComparesVersions(t *testing.T) {
+	const manifest = `{"id":"classic-scroll","sections":[]}`
+	tests := []struct {
+		name        string
+		stored      *domain.Layout
+		catalog     *domain.Layout
+		wantResult  SyncResult
+		wantWrite   bool
+		wantChanges []string
+	}{
+		{
+			name:       "missing layout is created",
+			catalog:    catalogLayout("classic-scroll", "1.0.0", manifest),
+			wantResult: SyncResultCreated,
+			wantWrite:  true,
+		},
+		{
+			name:        "newer catalog version updates",
+			stored:      catalogLayout("classic-scroll", "1.0.0", `{"id":"classic-scroll"}`),
+			catalog:     catalogLayout("classic-scroll", "1.1.0", manifest),
+			wantResult:  SyncResultUpdated,
+			wantWrite:   true,
+			wantChanges: []string{"version", "manifest"},
+		},
+		{
+			name:        "versions compare numerically",
+			stored:      catalogLayout("classic-scroll", "1.9.0", manifest),
+			catalog:     catalogLayout("classic-scroll", "1.10.0", manifest),
+			wantResult:  SyncResultUpdated,
+			wantWrite:   true,
+			wantChanges: []string{"version"},
+		},
+		{
+			name:        "same version and content is unchanged",
+			stored:      catalogLayout("classic-scroll", "1.0.0", manifest),
+			catalog:     catalogLayout("classic-scroll", "v1.0", `{ "id": "classic-scroll", "sections": [] }`),
+			wantResult:  SyncResultUnchanged,
+			wantChanges: []string{"version"},
+		},
+		{
+			name:        "same version with other content is not applied",
+			stored:      catalogLayout("classic-scroll", "1.0.0", `{"id":"classic-scroll"}`),
+			catalog:     catalogLayout("classic-scroll", "1.0.0", manifest),
+			wantResult:  SyncResultVersionNotBumped,
+			wantChanges: []string{"manifest"},
+		},
+		{
+			name:        "newer stored version is kept",
+			stored:      catalogLayout("classic-scroll", "2.0.0", manifest),
+			catalog:     catalogLayout("classic-scroll", "1.1.0", manifest),
+			wantResult:  SyncResultStoredNewer,
+			wantChanges: []string{"version"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			written := false
+			repo := &MockLayoutRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) { return tt.stored, nil },
+				CreateFn: func(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+					written = true
+					return nil
+				},
+				UpdateFn: func(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+					written = true
+					return nil
+				},
+			}
+			audit, entries := newAuditSpy(repo)
+
+			output, err := NewSyncLayoutsUseCase(repo, audit, nil).Execute(context.Background(), SyncLayoutsInput{Layouts: []*domain.Layout{tt.catalog}})
+
+			require.NoError(t, err)
+			require.Len(t, output.Results, 1)
+			assert.Equal(t, tt.wantResult, output.Results[0].Result)
+			assert.Equal(t, tt.wantWrite, written)
+			if tt.stored != nil {
+				assert.Equal(t, tt.wantChanges, output.Results[0].Changes)
+			}
+			if tt.wantWrite {
+				require.Len(t, *entries, 1)
+				assert.Equal(t, CatalogSyncActorID, (*entries)[0].ActorID)
+			} else {
+				assert.Empty(t, *entries)
+			}
+		})
+	}
+}
+
+func TestSyncLayoutsUseCase_Execute_KeepsAdminState(t *testing.T) {
+	// Arrange
+	stored := catalogLayout("classic-scroll", "1.0.0", `{"id":"classic-scroll"}`)
+	stored.IsActive = false
+	var updated *domain.Layout
+	repo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) { return stored, nil },
+		UpdateFn: func(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+			updated = layout
+			return nil
+		},
+	}
+
+	// Act
+	_, err := NewSyncLayoutsUseCase(repo, nil, nil).Execute(context.Background(), SyncLayoutsInput{
+		Layouts: []*domain.Layout{catalogLayout("classic-scroll", "1.1.0", `{"id":"classic-scroll","sections":[]}`)},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, "1.1.0", updated.Version)
+	assert.False(t, updated.IsActive)
+}
+
+func TestSyncLayoutsUseCase_Execute_DryRunDoesNotWrite(t *testing.T) {
+	repo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			if id == "classic-scroll" {
+				return catalogLayout(id, "1.0.0", `{"id":"classic-scroll"}`), nil
+			}
+			return nil, nil
+		},
+		CreateFn: func(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+			t.Fatal("Create should not be called")
+			return nil
+		},
+		UpdateFn: func(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+			t.Fatal("Update should not be called")
+			return nil
+		},
+	}
+
+	output, err := NewSyncLayoutsUseCase(repo, nil, nil).Execute(context.Background(), SyncLayoutsInput{
+		Layouts: []*domain.Layout{
+			catalogLayout("classic-scroll", "1.1.0", `{"id":"classic-scroll"}`),
+			catalogLayout("editorial-elegance", "1.0.0", `{"id":"editorial-elegance"}`),
+		},
+		DryRun: true,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Results, 2)
+	assert.Equal(t, SyncResultUpdated, output.Results[0].Result)
+	assert.Equal(t, SyncResultCreated, output.Results[1].Result)
+}
+
+func TestSyncLayoutsUseCase_Execute_InvalidCatalog_WritesNothing(t *testing.T) {
+	// Arrange
+	repo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			t.Fatal("FindByID should not be called")
+			return nil, nil
+		},
+	}
+	valid := catalogLayout("classic-scroll", "1.0.0", `{"id":"classic-scroll"}`)
+	invalid := catalogLayout("editorial-elegance", "latest", `{"id":"editorial-elegance","dataSchema":{"type":"date"}}`)
+
+	// Act
+	_, err := NewSyncLayoutsUseCase(repo, nil, nil).Execute(context.Background(), SyncLayoutsInput{
+		Layouts: []*domain.Layout{valid, invalid, valid},
+	})
+
+	// Assert
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "expected AppError, got %v", err)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	assert.Equal(t, []errors.FieldError{
+		{Field: "/editorial-elegance/version", Message: `must be a version like 1.2.0, got "latest"`},
+		{Field: "/editorial-elegance/manifest/dataSchema", Message: `invalid schema: /type has unknown type "date"`},
+		{Field: "/classic-scroll/id", Message: "is used by more than one layout"},
+	}, appErr.Details)
+}
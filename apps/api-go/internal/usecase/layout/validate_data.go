@@ -0,0 +1,120 @@
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/sacred-vows/api-go/pkg/jsonschema"
+)
+
+// ValidateInvitationDataUseCase checks invitation data against the JSON Schema a layout manifest
+// declares under "dataSchema", so mismatched sections or settings are rejected when they are
+// saved instead of failing in the renderer at publish time
+type ValidateInvitationDataUseCase struct {
+	layoutRepo repository.LayoutRepository
+}
+
+func NewValidateInvitationDataUseCase(layoutRepo repository.LayoutRepository) *ValidateInvitationDataUseCase {
+	return &ValidateInvitationDataUseCase{
+		layoutRepo: layoutRepo,
+	}
+}
+
+// Execute returns a 400 AppError whose details point at the offending fields, with paths
+// relative to the request body ("/layoutId", "/data/..."). Data is checked against the layout
+// version the invitation is pinned to; an empty or unknown version uses the current one.
+// Layouts without a dataSchema accept any data object.
+func (uc *ValidateInvitationDataUseCase) Execute(ctx context.Context, layoutID, layoutVersion string, data json.RawMessage) error {
+	_, err := uc.validate(ctx, layoutID, layoutVersion, data, false)
+	return err
+}
+
+// ExecuteForNewLayout is Execute for data moving onto a layout: a new invitation, a layout
+// switch or a version upgrade. Data is checked against the current version, which is returned
+// for the invitation to pin. It also rejects layouts that are inactive or retired; invitations
+// already on such a layout keep validating with Execute.
+func (uc *ValidateInvitationDataUseCase) ExecuteForNewLayout(ctx context.Context, layoutID string, data json.RawMessage) (string, error) {
+	layout, err := uc.validate(ctx, layoutID, "", data, true)
+	if err != nil {
+		return "", err
+	}
+	return layout.Version, nil
+}
+
+func (uc *ValidateInvitationDataUseCase) validate(ctx context.Context, layoutID, layoutVersion string, data json.RawMessage, newLayout bool) (*domain.Layout, error) {
+	layout, err := uc.layoutRepo.FindByID(ctx, layoutID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get layout", err)
+	}
+	if layout == nil {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Unknown layout", nil).WithDetails([]errors.FieldError{
+			{Field: "/layoutId", Message: fmt.Sprintf("layout %q does not exist", layoutID)},
+		})
+	}
+	if newLayout {
+		if err := checkSelectable(layout); err != nil {
+			return nil, err
+		}
+	}
+	pinned, err := findLayoutVersion(ctx, uc.layoutRepo, layout, layoutVersion)
+	if err != nil {
+		return nil, err
+	}
+	if pinned == nil {
+		// Versions saved before snapshots were kept validate against the current manifest
+		pinned = layout
+	}
+	if pinned.Manifest == nil {
+		return layout, nil
+	}
+
+	var manifest struct {
+		DataSchema json.RawMessage `json:"dataSchema"`
+	}
+	if err := json.Unmarshal(*pinned.Manifest, &manifest); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to parse manifest", err)
+	}
+	if len(manifest.DataSchema) == 0 || string(manifest.DataSchema) == "null" {
+		return layout, nil
+	}
+
+	schema, err := jsonschema.Compile(manifest.DataSchema)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Invalid layout data schema", err)
+	}
+
+	if len(data) == 0 {
+		data = json.RawMessage("{}")
+	}
+	violations, err := schema.Validate(data)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid invitation data", err)
+	}
+	if len(violations) == 0 {
+		return layout, nil
+	}
+
+	details := make([]errors.FieldError, len(violations))
+	for i, v := range violations {
+		details[i] = errors.FieldError{Field: "/data" + v.Path, Message: v.Message}
+	}
+	return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invitation data does not match the layout", nil).WithDetails(details)
+}
+
+// checkSelectable returns a 400 AppError when new content may not use the layout
+func checkSelectable(layout *domain.Layout) error {
+	if layout.IsSelectable() {
+		return nil
+	}
+	message := fmt.Sprintf("layout %q is not available", layout.ID)
+	if layout.IsRetired() {
+		message = fmt.Sprintf("layout %q has been retired", layout.ID)
+	}
+	return errors.Wrap(errors.ErrBadRequest.Code, "Layout not available", nil).WithDetails([]errors.FieldError{
+		{Field: "/layoutId", Message: message},
+	})
+}
@@ -0,0 +1,176 @@
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInvitationDataUseCase_Execute(t *testing.T) {
+	withSchema := json.RawMessage(`{
+		"id": "classic-scroll",
+		"dataSchema": {
+			"type": "object",
+			"properties": {
+				"layoutConfig": {
+					"type": "object",
+					"properties": {
+						"sections": {
+							"type": "array",
+							"items": {"type": "object", "properties": {"id": {"enum": ["hero", "couple"]}}}
+						}
+					}
+				}
+			}
+		}
+	}`)
+	withoutSchema := json.RawMessage(`{"id": "plain"}`)
+	brokenSchema := json.RawMessage(`{"id": "broken", "dataSchema": {"type": "date"}}`)
+
+	layouts := map[string]*domain.Layout{
+		"classic-scroll": {ID: "classic-scroll", Manifest: &withSchema},
+		"plain":          {ID: "plain", Manifest: &withoutSchema},
+		"no-manifest":    {ID: "no-manifest"},
+		"broken":         {ID: "broken", Manifest: &brokenSchema},
+	}
+	repo := &MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return layouts[id], nil
+		},
+	}
+	useCase := NewValidateInvitationDataUseCase(repo)
+
+	tests := []struct {
+		name        string
+		layoutID    string
+		data        string
+		wantCode    int
+		wantDetails []errors.FieldError
+	}{
+		{
+			name:     "valid data",
+			layoutID: "classic-scroll",
+			data:     `{"layoutConfig":{"sections":[{"id":"hero"}]}}`,
+		},
+		{
+			name:     "unknown section",
+			layoutID: "classic-scroll",
+			data:     `{"layoutConfig":{"sections":[{"id":"hero"},{"id":"venue"}]}}`,
+			wantCode: http.StatusBadRequest,
+			wantDetails: []errors.FieldError{
+				{Field: "/data/layoutConfig/sections/1/id", Message: `must be one of "hero", "couple"`},
+			},
+		},
+		{
+			name:     "layout without schema",
+			layoutID: "plain",
+			data:     `{"anything":true}`,
+		},
+		{
+			name:     "layout without manifest",
+			layoutID: "no-manifest",
+			data:     `{}`,
+		},
+		{
+			name:     "unknown layout",
+			layoutID: "missing",
+			data:     `{}`,
+			wantCode: http.StatusBadRequest,
+			wantDetails: []errors.FieldError{
+				{Field: "/layoutId", Message: `layout "missing" does not exist`},
+			},
+		},
+		{
+			name:     "broken schema",
+			layoutID: "broken",
+			data:     `{}`,
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := useCase.Execute(context.Background(), tt.layoutID, "", json.RawMessage(tt.data))
+			if tt.wantCode == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			appErr, ok := err.(*errors.AppError)
+			require.True(t, ok, "expected AppError, got %v", err)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+			assert.Equal(t, tt.wantDetails, appErr.Details)
+		})
+	}
+}
+
+func TestValidateInvitationDataUseCase_ExecuteForNewLayout_RejectsUnavailableLayouts(t *testing.T) {
+	retiredAt := time.Now()
+	layouts := map[string]*domain.Layout{
+		"active":   {ID: "active", Version: "1.2.0", IsActive: true},
+		"inactive": {ID: "inactive"},
+		"retired":  {ID: "retired", RetiredAt: &retiredAt},
+	}
+	useCase := NewValidateInvitationDataUseCase(&MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) {
+			return layouts[id], nil
+		},
+	})
+
+	tests := []struct {
+		layoutID    string
+		wantMessage string
+	}{
+		{layoutID: "active"},
+		{layoutID: "inactive", wantMessage: `layout "inactive" is not available`},
+		{layoutID: "retired", wantMessage: `layout "retired" has been retired`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layoutID, func(t *testing.T) {
+			version, err := useCase.ExecuteForNewLayout(context.Background(), tt.layoutID, json.RawMessage(`{}`))
+
+			// Invitations already on the layout are unaffected
+			require.NoError(t, useCase.Execute(context.Background(), tt.layoutID, "", json.RawMessage(`{}`)))
+			if tt.wantMessage == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, "1.2.0", version, "the current version is pinned")
+				return
+			}
+			appErr, ok := err.(*errors.AppError)
+			require.True(t, ok, "expected AppError, got %v", err)
+			assert.Equal(t, http.StatusBadRequest, appErr.Code)
+			assert.Equal(t, []errors.FieldError{{Field: "/layoutId", Message: tt.wantMessage}}, appErr.Details)
+		})
+	}
+}
+
+func TestValidateInvitationDataUseCase_Execute_UsesPinnedVersion(t *testing.T) {
+	// Version 2 added a "story" section; invitations on version 1 keep version 1's schema
+	schema := func(sections string) *json.RawMessage {
+		raw := json.RawMessage(`{"id": "classic-scroll", "dataSchema": {"type": "object", "properties": {"section": {"enum": [` + sections + `]}}}}`)
+		return &raw
+	}
+	current := &domain.Layout{ID: "classic-scroll", Version: "2.0.0", Manifest: schema(`"hero", "story"`), IsActive: true}
+	useCase := NewValidateInvitationDataUseCase(&MockLayoutRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Layout, error) { return current, nil },
+		FindVersionFn: func(ctx context.Context, id, version string) (*domain.Layout, error) {
+			if version == "1.0.0" {
+				return &domain.Layout{ID: id, Version: version, Manifest: schema(`"hero"`)}, nil
+			}
+			return nil, nil
+		},
+	})
+	story := json.RawMessage(`{"section": "story"}`)
+
+	assert.NoError(t, useCase.Execute(context.Background(), "classic-scroll", "2.0.0", story))
+	assert.Error(t, useCase.Execute(context.Background(), "classic-scroll", "1.0.0", story))
+	// A version without a snapshot falls back to the current manifest
+	assert.NoError(t, useCase.Execute(context.Background(), "classic-scroll", "0.9.0", story))
+}
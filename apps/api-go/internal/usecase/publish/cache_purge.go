@@ -0,0 +1,52 @@
+package publish
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/infrastructure/observability"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// cachePurgeTimeout bounds a background purge, retries included
+const cachePurgeTimeout = time.Minute
+
+// purgeSiteCache asks the CDN to drop cached responses for subdomain, including the pages of
+// locales, after its pointer moved. The purge runs in the background so a slow CDN does not hold up the response; it outlives the
+// request but is bounded by cachePurgeTimeout. The pointer update has already succeeded, so
+// failures are logged and counted but not returned: caches still expire on their own TTL.
+func purgeSiteCache(ctx context.Context, purger CachePurger, subdomain string, locales []string, trigger string) {
+	if purger == nil {
+		return
+	}
+	purgeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cachePurgeTimeout)
+	go func() {
+		defer cancel()
+		runSitePurge(purgeCtx, purger, subdomain, locales, trigger)
+	}()
+}
+
+func runSitePurge(ctx context.Context, purger CachePurger, subdomain string, locales []string, trigger string) {
+	if err := purger.PurgeSite(ctx, subdomain, locales); err != nil {
+		observability.RecordCachePurgeFailure(trigger)
+		logger.GetLogger().Warn("Failed to purge CDN cache for published site",
+			zap.String("subdomain", subdomain),
+			zap.String("trigger", trigger),
+			zap.Error(err),
+		)
+	}
+}
+
+// purgeLocales returns the locales served before and after a pointer move. Pages of a locale the
+// new version dropped are cached too and must go as well.
+func purgeLocales(previous, current []string) []string {
+	locales := append([]string(nil), current...)
+	for _, locale := range previous {
+		if !slices.Contains(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
@@ -0,0 +1,192 @@
+package publish
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+)
+
+type ExportPublishedSiteUseCase struct {
+	publishedRepo repository.PublishedSiteRepository
+	artifactStore ArtifactStorage
+	maxBytes      int64
+}
+
+func NewExportPublishedSiteUseCase(
+	publishedRepo repository.PublishedSiteRepository,
+	artifactStore ArtifactStorage,
+	maxBytes int64,
+) *ExportPublishedSiteUseCase {
+	return &ExportPublishedSiteUseCase{
+		publishedRepo: publishedRepo,
+		artifactStore: artifactStore,
+		maxBytes:      maxBytes,
+	}
+}
+
+// SiteExport is a published version ready to be written as a ZIP archive. Artifacts are read
+// from storage one at a time while the archive is written, so only one is held in memory.
+type SiteExport struct {
+	Filename string // e.g. alice-v3.zip
+
+	artifactStore ArtifactStorage
+	root          string // directory inside the archive, e.g. alice-v3
+	prefix        string // storage prefix of the version, e.g. sites/alice/v3/
+	keys          []string
+	maxBytes      int64
+}
+
+// Execute prepares a published version for download.
+// It validates that:
+// - The user owns the site
+// - The version exists (0 means the current version)
+// - The uncompressed size stays within the configured limit
+// Root-relative links in HTML and CSS are rewritten to relative ones so the copy works offline.
+func (uc *ExportPublishedSiteUseCase) Execute(ctx context.Context, subdomain string, version int, ownerUserID string) (*SiteExport, error) {
+	// Find the published site
+	site, err := uc.publishedRepo.FindBySubdomain(ctx, subdomain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find published site: %w", err)
+	}
+	if site == nil {
+		return nil, fmt.Errorf("published site not found")
+	}
+
+	// Validate ownership
+	if site.OwnerUserID != ownerUserID {
+		return nil, fmt.Errorf("forbidden: user does not own this site")
+	}
+
+	if version == 0 {
+		version = site.CurrentVersion
+	}
+	if version < 1 {
+		return nil, fmt.Errorf("published site has no versions")
+	}
+
+	objects, err := uc.artifactStore.ListObjects(ctx, subdomain, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("version %d does not exist", version)
+	}
+
+	stored := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		stored[obj.Key] = true
+	}
+
+	root := fmt.Sprintf("%s-v%d", subdomain, version)
+	export := &SiteExport{
+		Filename:      root + ".zip",
+		artifactStore: uc.artifactStore,
+		root:          root,
+		prefix:        fmt.Sprintf("sites/%s/v%d/", subdomain, version),
+		maxBytes:      uc.maxBytes,
+	}
+	// The listed sizes are checked up front so an oversized export fails before anything is sent
+	var total int64
+	for _, obj := range objects {
+		if isPrecompressedVariant(obj.Key, stored) {
+			continue
+		}
+		total += obj.Size
+		if uc.maxBytes > 0 && total > uc.maxBytes {
+			return nil, domain.ErrExportTooLarge
+		}
+		export.keys = append(export.keys, obj.Key)
+	}
+
+	return export, nil
+}
+
+// WriteZip streams the artifacts of the export into a ZIP archive written to w. The limit is
+// enforced again on the bytes actually read, in case the version changed since it was listed.
+func (e *SiteExport) WriteZip(ctx context.Context, w io.Writer) error {
+	zw := zip.NewWriter(w)
+	var total int64
+	for _, key := range e.keys {
+		artifact, err := e.artifactStore.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to read artifact %s: %w", key, err)
+		}
+		total += int64(len(artifact.Body))
+		if e.maxBytes > 0 && total > e.maxBytes {
+			return domain.ErrExportTooLarge
+		}
+
+		rel := strings.TrimPrefix(key, e.prefix)
+		body := artifact.Body
+		if isLinkRewritable(rel) {
+			body = relativizeLinks(body, strings.Count(rel, "/"))
+		}
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     e.root + "/" + rel,
+			Method:   zip.Deflate,
+			Modified: artifact.ModTime,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// isPrecompressedVariant reports whether key is a .br/.gz copy of another stored artifact.
+func isPrecompressedVariant(key string, stored map[string]bool) bool {
+	for _, suffix := range []string{BrotliVariantSuffix, GzipVariantSuffix} {
+		if strings.HasSuffix(key, suffix) && stored[strings.TrimSuffix(key, suffix)] {
+			return true
+		}
+	}
+	return false
+}
+
+func isLinkRewritable(rel string) bool {
+	switch path.Ext(rel) {
+	case ".html", ".htm", ".css":
+		return true
+	default:
+		return false
+	}
+}
+
+// rootRelativeLinkPattern matches href/src attributes and CSS url() values that start with "/".
+var rootRelativeLinkPattern = regexp.MustCompile(`((?:\b(?:href|src)\s*=\s*|url\(\s*)["']?)(/[^"'\s)>]*)`)
+
+// relativizeLinks rewrites root-relative links ("/assets/a.jpg") to paths relative to a file that
+// sits depth directories below the site root, and points directory links at index.html.
+// Protocol-relative ("//cdn...") and absolute URLs are left untouched.
+func relativizeLinks(body []byte, depth int) []byte {
+	up := "./"
+	if depth > 0 {
+		up = strings.Repeat("../", depth)
+	}
+	return rootRelativeLinkPattern.ReplaceAllFunc(body, func(m []byte) []byte {
+		sub := rootRelativeLinkPattern.FindSubmatch(m)
+		lead, link := string(sub[1]), string(sub[2])
+		if strings.HasPrefix(link, "//") {
+			return m
+		}
+		rest := strings.TrimPrefix(link, "/")
+		p, suffix := rest, ""
+		if i := strings.IndexAny(rest, "?#"); i >= 0 {
+			p, suffix = rest[:i], rest[i:]
+		}
+		if p == "" || strings.HasSuffix(p, "/") {
+			p += "index.html"
+		}
+		return []byte(lead + up + p + suffix)
+	})
+}
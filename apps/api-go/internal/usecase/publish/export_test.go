@@ -0,0 +1,205 @@
+package publish
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readZip(t *testing.T, export *SiteExport) map[string]string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteZip(context.Background(), &buf))
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		body, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(body)
+	}
+	return files
+}
+
+func TestExportPublishedSiteUseCase_Execute_DefaultsToCurrentVersion(t *testing.T) {
+	// Arrange
+	repo := &MockPublishedSiteRepository{
+		FindBySubdomainFn: func(ctx context.Context, subdomain string) (*domain.PublishedSite, error) {
+			return &domain.PublishedSite{Subdomain: subdomain, OwnerUserID: "user-1", Published: true, CurrentVersion: 2}, nil
+		},
+	}
+	bodies := map[string]string{
+		"sites/alice/v2/index.html":           `<link href="/styles.css"><img src="./assets/a.jpg">`,
+		"sites/alice/v2/index.html.br":        "compressed",
+		"sites/alice/v2/index.html.gz":        "compressed",
+		"sites/alice/v2/styles.css":           "body{}",
+		"sites/alice/v2/assets/a.jpg":         "jpeg",
+		"sites/alice/v2/assets/backup.tar.gz": "archive",
+	}
+	var listedVersion int
+	store := &MockArtifactStorage{
+		ListObjectsFn: func(ctx context.Context, subdomain string, version int) ([]ArtifactObject, error) {
+			listedVersion = version
+			objects := []ArtifactObject{}
+			for key, body := range bodies {
+				objects = append(objects, ArtifactObject{Key: key, Size: int64(len(body))})
+			}
+			return objects, nil
+		},
+		GetFn: func(ctx context.Context, key string) (*Artifact, error) {
+			return &Artifact{Body: []byte(bodies[key])}, nil
+		},
+	}
+	useCase := NewExportPublishedSiteUseCase(repo, store, 1024)
+
+	// Act
+	export, err := useCase.Execute(context.Background(), "alice", 0, "user-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, listedVersion)
+	assert.Equal(t, "alice-v2.zip", export.Filename)
+	files := readZip(t, export)
+	assert.Equal(t, map[string]string{
+		"alice-v2/index.html":           `<link href="./styles.css"><img src="./assets/a.jpg">`,
+		"alice-v2/styles.css":           "body{}",
+		"alice-v2/assets/a.jpg":         "jpeg",
+		"alice-v2/assets/backup.tar.gz": "archive",
+	}, files, "precompressed variants are skipped, real .gz files are kept")
+}
+
+func TestExportPublishedSiteUseCase_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
+	// Arrange
+	repo := &MockPublishedSiteRepository{
+		FindBySubdomainFn: func(ctx context.Context, subdomain string) (*domain.PublishedSite, error) {
+			return &domain.PublishedSite{Subdomain: subdomain, OwnerUserID: "user-1", Published: true, CurrentVersion: 2}, nil
+		},
+	}
+	store := &MockArtifactStorage{
+		ListObjectsFn: func(ctx context.Context, subdomain string, version int) ([]ArtifactObject, error) {
+			t.Fatal("artifacts must not be listed for another user")
+			return nil, nil
+		},
+	}
+	useCase := NewExportPublishedSiteUseCase(repo, store, 1024)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), "alice", 2, "someone-else")
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "forbidden")
+}
+
+func TestExportPublishedSiteUseCase_Execute_MissingVersion_ReturnsError(t *testing.T) {
+	// Arrange
+	repo := &MockPublishedSiteRepository{
+		FindBySubdomainFn: func(ctx context.Context, subdomain string) (*domain.PublishedSite, error) {
+			return &domain.PublishedSite{Subdomain: subdomain, OwnerUserID: "user-1", Published: true, CurrentVersion: 2}, nil
+		},
+	}
+	store := &MockArtifactStorage{
+		ListObjectsFn: func(ctx context.Context, subdomain string, version int) ([]ArtifactObject, error) {
+			// Version 5 was never published, so nothing is stored under it
+			return nil, nil
+		},
+	}
+	useCase := NewExportPublishedSiteUseCase(repo, store, 1024)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), "alice", 5, "user-1")
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+}
+
+func TestExportPublishedSiteUseCase_Execute_OverLimit_ReturnsTooLarge(t *testing.T) {
+	// Arrange
+	repo := &MockPublishedSiteRepository{
+		FindBySubdomainFn: func(ctx context.Context, subdomain string) (*domain.PublishedSite, error) {
+			return &domain.PublishedSite{Subdomain: subdomain, OwnerUserID: "user-1", Published: true, CurrentVersion: 2}, nil
+		},
+	}
+	store := &MockArtifactStorage{
+		ListObjectsFn: func(ctx context.Context, subdomain string, version int) ([]ArtifactObject, error) {
+			return []ArtifactObject{
+				{Key: "sites/alice/v2/index.html", Size: 10},
+				{Key: "sites/alice/v2/assets/a.jpg", Size: 10},
+			}, nil
+		},
+		GetFn: func(ctx context.Context, key string) (*Artifact, error) {
+			t.Fatal("an oversized export must fail before reading artifacts")
+			return nil, nil
+		},
+	}
+	useCase := NewExportPublishedSiteUseCase(repo, store, 15)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), "alice", 2, "user-1")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrExportTooLarge)
+}
+
+func TestSiteExport_WriteZip_GrownPastLimit_ReturnsTooLarge(t *testing.T) {
+	// Arrange: the listed sizes fit, but the artifacts grew before they were read
+	repo := &MockPublishedSiteRepository{
+		FindBySubdomainFn: func(ctx context.Context, subdomain string) (*domain.PublishedSite, error) {
+			return &domain.PublishedSite{Subdomain: subdomain, OwnerUserID: "user-1", Published: true, CurrentVersion: 2}, nil
+		},
+	}
+	store := &MockArtifactStorage{
+		ListObjectsFn: func(ctx context.Context, subdomain string, version int) ([]ArtifactObject, error) {
+			return []ArtifactObject{
+				{Key: "sites/alice/v2/index.html", Size: 10},
+				{Key: "sites/alice/v2/assets/a.jpg", Size: 10},
+			}, nil
+		},
+		GetFn: func(ctx context.Context, key string) (*Artifact, error) {
+			return &Artifact{Body: []byte("01234567890123456789")}, nil
+		},
+	}
+	useCase := NewExportPublishedSiteUseCase(repo, store, 25)
+	export, err := useCase.Execute(context.Background(), "alice", 2, "user-1")
+	require.NoError(t, err)
+
+	// Act
+	err = export.WriteZip(context.Background(), io.Discard)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrExportTooLarge)
+}
+
+func TestRelativizeLinks(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		depth int
+		want  string
+	}{
+		{"root_href", `<a href="/">home</a>`, 0, `<a href="./index.html">home</a>`},
+		{"asset_src", `<img src="/assets/a.jpg">`, 0, `<img src="./assets/a.jpg">`},
+		{"nested_file", `<img src='/assets/a.jpg'>`, 1, `<img src='../assets/a.jpg'>`},
+		{"directory_with_query", `<a href="/en/?x=1#top">`, 0, `<a href="./en/index.html?x=1#top">`},
+		{"css_url", `body{background:url(/assets/bg.png)}`, 0, `body{background:url(./assets/bg.png)}`},
+		{"css_url_quoted", `body{background:url("/assets/bg.png")}`, 1, `body{background:url("../assets/bg.png")}`},
+		{"protocol_relative_untouched", `<script src="//cdn.example.com/x.js">`, 0, `<script src="//cdn.example.com/x.js">`},
+		{"absolute_untouched", `<link href="https://fonts.googleapis.com/css2">`, 0, `<link href="https://fonts.googleapis.com/css2">`},
+		{"relative_untouched", `<img src="./assets/a.jpg">`, 0, `<img src="./assets/a.jpg">`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(relativizeLinks([]byte(tt.in), tt.depth)))
+		})
+	}
+}
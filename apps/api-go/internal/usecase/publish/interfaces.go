@@ -1,11 +1,15 @@
 package publish
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // SnapshotGenerator generates a published snapshot (at minimum index.html bytes).
 // Implementations may generate additional assets.
 type SnapshotGenerator interface {
-	GenerateBundle(ctx context.Context, invitationID string) (*SnapshotBundle, error)
+	// GenerateBundle renders the invitation with the translations for locale ("" for none).
+	GenerateBundle(ctx context.Context, invitationID string, locale string) (*SnapshotBundle, error)
 }
 
 type SnapshotBundle struct {
@@ -22,14 +26,46 @@ type SnapshotAsset struct {
 	Body        []byte
 }
 
+// Artifact is a stored artifact together with the headers recorded when it was Put.
+type Artifact struct {
+	Body         []byte
+	ContentType  string
+	CacheControl string
+	ModTime      time.Time
+}
+
+// ArtifactObject is a listed artifact key with the size of its body in bytes.
+type ArtifactObject struct {
+	Key  string
+	Size int64
+}
+
 // ArtifactStorage stores published artifacts (index.html, assets).
 type ArtifactStorage interface {
 	// Put stores bytes at key (e.g. sites/<subdomain>/v<version>/index.html)
 	Put(ctx context.Context, key string, contentType string, cacheControl string, body []byte) error
+	// Get returns the artifact stored at key, or domain.ErrArtifactNotFound if there is none.
+	Get(ctx context.Context, key string) (*Artifact, error)
 	// PublicURL returns a publicly reachable URL for key.
 	PublicURL(key string) string
 	// ListVersions returns all version numbers for a given subdomain
 	ListVersions(ctx context.Context, subdomain string) ([]int, error)
+	// ListObjects returns every artifact stored for a version of a subdomain, sorted by key
+	ListObjects(ctx context.Context, subdomain string, version int) ([]ArtifactObject, error)
 	// DeleteVersion deletes all artifacts for a specific version of a subdomain
 	DeleteVersion(ctx context.Context, subdomain string, version int) error
 }
+
+// CachePurger invalidates CDN caches for a published site once its current version changes,
+// so that republishes and rollbacks take effect without waiting for cache TTLs.
+type CachePurger interface {
+	// PurgeSite purges cached responses for subdomain: the resolve API response and the site's HTML,
+	// including the pages of each of locales.
+	PurgeSite(ctx context.Context, subdomain string, locales []string) error
+}
+
+// LayoutPopularity counts publishes towards the popularity ranking of layouts. Implementations
+// log failures themselves, as the site is live by the time a publish is recorded.
+type LayoutPopularity interface {
+	RecordPublish(ctx context.Context, layoutID string)
+}
@@ -0,0 +1,121 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+)
+
+// LocalesManifestKey is stored in every multi-language version (sites/<sub>/v<N>/locales.json)
+// so that rollbacks can restore the locales the version was published with.
+const LocalesManifestKey = "locales.json"
+
+type LocalesManifest struct {
+	DefaultLocale string   `json:"defaultLocale"`
+	Locales       []string `json:"locales"`
+}
+
+// loadLocalesManifest reads the locales a version was published with.
+// Versions without a manifest are single-language and yield an empty manifest.
+func loadLocalesManifest(ctx context.Context, store ArtifactStorage, subdomain string, version int) (LocalesManifest, error) {
+	var manifest LocalesManifest
+	artifact, err := store.Get(ctx, fmt.Sprintf("sites/%s/v%d/%s", subdomain, version, LocalesManifestKey))
+	if errors.Is(err, domain.ErrArtifactNotFound) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(artifact.Body, &manifest); err != nil {
+		return manifest, err
+	}
+	return manifest, nil
+}
+
+// NegotiateLocale picks the locale to serve from the available ones: an explicit request
+// (e.g. ?lang=hi) wins, then Accept-Language in q order, then defaultLocale.
+// Tags match exactly or by primary language ("en-US" matches "en", "pt" matches "pt-br").
+// It returns "" when there are no locales.
+func NegotiateLocale(requested, acceptLanguage string, available []string, defaultLocale string) string {
+	if len(available) == 0 {
+		return ""
+	}
+	if locale := matchLocale(requested, available); locale != "" {
+		return locale
+	}
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if locale := matchLocale(tag, available); locale != "" {
+			return locale
+		}
+	}
+	for _, locale := range available {
+		if locale == defaultLocale {
+			return defaultLocale
+		}
+	}
+	return available[0]
+}
+
+func matchLocale(tag string, available []string) string {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if tag == "" || tag == "*" {
+		return ""
+	}
+	for _, locale := range available {
+		if locale == tag {
+			return locale
+		}
+	}
+	primary, _, _ := strings.Cut(tag, "-")
+	for _, locale := range available {
+		if locale == primary {
+			return locale
+		}
+	}
+	for _, locale := range available {
+		if strings.HasPrefix(locale, primary+"-") {
+			return locale
+		}
+	}
+	return ""
+}
+
+// parseAcceptLanguage returns the tags of an Accept-Language header ordered by q value.
+// Tags with q=0 are dropped.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(k) == "q" {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	out := make([]string, len(tags))
+	for i, t := range tags {
+		out[i] = t.tag
+	}
+	return out
+}
@@ -0,0 +1,36 @@
+package publish
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateLocale(t *testing.T) {
+	available := []string{"en", "hi", "pt-br"}
+	tests := []struct {
+		name           string
+		requested      string
+		acceptLanguage string
+		want           string
+	}{
+		{"query_param_wins", "hi", "en-US,en;q=0.9", "hi"},
+		{"query_param_case_insensitive", "PT_br", "", "pt-br"},
+		{"unknown_query_falls_through_to_header", "fr", "hi", "hi"},
+		{"header_region_matches_primary", "", "en-GB", "en"},
+		{"header_primary_matches_region", "", "pt", "pt-br"},
+		{"header_q_order", "", "fr;q=1, en;q=0.5, hi;q=0.8", "hi"},
+		{"header_q_zero_ignored", "", "hi;q=0, en;q=0.1", "en"},
+		{"default_fallback", "", "fr, de", "hi"},
+		{"empty_inputs_default", "", "", "hi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NegotiateLocale(tt.requested, tt.acceptLanguage, available, "hi"))
+		})
+	}
+}
+
+func TestNegotiateLocale_NoLocales_ReturnsEmpty(t *testing.T) {
+	assert.Equal(t, "", NegotiateLocale("en", "en", nil, ""))
+}
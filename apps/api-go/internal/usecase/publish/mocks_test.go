@@ -2,10 +2,15 @@ package publish
 
 import (
 	"context"
+	"sync"
+	"testing"
 	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
 	"github.com/sacred-vows/api-go/internal/interfaces/clock"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // MockPublishedSiteRepository is a hand-written mock implementation of PublishedSiteRepository
@@ -44,6 +49,185 @@ func (m *MockPublishedSiteRepository) Update(ctx context.Context, site *domain.P
 	return nil
 }
 
+// MockInvitationRepository is a hand-written mock implementation of InvitationRepository
+type MockInvitationRepository struct {
+	FindByIDFn     func(ctx context.Context, id string) (*domain.Invitation, error)
+	UpdateStatusFn func(ctx context.Context, id string, status domain.InvitationStatus) error
+}
+
+func (m *MockInvitationRepository) Create(ctx context.Context, invitation *domain.Invitation) error {
+	return nil
+}
+
+func (m *MockInvitationRepository) FindByID(ctx context.Context, id string) (*domain.Invitation, error) {
+	if m.FindByIDFn != nil {
+		return m.FindByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockInvitationRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
+	return nil, nil
+}
+
+func (m *MockInvitationRepository) Update(ctx context.Context, invitation *domain.Invitation) error {
+	return nil
+}
+
+func (m *MockInvitationRepository) UpdateStatus(ctx context.Context, id string, status domain.InvitationStatus) error {
+	if m.UpdateStatusFn != nil {
+		return m.UpdateStatusFn(ctx, id, status)
+	}
+	return nil
+}
+
+func (m *MockInvitationRepository) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *MockInvitationRepository) MigrateUserInvitations(ctx context.Context, fromUserID, toUserID string) (int, error) {
+	return 0, nil
+}
+
+func (m *MockInvitationRepository) FindGuestInvitationsUpdatedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error) {
+	return nil, nil
+}
+
+func (m *MockInvitationRepository) MoveToTrash(ctx context.Context, id string, deletedAt time.Time) error {
+	return nil
+}
+
+func (m *MockInvitationRepository) RestoreFromTrash(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *MockInvitationRepository) FindTrashedByID(ctx context.Context, id string) (*domain.Invitation, error) {
+	return nil, nil
+}
+
+func (m *MockInvitationRepository) FindTrashedByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Invitation], error) {
+	return nil, nil
+}
+
+func (m *MockInvitationRepository) FindTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Invitation, error) {
+	return nil, nil
+}
+
+// MockSnapshotGenerator is a hand-written mock implementation of SnapshotGenerator
+type MockSnapshotGenerator struct {
+	GenerateBundleFn func(ctx context.Context, invitationID string, locale string) (*SnapshotBundle, error)
+}
+
+func (m *MockSnapshotGenerator) GenerateBundle(ctx context.Context, invitationID string, locale string) (*SnapshotBundle, error) {
+	if m.GenerateBundleFn != nil {
+		return m.GenerateBundleFn(ctx, invitationID, locale)
+	}
+	return &SnapshotBundle{IndexHTML: []byte("<html></html>")}, nil
+}
+
+// MockArtifactStorage is a hand-written mock implementation of ArtifactStorage
+type MockArtifactStorage struct {
+	PutFn           func(ctx context.Context, key string, contentType string, cacheControl string, body []byte) error
+	GetFn           func(ctx context.Context, key string) (*Artifact, error)
+	PublicURLFn     func(key string) string
+	ListVersionsFn  func(ctx context.Context, subdomain string) ([]int, error)
+	ListObjectsFn   func(ctx context.Context, subdomain string, version int) ([]ArtifactObject, error)
+	DeleteVersionFn func(ctx context.Context, subdomain string, version int) error
+}
+
+func (m *MockArtifactStorage) Put(ctx context.Context, key string, contentType string, cacheControl string, body []byte) error {
+	if m.PutFn != nil {
+		return m.PutFn(ctx, key, contentType, cacheControl, body)
+	}
+	return nil
+}
+
+func (m *MockArtifactStorage) Get(ctx context.Context, key string) (*Artifact, error) {
+	if m.GetFn != nil {
+		return m.GetFn(ctx, key)
+	}
+	return nil, domain.ErrArtifactNotFound
+}
+
+func (m *MockArtifactStorage) PublicURL(key string) string {
+	if m.PublicURLFn != nil {
+		return m.PublicURLFn(key)
+	}
+	return "/published/" + key
+}
+
+func (m *MockArtifactStorage) ListVersions(ctx context.Context, subdomain string) ([]int, error) {
+	if m.ListVersionsFn != nil {
+		return m.ListVersionsFn(ctx, subdomain)
+	}
+	return nil, nil
+}
+
+func (m *MockArtifactStorage) ListObjects(ctx context.Context, subdomain string, version int) ([]ArtifactObject, error) {
+	if m.ListObjectsFn != nil {
+		return m.ListObjectsFn(ctx, subdomain, version)
+	}
+	return nil, nil
+}
+
+func (m *MockArtifactStorage) DeleteVersion(ctx context.Context, subdomain string, version int) error {
+	if m.DeleteVersionFn != nil {
+		return m.DeleteVersionFn(ctx, subdomain, version)
+	}
+	return nil
+}
+
+// RecordingCachePurger is a CachePurger fake that records purged subdomains. Purges run in the
+// background, so tests read them with WaitPurged.
+type RecordingCachePurger struct {
+	mu      sync.Mutex
+	purged  []string
+	locales map[string][]string
+	Err     error
+	// Block, when set, holds every purge until it is closed
+	Block chan struct{}
+}
+
+func (p *RecordingCachePurger) PurgeSite(ctx context.Context, subdomain string, locales []string) error {
+	if p.Block != nil {
+		select {
+		case <-p.Block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.purged = append(p.purged, subdomain)
+	if p.locales == nil {
+		p.locales = map[string][]string{}
+	}
+	p.locales[subdomain] = locales
+	return p.Err
+}
+
+// PurgedLocales returns the locales of the last purge of subdomain
+func (p *RecordingCachePurger) PurgedLocales(subdomain string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.locales[subdomain]
+}
+
+// Purged returns the subdomains purged so far
+func (p *RecordingCachePurger) Purged() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.purged...)
+}
+
+// WaitPurged waits for the background purges to reach want
+func (p *RecordingCachePurger) WaitPurged(t *testing.T, want []string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(want, p.Purged())
+	}, time.Second, time.Millisecond, "purged %v, want %v", p.Purged(), want)
+}
+
 // MockClock is a hand-written mock implementation of Clock for publish tests
 type MockClock struct {
 	NowFn func() time.Time
@@ -58,3 +242,12 @@ func (m *MockClock) Now() time.Time {
 
 // Ensure MockClock implements clock.Clock interface
 var _ clock.Clock = (*MockClock)(nil)
+
+// RecordingPopularity is a LayoutPopularity fake that records the layouts of counted publishes.
+type RecordingPopularity struct {
+	Published []string
+}
+
+func (p *RecordingPopularity) RecordPublish(ctx context.Context, layoutID string) {
+	p.Published = append(p.Published, layoutID)
+}
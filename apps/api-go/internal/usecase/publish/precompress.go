@@ -0,0 +1,68 @@
+package publish
+
+import (
+	"bytes"
+	"compress/gzip"
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Precompressed variants are stored next to the original artifact (e.g. index.html.br)
+// so that servers can pick one by Accept-Encoding without compressing per request.
+const (
+	BrotliVariantSuffix = ".br"
+	GzipVariantSuffix   = ".gz"
+)
+
+// IsCompressibleContentType reports whether artifacts of this type benefit from precompression.
+// Images and fonts are already compressed and are skipped.
+func IsCompressibleContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+	switch mediaType {
+	case "application/javascript", "application/json", "application/manifest+json", "application/xml", "image/svg+xml":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsCompressibleKey reports whether the artifact at key would have precompressed variants,
+// judging by its file extension.
+func IsCompressibleKey(key string) bool {
+	return IsCompressibleContentType(mime.TypeByExtension(path.Ext(key)))
+}
+
+// compressVariants returns the brotli and gzip encodings of body.
+func compressVariants(body []byte) (br []byte, gz []byte, err error) {
+	var brBuf bytes.Buffer
+	bw := brotli.NewWriterLevel(&brBuf, brotli.BestCompression)
+	if _, err := bw.Write(body); err != nil {
+		return nil, nil, err
+	}
+	if err := bw.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	var gzBuf bytes.Buffer
+	gw, err := gzip.NewWriterLevel(&gzBuf, gzip.BestCompression)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := gw.Write(body); err != nil {
+		return nil, nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	return brBuf.Bytes(), gzBuf.Bytes(), nil
+}
@@ -0,0 +1,60 @@
+package publish
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCompressibleContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{"text/html; charset=utf-8", true},
+		{"text/css; charset=utf-8", true},
+		{"application/javascript; charset=utf-8", true},
+		{"application/json; charset=utf-8", true},
+		{"image/svg+xml", true},
+		{"image/jpeg", false},
+		{"image/webp", false},
+		{"font/woff2", false},
+		{"application/octet-stream", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsCompressibleContentType(tt.contentType))
+		})
+	}
+}
+
+func TestIsCompressibleKey(t *testing.T) {
+	assert.True(t, IsCompressibleKey("sites/a/v1/index.html"))
+	assert.True(t, IsCompressibleKey("sites/a/v1/app.js"))
+	assert.False(t, IsCompressibleKey("sites/a/v1/assets/photo.jpg"))
+}
+
+func TestCompressVariants_RoundTrip(t *testing.T) {
+	body := bytes.Repeat([]byte("<p>Sacred Vows</p>"), 200)
+
+	br, gz, err := compressVariants(body)
+	require.NoError(t, err)
+	assert.Less(t, len(br), len(body))
+	assert.Less(t, len(gz), len(body))
+
+	brOut, err := io.ReadAll(brotli.NewReader(bytes.NewReader(br)))
+	require.NoError(t, err)
+	assert.Equal(t, body, brOut)
+
+	gr, err := gzip.NewReader(bytes.NewReader(gz))
+	require.NoError(t, err)
+	gzOut, err := io.ReadAll(gr)
+	require.NoError(t, err)
+	assert.Equal(t, body, gzOut)
+}
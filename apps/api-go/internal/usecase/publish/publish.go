@@ -2,6 +2,7 @@ package publish
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/sacred-vows/api-go/internal/domain"
@@ -18,6 +19,8 @@ type PublishInvitationUseCase struct {
 	publishedRepo         repository.PublishedSiteRepository
 	snapshotGen           SnapshotGenerator
 	artifactStore         ArtifactStorage
+	cachePurger           CachePurger
+	popularity            LayoutPopularity // Optional
 	clock                 clock.Clock
 	versionRetentionCount int
 }
@@ -27,6 +30,8 @@ func NewPublishInvitationUseCase(
 	publishedRepo repository.PublishedSiteRepository,
 	snapshotGen SnapshotGenerator,
 	artifactStore ArtifactStorage,
+	cachePurger CachePurger,
+	popularity LayoutPopularity,
 	clk clock.Clock,
 	versionRetentionCount int,
 ) *PublishInvitationUseCase {
@@ -35,6 +40,8 @@ func NewPublishInvitationUseCase(
 		publishedRepo:         publishedRepo,
 		snapshotGen:           snapshotGen,
 		artifactStore:         artifactStore,
+		cachePurger:           cachePurger,
+		popularity:            popularity,
 		clock:                 clk,
 		versionRetentionCount: versionRetentionCount,
 	}
@@ -59,6 +66,9 @@ func (uc *PublishInvitationUseCase) Execute(ctx context.Context, invitationID, o
 	if inv.UserID != ownerUserID {
 		return "", 0, "", fmt.Errorf("forbidden")
 	}
+	if err := inv.TransitionTo(domain.InvitationStatusPublished); err != nil {
+		return "", 0, "", err
+	}
 
 	// Ensure subdomain isn't owned by someone else.
 	existingBySub, err := uc.publishedRepo.FindBySubdomain(ctx, subdomain)
@@ -100,39 +110,111 @@ func (uc *PublishInvitationUseCase) Execute(ctx context.Context, invitationID, o
 
 	version = site.CurrentVersion + 1
 
-	// Generate snapshot bundle first. If this fails, do not advance any published pointers.
-	bundle, err := uc.snapshotGen.GenerateBundle(ctx, invitationID)
+	// Generate snapshot bundles first (one per locale). If this fails, do not advance any published pointers.
+	locales := inv.Locales()
+	renderLocales := locales
+	if len(renderLocales) == 0 {
+		renderLocales = []string{""}
+	}
+	bundles := make(map[string]*SnapshotBundle, len(renderLocales))
+	for _, locale := range renderLocales {
+		bundle, err := uc.snapshotGen.GenerateBundle(ctx, invitationID, locale)
+		if err != nil {
+			observability.RecordPublishAttempt(false)
+			return "", 0, "", err
+		}
+		bundles[locale] = bundle
+	}
+
+	prefix := fmt.Sprintf("sites/%s/v%d", subdomain, version)
+	// The default locale is also stored at the version root so locale-less links keep working.
+	indexKey, err := uc.putBundle(ctx, prefix, bundles[inv.DefaultLocale])
 	if err != nil {
 		observability.RecordPublishAttempt(false)
 		return "", 0, "", err
 	}
+	for _, locale := range locales {
+		if _, err := uc.putBundle(ctx, prefix+"/"+locale, bundles[locale]); err != nil {
+			observability.RecordPublishAttempt(false)
+			return "", 0, "", err
+		}
+	}
+	if len(locales) > 0 {
+		manifest, err := json.Marshal(LocalesManifest{DefaultLocale: inv.DefaultLocale, Locales: locales})
+		if err != nil {
+			observability.RecordPublishAttempt(false)
+			return "", 0, "", err
+		}
+		if err := uc.artifactStore.Put(ctx, prefix+"/"+LocalesManifestKey, "application/json; charset=utf-8", "public, max-age=31536000, immutable", manifest); err != nil {
+			observability.RecordPublishAttempt(false)
+			return "", 0, "", err
+		}
+	}
 
-	prefix := fmt.Sprintf("sites/%s/v%d", subdomain, version)
-	indexKey := prefix + "/index.html"
-	if err := uc.artifactStore.Put(ctx, indexKey, "text/html; charset=utf-8", "public, max-age=60", bundle.IndexHTML); err != nil {
+	// Only after all uploads succeed, update pointer to new version.
+	previousLocales := site.Locales
+	site.Published = true
+	site.CurrentVersion = version
+	site.Locales = locales
+	site.DefaultLocale = inv.DefaultLocale
+	site.PublishedAt = &now
+	site.UpdatedAt = now
+	if err := uc.publishedRepo.Update(ctx, site); err != nil {
 		observability.RecordPublishAttempt(false)
 		return "", 0, "", err
 	}
 
+	// The site is live at this point, so a failed status write is logged rather than failing the publish
+	if err := uc.invitationRepo.UpdateStatus(ctx, invitationID, domain.InvitationStatusPublished); err != nil {
+		logger.GetLogger().Warn("Failed to mark invitation published",
+			zap.String("invitationID", invitationID),
+			zap.Error(err),
+		)
+	}
+
+	// Track successful publish
+	observability.RecordPublishAttempt(true)
+	observability.RecordInvitationPublished()
+	// Republishing the same site is not another vote for its layout
+	if version == 1 && uc.popularity != nil {
+		uc.popularity.RecordPublish(ctx, inv.LayoutID)
+	}
+
+	// The pointer has moved; make the CDN drop the cached resolve response and HTML.
+	purgeSiteCache(ctx, uc.cachePurger, subdomain, purgeLocales(previousLocales, locales), "publish")
+
+	// Cleanup old versions in background (don't block response)
+	go uc.cleanupOldVersions(context.Background(), subdomain, version)
+
+	indexURL = uc.artifactStore.PublicURL(indexKey)
+	return subdomain, version, indexURL, nil
+}
+
+// putBundle stores a rendered bundle under prefix (a version root or a locale directory)
+// and returns the key of its index.html.
+func (uc *PublishInvitationUseCase) putBundle(ctx context.Context, prefix string, bundle *SnapshotBundle) (string, error) {
+	indexKey := prefix + "/index.html"
+	if err := uc.putArtifact(ctx, indexKey, "text/html; charset=utf-8", "public, max-age=60", bundle.IndexHTML); err != nil {
+		return "", err
+	}
+
 	manifestKey := prefix + "/manifest.json"
 	if len(bundle.Manifest) > 0 {
-		_ = uc.artifactStore.Put(ctx, manifestKey, "application/json; charset=utf-8", "public, max-age=31536000, immutable", bundle.Manifest)
+		_ = uc.putArtifact(ctx, manifestKey, "application/json; charset=utf-8", "public, max-age=31536000, immutable", bundle.Manifest)
 	}
 
 	cssKey := prefix + "/styles.css"
 	if len(bundle.StylesCSS) > 0 {
-		if err := uc.artifactStore.Put(ctx, cssKey, "text/css; charset=utf-8", "public, max-age=31536000, immutable", bundle.StylesCSS); err != nil {
-			observability.RecordPublishAttempt(false)
-			return "", 0, "", err
+		if err := uc.putArtifact(ctx, cssKey, "text/css; charset=utf-8", "public, max-age=31536000, immutable", bundle.StylesCSS); err != nil {
+			return "", err
 		}
 	}
 
 	jsKey := prefix + "/app.js"
 	jsBody := []byte("// placeholder\n")
 	// Optional placeholder (can be removed once layouts no longer reference app.js)
-	if err := uc.artifactStore.Put(ctx, jsKey, "application/javascript; charset=utf-8", "public, max-age=31536000, immutable", jsBody); err != nil {
-		observability.RecordPublishAttempt(false)
-		return "", 0, "", err
+	if err := uc.putArtifact(ctx, jsKey, "application/javascript; charset=utf-8", "public, max-age=31536000, immutable", jsBody); err != nil {
+		return "", err
 	}
 
 	for _, a := range bundle.Assets {
@@ -145,31 +227,30 @@ func (uc *PublishInvitationUseCase) Execute(ctx context.Context, invitationID, o
 		if ct == "" {
 			ct = "application/octet-stream"
 		}
-		if err := uc.artifactStore.Put(ctx, key, ct, cc, a.Body); err != nil {
-			observability.RecordPublishAttempt(false)
-			return "", 0, "", err
+		if err := uc.putArtifact(ctx, key, ct, cc, a.Body); err != nil {
+			return "", err
 		}
 	}
+	return indexKey, nil
+}
 
-	// Only after all uploads succeed, update pointer to new version.
-	site.Published = true
-	site.CurrentVersion = version
-	site.PublishedAt = &now
-	site.UpdatedAt = now
-	if err := uc.publishedRepo.Update(ctx, site); err != nil {
-		observability.RecordPublishAttempt(false)
-		return "", 0, "", err
+// putArtifact stores an artifact and, for compressible content, its brotli and gzip variants.
+// Variants keep the original content type; servers add Content-Encoding when serving them.
+func (uc *PublishInvitationUseCase) putArtifact(ctx context.Context, key, contentType, cacheControl string, body []byte) error {
+	if err := uc.artifactStore.Put(ctx, key, contentType, cacheControl, body); err != nil {
+		return err
 	}
-
-	// Track successful publish
-	observability.RecordPublishAttempt(true)
-	observability.RecordInvitationPublished()
-
-	// Cleanup old versions in background (don't block response)
-	go uc.cleanupOldVersions(context.Background(), subdomain, version)
-
-	indexURL = uc.artifactStore.PublicURL(indexKey)
-	return subdomain, version, indexURL, nil
+	if !IsCompressibleContentType(contentType) {
+		return nil
+	}
+	br, gz, err := compressVariants(body)
+	if err != nil {
+		return fmt.Errorf("failed to compress %s: %w", key, err)
+	}
+	if err := uc.artifactStore.Put(ctx, key+BrotliVariantSuffix, contentType, cacheControl, br); err != nil {
+		return err
+	}
+	return uc.artifactStore.Put(ctx, key+GzipVariantSuffix, contentType, cacheControl, gz)
 }
 
 // cleanupOldVersions deletes versions older than the retention count.
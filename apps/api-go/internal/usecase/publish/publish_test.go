@@ -0,0 +1,220 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPublishUseCase(snapshotGen SnapshotGenerator, purger CachePurger) *PublishInvitationUseCase {
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "user-1"}, nil
+		},
+	}
+	// Retention 0 disables background cleanup so tests don't race with it.
+	return NewPublishInvitationUseCase(invitationRepo, &MockPublishedSiteRepository{}, snapshotGen, &MockArtifactStorage{}, purger, nil, &MockClock{}, 0)
+}
+
+func TestPublishInvitationUseCase_Execute_PurgesCacheAfterPublish(t *testing.T) {
+	// Arrange
+	purger := &RecordingCachePurger{}
+	useCase := newPublishUseCase(&MockSnapshotGenerator{}, purger)
+
+	// Act
+	subdomain, version, _, err := useCase.Execute(context.Background(), "inv-1", "user-1", "Alice")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "alice", subdomain)
+	assert.Equal(t, 1, version)
+	purger.WaitPurged(t, []string{"alice"})
+}
+
+func TestPublishInvitationUseCase_Execute_DoesNotWaitForPurge(t *testing.T) {
+	// Arrange
+	purger := &RecordingCachePurger{Block: make(chan struct{})}
+	useCase := newPublishUseCase(&MockSnapshotGenerator{}, purger)
+
+	// Act
+	_, _, _, err := useCase.Execute(context.Background(), "inv-1", "user-1", "alice")
+
+	// Assert
+	require.NoError(t, err, "publish returns while the CDN is still being purged")
+	assert.Empty(t, purger.Purged())
+	close(purger.Block)
+	purger.WaitPurged(t, []string{"alice"})
+}
+
+func TestPublishInvitationUseCase_Execute_SnapshotFails_DoesNotPurge(t *testing.T) {
+	// Arrange
+	purger := &RecordingCachePurger{}
+	snapshotGen := &MockSnapshotGenerator{
+		GenerateBundleFn: func(ctx context.Context, invitationID string, locale string) (*SnapshotBundle, error) {
+			return nil, errors.New("renderer crashed")
+		},
+	}
+	useCase := newPublishUseCase(snapshotGen, purger)
+
+	// Act
+	_, _, _, err := useCase.Execute(context.Background(), "inv-1", "user-1", "alice")
+
+	// Assert
+	require.Error(t, err)
+	assert.Empty(t, purger.Purged())
+}
+
+func TestPublishInvitationUseCase_Execute_PurgeFailure_StillSucceeds(t *testing.T) {
+	// Arrange
+	purger := &RecordingCachePurger{Err: errors.New("cloudflare unavailable")}
+	useCase := newPublishUseCase(&MockSnapshotGenerator{}, purger)
+
+	// Act
+	_, version, _, err := useCase.Execute(context.Background(), "inv-1", "user-1", "alice")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+}
+
+func TestPublishInvitationUseCase_Execute_PublishesOneVariantPerLocale(t *testing.T) {
+	// Arrange
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{
+				ID:     id,
+				UserID: "user-1",
+				Translations: map[string]json.RawMessage{
+					"en": json.RawMessage(`{"title":"Wedding"}`),
+					"hi": json.RawMessage(`{"title":"Vivah"}`),
+				},
+				DefaultLocale: "hi",
+			}, nil
+		},
+	}
+	var mu sync.Mutex
+	stored := map[string][]byte{}
+	store := &MockArtifactStorage{
+		PutFn: func(ctx context.Context, key string, contentType string, cacheControl string, body []byte) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stored[key] = body
+			return nil
+		},
+	}
+	snapshotGen := &MockSnapshotGenerator{
+		GenerateBundleFn: func(ctx context.Context, invitationID string, locale string) (*SnapshotBundle, error) {
+			return &SnapshotBundle{IndexHTML: []byte("<html>" + locale + "</html>")}, nil
+		},
+	}
+	var updated *domain.PublishedSite
+	publishedRepo := &MockPublishedSiteRepository{
+		UpdateFn: func(ctx context.Context, site *domain.PublishedSite) error {
+			updated = site
+			return nil
+		},
+	}
+	useCase := NewPublishInvitationUseCase(invitationRepo, publishedRepo, snapshotGen, store, nil, nil, &MockClock{}, 0)
+
+	// Act
+	_, version, _, err := useCase.Execute(context.Background(), "inv-1", "user-1", "alice")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "<html>hi</html>", string(stored["sites/alice/v1/index.html"]), "default locale at version root")
+	assert.Equal(t, "<html>en</html>", string(stored["sites/alice/v1/en/index.html"]))
+	assert.Equal(t, "<html>hi</html>", string(stored["sites/alice/v1/hi/index.html"]))
+
+	var manifest LocalesManifest
+	require.NoError(t, json.Unmarshal(stored["sites/alice/v1/"+LocalesManifestKey], &manifest))
+	assert.Equal(t, LocalesManifest{DefaultLocale: "hi", Locales: []string{"en", "hi"}}, manifest)
+
+	require.NotNil(t, updated)
+	assert.Equal(t, version, updated.CurrentVersion)
+	assert.Equal(t, []string{"en", "hi"}, updated.Locales)
+	assert.Equal(t, "hi", updated.DefaultLocale)
+}
+
+func TestPublishInvitationUseCase_Execute_MarksInvitationPublished(t *testing.T) {
+	// Arrange
+	var written domain.InvitationStatus
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "user-1", Status: domain.InvitationStatusDraft}, nil
+		},
+		UpdateStatusFn: func(ctx context.Context, id string, status domain.InvitationStatus) error {
+			written = status
+			return nil
+		},
+	}
+	useCase := NewPublishInvitationUseCase(invitationRepo, &MockPublishedSiteRepository{}, &MockSnapshotGenerator{}, &MockArtifactStorage{}, nil, nil, &MockClock{}, 0)
+
+	// Act
+	_, _, _, err := useCase.Execute(context.Background(), "inv-1", "user-1", "alice")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.InvitationStatusPublished, written)
+}
+
+func TestPublishInvitationUseCase_Execute_ArchivedInvitation_Rejected(t *testing.T) {
+	// Arrange
+	generated := false
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "user-1", Status: domain.InvitationStatusArchived}, nil
+		},
+	}
+	snapshotGen := &MockSnapshotGenerator{
+		GenerateBundleFn: func(ctx context.Context, invitationID string, locale string) (*SnapshotBundle, error) {
+			generated = true
+			return &SnapshotBundle{}, nil
+		},
+	}
+	useCase := NewPublishInvitationUseCase(invitationRepo, &MockPublishedSiteRepository{}, snapshotGen, &MockArtifactStorage{}, nil, nil, &MockClock{}, 0)
+
+	// Act
+	_, _, _, err := useCase.Execute(context.Background(), "inv-1", "user-1", "alice")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	assert.False(t, generated, "Nothing should be rendered for an invalid transition")
+}
+
+func TestPublishInvitationUseCase_Execute_CountsFirstPublishOfLayout(t *testing.T) {
+	// Arrange
+	invitationRepo := &MockInvitationRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+			return &domain.Invitation{ID: id, UserID: "user-1", LayoutID: "classic-scroll"}, nil
+		},
+	}
+	var site *domain.PublishedSite
+	publishedRepo := &MockPublishedSiteRepository{
+		FindByInvitationIDFn: func(ctx context.Context, invitationID string) (*domain.PublishedSite, error) {
+			return site, nil
+		},
+		CreateFn: func(ctx context.Context, created *domain.PublishedSite) error {
+			site = created
+			return nil
+		},
+	}
+	popularity := &RecordingPopularity{}
+	useCase := NewPublishInvitationUseCase(invitationRepo, publishedRepo, &MockSnapshotGenerator{}, &MockArtifactStorage{}, nil, popularity, &MockClock{}, 0)
+
+	// Act
+	_, first, _, err := useCase.Execute(context.Background(), "inv-1", "user-1", "alice")
+	require.NoError(t, err)
+	_, second, _, err := useCase.Execute(context.Background(), "inv-1", "user-1", "alice")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 2, second)
+	assert.Equal(t, []string{"classic-scroll"}, popularity.Published, "republishing is not counted again")
+}
@@ -10,15 +10,18 @@ import (
 type RollbackPublishedSiteUseCase struct {
 	publishedRepo repository.PublishedSiteRepository
 	artifactStore ArtifactStorage
+	cachePurger   CachePurger
 }
 
 func NewRollbackPublishedSiteUseCase(
 	publishedRepo repository.PublishedSiteRepository,
 	artifactStore ArtifactStorage,
+	cachePurger CachePurger,
 ) *RollbackPublishedSiteUseCase {
 	return &RollbackPublishedSiteUseCase{
 		publishedRepo: publishedRepo,
 		artifactStore: artifactStore,
+		cachePurger:   cachePurger,
 	}
 }
 
@@ -65,11 +68,22 @@ func (uc *RollbackPublishedSiteUseCase) Execute(ctx context.Context, subdomain s
 		return fmt.Errorf("target version %d does not exist", targetVersion)
 	}
 
+	// Restore the locales the target version was published with
+	locales, err := loadLocalesManifest(ctx, uc.artifactStore, subdomain, targetVersion)
+	if err != nil {
+		return fmt.Errorf("failed to read locales for version %d: %w", targetVersion, err)
+	}
+
 	// Update current version pointer
+	previousLocales := site.Locales
 	site.CurrentVersion = targetVersion
+	site.Locales = locales.Locales
+	site.DefaultLocale = locales.DefaultLocale
 	if err := uc.publishedRepo.Update(ctx, site); err != nil {
 		return fmt.Errorf("failed to update published site: %w", err)
 	}
 
+	purgeSiteCache(ctx, uc.cachePurger, subdomain, purgeLocales(previousLocales, site.Locales), "rollback")
+
 	return nil
 }
@@ -0,0 +1,156 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackPublishedSiteUseCase_Execute_PurgesCacheAfterPointerMoves(t *testing.T) {
+	// Arrange
+	var saved *domain.PublishedSite
+	repo := &MockPublishedSiteRepository{
+		FindBySubdomainFn: func(ctx context.Context, subdomain string) (*domain.PublishedSite, error) {
+			return &domain.PublishedSite{ID: "site-1", OwnerUserID: "user-1", Subdomain: subdomain, Published: true, CurrentVersion: 3}, nil
+		},
+		UpdateFn: func(ctx context.Context, site *domain.PublishedSite) error {
+			saved = site
+			return nil
+		},
+	}
+	store := &MockArtifactStorage{
+		ListVersionsFn: func(ctx context.Context, subdomain string) ([]int, error) {
+			return []int{3, 2, 1}, nil
+		},
+	}
+	purger := &RecordingCachePurger{}
+	useCase := NewRollbackPublishedSiteUseCase(repo, store, purger)
+
+	// Act
+	err := useCase.Execute(context.Background(), "alice", 2, "user-1")
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, 2, saved.CurrentVersion)
+	purger.WaitPurged(t, []string{"alice"})
+}
+
+func TestRollbackPublishedSiteUseCase_Execute_PurgesPagesOfPreviousAndRestoredLocales(t *testing.T) {
+	// Arrange
+	// Version 3 is published in en and hi; version 2 was published in en and fr
+	repo := &MockPublishedSiteRepository{
+		FindBySubdomainFn: func(ctx context.Context, subdomain string) (*domain.PublishedSite, error) {
+			return &domain.PublishedSite{ID: "site-1", OwnerUserID: "user-1", Subdomain: subdomain, Published: true, CurrentVersion: 3, Locales: []string{"en", "hi"}}, nil
+		},
+		UpdateFn: func(ctx context.Context, site *domain.PublishedSite) error {
+			return nil
+		},
+	}
+	store := &MockArtifactStorage{
+		ListVersionsFn: func(ctx context.Context, subdomain string) ([]int, error) {
+			return []int{3, 2, 1}, nil
+		},
+		GetFn: func(ctx context.Context, key string) (*Artifact, error) {
+			require.Equal(t, "sites/alice/v2/"+LocalesManifestKey, key)
+			return &Artifact{Body: []byte(`{"defaultLocale":"en","locales":["en","fr"]}`)}, nil
+		},
+	}
+	purger := &RecordingCachePurger{}
+	useCase := NewRollbackPublishedSiteUseCase(repo, store, purger)
+
+	// Act
+	err := useCase.Execute(context.Background(), "alice", 2, "user-1")
+
+	// Assert
+	require.NoError(t, err)
+	purger.WaitPurged(t, []string{"alice"})
+	assert.Equal(t, []string{"en", "fr", "hi"}, purger.PurgedLocales("alice"), "pages of the dropped locale are cached too")
+}
+
+func TestRollbackPublishedSiteUseCase_Execute_UpdateFails_DoesNotPurge(t *testing.T) {
+	// Arrange
+	repo := &MockPublishedSiteRepository{
+		FindBySubdomainFn: func(ctx context.Context, subdomain string) (*domain.PublishedSite, error) {
+			return &domain.PublishedSite{ID: "site-1", OwnerUserID: "user-1", Subdomain: subdomain, Published: true, CurrentVersion: 3}, nil
+		},
+		UpdateFn: func(ctx context.Context, site *domain.PublishedSite) error {
+			return errors.New("firestore unavailable")
+		},
+	}
+	store := &MockArtifactStorage{
+		ListVersionsFn: func(ctx context.Context, subdomain string) ([]int, error) {
+			return []int{3, 2, 1}, nil
+		},
+	}
+	purger := &RecordingCachePurger{}
+	useCase := NewRollbackPublishedSiteUseCase(repo, store, purger)
+
+	// Act
+	err := useCase.Execute(context.Background(), "alice", 2, "user-1")
+
+	// Assert
+	require.Error(t, err)
+	assert.Empty(t, purger.Purged(), "cache must not be purged before the pointer moves")
+}
+
+func TestRollbackPublishedSiteUseCase_Execute_PurgeFailure_StillSucceeds(t *testing.T) {
+	// Arrange
+	var saved *domain.PublishedSite
+	repo := &MockPublishedSiteRepository{
+		FindBySubdomainFn: func(ctx context.Context, subdomain string) (*domain.PublishedSite, error) {
+			return &domain.PublishedSite{ID: "site-1", OwnerUserID: "user-1", Subdomain: subdomain, Published: true, CurrentVersion: 3}, nil
+		},
+		UpdateFn: func(ctx context.Context, site *domain.PublishedSite) error {
+			saved = site
+			return nil
+		},
+	}
+	store := &MockArtifactStorage{
+		ListVersionsFn: func(ctx context.Context, subdomain string) ([]int, error) {
+			return []int{3, 2, 1}, nil
+		},
+	}
+	purger := &RecordingCachePurger{Err: errors.New("cloudflare unavailable")}
+	useCase := NewRollbackPublishedSiteUseCase(repo, store, purger)
+
+	// Act
+	err := useCase.Execute(context.Background(), "alice", 1, "user-1")
+
+	// Assert
+	require.NoError(t, err, "purge failures are logged and counted, not returned")
+	require.NotNil(t, saved)
+	assert.Equal(t, 1, saved.CurrentVersion)
+	purger.WaitPurged(t, []string{"alice"})
+}
+
+func TestRollbackPublishedSiteUseCase_Execute_NotOwner_DoesNotPurge(t *testing.T) {
+	// Arrange
+	repo := &MockPublishedSiteRepository{
+		FindBySubdomainFn: func(ctx context.Context, subdomain string) (*domain.PublishedSite, error) {
+			return &domain.PublishedSite{ID: "site-1", OwnerUserID: "user-1", Subdomain: subdomain, Published: true, CurrentVersion: 3}, nil
+		},
+		UpdateFn: func(ctx context.Context, site *domain.PublishedSite) error {
+			t.Fatal("Update should not be called for another user")
+			return nil
+		},
+	}
+	store := &MockArtifactStorage{
+		ListVersionsFn: func(ctx context.Context, subdomain string) ([]int, error) {
+			return []int{3, 2, 1}, nil
+		},
+	}
+	purger := &RecordingCachePurger{}
+	useCase := NewRollbackPublishedSiteUseCase(repo, store, purger)
+
+	// Act
+	err := useCase.Execute(context.Background(), "alice", 2, "someone-else")
+
+	// Assert
+	require.Error(t, err)
+	assert.Empty(t, purger.Purged())
+}
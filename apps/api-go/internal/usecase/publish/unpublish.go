@@ -0,0 +1,77 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/infrastructure/observability"
+	"github.com/sacred-vows/api-go/internal/interfaces/clock"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+)
+
+type UnpublishInvitationUseCase struct {
+	invitationRepo repository.InvitationRepository
+	publishedRepo  repository.PublishedSiteRepository
+	cachePurger    CachePurger
+	clock          clock.Clock
+}
+
+func NewUnpublishInvitationUseCase(
+	invitationRepo repository.InvitationRepository,
+	publishedRepo repository.PublishedSiteRepository,
+	cachePurger CachePurger,
+	clk clock.Clock,
+) *UnpublishInvitationUseCase {
+	return &UnpublishInvitationUseCase{
+		invitationRepo: invitationRepo,
+		publishedRepo:  publishedRepo,
+		cachePurger:    cachePurger,
+		clock:          clk,
+	}
+}
+
+// Execute takes an invitation's site offline and marks the invitation unpublished.
+// Published versions stay in storage, so publishing again only adds a new version.
+// Archiving leaves a site online, so an archived (or since unarchived) invitation with a live site
+// can still be unpublished; it keeps its status.
+func (uc *UnpublishInvitationUseCase) Execute(ctx context.Context, invitationID, ownerUserID string) error {
+	inv, err := uc.invitationRepo.FindByID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if inv == nil {
+		return fmt.Errorf("invitation not found")
+	}
+	if inv.UserID != ownerUserID {
+		return fmt.Errorf("forbidden")
+	}
+
+	site, err := uc.publishedRepo.FindByInvitationID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	online := site != nil && site.Published
+	keepStatus := false
+	if err := inv.TransitionTo(domain.InvitationStatusUnpublished); err != nil {
+		if !online {
+			return err
+		}
+		keepStatus = true
+	}
+
+	if online {
+		site.Published = false
+		site.UpdatedAt = uc.clock.Now()
+		if err := uc.publishedRepo.Update(ctx, site); err != nil {
+			return err
+		}
+		observability.RecordInvitationUnpublished()
+		purgeSiteCache(ctx, uc.cachePurger, site.Subdomain, site.Locales, "unpublish")
+	}
+
+	if keepStatus {
+		return nil
+	}
+	return uc.invitationRepo.UpdateStatus(ctx, invitationID, domain.InvitationStatusUnpublished)
+}
@@ -0,0 +1,76 @@
+package publish
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpublishInvitationUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        domain.InvitationStatus
+		siteOnline    bool
+		owner         string
+		wantErr       error
+		wantOffline   bool
+		wantNewStatus domain.InvitationStatus
+	}{
+		{"published site goes offline", domain.InvitationStatusPublished, true, "user-1", nil, true, domain.InvitationStatusUnpublished},
+		{"draft without a live site cannot be unpublished", domain.InvitationStatusDraft, false, "user-1", domain.ErrInvalidTransition, false, ""},
+		{"archived site goes offline and stays archived", domain.InvitationStatusArchived, true, "user-1", nil, true, ""},
+		{"unarchived draft site goes offline and stays draft", domain.InvitationStatusDraft, true, "user-1", nil, true, ""},
+		{"other owner", domain.InvitationStatusPublished, true, "user-2", nil, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var written domain.InvitationStatus
+			invitationRepo := &MockInvitationRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Invitation, error) {
+					return &domain.Invitation{ID: id, UserID: tt.owner, Status: tt.status}, nil
+				},
+				UpdateStatusFn: func(ctx context.Context, id string, status domain.InvitationStatus) error {
+					written = status
+					return nil
+				},
+			}
+			var updatedSite *domain.PublishedSite
+			publishedRepo := &MockPublishedSiteRepository{
+				FindByInvitationIDFn: func(ctx context.Context, invitationID string) (*domain.PublishedSite, error) {
+					return &domain.PublishedSite{InvitationID: invitationID, Subdomain: "alice", Published: tt.siteOnline, CurrentVersion: 3}, nil
+				},
+				UpdateFn: func(ctx context.Context, site *domain.PublishedSite) error {
+					updatedSite = site
+					return nil
+				},
+			}
+			purger := &RecordingCachePurger{}
+			useCase := NewUnpublishInvitationUseCase(invitationRepo, publishedRepo, purger, &MockClock{})
+
+			// Act
+			err := useCase.Execute(context.Background(), "inv-1", "user-1")
+
+			// Assert
+			if !tt.wantOffline {
+				require.Error(t, err)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				assert.Nil(t, updatedSite, "Site must stay online")
+				assert.Empty(t, written)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, updatedSite)
+			assert.False(t, updatedSite.Published)
+			assert.Equal(t, 3, updatedSite.CurrentVersion, "Versions are kept for republishing")
+			purger.WaitPurged(t, []string{"alice"})
+			assert.Equal(t, tt.wantNewStatus, written)
+		})
+	}
+}
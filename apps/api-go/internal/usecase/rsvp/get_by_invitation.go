@@ -2,6 +2,7 @@ package rsvp
 
 import (
 	"context"
+	stderrors "errors"
 
 	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 	"github.com/sacred-vows/api-go/pkg/errors"
@@ -19,14 +20,26 @@ func NewGetRSVPByInvitationUseCase(rsvpRepo repository.RSVPRepository) *GetRSVPB
 
 type GetRSVPByInvitationOutput struct {
 	Responses []*RSVPDTO
-	Count     int
+	// Count is the number of responses across all pages that match the filters
+	Count int
+	// NextCursor fetches the next page; empty on the last page
+	NextCursor string
 }
 
-func (uc *GetRSVPByInvitationUseCase) Execute(ctx context.Context, invitationID string) (*GetRSVPByInvitationOutput, error) {
-	responses, err := uc.rsvpRepo.FindByInvitationID(ctx, invitationID)
+func (uc *GetRSVPByInvitationUseCase) Execute(ctx context.Context, invitationID string, opts repository.ListOptions) (*GetRSVPByInvitationOutput, error) {
+	page, err := uc.rsvpRepo.FindByInvitationID(ctx, invitationID, opts)
 	if err != nil {
+		if stderrors.Is(err, repository.ErrInvalidListOptions) {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, err.Error(), err)
+		}
 		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get RSVP responses", err)
 	}
+	responses := page.Items
+
+	count, err := uc.rsvpRepo.CountByInvitationID(ctx, invitationID, opts.Filters)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to count RSVP responses", err)
+	}
 
 	dtos := make([]*RSVPDTO, len(responses))
 	for i, rsvp := range responses {
@@ -34,7 +47,8 @@ func (uc *GetRSVPByInvitationUseCase) Execute(ctx context.Context, invitationID
 	}
 
 	return &GetRSVPByInvitationOutput{
-		Responses: dtos,
-		Count:     len(dtos),
+		Responses:  dtos,
+		Count:      count,
+		NextCursor: page.NextCursor,
 	}, nil
 }
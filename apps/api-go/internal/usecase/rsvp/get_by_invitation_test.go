@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -36,24 +37,27 @@ func TestGetRSVPByInvitationUseCase_Execute_InvitationHasRSVPs_ReturnsRSVPs(t *t
 	}
 
 	mockRepo := &MockRSVPRepository{
-		FindByInvitationIDFn: func(ctx context.Context, id string) ([]*domain.RSVPResponse, error) {
+		FindByInvitationIDFn: func(ctx context.Context, id string, opts repository.ListOptions) (*repository.Page[*domain.RSVPResponse], error) {
 			if id == invitationID {
-				return rsvps, nil
+				return &repository.Page[*domain.RSVPResponse]{Items: rsvps}, nil
 			}
 			return nil, nil
 		},
+		CountByInvitationIDFn: func(ctx context.Context, id string, filters map[string]string) (int, error) {
+			return 7, nil
+		},
 	}
 
 	useCase := NewGetRSVPByInvitationUseCase(mockRepo)
 
 	// Act
-	output, err := useCase.Execute(context.Background(), invitationID)
+	output, err := useCase.Execute(context.Background(), invitationID, repository.ListOptions{})
 
 	// Assert
 	require.NoError(t, err, "Get RSVPs should not return error")
 	require.NotNil(t, output, "Output should not be nil")
 	require.Len(t, output.Responses, 2, "Should return 2 RSVPs")
-	assert.Equal(t, 2, output.Count, "Count should be 2")
+	assert.Equal(t, 7, output.Count, "Count should include every page")
 	assert.Equal(t, "rsvp-1", output.Responses[0].ID, "First RSVP ID should match")
 	assert.Equal(t, "rsvp-2", output.Responses[1].ID, "Second RSVP ID should match")
 }
@@ -4,13 +4,15 @@ import (
 	"context"
 
 	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
 )
 
 // MockRSVPRepository is a hand-written mock implementation of RSVPRepository
 type MockRSVPRepository struct {
-	CreateFn             func(ctx context.Context, rsvp *domain.RSVPResponse) error
-	FindByInvitationIDFn func(ctx context.Context, invitationID string) ([]*domain.RSVPResponse, error)
-	FindByIDFn           func(ctx context.Context, id string) (*domain.RSVPResponse, error)
+	CreateFn              func(ctx context.Context, rsvp *domain.RSVPResponse) error
+	FindByInvitationIDFn  func(ctx context.Context, invitationID string, opts repository.ListOptions) (*repository.Page[*domain.RSVPResponse], error)
+	CountByInvitationIDFn func(ctx context.Context, invitationID string, filters map[string]string) (int, error)
+	FindByIDFn            func(ctx context.Context, id string) (*domain.RSVPResponse, error)
 }
 
 func (m *MockRSVPRepository) Create(ctx context.Context, rsvp *domain.RSVPResponse) error {
@@ -20,13 +22,20 @@ func (m *MockRSVPRepository) Create(ctx context.Context, rsvp *domain.RSVPRespon
 	return nil
 }
 
-func (m *MockRSVPRepository) FindByInvitationID(ctx context.Context, invitationID string) ([]*domain.RSVPResponse, error) {
+func (m *MockRSVPRepository) FindByInvitationID(ctx context.Context, invitationID string, opts repository.ListOptions) (*repository.Page[*domain.RSVPResponse], error) {
 	if m.FindByInvitationIDFn != nil {
-		return m.FindByInvitationIDFn(ctx, invitationID)
+		return m.FindByInvitationIDFn(ctx, invitationID, opts)
 	}
 	return nil, nil
 }
 
+func (m *MockRSVPRepository) CountByInvitationID(ctx context.Context, invitationID string, filters map[string]string) (int, error) {
+	if m.CountByInvitationIDFn != nil {
+		return m.CountByInvitationIDFn(ctx, invitationID, filters)
+	}
+	return 0, nil
+}
+
 func (m *MockRSVPRepository) FindByID(ctx context.Context, id string) (*domain.RSVPResponse, error) {
 	if m.FindByIDFn != nil {
 		return m.FindByIDFn(ctx, id)
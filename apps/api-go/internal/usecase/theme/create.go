@@ -0,0 +1,53 @@
+package theme
+
+import (
+	"context"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// CreateThemeUseCase saves a custom theme for the caller
+type CreateThemeUseCase struct {
+	themeRepo repository.ThemeRepository
+	validator styleValidator
+}
+
+func NewCreateThemeUseCase(themeRepo repository.ThemeRepository, catalog *layout.LayoutCatalog) *CreateThemeUseCase {
+	return &CreateThemeUseCase{
+		themeRepo: themeRepo,
+		validator: styleValidator{catalog: catalog},
+	}
+}
+
+type CreateThemeInput struct {
+	UserID string
+	Name   string
+	// Colors and Fonts are keyed by role, like the themes of layout manifests
+	Colors map[string]string
+	Fonts  map[string]string
+}
+
+func (uc *CreateThemeUseCase) Execute(ctx context.Context, input CreateThemeInput) (*ThemeDTO, error) {
+	fonts, details, err := uc.validator.validate(ctx, input.Colors, input.Fonts)
+	if err != nil {
+		return nil, err
+	}
+	theme, err := domain.NewTheme(input.UserID, input.Name, input.Colors, fonts)
+	if err != nil {
+		details = append([]errors.FieldError{{Field: "/name", Message: "is required (at most 60 characters)"}}, details...)
+	}
+	if len(details) > 0 {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid theme", nil).WithDetails(details)
+	}
+
+	theme.ID = ksuid.New().String()
+	if err := uc.themeRepo.Create(ctx, theme); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to save theme", err)
+	}
+
+	return toThemeDTO(theme), nil
+}
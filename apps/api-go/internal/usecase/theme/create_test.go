@@ -0,0 +1,108 @@
+package theme
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFontCatalog returns an uncached catalog with one layout whose theme uses Playfair Display,
+// Lato and Great Vibes
+func newFontCatalog() *layout.LayoutCatalog {
+	manifest := json.RawMessage(`{"themes": [{"id": "gold", "fonts": {"heading": "Playfair Display", "body": "Lato", "script": "Great Vibes"}}]}`)
+	repo := &MockLayoutRepository{
+		FindAllFn: func(ctx context.Context) ([]*domain.Layout, error) {
+			return []*domain.Layout{{ID: "classic-scroll", Name: "Classic Scroll", Manifest: &manifest, IsActive: true}}, nil
+		},
+	}
+	return layout.NewLayoutCatalog(repo, nil, 0, nil)
+}
+
+func validColors() map[string]string {
+	return map[string]string{"primary": "#1a2238", "secondary": "#d4af37", "background": "#fffaf0", "text": "#222"}
+}
+
+func TestCreateThemeUseCase_Execute_SavesThemeWithCatalogFontNames(t *testing.T) {
+	// Arrange
+	var saved *domain.Theme
+	repo := &MockThemeRepository{
+		CreateFn: func(ctx context.Context, theme *domain.Theme) error {
+			saved = theme
+			return nil
+		},
+	}
+	uc := NewCreateThemeUseCase(repo, newFontCatalog())
+
+	// Act
+	output, err := uc.Execute(context.Background(), CreateThemeInput{
+		UserID: "user-1",
+		Name:   "  Midnight gold ",
+		Colors: validColors(),
+		Fonts:  map[string]string{"heading": "playfair display", "body": "Lato", "script": " GREAT VIBES"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.NotEmpty(t, output.ID)
+	assert.Equal(t, "Midnight gold", output.Name)
+	assert.Equal(t, "user-1", output.UserID)
+	assert.Equal(t, map[string]string{"heading": "Playfair Display", "body": "Lato", "script": "Great Vibes"}, saved.Fonts)
+}
+
+func TestCreateThemeUseCase_Execute_InvalidStyleListsEveryProblem(t *testing.T) {
+	// Arrange
+	created := false
+	repo := &MockThemeRepository{
+		CreateFn: func(ctx context.Context, theme *domain.Theme) error {
+			created = true
+			return nil
+		},
+	}
+	uc := NewCreateThemeUseCase(repo, newFontCatalog())
+	colors := validColors()
+	colors["primary"] = "navy"
+	colors["border"] = "#000000"
+	delete(colors, "text")
+
+	// Act
+	_, err := uc.Execute(context.Background(), CreateThemeInput{
+		UserID: "user-1",
+		Name:   " ",
+		Colors: colors,
+		Fonts:  map[string]string{"heading": "Comic Sans MS", "body": "Lato"},
+	})
+
+	// Assert
+	require.Error(t, err)
+	assert.False(t, created)
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrBadRequest.Code, appErr.Code)
+	assert.Equal(t, "Invalid theme", appErr.Message)
+	fields := make([]string, len(appErr.Details))
+	for i, detail := range appErr.Details {
+		fields[i] = detail.Field
+	}
+	assert.Equal(t, []string{"/name", "/colors/text", "/colors/border", "/colors/primary", "/fonts/script", "/fonts/heading"}, fields)
+}
+
+func TestCreateThemeUseCase_Execute_WithoutCatalogAcceptsAnyFont(t *testing.T) {
+	uc := NewCreateThemeUseCase(&MockThemeRepository{}, nil)
+
+	output, err := uc.Execute(context.Background(), CreateThemeInput{
+		UserID: "user-1",
+		Name:   "Plain",
+		Colors: validColors(),
+		Fonts:  map[string]string{"heading": "Georgia", "body": "Georgia", "script": "Georgia"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Georgia", output.Fonts["heading"])
+}
@@ -0,0 +1,30 @@
+package theme
+
+import (
+	"context"
+
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// DeleteThemeUseCase permanently deletes one of the caller's themes. Invitations that still refer
+// to it render with the colors and fonts saved in their own data.
+type DeleteThemeUseCase struct {
+	themeRepo repository.ThemeRepository
+}
+
+func NewDeleteThemeUseCase(themeRepo repository.ThemeRepository) *DeleteThemeUseCase {
+	return &DeleteThemeUseCase{
+		themeRepo: themeRepo,
+	}
+}
+
+func (uc *DeleteThemeUseCase) Execute(ctx context.Context, id, userID string) error {
+	if _, err := findOwnedTheme(ctx, uc.themeRepo, id, userID); err != nil {
+		return err
+	}
+	if err := uc.themeRepo.Delete(ctx, id); err != nil {
+		return errors.Wrap(errors.ErrInternalServerError.Code, "Failed to delete theme", err)
+	}
+	return nil
+}
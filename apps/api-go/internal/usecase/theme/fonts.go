@@ -0,0 +1,26 @@
+package theme
+
+import (
+	"context"
+
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+)
+
+// ListFontsUseCase lists the fonts custom themes may use: those of the catalog layouts' themes
+type ListFontsUseCase struct {
+	catalog *layout.LayoutCatalog
+}
+
+func NewListFontsUseCase(catalog *layout.LayoutCatalog) *ListFontsUseCase {
+	return &ListFontsUseCase{
+		catalog: catalog,
+	}
+}
+
+func (uc *ListFontsUseCase) Execute(ctx context.Context) ([]string, error) {
+	snapshot, err := uc.catalog.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Fonts(), nil
+}
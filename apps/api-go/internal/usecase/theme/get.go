@@ -0,0 +1,65 @@
+package theme
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// ListThemesUseCase lists a user's themes, one page at a time
+type ListThemesUseCase struct {
+	themeRepo repository.ThemeRepository
+}
+
+func NewListThemesUseCase(themeRepo repository.ThemeRepository) *ListThemesUseCase {
+	return &ListThemesUseCase{
+		themeRepo: themeRepo,
+	}
+}
+
+type ListThemesOutput struct {
+	Themes []*ThemeDTO
+	// NextCursor fetches the next page; empty on the last page
+	NextCursor string
+}
+
+func (uc *ListThemesUseCase) Execute(ctx context.Context, userID string, opts repository.ListOptions) (*ListThemesOutput, error) {
+	page, err := uc.themeRepo.FindByUserID(ctx, userID, opts)
+	if err != nil {
+		if stderrors.Is(err, repository.ErrInvalidListOptions) {
+			return nil, errors.Wrap(errors.ErrBadRequest.Code, err.Error(), err)
+		}
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to get themes", err)
+	}
+
+	dtos := make([]*ThemeDTO, len(page.Items))
+	for i, theme := range page.Items {
+		dtos[i] = toThemeDTO(theme)
+	}
+
+	return &ListThemesOutput{
+		Themes:     dtos,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// GetThemeUseCase returns one of the caller's themes
+type GetThemeUseCase struct {
+	themeRepo repository.ThemeRepository
+}
+
+func NewGetThemeUseCase(themeRepo repository.ThemeRepository) *GetThemeUseCase {
+	return &GetThemeUseCase{
+		themeRepo: themeRepo,
+	}
+}
+
+func (uc *GetThemeUseCase) Execute(ctx context.Context, id, userID string) (*ThemeDTO, error) {
+	theme, err := findOwnedTheme(ctx, uc.themeRepo, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toThemeDTO(theme), nil
+}
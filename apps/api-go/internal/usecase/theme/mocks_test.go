@@ -0,0 +1,89 @@
+package theme
+
+import (
+	"context"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+)
+
+// MockThemeRepository is a hand-written mock implementation of ThemeRepository
+type MockThemeRepository struct {
+	CreateFn       func(ctx context.Context, theme *domain.Theme) error
+	FindByIDFn     func(ctx context.Context, id string) (*domain.Theme, error)
+	FindByUserIDFn func(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Theme], error)
+	UpdateFn       func(ctx context.Context, theme *domain.Theme) error
+	DeleteFn       func(ctx context.Context, id string) error
+}
+
+func (m *MockThemeRepository) Create(ctx context.Context, theme *domain.Theme) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, theme)
+	}
+	return nil
+}
+
+func (m *MockThemeRepository) FindByID(ctx context.Context, id string) (*domain.Theme, error) {
+	if m.FindByIDFn != nil {
+		return m.FindByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockThemeRepository) FindByUserID(ctx context.Context, userID string, opts repository.ListOptions) (*repository.Page[*domain.Theme], error) {
+	if m.FindByUserIDFn != nil {
+		return m.FindByUserIDFn(ctx, userID, opts)
+	}
+	return &repository.Page[*domain.Theme]{}, nil
+}
+
+func (m *MockThemeRepository) Update(ctx context.Context, theme *domain.Theme) error {
+	if m.UpdateFn != nil {
+		return m.UpdateFn(ctx, theme)
+	}
+	return nil
+}
+
+func (m *MockThemeRepository) Delete(ctx context.Context, id string) error {
+	if m.DeleteFn != nil {
+		return m.DeleteFn(ctx, id)
+	}
+	return nil
+}
+
+// MockLayoutRepository is a hand-written mock implementation of LayoutRepository that serves the
+// layouts the font catalog is built from
+type MockLayoutRepository struct {
+	FindAllFn func(ctx context.Context) ([]*domain.Layout, error)
+}
+
+func (m *MockLayoutRepository) Create(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+	return nil
+}
+
+func (m *MockLayoutRepository) FindByID(ctx context.Context, id string) (*domain.Layout, error) {
+	return nil, nil
+}
+
+func (m *MockLayoutRepository) FindAll(ctx context.Context) ([]*domain.Layout, error) {
+	if m.FindAllFn != nil {
+		return m.FindAllFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockLayoutRepository) FindAllIncludingInactive(ctx context.Context) ([]*domain.Layout, error) {
+	return m.FindAll(ctx)
+}
+
+func (m *MockLayoutRepository) FindVersion(ctx context.Context, id, version string) (*domain.Layout, error) {
+	return nil, nil
+}
+
+func (m *MockLayoutRepository) Update(ctx context.Context, layout *domain.Layout, audit *domain.AuditEntry) error {
+	return nil
+}
+
+func (m *MockLayoutRepository) Delete(ctx context.Context, id string) error {
+	return nil
+}
@@ -0,0 +1,68 @@
+package theme
+
+import (
+	"context"
+	"maps"
+
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// CustomThemePreset is the theme preset of invitations styled with a custom theme
+const CustomThemePreset = "custom"
+
+// ResolveThemeUseCase replaces the custom theme an invitation refers to
+// (layoutConfig.theme.customThemeId) with its colors and fonts, so the renderer does not need
+// to know about saved themes.
+type ResolveThemeUseCase struct {
+	themeRepo repository.ThemeRepository
+}
+
+func NewResolveThemeUseCase(themeRepo repository.ThemeRepository) *ResolveThemeUseCase {
+	return &ResolveThemeUseCase{
+		themeRepo: themeRepo,
+	}
+}
+
+// Execute resolves the custom theme of layoutConfig in place. ownerID is the invitation owner;
+// themes of other users are never applied. Deleted or foreign themes are logged and the
+// invitation keeps the colors and fonts saved in its data. Only repository failures are returned.
+func (uc *ResolveThemeUseCase) Execute(ctx context.Context, ownerID string, layoutConfig map[string]any) error {
+	themeConfig, ok := layoutConfig["theme"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	themeID, _ := themeConfig["customThemeId"].(string)
+	if themeID == "" {
+		return nil
+	}
+
+	theme, err := uc.themeRepo.FindByID(ctx, themeID)
+	if err != nil {
+		return err
+	}
+	if theme == nil || theme.UserID != ownerID {
+		logger.GetLogger().Warn("Custom theme of invitation not found; rendering its saved theme",
+			zap.String("themeId", themeID),
+			zap.String("userId", ownerID),
+		)
+		return nil
+	}
+
+	colors := make(map[string]any, len(theme.Colors))
+	for role, color := range theme.Colors {
+		colors[role] = color
+	}
+	fonts := make(map[string]any, len(theme.Fonts))
+	for role, font := range theme.Fonts {
+		fonts[role] = font
+	}
+
+	resolved := maps.Clone(themeConfig)
+	resolved["preset"] = CustomThemePreset
+	resolved["colors"] = colors
+	resolved["fonts"] = fonts
+	layoutConfig["theme"] = resolved
+	return nil
+}
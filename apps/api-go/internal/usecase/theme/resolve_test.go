@@ -0,0 +1,80 @@
+package theme
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveThemeUseCase_Execute_AppliesOwnersTheme(t *testing.T) {
+	// Arrange
+	repo := &MockThemeRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Theme, error) {
+			assert.Equal(t, "theme-1", id)
+			return storedTheme(), nil
+		},
+	}
+	uc := NewResolveThemeUseCase(repo)
+	layoutConfig := map[string]any{
+		"theme": map[string]any{
+			"preset":        "gold",
+			"customThemeId": "theme-1",
+			"colors":        map[string]any{"primary": "#ffffff"},
+		},
+	}
+
+	// Act
+	err := uc.Execute(context.Background(), "user-1", layoutConfig)
+
+	// Assert
+	require.NoError(t, err)
+	theme := layoutConfig["theme"].(map[string]any)
+	assert.Equal(t, CustomThemePreset, theme["preset"])
+	assert.Equal(t, "theme-1", theme["customThemeId"])
+	assert.Equal(t, "#1a2238", theme["colors"].(map[string]any)["primary"])
+	assert.Equal(t, "Great Vibes", theme["fonts"].(map[string]any)["script"])
+}
+
+func TestResolveThemeUseCase_Execute_KeepsSavedThemeWhenUnavailable(t *testing.T) {
+	tests := []struct {
+		name  string
+		theme *domain.Theme
+	}{
+		{name: "deleted theme", theme: nil},
+		{name: "theme of another user", theme: storedTheme()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockThemeRepository{
+				FindByIDFn: func(ctx context.Context, id string) (*domain.Theme, error) {
+					return tt.theme, nil
+				},
+			}
+			original := map[string]any{"preset": "gold", "customThemeId": "theme-1"}
+			layoutConfig := map[string]any{"theme": original}
+
+			err := NewResolveThemeUseCase(repo).Execute(context.Background(), "user-2", layoutConfig)
+
+			require.NoError(t, err)
+			assert.Equal(t, original, layoutConfig["theme"])
+		})
+	}
+}
+
+func TestResolveThemeUseCase_Execute_RepositoryFailure(t *testing.T) {
+	repo := &MockThemeRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Theme, error) {
+			return nil, stderrors.New("firestore unavailable")
+		},
+	}
+	layoutConfig := map[string]any{"theme": map[string]any{"customThemeId": "theme-1"}}
+
+	err := NewResolveThemeUseCase(repo).Execute(context.Background(), "user-1", layoutConfig)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package theme
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// ThemeDTO represents a user's custom theme
+type ThemeDTO struct {
+	ID        string            `json:"id"`
+	UserID    string            `json:"userId"`
+	Name      string            `json:"name"`
+	Colors    map[string]string `json:"colors"`
+	Fonts     map[string]string `json:"fonts"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+func toThemeDTO(theme *domain.Theme) *ThemeDTO {
+	return &ThemeDTO{
+		ID:        theme.ID,
+		UserID:    theme.UserID,
+		Name:      theme.Name,
+		Colors:    theme.Colors,
+		Fonts:     theme.Fonts,
+		CreatedAt: theme.CreatedAt,
+		UpdatedAt: theme.UpdatedAt,
+	}
+}
+
+// findOwnedTheme returns the theme when it exists and belongs to userID
+func findOwnedTheme(ctx context.Context, themeRepo repository.ThemeRepository, id, userID string) (*domain.Theme, error) {
+	theme, err := themeRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to find theme", err)
+	}
+	if theme == nil {
+		return nil, errors.Wrap(errors.ErrNotFound.Code, "Theme not found", nil)
+	}
+	if theme.UserID != userID {
+		return nil, errors.Wrap(errors.ErrForbidden.Code, "Theme belongs to another user", nil)
+	}
+	return theme, nil
+}
+
+// styleValidator checks theme colors and fonts. Fonts must be used by a theme of a catalog
+// layout, as only those are loaded by the renderer.
+type styleValidator struct {
+	catalog *layout.LayoutCatalog // Optional; without it any font name is accepted
+}
+
+// validate returns the problems with colors and fonts as field errors ("/colors/primary"), and
+// fonts with their names spelled as in the catalog
+func (v styleValidator) validate(ctx context.Context, colors, fonts map[string]string) (map[string]string, []errors.FieldError, error) {
+	var details []errors.FieldError
+	for _, role := range domain.RequiredThemeColorRoles {
+		if _, ok := colors[role]; !ok {
+			details = append(details, errors.FieldError{Field: "/colors/" + role, Message: "is required"})
+		}
+	}
+	for _, role := range sortedKeys(colors) {
+		switch {
+		case !domain.IsThemeColorRole(role):
+			details = append(details, errors.FieldError{Field: "/colors/" + role, Message: fmt.Sprintf("is not a theme color; use one of %s", strings.Join(domain.ThemeColorRoles, ", "))})
+		case !domain.IsValidThemeColor(colors[role]):
+			details = append(details, errors.FieldError{Field: "/colors/" + role, Message: fmt.Sprintf("must be a hex color like #d4af37, got %q", colors[role])})
+		}
+	}
+
+	supported, err := v.supportedFonts(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved := make(map[string]string, len(fonts))
+	for _, role := range domain.ThemeFontRoles {
+		if _, ok := fonts[role]; !ok {
+			details = append(details, errors.FieldError{Field: "/fonts/" + role, Message: "is required"})
+		}
+	}
+	for _, role := range sortedKeys(fonts) {
+		font := strings.TrimSpace(fonts[role])
+		if !domain.IsThemeFontRole(role) {
+			details = append(details, errors.FieldError{Field: "/fonts/" + role, Message: fmt.Sprintf("is not a theme font; use one of %s", strings.Join(domain.ThemeFontRoles, ", "))})
+			continue
+		}
+		if font == "" {
+			details = append(details, errors.FieldError{Field: "/fonts/" + role, Message: "is required"})
+			continue
+		}
+		if supported != nil {
+			name, ok := supported[strings.ToLower(font)]
+			if !ok {
+				details = append(details, errors.FieldError{Field: "/fonts/" + role, Message: fmt.Sprintf("font %q is not supported by any layout", font)})
+				continue
+			}
+			font = name
+		}
+		resolved[role] = font
+	}
+	return resolved, details, nil
+}
+
+// supportedFonts maps the lowercased fonts of the catalog to their spelling; nil without a catalog
+func (v styleValidator) supportedFonts(ctx context.Context) (map[string]string, error) {
+	if v.catalog == nil {
+		return nil, nil
+	}
+	snapshot, err := v.catalog.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	supported := map[string]string{}
+	for _, font := range snapshot.Fonts() {
+		supported[strings.ToLower(font)] = font
+	}
+	return supported, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
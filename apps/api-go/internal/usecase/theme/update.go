@@ -0,0 +1,70 @@
+package theme
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"github.com/sacred-vows/api-go/internal/interfaces/repository"
+	"github.com/sacred-vows/api-go/internal/usecase/layout"
+	"github.com/sacred-vows/api-go/pkg/errors"
+)
+
+// UpdateThemeUseCase changes one of the caller's themes. Invitations using it render with the
+// new colors and fonts the next time they are published.
+type UpdateThemeUseCase struct {
+	themeRepo repository.ThemeRepository
+	validator styleValidator
+}
+
+func NewUpdateThemeUseCase(themeRepo repository.ThemeRepository, catalog *layout.LayoutCatalog) *UpdateThemeUseCase {
+	return &UpdateThemeUseCase{
+		themeRepo: themeRepo,
+		validator: styleValidator{catalog: catalog},
+	}
+}
+
+// UpdateThemeInput changes the fields that are set. Colors and Fonts replace the stored maps.
+type UpdateThemeInput struct {
+	ID     string
+	UserID string
+	Name   *string
+	Colors map[string]string
+	Fonts  map[string]string
+}
+
+func (uc *UpdateThemeUseCase) Execute(ctx context.Context, input UpdateThemeInput) (*ThemeDTO, error) {
+	theme, err := findOwnedTheme(ctx, uc.themeRepo, input.ID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		theme.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Colors != nil {
+		theme.Colors = maps.Clone(input.Colors)
+	}
+	if input.Fonts != nil {
+		theme.Fonts = input.Fonts
+	}
+
+	// Stored themes are checked again: a font may have left the catalog since they were saved
+	fonts, details, err := uc.validator.validate(ctx, theme.Colors, theme.Fonts)
+	if err != nil {
+		return nil, err
+	}
+	if err := theme.Validate(); err != nil {
+		details = append([]errors.FieldError{{Field: "/name", Message: "is required (at most 60 characters)"}}, details...)
+	}
+	if len(details) > 0 {
+		return nil, errors.Wrap(errors.ErrBadRequest.Code, "Invalid theme", nil).WithDetails(details)
+	}
+	theme.Fonts = fonts
+
+	if err := uc.themeRepo.Update(ctx, theme); err != nil {
+		return nil, errors.Wrap(errors.ErrInternalServerError.Code, "Failed to update theme", err)
+	}
+
+	return toThemeDTO(theme), nil
+}
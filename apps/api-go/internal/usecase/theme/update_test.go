@@ -0,0 +1,73 @@
+package theme
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacred-vows/api-go/internal/domain"
+	"github.com/sacred-vows/api-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func storedTheme() *domain.Theme {
+	return &domain.Theme{
+		ID:     "theme-1",
+		UserID: "user-1",
+		Name:   "Midnight gold",
+		Colors: validColors(),
+		Fonts:  map[string]string{"heading": "Playfair Display", "body": "Lato", "script": "Great Vibes"},
+	}
+}
+
+func TestUpdateThemeUseCase_Execute_ReplacesChangedFields(t *testing.T) {
+	// Arrange
+	var updated *domain.Theme
+	repo := &MockThemeRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Theme, error) {
+			return storedTheme(), nil
+		},
+		UpdateFn: func(ctx context.Context, theme *domain.Theme) error {
+			updated = theme
+			return nil
+		},
+	}
+	uc := NewUpdateThemeUseCase(repo, newFontCatalog())
+	name := "Ivory"
+
+	// Act
+	output, err := uc.Execute(context.Background(), UpdateThemeInput{
+		ID:     "theme-1",
+		UserID: "user-1",
+		Name:   &name,
+		Fonts:  map[string]string{"heading": "lato", "body": "Lato", "script": "Great Vibes"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, "Ivory", output.Name)
+	assert.Equal(t, "Lato", updated.Fonts["heading"])
+	assert.Equal(t, validColors(), updated.Colors, "colors are kept when not sent")
+}
+
+func TestUpdateThemeUseCase_Execute_ThemeOfAnotherUser(t *testing.T) {
+	repo := &MockThemeRepository{
+		FindByIDFn: func(ctx context.Context, id string) (*domain.Theme, error) {
+			return storedTheme(), nil
+		},
+		UpdateFn: func(ctx context.Context, theme *domain.Theme) error {
+			t.Fatal("theme of another user must not be updated")
+			return nil
+		},
+	}
+	uc := NewUpdateThemeUseCase(repo, newFontCatalog())
+
+	_, err := uc.Execute(context.Background(), UpdateThemeInput{ID: "theme-1", UserID: "user-2"})
+
+	require.Error(t, err)
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrForbidden.Code, appErr.Code)
+	assert.Equal(t, "Theme belongs to another user", appErr.Message)
+}
@@ -0,0 +1,11 @@
+// Package layouts is the layout catalog: one directory per layout, named after its ID, holding
+// the layout's manifest.json and config.json. It is embedded into the binaries that seed and
+// sync layouts, so editing a layout no longer takes a Firestore migration.
+package layouts
+
+import "embed"
+
+// FS holds the catalog files
+//
+//go:embed */manifest.json */config.json
+var FS embed.FS
@@ -7,9 +7,17 @@ import (
 
 // AppError represents an application error
 type AppError struct {
-	Code    int    `json:"code"`
-	Message string `json:"error"`
-	Err     error  `json:"-"`
+	Code    int          `json:"code"`
+	Message string       `json:"error"`
+	Details []FieldError `json:"details,omitempty"`
+	Err     error        `json:"-"`
+}
+
+// FieldError describes a problem with one field of the request. Field is a JSON Pointer into
+// the request body, e.g. "/data/layoutConfig/sections/0/id".
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 func (e *AppError) Error() string {
@@ -40,6 +48,12 @@ func Wrap(code int, message string, err error) *AppError {
 	}
 }
 
+// WithDetails attaches field-level errors to the AppError and returns it
+func (e *AppError) WithDetails(details []FieldError) *AppError {
+	e.Details = details
+	return e
+}
+
 // Predefined errors
 var (
 	ErrNotFound            = New(http.StatusNotFound, "Resource not found")
@@ -53,12 +67,14 @@ var (
 
 // ErrorResponse represents an error response
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error   string       `json:"error"`
+	Details []FieldError `json:"details,omitempty"`
 }
 
 // ToResponse converts AppError to ErrorResponse
 func (e *AppError) ToResponse() ErrorResponse {
 	return ErrorResponse{
-		Error: e.Message,
+		Error:   e.Message,
+		Details: e.Details,
 	}
 }
@@ -0,0 +1,197 @@
+package jsonpatch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "add object member",
+			doc:   `{"bride":"Jane"}`,
+			patch: `[{"op":"add","path":"/groom","value":"John"}]`,
+			want:  `{"bride":"Jane","groom":"John"}`,
+		},
+		{
+			name:  "add nested object",
+			doc:   `{}`,
+			patch: `[{"op":"add","path":"/venue","value":{"name":"Hall"}}]`,
+			want:  `{"venue":{"name":"Hall"}}`,
+		},
+		{
+			name:  "insert into array",
+			doc:   `{"photos":["a","c"]}`,
+			patch: `[{"op":"add","path":"/photos/1","value":"b"}]`,
+			want:  `{"photos":["a","b","c"]}`,
+		},
+		{
+			name:  "append to array",
+			doc:   `{"photos":["a"]}`,
+			patch: `[{"op":"add","path":"/photos/-","value":"b"}]`,
+			want:  `{"photos":["a","b"]}`,
+		},
+		{
+			name:  "remove array element",
+			doc:   `{"photos":["a","b","c"]}`,
+			patch: `[{"op":"remove","path":"/photos/1"}]`,
+			want:  `{"photos":["a","c"]}`,
+		},
+		{
+			name:  "replace value",
+			doc:   `{"bride":"Jane"}`,
+			patch: `[{"op":"replace","path":"/bride","value":"Janet"}]`,
+			want:  `{"bride":"Janet"}`,
+		},
+		{
+			name:  "move value",
+			doc:   `{"a":{"x":1},"b":{}}`,
+			patch: `[{"op":"move","from":"/a/x","path":"/b/y"}]`,
+			want:  `{"a":{},"b":{"y":1}}`,
+		},
+		{
+			name:  "copy value",
+			doc:   `{"a":{"x":[1]}}`,
+			patch: `[{"op":"copy","from":"/a/x","path":"/b"}]`,
+			want:  `{"a":{"x":[1]},"b":[1]}`,
+		},
+		{
+			name:  "test passes",
+			doc:   `{"count":1.0}`,
+			patch: `[{"op":"test","path":"/count","value":1},{"op":"replace","path":"/count","value":2}]`,
+			want:  `{"count":2}`,
+		},
+		{
+			name:  "escaped pointer tokens",
+			doc:   `{"a/b":1,"c~d":2}`,
+			patch: `[{"op":"remove","path":"/a~1b"},{"op":"remove","path":"/c~0d"}]`,
+			want:  `{}`,
+		},
+		{
+			name:  "large numbers keep precision",
+			doc:   `{"id":12345678901234567890}`,
+			patch: `[{"op":"add","path":"/x","value":1}]`,
+			want:  `{"id":12345678901234567890,"x":1}`,
+		},
+		{
+			name:    "test fails",
+			doc:     `{"bride":"Jane"}`,
+			patch:   `[{"op":"test","path":"/bride","value":"Janet"}]`,
+			wantErr: ErrTestFailed,
+		},
+		{
+			name:    "replace missing path",
+			doc:     `{}`,
+			patch:   `[{"op":"replace","path":"/bride","value":"Jane"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "remove missing path",
+			doc:     `{}`,
+			patch:   `[{"op":"remove","path":"/bride"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "add to missing parent",
+			doc:     `{}`,
+			patch:   `[{"op":"add","path":"/venue/name","value":"Hall"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "array index out of range",
+			doc:     `{"photos":["a"]}`,
+			patch:   `[{"op":"add","path":"/photos/5","value":"b"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "leading zero index",
+			doc:     `{"photos":["a","b"]}`,
+			patch:   `[{"op":"remove","path":"/photos/01"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "move into own child",
+			doc:     `{"a":{"b":{}}}`,
+			patch:   `[{"op":"move","from":"/a","path":"/a/b/c"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "unknown op",
+			doc:     `{}`,
+			patch:   `[{"op":"merge","path":"/a"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "missing value",
+			doc:     `{}`,
+			patch:   `[{"op":"add","path":"/a"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "pointer without leading slash",
+			doc:     `{}`,
+			patch:   `[{"op":"add","path":"a","value":1}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "patch is not an array",
+			doc:     `{}`,
+			patch:   `{"op":"add"}`,
+			wantErr: ErrInvalidPatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.doc), []byte(tt.patch))
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v, want %v", err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"null removes member", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"arrays are replaced", `{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{"nested merge", `{"a":{"b":"c","d":"e"}}`, `{"a":{"d":null,"f":"g"}}`, `{"a":{"b":"c","f":"g"}}`},
+		{"object replaces scalar", `{"a":"b"}`, `{"a":{"c":null,"d":1}}`, `{"a":{"d":1}}`},
+		{"non-object patch replaces document", `{"a":"b"}`, `["c"]`, `["c"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestMergePatch_InvalidPatch(t *testing.T) {
+	_, err := MergePatch([]byte(`{}`), []byte(`{"a":`))
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidPatch))
+}
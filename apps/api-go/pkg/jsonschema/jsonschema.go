@@ -0,0 +1,472 @@
+// Package jsonschema validates JSON documents against a subset of JSON Schema (draft 7) and
+// reports every violation with the JSON Pointer of the offending value.
+//
+// Supported keywords: type, enum, const, properties, required, additionalProperties, items,
+// minItems, maxItems, minLength, maxLength, pattern, minimum, maximum and local $ref
+// ("#/definitions/..." or "#/$defs/..."), plus the annotations $schema, $id, $comment, title,
+// description, default and examples. Compile rejects any other keyword, so a schema never looks
+// stricter than the checks that actually run.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidSchema   = errors.New("invalid schema")
+	ErrInvalidDocument = errors.New("invalid document")
+)
+
+// MaxErrors bounds the number of violations reported for one document.
+const MaxErrors = 50
+
+// maxRefDepth stops $ref cycles that never reach a value
+const maxRefDepth = 32
+
+// ValidationError is one violation. Path is a JSON Pointer ("" for the document root).
+type ValidationError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Schema is a compiled schema.
+type Schema struct {
+	root     map[string]interface{}
+	patterns map[string]*regexp.Regexp
+}
+
+// Compile parses a schema, rejects unsupported keywords and checks that the supported ones are
+// well formed.
+func Compile(data []byte) (*Schema, error) {
+	v, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	root, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: schema must be an object", ErrInvalidSchema)
+	}
+	s := &Schema{root: root, patterns: map[string]*regexp.Regexp{}}
+	if err := s.check(root, ""); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate returns the violations of doc, in document order, at most MaxErrors of them.
+// The error is only set when doc is not valid JSON.
+func (s *Schema) Validate(doc []byte) ([]ValidationError, error) {
+	v, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	var errs []ValidationError
+	s.validate(s.root, v, "", 0, &errs)
+	return errs, nil
+}
+
+// decode keeps numbers as json.Number so integers and large values are checked exactly.
+func decode(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("trailing data after JSON value")
+	}
+	return v, nil
+}
+
+func (s *Schema) check(node map[string]interface{}, at string) error {
+	fail := func(keyword, msg string) error {
+		return fmt.Errorf("%w: %s/%s %s", ErrInvalidSchema, at, keyword, msg)
+	}
+
+	for keyword, value := range node {
+		switch keyword {
+		case "type":
+			types, ok := typeList(value)
+			if !ok {
+				return fail(keyword, "must be a type name or a list of them")
+			}
+			for _, t := range types {
+				if !knownTypes[t] {
+					return fail(keyword, fmt.Sprintf("has unknown type %q", t))
+				}
+			}
+		case "enum":
+			if _, ok := value.([]interface{}); !ok {
+				return fail(keyword, "must be an array")
+			}
+		case "required":
+			list, ok := value.([]interface{})
+			if !ok {
+				return fail(keyword, "must be an array of strings")
+			}
+			for _, item := range list {
+				if _, ok := item.(string); !ok {
+					return fail(keyword, "must be an array of strings")
+				}
+			}
+		case "properties", "definitions", "$defs":
+			props, ok := value.(map[string]interface{})
+			if !ok {
+				return fail(keyword, "must be an object")
+			}
+			for name, sub := range props {
+				subSchema, ok := sub.(map[string]interface{})
+				if !ok {
+					return fail(keyword+"/"+escape(name), "must be a schema")
+				}
+				if err := s.check(subSchema, at+"/"+keyword+"/"+escape(name)); err != nil {
+					return err
+				}
+			}
+		case "items":
+			sub, ok := value.(map[string]interface{})
+			if !ok {
+				return fail(keyword, "must be a schema")
+			}
+			if err := s.check(sub, at+"/items"); err != nil {
+				return err
+			}
+		case "additionalProperties":
+			switch sub := value.(type) {
+			case bool:
+			case map[string]interface{}:
+				if err := s.check(sub, at+"/additionalProperties"); err != nil {
+					return err
+				}
+			default:
+				return fail(keyword, "must be a boolean or a schema")
+			}
+		case "minItems", "maxItems", "minLength", "maxLength":
+			if n, ok := value.(json.Number); !ok || !isNonNegativeInteger(n) {
+				return fail(keyword, "must be a non-negative integer")
+			}
+		case "minimum", "maximum":
+			if _, ok := value.(json.Number); !ok {
+				return fail(keyword, "must be a number")
+			}
+		case "pattern":
+			pattern, ok := value.(string)
+			if !ok {
+				return fail(keyword, "must be a string")
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fail(keyword, err.Error())
+			}
+			s.patterns[pattern] = re
+		case "$ref":
+			ref, ok := value.(string)
+			if !ok {
+				return fail(keyword, "must be a string")
+			}
+			if _, err := s.resolve(ref); err != nil {
+				return fail(keyword, err.Error())
+			}
+		case "const":
+		default:
+			if !annotations[keyword] {
+				return fail(escape(keyword), "is not supported")
+			}
+		}
+	}
+	return nil
+}
+
+// annotations are keywords that describe a schema without constraining documents
+var annotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true,
+	"description": true, "default": true, "examples": true,
+}
+
+var knownTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true,
+	"integer": true, "boolean": true, "null": true,
+}
+
+func typeList(value interface{}) ([]string, bool) {
+	switch t := value.(type) {
+	case string:
+		return []string{t}, true
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			types = append(types, name)
+		}
+		return types, true
+	}
+	return nil, false
+}
+
+// resolve follows a local reference such as "#/definitions/color".
+func (s *Schema) resolve(ref string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("only local references are supported, got %q", ref)
+	}
+	var node interface{} = s.root
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer != "" {
+		for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			obj, ok := node.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("reference %q not found", ref)
+			}
+			if node, ok = obj[token]; !ok {
+				return nil, fmt.Errorf("reference %q not found", ref)
+			}
+		}
+	}
+	schema, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("reference %q is not a schema", ref)
+	}
+	return schema, nil
+}
+
+func (s *Schema) validate(schema map[string]interface{}, value interface{}, path string, depth int, errs *[]ValidationError) {
+	if len(*errs) >= MaxErrors {
+		return
+	}
+	report := func(format string, args ...interface{}) {
+		if len(*errs) < MaxErrors {
+			*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		if depth >= maxRefDepth {
+			report("schema reference nesting is too deep")
+			return
+		}
+		// Compile checked that the reference resolves; in draft 7 $ref replaces its siblings
+		target, _ := s.resolve(ref)
+		s.validate(target, value, path, depth+1, errs)
+		return
+	}
+
+	if raw, ok := schema["type"]; ok {
+		types, _ := typeList(raw)
+		if !matchesAnyType(value, types) {
+			report("must be %s, got %s", strings.Join(types, " or "), typeName(value))
+			// Keywords for the expected type would only repeat the mismatch
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		if !containsEqual(enum, value) {
+			report("must be one of %s", describeValues(enum))
+		}
+	}
+	if constant, ok := schema["const"]; ok && !equal(constant, value) {
+		report("must be %s", describeValues([]interface{}{constant}))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		s.validateObject(schema, v, path, depth, errs, report)
+	case []interface{}:
+		if n, ok := schema["minItems"].(json.Number); ok && int64(len(v)) < intValue(n) {
+			report("must have at least %s items", n)
+		}
+		if n, ok := schema["maxItems"].(json.Number); ok && int64(len(v)) > intValue(n) {
+			report("must have at most %s items", n)
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				s.validate(items, item, fmt.Sprintf("%s/%d", path, i), depth, errs)
+			}
+		}
+	case string:
+		length := int64(utf8.RuneCountInString(v))
+		if n, ok := schema["minLength"].(json.Number); ok && length < intValue(n) {
+			report("must be at least %s characters", n)
+		}
+		if n, ok := schema["maxLength"].(json.Number); ok && length > intValue(n) {
+			report("must be at most %s characters", n)
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !s.patterns[pattern].MatchString(v) {
+			report("must match pattern %s", pattern)
+		}
+	case json.Number:
+		if n, ok := schema["minimum"].(json.Number); ok && compareNumbers(v, n) < 0 {
+			report("must be at least %s", n)
+		}
+		if n, ok := schema["maximum"].(json.Number); ok && compareNumbers(v, n) > 0 {
+			report("must be at most %s", n)
+		}
+	}
+}
+
+func (s *Schema) validateObject(schema map[string]interface{}, obj map[string]interface{}, path string, depth int, errs *[]ValidationError, report func(string, ...interface{})) {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, item := range required {
+			name := item.(string)
+			if _, ok := obj[name]; !ok {
+				report("missing required property %q", name)
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	// Sorted so the reported errors are stable
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := path + "/" + escape(key)
+		if sub, ok := properties[key].(map[string]interface{}); ok {
+			s.validate(sub, obj[key], childPath, depth, errs)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional && len(*errs) < MaxErrors {
+				*errs = append(*errs, ValidationError{Path: childPath, Message: "is not allowed"})
+			}
+		case map[string]interface{}:
+			s.validate(additional, obj[key], childPath, depth, errs)
+		}
+	}
+}
+
+func matchesAnyType(value interface{}, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case "object":
+			if _, ok := value.(map[string]interface{}); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]interface{}); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(json.Number); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := value.(json.Number); ok && isInteger(n) {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "null":
+			if value == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeName(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		if isInteger(v) {
+			return "integer"
+		}
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+func isInteger(n json.Number) bool {
+	r, ok := new(big.Rat).SetString(n.String())
+	return ok && r.IsInt()
+}
+
+func isNonNegativeInteger(n json.Number) bool {
+	return isInteger(n) && !strings.HasPrefix(n.String(), "-")
+}
+
+func intValue(n json.Number) int64 {
+	i, _ := n.Int64()
+	return i
+}
+
+func compareNumbers(a, b json.Number) int {
+	ra, _ := new(big.Rat).SetString(a.String())
+	rb, _ := new(big.Rat).SetString(b.String())
+	if ra == nil || rb == nil {
+		return 0
+	}
+	return ra.Cmp(rb)
+}
+
+func containsEqual(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if equal(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a, b interface{}) bool {
+	an, aok := a.(json.Number)
+	bn, bok := b.(json.Number)
+	if aok && bok {
+		return compareNumbers(an, bn) == 0
+	}
+	ab, _ := json.Marshal(a)
+	bb, _ := json.Marshal(b)
+	return bytes.Equal(ab, bb)
+}
+
+func describeValues(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		b, _ := json.Marshal(v)
+		parts[i] = string(b)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// escape encodes a property name as a JSON Pointer reference token.
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,190 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+	"type": "object",
+	"properties": {
+		"title": {"type": "string", "minLength": 1, "maxLength": 5},
+		"count": {"type": "integer", "minimum": 0, "maximum": 10},
+		"volume": {"type": "number", "minimum": 0, "maximum": 1},
+		"color": {"$ref": "#/definitions/color"},
+		"kind": {"enum": ["a", "b"]},
+		"version": {"const": 1},
+		"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2},
+		"strict": {
+			"type": "object",
+			"required": ["id"],
+			"properties": {"id": {"type": "string"}},
+			"additionalProperties": false
+		},
+		"map": {"type": "object", "additionalProperties": {"type": "boolean"}},
+		"nullable": {"type": ["string", "null"]}
+	},
+	"definitions": {
+		"color": {"type": "string", "pattern": "^#[0-9a-fA-F]{6}$"}
+	}
+}`
+
+func TestValidate(t *testing.T) {
+	schema, err := Compile([]byte(testSchema))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		doc  string
+		want []ValidationError
+	}{
+		{
+			name: "valid document",
+			doc:  `{"title":"Hi","count":3,"volume":0.5,"color":"#AABBCC","kind":"a","version":1.0,"tags":["x"],"strict":{"id":"1"},"map":{"on":true},"nullable":null,"extra":1}`,
+		},
+		{
+			name: "root type mismatch",
+			doc:  `[]`,
+			want: []ValidationError{{Path: "", Message: "must be object, got array"}},
+		},
+		{
+			name: "string length counts characters",
+			doc:  `{"title":"héllo"}`,
+		},
+		{
+			name: "string too long",
+			doc:  `{"title":"toolong"}`,
+			want: []ValidationError{{Path: "/title", Message: "must be at most 5 characters"}},
+		},
+		{
+			name: "integer rejects fractions",
+			doc:  `{"count":1.5}`,
+			want: []ValidationError{{Path: "/count", Message: "must be integer, got number"}},
+		},
+		{
+			name: "number range",
+			doc:  `{"count":11,"volume":-0.1}`,
+			want: []ValidationError{
+				{Path: "/count", Message: "must be at most 10"},
+				{Path: "/volume", Message: "must be at least 0"},
+			},
+		},
+		{
+			name: "pattern through ref",
+			doc:  `{"color":"red"}`,
+			want: []ValidationError{{Path: "/color", Message: "must match pattern ^#[0-9a-fA-F]{6}$"}},
+		},
+		{
+			name: "enum and const",
+			doc:  `{"kind":"c","version":2}`,
+			want: []ValidationError{
+				{Path: "/kind", Message: `must be one of "a", "b"`},
+				{Path: "/version", Message: "must be 1"},
+			},
+		},
+		{
+			name: "array items and size",
+			doc:  `{"tags":["a",2,"c"]}`,
+			want: []ValidationError{
+				{Path: "/tags", Message: "must have at most 2 items"},
+				{Path: "/tags/1", Message: "must be string, got integer"},
+			},
+		},
+		{
+			name: "required and additional properties",
+			doc:  `{"strict":{"other":1}}`,
+			want: []ValidationError{
+				{Path: "/strict", Message: `missing required property "id"`},
+				{Path: "/strict/other", Message: "is not allowed"},
+			},
+		},
+		{
+			name: "additional properties schema",
+			doc:  `{"map":{"a/b":"yes"}}`,
+			want: []ValidationError{{Path: "/map/a~1b", Message: "must be boolean, got string"}},
+		},
+		{
+			name: "type list",
+			doc:  `{"nullable":1}`,
+			want: []ValidationError{{Path: "/nullable", Message: "must be string or null, got integer"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := schema.Validate([]byte(tt.doc))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidate_CapsErrors(t *testing.T) {
+	schema, err := Compile([]byte(`{"type":"array","items":{"type":"string"}}`))
+	require.NoError(t, err)
+
+	items := make([]string, MaxErrors+10)
+	for i := range items {
+		items[i] = fmt.Sprint(i)
+	}
+	got, err := schema.Validate([]byte("[" + strings.Join(items, ",") + "]"))
+	require.NoError(t, err)
+	assert.Len(t, got, MaxErrors)
+}
+
+func TestValidate_InvalidDocument(t *testing.T) {
+	schema, err := Compile([]byte(`{}`))
+	require.NoError(t, err)
+
+	_, err = schema.Validate([]byte(`{"a":`))
+	assert.True(t, errors.Is(err, ErrInvalidDocument))
+}
+
+func TestCompile_InvalidSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{name: "not JSON", schema: `{`},
+		{name: "not an object", schema: `[]`},
+		{name: "unknown type", schema: `{"type":"date"}`},
+		{name: "bad pattern", schema: `{"pattern":"("}`},
+		{name: "negative length", schema: `{"minLength":-1}`},
+		{name: "missing ref", schema: `{"$ref":"#/definitions/missing"}`},
+		{name: "remote ref", schema: `{"$ref":"http://example.com/schema.json"}`},
+		{name: "nested error", schema: `{"properties":{"a":{"items":{"type":1}}}}`},
+		{name: "unsupported oneOf", schema: `{"oneOf":[{"type":"string"},{"type":"integer"}]}`},
+		{name: "unsupported anyOf", schema: `{"anyOf":[{"type":"string"}]}`},
+		{name: "unsupported allOf", schema: `{"allOf":[{"type":"string"}]}`},
+		{name: "unsupported patternProperties", schema: `{"patternProperties":{"^x":{"type":"string"}}}`},
+		{name: "nested unsupported format", schema: `{"properties":{"email":{"type":"string","format":"email"}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile([]byte(tt.schema))
+			assert.True(t, errors.Is(err, ErrInvalidSchema), "got %v", err)
+		})
+	}
+}
+
+func TestCompile_AcceptsAnnotations(t *testing.T) {
+	_, err := Compile([]byte(`{"$schema":"http://json-schema.org/draft-07/schema#","$id":"x","$comment":"c","title":"T","description":"D","properties":{"a":{"type":"string","default":"x","examples":["y"]}}}`))
+
+	assert.NoError(t, err)
+}
+
+func TestValidate_RefCycle(t *testing.T) {
+	schema, err := Compile([]byte(`{"$ref":"#/definitions/a","definitions":{"a":{"$ref":"#/definitions/a"}}}`))
+	require.NoError(t, err)
+
+	got, err := schema.Validate([]byte(`{}`))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "schema reference nesting is too deep", got[0].Message)
+}
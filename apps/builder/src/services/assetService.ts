@@ -5,6 +5,7 @@
 
 import { apiRequest } from "./apiClient";
 import { getAccessToken } from "./tokenStorage";
+import type { AssetVariant } from "@shared/utils/assetService";
 
 const MAX_RETRIES = 3;
 const INITIAL_RETRY_DELAY = 1000; // 1 second
@@ -12,6 +13,9 @@ const INITIAL_RETRY_DELAY = 1000; // 1 second
 export interface Asset {
   id: string;
   url: string;
+  width?: number;
+  height?: number;
+  variants?: AssetVariant[];
   [key: string]: unknown;
 }
 
@@ -33,6 +37,7 @@ interface ErrorResponse {
 
 interface AssetsResponse {
   assets: Asset[];
+  nextCursor?: string;
 }
 
 interface CountResponse {
@@ -69,11 +74,13 @@ async function retryWithBackoff<T>(
  * Upload a single image file with progress tracking
  * @param file - Image file to upload
  * @param onProgress - Progress callback (progress: number 0-100)
+ * @param options - keepCaptureDate keeps the date the photo was taken; other metadata is always removed
  * @returns Upload result
  */
 export async function uploadImage(
   file: File,
-  onProgress?: (progress: number) => void
+  onProgress?: (progress: number) => void,
+  options: { keepCaptureDate?: boolean } = {}
 ): Promise<{ url: string; asset: Asset }> {
   // Client-side validation
   const maxSize = 10 * 1024 * 1024; // 10MB
@@ -88,6 +95,9 @@ export async function uploadImage(
 
   const formData = new FormData();
   formData.append("image", file);
+  if (options.keepCaptureDate) {
+    formData.append("keepCaptureDate", "true");
+  }
 
   const uploadFn = async (): Promise<{ url: string; asset: Asset }> => {
     // Use XMLHttpRequest for progress tracking
@@ -194,23 +204,32 @@ export async function deleteImage(imageUrl: string): Promise<void> {
 }
 
 /**
- * Get list of uploaded assets for current user
+ * Get list of uploaded assets for current user, following pagination cursors
  * @returns Array of asset objects
  */
 export async function getAssets(): Promise<Asset[]> {
-  const response = await apiRequest("/assets", {
-    method: "GET",
-  });
+  const assets: Asset[] = [];
+  let cursor: string | undefined;
 
-  if (!response.ok) {
-    const errorData = (await response
-      .json()
-      .catch(() => ({ error: "Failed to fetch assets" }))) as ErrorResponse;
-    throw new Error(errorData.error || "Failed to fetch assets");
-  }
+  do {
+    const path = cursor ? `/assets?cursor=${encodeURIComponent(cursor)}` : "/assets";
+    const response = await apiRequest(path, {
+      method: "GET",
+    });
+
+    if (!response.ok) {
+      const errorData = (await response
+        .json()
+        .catch(() => ({ error: "Failed to fetch assets" }))) as ErrorResponse;
+      throw new Error(errorData.error || "Failed to fetch assets");
+    }
+
+    const data = (await response.json()) as AssetsResponse;
+    assets.push(...(data.assets || []));
+    cursor = data.nextCursor;
+  } while (cursor);
 
-  const data = (await response.json()) as AssetsResponse;
-  return data.assets || [];
+  return assets;
 }
 
 /**
@@ -4,6 +4,7 @@
  */
 
 import { setAccessToken, getAccessToken, clearAccessToken, hasAccessToken } from "./tokenStorage";
+import { claimGuestInvitations } from "./invitationService";
 import { apiRequest } from "./apiClient";
 
 const API_BASE_URL = import.meta.env.VITE_API_URL || "http://localhost:3000/api";
@@ -100,6 +101,13 @@ export async function register(userData: RegisterData): Promise<AuthResponse> {
     // Store user in localStorage (for display purposes)
     if (data.user) {
       localStorage.setItem(USER_KEY, JSON.stringify(data.user));
+
+      // Move drafts made before signing up into the new account
+      try {
+        await claimGuestInvitations(data.user.id);
+      } catch (claimError) {
+        console.warn("Failed to claim guest drafts:", claimError);
+      }
     }
 
     return data;
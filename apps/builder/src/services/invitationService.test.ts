@@ -96,7 +96,7 @@ describe("invitationService", () => {
   });
 
   describe("updateInvitation", () => {
-    it("should update an existing invitation", async () => {
+    it("should update an existing invitation based on the last seen version", async () => {
       const { apiRequest } = await import("./apiClient");
       const updates = {
         data: { couple: { bride: { name: "Updated Sarah" }, groom: { name: "Updated John" } } },
@@ -107,19 +107,43 @@ describe("invitationService", () => {
         layoutId: "classic-scroll",
         ...updates,
         layoutConfig: { sections: [], theme: { preset: "default", colors: {}, fonts: {} } },
+        version: 4,
       };
 
-      vi.mocked(apiRequest).mockResolvedValue({
-        ok: true,
-        json: async () => ({ invitation: mockUpdatedInvitation }),
-      } as Response);
-
+      vi.mocked(apiRequest)
+        .mockResolvedValueOnce({
+          ok: true,
+          json: async () => ({ invitation: { ...mockUpdatedInvitation, version: 3 } }),
+        } as Response)
+        .mockResolvedValueOnce({
+          ok: true,
+          status: 200,
+          json: async () => ({ invitation: mockUpdatedInvitation }),
+        } as Response);
+
+      await getInvitation("inv-1");
       const result = await updateInvitation("inv-1", updates);
 
       expect(result).toEqual(mockUpdatedInvitation);
-      expect(apiRequest).toHaveBeenCalledWith("/invitations/inv-1", {
+      expect(apiRequest).toHaveBeenLastCalledWith("/invitations/inv-1", {
         method: "PUT",
         body: JSON.stringify(updates),
+        headers: { "If-Match": '"3"' },
+      });
+    });
+
+    it("should throw InvitationConflictError when another session saved first", async () => {
+      const { apiRequest } = await import("./apiClient");
+
+      vi.mocked(apiRequest).mockResolvedValue({
+        ok: false,
+        status: 409,
+        json: async () => ({ error: "conflict", currentVersion: 7 }),
+      } as Response);
+
+      await expect(updateInvitation("inv-1", { layoutId: "classic-scroll" })).rejects.toMatchObject({
+        name: "InvitationConflictError",
+        currentVersion: 7,
       });
     });
   });
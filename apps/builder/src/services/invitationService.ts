@@ -10,15 +10,20 @@ export interface Invitation {
   id: string;
   userId: string;
   layoutId: string;
+  /** Layout version the invitation renders with; absent means the current version */
+  layoutVersion?: string;
   data: UniversalWeddingData;
   layoutConfig: LayoutConfig;
   translations?: Record<string, unknown> | null;
+  /** Increases on every save; updates must be based on the latest version. */
+  version?: number;
   createdAt?: string;
   updatedAt?: string;
 }
 
 interface InvitationsResponse {
   invitations: Invitation[];
+  nextCursor?: string;
 }
 
 interface InvitationResponse {
@@ -31,22 +36,52 @@ interface UpdateInvitationPayload {
   layoutId?: string;
 }
 
+/**
+ * Thrown when an update is based on a stale version, i.e. the invitation was
+ * saved from another tab or device in the meantime. Reload before saving again.
+ */
+export class InvitationConflictError extends Error {
+  constructor(public readonly currentVersion: number) {
+    super("Invitation was modified by another session");
+    this.name = "InvitationConflictError";
+  }
+}
+
+// Last version seen from the server per invitation, sent back as If-Match on update.
+const invitationVersions = new Map<string, number>();
+
+function rememberVersion(invitation: Invitation | undefined): void {
+  if (invitation?.id && typeof invitation.version === "number") {
+    invitationVersions.set(invitation.id, invitation.version);
+  }
+}
+
 /**
  * Get all invitations for current user
  * @returns Array of invitations
  */
 export async function getInvitations(): Promise<Invitation[]> {
   try {
-    const response = await apiRequest("/invitations", {
-      method: "GET",
-    });
+    const invitations: Invitation[] = [];
+    let cursor: string | undefined;
 
-    if (!response.ok) {
-      throw new Error("Failed to fetch invitations");
-    }
+    do {
+      const path = cursor ? `/invitations?cursor=${encodeURIComponent(cursor)}` : "/invitations";
+      const response = await apiRequest(path, {
+        method: "GET",
+      });
+
+      if (!response.ok) {
+        throw new Error("Failed to fetch invitations");
+      }
+
+      const data = (await response.json()) as InvitationsResponse;
+      data.invitations?.forEach(rememberVersion);
+      invitations.push(...(data.invitations || []));
+      cursor = data.nextCursor;
+    } while (cursor);
 
-    const data = (await response.json()) as InvitationsResponse;
-    return data.invitations || [];
+    return invitations;
   } catch (error) {
     console.error("Get invitations error:", error);
     throw error;
@@ -69,6 +104,7 @@ export async function getInvitation(id: string): Promise<Invitation> {
     }
 
     const data = (await response.json()) as InvitationResponse;
+    rememberVersion(data.invitation);
     return data.invitation;
   } catch (error) {
     console.error("Get invitation error:", error);
@@ -93,6 +129,7 @@ export async function createInvitation(invitationData: Partial<Invitation>): Pro
     }
 
     const data = (await response.json()) as InvitationResponse;
+    rememberVersion(data.invitation);
     return data.invitation;
   } catch (error) {
     console.error("Create invitation error:", error);
@@ -111,16 +148,29 @@ export async function updateInvitation(
   updates: UpdateInvitationPayload
 ): Promise<Invitation> {
   try {
+    // The API rejects updates that don't say which version they are based on
+    if (!invitationVersions.has(id)) {
+      await getInvitation(id);
+    }
+    const version = invitationVersions.get(id);
+
     const response = await apiRequest(`/invitations/${id}`, {
       method: "PUT",
       body: JSON.stringify(updates),
+      ...(version !== undefined && { headers: { "If-Match": `"${version}"` } }),
     });
 
+    if (response.status === 409) {
+      const conflict = (await response.json()) as { currentVersion: number };
+      throw new InvitationConflictError(conflict.currentVersion);
+    }
+
     if (!response.ok) {
       throw new Error("Failed to update invitation");
     }
 
     const data = (await response.json()) as InvitationResponse;
+    rememberVersion(data.invitation);
     return data.invitation;
   } catch (error) {
     console.error("Update invitation error:", error);
@@ -128,6 +178,86 @@ export async function updateInvitation(
   }
 }
 
+/**
+ * JSON Patch (RFC 6902) operation
+ */
+export interface JsonPatchOperation {
+  op: "add" | "remove" | "replace" | "move" | "copy" | "test";
+  path: string;
+  from?: string;
+  value?: unknown;
+}
+
+/**
+ * Patch invitation data, sending only the changed fields
+ * @param id - Invitation ID
+ * @param patch - JSON Patch operations, or a merge patch object
+ * @returns Updated invitation
+ */
+export async function patchInvitation(
+  id: string,
+  patch: JsonPatchOperation[] | Record<string, unknown>
+): Promise<Invitation> {
+  try {
+    // Patches are computed against a specific version, so it must be known up front
+    if (!invitationVersions.has(id)) {
+      await getInvitation(id);
+    }
+    const version = invitationVersions.get(id);
+
+    const response = await apiRequest(`/invitations/${id}`, {
+      method: "PATCH",
+      body: JSON.stringify(patch),
+      headers: {
+        "Content-Type": Array.isArray(patch)
+          ? "application/json-patch+json"
+          : "application/merge-patch+json",
+        "If-Match": version !== undefined ? `"${version}"` : "*",
+      },
+    });
+
+    if (response.status === 409) {
+      const conflict = (await response.json()) as { currentVersion?: number };
+      throw new InvitationConflictError(conflict.currentVersion ?? version ?? 0);
+    }
+
+    if (!response.ok) {
+      throw new Error("Failed to patch invitation");
+    }
+
+    const data = (await response.json()) as InvitationResponse;
+    rememberVersion(data.invitation);
+    return data.invitation;
+  } catch (error) {
+    console.error("Patch invitation error:", error);
+    throw error;
+  }
+}
+
+/**
+ * Claim the drafts created in this browser's guest session for the signed-in user.
+ * The API identifies the guest session from its HttpOnly cookie.
+ * @param userId - ID of the signed-in user
+ * @returns Number of drafts claimed
+ */
+export async function claimGuestInvitations(userId: string): Promise<number> {
+  const response = await apiRequest("/invitations/migrate", {
+    method: "POST",
+    body: JSON.stringify({ toUserID: userId }),
+  });
+
+  // No guest session means there is nothing to claim
+  if (response.status === 400) {
+    return 0;
+  }
+  if (!response.ok) {
+    throw new Error("Failed to claim guest invitations");
+  }
+
+  const data = (await response.json()) as { migratedCount: number };
+  return data.migratedCount;
+}
+
 /**
  * Delete invitation
  * @param id - Invitation ID
@@ -147,6 +277,147 @@ export async function deleteInvitation(id: string): Promise<void> {
   }
 }
 
+export interface DuplicateInvitationOptions {
+  /** Layout for the copy; defaults to the source layout */
+  layoutId?: string;
+  /** Title for the copy; defaults to "<source title> (copy)" */
+  title?: string;
+  /** Leave out names, dates, venue, RSVP contacts and photos */
+  stripPersonalData?: boolean;
+}
+
+/**
+ * Duplicate invitation into a new draft
+ * @param id - Invitation ID
+ * @param options - Duplicate options
+ * @returns The copy
+ */
+export async function duplicateInvitation(
+  id: string,
+  options: DuplicateInvitationOptions = {}
+): Promise<Invitation> {
+  try {
+    const response = await apiRequest(`/invitations/${id}/duplicate`, {
+      method: "POST",
+      body: JSON.stringify(options),
+    });
+
+    if (!response.ok) {
+      throw new Error("Failed to duplicate invitation");
+    }
+
+    const data = (await response.json()) as InvitationResponse;
+    rememberVersion(data.invitation);
+    return data.invitation;
+  } catch (error) {
+    console.error("Duplicate invitation error:", error);
+    throw error;
+  }
+}
+
+export interface LayoutSwitchReport {
+  fromLayoutId: string;
+  toLayoutId: string;
+  /** Sections of the new layout the invitation did not have */
+  addedSections: string[];
+  /** Content the new layout cannot show; path points into the old data */
+  notCarriedOver: { path: string; reason: string }[];
+}
+
+export interface LayoutSwitchResult {
+  /** Updated invitation; absent on a dry run */
+  invitation?: Invitation;
+  /** Invitation data after the switch */
+  data: string;
+  report: LayoutSwitchReport;
+}
+
+/**
+ * Switch invitation to another layout, migrating its data
+ * @param id - Invitation ID
+ * @param layoutId - Target layout ID
+ * @param dryRun - Preview the migrated data and report without saving
+ * @returns Migrated data and report
+ */
+export async function switchInvitationLayout(
+  id: string,
+  layoutId: string,
+  dryRun = false
+): Promise<LayoutSwitchResult> {
+  try {
+    if (!dryRun && !invitationVersions.has(id)) {
+      await getInvitation(id);
+    }
+    const version = invitationVersions.get(id);
+
+    const response = await apiRequest(`/invitations/${id}/switch-layout`, {
+      method: "POST",
+      body: JSON.stringify({ layoutId, dryRun }),
+      ...(!dryRun && version !== undefined && { headers: { "If-Match": `"${version}"` } }),
+    });
+
+    if (response.status === 409) {
+      const conflict = (await response.json()) as { currentVersion: number };
+      throw new InvitationConflictError(conflict.currentVersion);
+    }
+
+    if (!response.ok) {
+      throw new Error("Failed to switch layout");
+    }
+
+    const result = (await response.json()) as LayoutSwitchResult;
+    rememberVersion(result.invitation);
+    return result;
+  } catch (error) {
+    console.error("Switch layout error:", error);
+    throw error;
+  }
+}
+
+export interface LayoutUpgradeResult {
+  /** Updated invitation; absent on a dry run */
+  invitation?: Invitation;
+  fromVersion: string;
+  toVersion: string;
+}
+
+/**
+ * Upgrade invitation to the latest version of its layout
+ * @param id - Invitation ID
+ * @param dryRun - Check the data against the latest version without saving
+ * @returns Versions before and after the upgrade
+ */
+export async function upgradeInvitationLayout(id: string, dryRun = false): Promise<LayoutUpgradeResult> {
+  try {
+    if (!dryRun && !invitationVersions.has(id)) {
+      await getInvitation(id);
+    }
+    const version = invitationVersions.get(id);
+
+    const response = await apiRequest(`/invitations/${id}/layout/upgrade`, {
+      method: "POST",
+      body: JSON.stringify({ dryRun }),
+      ...(!dryRun && version !== undefined && { headers: { "If-Match": `"${version}"` } }),
+    });
+
+    if (response.status === 409) {
+      const conflict = (await response.json()) as { currentVersion: number };
+      throw new InvitationConflictError(conflict.currentVersion);
+    }
+
+    if (!response.ok) {
+      throw new Error("Failed to upgrade layout");
+    }
+
+    const result = (await response.json()) as LayoutUpgradeResult;
+    rememberVersion(result.invitation);
+    return result;
+  } catch (error) {
+    console.error("Upgrade layout error:", error);
+    throw error;
+  }
+}
+
 /**
  * Auto-save invitation (debounced)
  * @param id - Invitation ID
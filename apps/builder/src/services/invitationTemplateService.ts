@@ -0,0 +1,135 @@
+/**
+ * Invitation Template Service
+ * Handles API calls for personal invitation templates (saved from an invitation
+ * and used to start new ones). Layout templates live in templateService.
+ */
+
+import { apiRequest } from "./apiClient";
+import type { UniversalWeddingData } from "@shared/types/wedding-data";
+import type { Invitation } from "./invitationService";
+
+export interface InvitationTemplate {
+  id: string;
+  userId: string;
+  name: string;
+  description?: string;
+  layoutId: string;
+  data: Partial<UniversalWeddingData>;
+  translations?: Record<string, unknown>;
+  defaultLocale?: string;
+  sourceInvitationId?: string;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface SaveTemplateOptions {
+  /** Defaults to the invitation title */
+  name?: string;
+  description?: string;
+  /** Leave out names, dates, venue, RSVP contacts and photos */
+  stripPersonalData?: boolean;
+}
+
+interface TemplateResponse {
+  template: InvitationTemplate;
+}
+
+interface TemplatesResponse {
+  templates: InvitationTemplate[];
+  nextCursor?: string;
+}
+
+/**
+ * Get all templates for current user
+ * @returns Array of templates, newest first
+ */
+export async function getInvitationTemplates(): Promise<InvitationTemplate[]> {
+  try {
+    const templates: InvitationTemplate[] = [];
+    let cursor: string | undefined;
+    do {
+      const query = cursor ? `?limit=100&cursor=${encodeURIComponent(cursor)}` : "?limit=100";
+      const response = await apiRequest(`/templates${query}`, { method: "GET" });
+      if (!response.ok) {
+        throw new Error("Failed to fetch templates");
+      }
+      const data = (await response.json()) as TemplatesResponse;
+      templates.push(...data.templates);
+      cursor = data.nextCursor;
+    } while (cursor);
+    return templates;
+  } catch (error) {
+    console.error("Get templates error:", error);
+    throw error;
+  }
+}
+
+/**
+ * Save invitation as template
+ * @param invitationId - Invitation ID
+ * @param options - Template options
+ * @returns Saved template
+ */
+export async function saveInvitationAsTemplate(
+  invitationId: string,
+  options: SaveTemplateOptions = {}
+): Promise<InvitationTemplate> {
+  try {
+    const response = await apiRequest("/templates", {
+      method: "POST",
+      body: JSON.stringify({ invitationId, ...options }),
+    });
+
+    if (!response.ok) {
+      throw new Error("Failed to save template");
+    }
+
+    const data = (await response.json()) as TemplateResponse;
+    return data.template;
+  } catch (error) {
+    console.error("Save template error:", error);
+    throw error;
+  }
+}
+
+/**
+ * Delete template
+ * @param id - Template ID
+ */
+export async function deleteInvitationTemplate(id: string): Promise<void> {
+  try {
+    const response = await apiRequest(`/templates/${id}`, { method: "DELETE" });
+
+    if (!response.ok) {
+      throw new Error("Failed to delete template");
+    }
+  } catch (error) {
+    console.error("Delete template error:", error);
+    throw error;
+  }
+}
+
+/**
+ * Create invitation from template
+ * @param id - Template ID
+ * @param title - Optional title for the new invitation
+ * @returns Created invitation
+ */
+export async function createInvitationFromTemplate(id: string, title?: string): Promise<Invitation> {
+  try {
+    const response = await apiRequest(`/templates/${id}/invitations`, {
+      method: "POST",
+      body: JSON.stringify(title ? { title } : {}),
+    });
+
+    if (!response.ok) {
+      throw new Error("Failed to create invitation from template");
+    }
+
+    const data = (await response.json()) as { invitation: Invitation };
+    return data.invitation;
+  } catch (error) {
+    console.error("Create invitation from template error:", error);
+    throw error;
+  }
+}
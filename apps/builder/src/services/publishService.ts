@@ -106,3 +106,16 @@ export async function rollbackToVersion(
   }
   return (await response.json()) as RollbackResponse;
 }
+
+export async function unpublishInvitation(invitationId: string): Promise<void> {
+  const response = await apiRequest("/publish/unpublish", {
+    method: "POST",
+    body: JSON.stringify({ invitationId }),
+  });
+  if (!response.ok) {
+    const err = (await response
+      .json()
+      .catch(() => ({ error: "Unpublish failed" }))) as ErrorResponse;
+    throw new Error(err.error || "Unpublish failed");
+  }
+}
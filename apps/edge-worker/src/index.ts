@@ -9,6 +9,8 @@ type ResolveResponse = {
   subdomain: string;
   published: boolean;
   currentVersion: number;
+  locales?: string[];
+  defaultLocale?: string;
 };
 
 function stripPort(host: string): string {
@@ -38,6 +40,45 @@ function normalizePath(pathname: string): string {
   return pathname;
 }
 
+// Matches a language tag exactly or by primary subtag ("en-US" -> "en", "pt" -> "pt-br").
+function matchLocale(tag: string, available: string[]): string | null {
+  const t = tag.trim().toLowerCase().replace(/_/g, "-");
+  if (!t || t === "*") return null;
+  if (available.includes(t)) return t;
+  const primary = t.split("-")[0];
+  if (available.includes(primary)) return primary;
+  return available.find((l) => l.startsWith(`${primary}-`)) || null;
+}
+
+// Mirrors publish.NegotiateLocale in the API: ?lang= wins, then Accept-Language by q, then the default.
+function negotiateLocale(request: Request, resolved: ResolveResponse): string | null {
+  const available = resolved.locales || [];
+  if (available.length === 0) return null;
+  const requested = new URL(request.url).searchParams.get("lang");
+  if (requested) {
+    const m = matchLocale(requested, available);
+    if (m) return m;
+  }
+  const tags = (request.headers.get("Accept-Language") || "")
+    .split(",")
+    .map((part) => {
+      const [tag, ...params] = part.split(";");
+      const qParam = params.map((p) => p.trim()).find((p) => p.startsWith("q="));
+      const q = qParam ? Number(qParam.slice(2)) : 1;
+      return { tag: tag.trim(), q: Number.isNaN(q) ? 0 : q };
+    })
+    .filter((t) => t.tag && t.q > 0)
+    .sort((a, b) => b.q - a.q);
+  for (const { tag } of tags) {
+    const m = matchLocale(tag, available);
+    if (m) return m;
+  }
+  if (resolved.defaultLocale && available.includes(resolved.defaultLocale)) {
+    return resolved.defaultLocale;
+  }
+  return available[0];
+}
+
 async function resolveSubdomain(env: Env, subdomain: string): Promise<ResolveResponse | null> {
   const url = new URL("/api/published/resolve", env.API_ORIGIN);
   url.searchParams.set("subdomain", subdomain);
@@ -75,7 +116,20 @@ export default {
       return new Response("Not found", { status: 404 });
     }
 
-    const path = normalizePath(new URL(request.url).pathname);
+    let pathname = new URL(request.url).pathname;
+    // Multi-language sites keep each locale under v<N>/<locale>/; prefix it unless the path names one.
+    const locales = resolved.locales || [];
+    const firstSegment = pathname.split("/")[1] || "";
+    let locale: string | null = null;
+    if (locales.includes(firstSegment)) {
+      locale = firstSegment;
+      if (pathname === `/${firstSegment}`) pathname += "/";
+    } else {
+      locale = negotiateLocale(request, resolved);
+      if (locale) pathname = `/${locale}${pathname}`;
+    }
+
+    const path = normalizePath(pathname);
     const key = `sites/${subdomain}/v${resolved.currentVersion}${path}`;
 
     const obj = await env.R2_BUCKET.get(key);
@@ -103,6 +157,11 @@ export default {
       headers.set("Cache-Control", "public, max-age=31536000, immutable");
     }
 
+    if (locale) {
+      headers.set("Content-Language", locale);
+      headers.set("Vary", "Accept-Language");
+    }
+
     const sec = securityHeaders();
     for (const [k, v] of Object.entries(sec)) headers.set(k, v);
 
@@ -7,6 +7,7 @@
 
 import React from "react";
 import type { InvitationData } from "@shared/types/wedding-data";
+import type { LayoutManifest } from "@shared/types/layout";
 import {
   getLayout,
   getViewComponents,
@@ -21,9 +22,15 @@ import "@shared/layouts/editorial-elegance";
 interface InvitationPageProps {
   invitation: InvitationData;
   translations?: Record<string, unknown>;
+  /** Pinned layout manifest; the bundled manifest is used when absent. */
+  layoutManifest?: LayoutManifest;
 }
 
-export function InvitationPage({ invitation, translations = {} }: InvitationPageProps) {
+export function InvitationPage({
+  invitation,
+  translations = {},
+  layoutManifest,
+}: InvitationPageProps) {
   const layoutId = invitation.layoutId || "classic-scroll";
   const layout = getLayout(layoutId);
 
@@ -39,7 +46,7 @@ export function InvitationPage({ invitation, translations = {} }: InvitationPage
 
   // Get enabled sections from layoutConfig, sorted by order
   // Match builder's getEnabledSections logic exactly for consistency
-  const manifest = getLayoutManifest(layoutId);
+  const manifest = layoutManifest ?? getLayoutManifest(layoutId);
   let sections = invitation.layoutConfig?.sections || [];
 
   // Filter by manifest if available (same as builder)
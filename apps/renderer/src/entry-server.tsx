@@ -7,6 +7,7 @@
 
 import { renderToStaticMarkup } from "react-dom/server";
 import type { InvitationData } from "@shared/types/wedding-data";
+import type { LayoutManifest } from "@shared/types/layout";
 import { InvitationPage } from "./InvitationPage";
 import { getLayout } from "@shared/layouts";
 // Import layouts to ensure they're registered
@@ -30,6 +31,13 @@ const logger = {
 export interface RenderOptions {
   invitation: InvitationData;
   translations?: Record<string, unknown>;
+  /** Locale of the translations (e.g. "hi"); used for the document language. */
+  locale?: string;
+  /**
+   * Manifest of the layout version the invitation is pinned to. Defaults to the manifest
+   * bundled with the layout.
+   */
+  layoutManifest?: LayoutManifest;
 }
 
 export interface RenderResult {
@@ -308,7 +316,8 @@ async function bundleAssets(html: string): Promise<{
  * This ensures the published site uses the exact same components as the builder preview
  */
 export async function render(options: RenderOptions): Promise<RenderResult> {
-  const { invitation, translations = {} } = options;
+  const { invitation, translations = {}, locale, layoutManifest } = options;
+  const htmlLang = locale && /^[a-z]{2,3}(-[a-z0-9]{2,8})*$/.test(locale) ? locale : "en";
 
   const layoutId = invitation.layoutId || "classic-scroll";
   const layout = getLayout(layoutId);
@@ -318,7 +327,13 @@ export async function render(options: RenderOptions): Promise<RenderResult> {
   }
 
   // Use React SSR to render the same components as the builder preview
-  const element = <InvitationPage invitation={invitation} translations={translations} />;
+  const element = (
+    <InvitationPage
+      invitation={invitation}
+      translations={translations}
+      layoutManifest={layoutManifest}
+    />
+  );
   let bodyContent: string;
   try {
     bodyContent = renderToStaticMarkup(element);
@@ -389,7 +404,7 @@ export async function render(options: RenderOptions): Promise<RenderResult> {
   const html = `<!DOCTYPE html>
 ${protection.htmlComment}
 ${protection.decoyComments}
-<html lang="en">
+<html lang="${htmlLang}">
 <head>
   <meta charset="UTF-8" />
   <meta name="viewport" content="width=device-width, initial-scale=1.0" />
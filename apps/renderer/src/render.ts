@@ -12,10 +12,14 @@
 import { render } from "./entry-server";
 
 import type { InvitationData } from "@shared/types/wedding-data";
+import type { LayoutManifest } from "@shared/types/layout";
 
 interface Payload {
   invitation?: InvitationData;
+  /** Manifest of the layout version the invitation is pinned to. */
+  layoutManifest?: LayoutManifest;
   translations?: Record<string, unknown>;
+  locale?: string;
 }
 
 async function main(): Promise<void> {
@@ -36,6 +40,8 @@ async function main(): Promise<void> {
   const result = await render({
     invitation,
     translations,
+    locale: payload.locale,
+    layoutManifest: payload.layoutManifest,
   });
 
   if (mode === "bundle") {
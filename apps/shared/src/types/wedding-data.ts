@@ -154,6 +154,8 @@ export interface ThemeConfig {
   preset: string;
   colors: ThemeColors;
   fonts: ThemeFonts;
+  /** Saved custom theme (GET /api/themes); applied over colors and fonts when publishing */
+  customThemeId?: string;
 }
 
 export interface LayoutConfig {
@@ -134,3 +134,26 @@ export function convertAssetPathToUrl(assetPath: string): string {
   // Unknown format, return as-is (might be a custom URL)
   return assetPath;
 }
+
+/**
+ * Responsive copy of an uploaded image, as returned by the API
+ */
+export interface AssetVariant {
+  name: string;
+  url: string;
+  width: number;
+  height: number;
+}
+
+/**
+ * Build a srcset attribute from an uploaded image and its variants
+ * Returns an empty string when the image has no variants, so callers can fall back to src only
+ */
+export function buildSrcSet(url: string, width: number | undefined, variants: AssetVariant[] | undefined): string {
+  if (!variants || variants.length === 0) return "";
+  const candidates = variants.map((variant) => `${variant.url} ${variant.width}w`);
+  if (width) {
+    candidates.push(`${url} ${width}w`);
+  }
+  return candidates.join(", ");
+}
@@ -17,6 +17,71 @@ resource "google_firestore_database" "database" {
   app_engine_integration_mode = "DISABLED"
 }
 
+# Firestore composite indexes for the API's list and cleanup queries.
+# Each query always filters on `equality`; every set in `filters` is an optional combination of
+# equality filters the caller can add, and each is indexed for every `sort` field in every
+# direction. Pagination also orders by document ID, which the index covers implicitly.
+locals {
+  firestore_queries = [
+    # GET /api/invitations and its trash
+    { collection = "invitations", equality = ["user_id", "trashed"], filters = [[], ["layout_id"], ["status"], ["layout_id", "status"]], sort = ["created_at", "updated_at"] },
+    { collection = "invitations", equality = ["user_id", "trashed"], filters = [[]], sort = ["deleted_at"] },
+    # cmd/purge-trash and cmd/expire-guest-drafts
+    { collection = "invitations", equality = ["trashed"], filters = [[]], sort = ["deleted_at"], directions = ["ASCENDING"] },
+    { collection = "invitations", equality = ["guest", "trashed"], filters = [[]], sort = ["updated_at"], directions = ["ASCENDING"] },
+    # GET /api/assets and its trash
+    { collection = "assets", equality = ["user_id", "trashed"], filters = [[], ["mime_type"]], sort = ["created_at", "size"] },
+    { collection = "assets", equality = ["user_id", "trashed"], filters = [[]], sort = ["deleted_at"] },
+    { collection = "assets", equality = ["trashed"], filters = [[]], sort = ["deleted_at"], directions = ["ASCENDING"] },
+    # GET /api/templates, /api/themes, /api/rsvp/:invitationId and /api/analytics/:invitationId
+    { collection = "invitation_templates", equality = ["user_id"], filters = [[], ["layout_id"]], sort = ["created_at", "name"] },
+    { collection = "themes", equality = ["user_id"], filters = [[]], sort = ["created_at", "name"] },
+    { collection = "rsvp_responses", equality = ["invitation_id"], filters = [[], ["date"]], sort = ["submitted_at", "name"] },
+    { collection = "analytics", equality = ["invitation_id"], filters = [[], ["type"]], sort = ["timestamp"] },
+    # GET /api/admin/layouts/:id/audit
+    { collection = "audit_log", equality = ["resource_type", "resource_id"], filters = [[], ["action"], ["actor_id"], ["action", "actor_id"]], sort = ["created_at"] },
+  ]
+
+  firestore_indexes = flatten([
+    for query in local.firestore_queries : [
+      for filter in query.filters : [
+        for sort in query.sort : [
+          for direction in try(query.directions, ["ASCENDING", "DESCENDING"]) : {
+            collection = query.collection
+            equality   = concat(query.equality, filter)
+            sort       = sort
+            direction  = direction
+          }
+        ]
+      ]
+    ]
+  ])
+}
+
+resource "google_firestore_index" "composite" {
+  for_each = {
+    for index in local.firestore_indexes :
+    "${index.collection}:${join(",", index.equality)}:${index.sort}:${index.direction}" => index
+  }
+
+  project    = var.project_id
+  database   = google_firestore_database.database.name
+  collection = each.value.collection
+
+  dynamic "fields" {
+    for_each = each.value.equality
+    content {
+      field_path = fields.value
+      order      = "ASCENDING"
+    }
+  }
+
+  fields {
+    field_path = each.value.sort
+    order      = each.value.direction
+  }
+}
+
 # GCS Bucket for assets (private bucket - accessed via signed URLs only)
 resource "google_storage_bucket" "assets" {
   project       = var.project_id
@@ -46,6 +111,18 @@ resource "google_storage_bucket" "assets" {
       type = "Delete"
     }
   }
+
+  # Direct uploads wait under pending/ until the API finalizes them, which happens right after the
+  # upload; the orphan cleanup does not list prefixed objects, so abandoned ones are deleted here
+  lifecycle_rule {
+    condition {
+      age            = 1
+      matches_prefix = ["pending/"]
+    }
+    action {
+      type = "Delete"
+    }
+  }
 }
 
 # Service Account for Cloud Run